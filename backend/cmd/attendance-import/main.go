@@ -0,0 +1,85 @@
+// Command attendance-import mengimpor file ekspor mesin fingerprint (CSV, ATTLOG .dat, XLSX) ke tabel attendances.
+//
+// Usage:
+//
+//	go run ./cmd/attendance-import -file ATTLOG.dat [-format ATTLOG] [-dry-run]
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"hr-payroll/config"
+	"hr-payroll/database"
+	"hr-payroll/internal/domain"
+	"hr-payroll/internal/repository"
+	"hr-payroll/internal/service"
+)
+
+func main() {
+	filePath := flag.String("file", "", "path to the device export file")
+	format := flag.String("format", "", "CSV, ATTLOG or XLSX (default: from file extension)")
+	dryRun := flag.Bool("dry-run", false, "validate only, do not save")
+	flag.Parse()
+
+	if *filePath == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *format == "" {
+		*format = domain.ImportFormatFromFilename(*filePath)
+	}
+	if *format == "" {
+		log.Fatal("Unknown file format, use -format CSV, ATTLOG or XLSX")
+	}
+
+	file, err := os.Open(*filePath)
+	if err != nil {
+		log.Fatalf("Failed to open file: %v", err)
+	}
+	defer file.Close()
+
+	cfg := config.LoadConfig()
+	db := database.InitDB(cfg)
+
+	employeeRepo := repository.NewEmployeeGormRepository(db)
+	attendanceRepo := repository.NewAttendanceGormRepository(db)
+	punchRepo := repository.NewPunchGormRepository(db)
+	attendanceStatusRepo := repository.NewAttendanceStatusGormRepository(db)
+	attendancePeriodRepo := repository.NewAttendancePeriodGormRepository(db)
+	payrollRepo := repository.NewPayrollGormRepository(db)
+	txIsolation, err := repository.ParseIsolationLevel(cfg.DBTxIsolation)
+	if err != nil {
+		log.Fatalf("Invalid DB_TX_ISOLATION: %v", err)
+	}
+	txManager := repository.NewGormTxManager(db, repository.TxManagerConfig{
+		Isolation:  txIsolation,
+		MaxRetries: cfg.DBTxMaxRetries,
+	})
+	attendanceService := service.NewAttendanceServiceImpl(attendanceRepo, punchRepo, attendanceStatusRepo, attendancePeriodRepo, payrollRepo, txManager, service.AttendanceConfig{
+		PairingRule: cfg.AttendancePairingRule,
+	})
+	importService := service.NewAttendanceImportServiceImpl(attendanceService, employeeRepo)
+
+	// Perubahan absensi dari CLI dicatat di audit log dengan actor khusus
+	ctx := domain.WithAuditMeta(context.Background(), domain.AuditMeta{Actor: "cli:attendance-import"})
+	result, err := importService.Import(ctx, file, *format, *dryRun)
+	if err != nil {
+		log.Fatalf("Import failed: %v", err)
+	}
+
+	// Laporan lengkap (termasuk error per baris) ke stdout sebagai JSON
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		log.Fatalf("Failed to write report: %v", err)
+	}
+	log.Printf("Punches: %d, days: %d, imported: %d, failed: %d, row errors: %d (dry run: %v)",
+		result.Punches, result.Days, result.Imported, result.Failed, len(result.RowErrors), result.DryRun)
+	if len(result.RowErrors) > 0 {
+		os.Exit(1)
+	}
+}
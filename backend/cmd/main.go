@@ -1,62 +1,26 @@
 package main
 
 import (
-	"fmt"
+	"context"
 	"log"
-	"os"
+	"time"
 
 	"hr-payroll/config"
+	"hr-payroll/database"
 	"hr-payroll/internal/delivery/handler"
 	"hr-payroll/internal/delivery/http"
 	"hr-payroll/internal/domain"
+	"hr-payroll/internal/job"
 	"hr-payroll/internal/repository"
 	"hr-payroll/internal/service"
+	"hr-payroll/internal/storage"
 
 	"github.com/gin-gonic/gin"
-	"gorm.io/driver/postgres"
-	"gorm.io/gorm"
 
 	// Swagger docs (generated by swag)
 	_ "hr-payroll/docs"
 )
 
-// initDB menginisialisasi koneksi GORM ke PostgreSQL
-func initDB() *gorm.DB {
-	// Prefer an explicit full DSN if provided (DATABASE_URL or POSTGRES_DSN)
-	if dsnEnv := os.Getenv("DATABASE_URL"); dsnEnv != "" {
-		db, err := gorm.Open(postgres.Open(dsnEnv), &gorm.Config{})
-		if err != nil {
-			log.Fatalf("Failed to connect to database (DATABASE_URL): %v", err)
-		}
-		db.AutoMigrate(&domain.Employee{}, &domain.Attendance{}, &domain.Payroll{})
-		return db
-	}
-	if dsnEnv := os.Getenv("POSTGRES_DSN"); dsnEnv != "" {
-		db, err := gorm.Open(postgres.Open(dsnEnv), &gorm.Config{})
-		if err != nil {
-			log.Fatalf("Failed to connect to database (POSTGRES_DSN): %v", err)
-		}
-		db.AutoMigrate(&domain.Employee{}, &domain.Attendance{}, &domain.Payroll{})
-		return db
-	}
-
-	// Otherwise load configuration from .env / environment via config package
-	cfg := config.LoadConfig()
-
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=Asia/Jakarta",
-		cfg.DBHost, cfg.DBUser, cfg.DBPassword, cfg.DBName, cfg.DBPort)
-
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
-	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
-	}
-
-	// Auto-migrate skema tabel (Hanya untuk development!)
-	db.AutoMigrate(&domain.Employee{}, &domain.Attendance{}, &domain.Payroll{})
-
-	return db
-}
-
 // @title Mini HR & Payroll System API
 // @version 1.0
 // @description Backend Technical Test (Golang + PostgreSQL)
@@ -64,23 +28,111 @@ func initDB() *gorm.DB {
 // @BasePath /api/v1
 // @schemes http
 func main() {
+	// 0. KONFIGURASI (.env / environment variables)
+	cfg := config.LoadConfig()
+
 	// 1. INJEKSI DATABASE
-	db := initDB()
+	db := database.InitDB(cfg)
 
 	// 2. INJEKSI REPOSITORY (Implementasi Database Adapter)
 	employeeRepo := repository.NewEmployeeGormRepository(db)
 	attendanceRepo := repository.NewAttendanceGormRepository(db)
+	punchRepo := repository.NewPunchGormRepository(db)
+	attendanceStatusRepo := repository.NewAttendanceStatusGormRepository(db)
+	attendancePeriodRepo := repository.NewAttendancePeriodGormRepository(db)
 	payrollRepo := repository.NewPayrollGormRepository(db)
+	holidayRepo := repository.NewHolidayGormRepository(db)
+	salaryHistoryRepo := repository.NewSalaryHistoryGormRepository(db)
+	thrScheduleRepo := repository.NewTHRScheduleGormRepository(db)
+	loanRepo := repository.NewLoanGormRepository(db)
+	reimbursementRepo := repository.NewReimbursementGormRepository(db)
+	adjustmentRepo := repository.NewPayrollAdjustmentGormRepository(db)
+	correctionRepo := repository.NewAttendanceCorrectionGormRepository(db)
+	officeRepo := repository.NewOfficeGormRepository(db)
+	kioskTokenUseRepo := repository.NewKioskTokenUseGormRepository(db)
+	auditLogRepo := repository.NewAuditLogGormRepository(db)
+	idempotencyRepo := repository.NewIdempotencyGormRepository(db)
+	fileStorage := storage.NewLocalFileStorage(cfg.UploadDir)
+	txIsolation, err := repository.ParseIsolationLevel(cfg.DBTxIsolation)
+	if err != nil {
+		log.Fatalf("Invalid DB_TX_ISOLATION: %v", err)
+	}
+	txManager := repository.NewGormTxManager(db, repository.TxManagerConfig{
+		Isolation:  txIsolation,
+		MaxRetries: cfg.DBTxMaxRetries,
+	})
 
 	// 3. INJEKSI SERVICE (Implementasi Use Case/Logika Bisnis)
-	employeeService := service.NewEmployeeServiceImpl(employeeRepo)
-	attendanceService := service.NewAttendanceServiceImpl(attendanceRepo)
-	payrollService := service.NewPayrollServiceImpl(employeeRepo, attendanceRepo, payrollRepo)
+	employeeService := service.NewEmployeeServiceImpl(employeeRepo, salaryHistoryRepo)
+	attendanceService := service.NewAttendanceServiceImpl(attendanceRepo, punchRepo, attendanceStatusRepo, attendancePeriodRepo, payrollRepo, txManager, service.AttendanceConfig{
+		PairingRule: cfg.AttendancePairingRule,
+	})
+	attendanceImportService := service.NewAttendanceImportServiceImpl(attendanceService, employeeRepo)
+	absenceMarkingService := service.NewAbsenceMarkingServiceImpl(attendanceService, attendanceRepo, employeeRepo, holidayRepo)
+	payrollService := service.NewPayrollServiceImpl(employeeRepo, attendanceRepo, payrollRepo, holidayRepo, salaryHistoryRepo, attendanceStatusRepo, loanRepo, reimbursementRepo, adjustmentRepo, txManager, service.PayrollConfig{
+		ProrationMethod: cfg.PayrollProrationMethod,
+		THRWageBase:     cfg.THRWageBase,
+		TakeHomeFloor:   cfg.PayrollTakeHomeFloor,
+		TaxWithholding:  cfg.PayrollTaxWithholding,
+	})
+	thrService := service.NewTHRServiceImpl(thrScheduleRepo, employeeRepo, payrollRepo, salaryHistoryRepo, service.THRConfig{
+		WageBase:       cfg.THRWageBase,
+		TaxWithholding: cfg.PayrollTaxWithholding,
+	})
+	adjustmentService := service.NewPayrollAdjustmentServiceImpl(adjustmentRepo, employeeRepo, payrollRepo)
+	taxCertificateService := service.NewTaxCertificateServiceImpl(payrollRepo, employeeRepo, service.TaxCertificateConfig{
+		Withholder: domain.TaxWithholder{
+			Name:       cfg.CompanyName,
+			NPWP:       cfg.CompanyNPWP,
+			SignerName: cfg.TaxSignerName,
+			SignerNPWP: cfg.TaxSignerNPWP,
+		},
+	})
+	holidayService := service.NewHolidayServiceImpl(holidayRepo)
+	correctionService := service.NewAttendanceCorrectionServiceImpl(correctionRepo, attendanceRepo, employeeRepo, payrollRepo, attendanceStatusRepo, attendancePeriodRepo, attendanceService)
+	attendancePeriodService := service.NewAttendancePeriodServiceImpl(attendancePeriodRepo, payrollRepo)
+	officeService := service.NewOfficeServiceImpl(officeRepo)
+	loanService := service.NewLoanServiceImpl(loanRepo, employeeRepo, txManager)
+	reimbursementService := service.NewReimbursementServiceImpl(reimbursementRepo, employeeRepo, fileStorage)
+	attendanceStatusService := service.NewAttendanceStatusServiceImpl(attendanceStatusRepo)
+	auditLogService := service.NewAuditLogServiceImpl(auditLogRepo)
+	idempotencyService := service.NewIdempotencyServiceImpl(idempotencyRepo, service.IdempotencyConfig{
+		TTL:   cfg.IdempotencyTTL,
+		Lease: cfg.IdempotencyLease,
+	})
+	workLocation, err := time.LoadLocation(cfg.Timezone)
+	if err != nil {
+		log.Fatalf("Invalid TIMEZONE %q: %v", cfg.Timezone, err)
+	}
+	timesheetService := service.NewTimesheetServiceImpl(employeeRepo, attendanceRepo, attendanceStatusRepo, service.TimesheetConfig{
+		WorkStart:        cfg.WorkStartTime,
+		WorkHours:        cfg.WorkHoursPerDay,
+		LateGraceMinutes: cfg.LateGraceMinutes,
+		Location:         workLocation,
+	})
+	mobileAttendanceService := service.NewMobileAttendanceServiceImpl(attendanceService, punchRepo, employeeRepo, officeRepo, fileStorage, attendancePeriodRepo, payrollRepo)
+	kioskService := service.NewKioskServiceImpl(attendanceService, officeRepo, employeeRepo, kioskTokenUseRepo, txManager, service.KioskConfig{
+		Secret: []byte(cfg.KioskTokenSecret),
+	})
 
 	// 4. INJEKSI HANDLER (Delivery Adapter)
 	employeeHandler := handler.NewEmployeeHandler(employeeService)
-	attendanceHandler := handler.NewAttendanceHandler(attendanceService)
+	attendanceHandler := handler.NewAttendanceHandler(attendanceService, attendanceImportService, absenceMarkingService)
 	payrollHandler := handler.NewPayrollHandler(payrollService)
+	thrHandler := handler.NewTHRHandler(thrService)
+	adjustmentHandler := handler.NewPayrollAdjustmentHandler(adjustmentService)
+	taxCertificateHandler := handler.NewTaxCertificateHandler(taxCertificateService)
+	loanHandler := handler.NewLoanHandler(loanService)
+	reimbursementHandler := handler.NewReimbursementHandler(reimbursementService)
+	holidayHandler := handler.NewHolidayHandler(holidayService)
+	correctionHandler := handler.NewAttendanceCorrectionHandler(correctionService)
+	mobileHandler := handler.NewMobileAttendanceHandler(mobileAttendanceService)
+	officeHandler := handler.NewOfficeHandler(officeService)
+	kioskHandler := handler.NewKioskHandler(kioskService)
+	attendanceStatusHandler := handler.NewAttendanceStatusHandler(attendanceStatusService)
+	timesheetHandler := handler.NewTimesheetHandler(timesheetService)
+	attendancePeriodHandler := handler.NewAttendancePeriodHandler(attendancePeriodService)
+	auditLogHandler := handler.NewAuditLogHandler(auditLogService)
 
 	// 5. SETUP ROUTER (Memetakan Handler ke URL)
 	router := gin.New()
@@ -91,11 +143,40 @@ func main() {
 	routerConfig := http.RouterConfig{
 		EmployeeHandler:   employeeHandler,
 		AttendanceHandler: attendanceHandler,
+		CorrectionHandler: correctionHandler,
+		MobileHandler:     mobileHandler,
+		OfficeHandler:     officeHandler,
+		KioskHandler:      kioskHandler,
+		StatusHandler:     attendanceStatusHandler,
+		TimesheetHandler:  timesheetHandler,
+		PeriodHandler:     attendancePeriodHandler,
 		PayrollHandler:    payrollHandler,
+		THRHandler:        thrHandler,
+		AdjustmentHandler: adjustmentHandler,
+		TaxHandler:        taxCertificateHandler,
+		LoanHandler:       loanHandler,
+		ReimbHandler:      reimbursementHandler,
+		HolidayHandler:    holidayHandler,
+		AuditHandler:      auditLogHandler,
+		Timeouts: http.TimeoutConfig{
+			Default: cfg.RequestTimeout,
+			Routes:  cfg.RouteTimeouts,
+		},
+		Idempotency: idempotencyService,
+		AdminKey:    cfg.AdminAPIKey,
 	}
 	http.SetupRouter(router, routerConfig)
 
-	// 6. MENJALANKAN SERVER
+	// 6. JOB TERJADWAL
+	if cfg.AbsenceJobEnabled {
+		absenceJob, err := job.NewAbsenceMarkingJob(absenceMarkingService, cfg.AbsenceJobTime, cfg.AbsenceJobLookbackDays)
+		if err != nil {
+			log.Fatalf("Failed to configure absence marking job: %v", err)
+		}
+		absenceJob.Start(context.Background())
+	}
+
+	// 7. MENJALANKAN SERVER
 	log.Println("Server running on port :8080. Swagger URL: http://localhost:8080/swagger/index.html")
 	if err := router.Run(":8080"); err != nil {
 		log.Fatalf("Server failed to run: %v", err)
@@ -3,17 +3,79 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 // Config holds the application configuration
 type Config struct {
+	// DBDriver: postgres (produksi) atau sqlite (development/demo offline)
+	DBDriver string
+	// DBPath: file database SQLite, atau ":memory:" untuk database sementara di memori
+	DBPath string
+
 	DBHost     string
 	DBUser     string
 	DBPassword string
 	DBName     string
 	DBPort     string
+
+	// Transaksi unit-of-work: level isolasi (SERIALIZABLE, REPEATABLE_READ, READ_COMMITTED)
+	// dan jumlah percobaan ulang saat gagal serialisasi/deadlock
+	DBTxIsolation  string
+	DBTxMaxRetries int
+
+	// Batas waktu request: default semua route dan pengecualian per route ("METHOD /path")
+	RequestTimeout time.Duration
+	RouteTimeouts  map[string]time.Duration
+
+	// IdempotencyTTL: masa berlaku respons tersimpan untuk header Idempotency-Key
+	IdempotencyTTL time.Duration
+	// IdempotencyLease: masa berlaku reservasi key yang request-nya belum selesai (mis. proses mati di tengah jalan)
+	IdempotencyLease time.Duration
+
+	// PayrollProrationMethod: CALENDAR_DAYS, WORKING_DAYS, atau FIXED_30
+	PayrollProrationMethod string
+
+	// THRWageBase: BASE (gaji pokok) atau BASE_PLUS_ALLOWANCE (gaji pokok + tunjangan tetap)
+	THRWageBase string
+
+	// PayrollTakeHomeFloor: take-home pay minimal setelah potongan cicilan pinjaman
+	PayrollTakeHomeFloor float64
+
+	// PayrollTaxWithholding: NONE (PPh 21 hanya atas THR/bonus) atau TER (PPh 21 bulanan tarif efektif PP 58/2023)
+	PayrollTaxWithholding string
+
+	// Identitas pemotong pajak pada bukti potong 1721-A1
+	CompanyName   string
+	CompanyNPWP   string
+	TaxSignerName string
+	TaxSignerNPWP string
+
+	// AttendancePairingRule: FIRST_IN_LAST_OUT atau PAIRED_SESSIONS
+	AttendancePairingRule string
+
+	// Job penandaan ABSENT otomatis
+	AbsenceJobEnabled      bool
+	AbsenceJobTime         string // HH:MM waktu lokal server
+	AbsenceJobLookbackDays int
+
+	// UploadDir: folder penyimpanan file unggahan (selfie check-in)
+	UploadDir string
+
+	// KioskTokenSecret: kunci HMAC token QR kiosk (wajib sama di semua instance)
+	KioskTokenSecret string
+	// AdminAPIKey: kunci header X-Admin-Key untuk endpoint admin (penerbitan kunci kiosk); kosong = endpoint ditolak
+	AdminAPIKey string
+
+	// Jadwal kerja standar untuk rekap absensi (terlambat & lembur)
+	Timezone         string
+	WorkStartTime    string
+	WorkHoursPerDay  float64
+	LateGraceMinutes int
 }
 
 // LoadConfig loads configuration from .env file
@@ -24,11 +86,53 @@ func LoadConfig() *Config {
 	}
 
 	return &Config{
+		DBDriver: strings.ToLower(strings.TrimSpace(getEnv("DB_DRIVER", "postgres"))),
+		DBPath:   getEnv("DB_PATH", "hr_payroll.db"),
+
 		DBHost:     getEnv("DB_HOST", "localhost"),
 		DBUser:     getEnv("DB_USER", "user"),
 		DBPassword: getEnv("DB_PASSWORD", "password"),
 		DBName:     getEnv("DB_NAME", "hr_payroll"),
 		DBPort:     getEnv("DB_PORT", "5432"),
+
+		DBTxIsolation:  getEnv("DB_TX_ISOLATION", "SERIALIZABLE"),
+		DBTxMaxRetries: getEnvInt("DB_TX_MAX_RETRIES", 3),
+
+		RequestTimeout: getEnvDuration("REQUEST_TIMEOUT", 30*time.Second),
+		RouteTimeouts: getEnvDurationMap("ROUTE_TIMEOUTS", map[string]time.Duration{
+			"POST /api/v1/attendances/import":         2 * time.Minute,
+			"POST /api/v1/attendances/absences/mark":  2 * time.Minute,
+			"POST /api/v1/payroll/preview":            2 * time.Minute,
+			"POST /api/v1/payroll/thr/run":            2 * time.Minute,
+			"POST /api/v1/payroll/adjustments/import": 2 * time.Minute,
+			"GET /api/v1/payroll/tax-certificates":    2 * time.Minute,
+		}),
+
+		IdempotencyTTL:   getEnvDuration("IDEMPOTENCY_TTL", 24*time.Hour),
+		IdempotencyLease: getEnvDuration("IDEMPOTENCY_LEASE", 5*time.Minute),
+
+		PayrollProrationMethod: getEnv("PAYROLL_PRORATION_METHOD", "CALENDAR_DAYS"),
+		THRWageBase:            getEnv("THR_WAGE_BASE", "BASE_PLUS_ALLOWANCE"),
+		PayrollTakeHomeFloor:   getEnvFloat("PAYROLL_TAKE_HOME_FLOOR", 0),
+		PayrollTaxWithholding:  getEnv("PAYROLL_TAX_WITHHOLDING", "NONE"),
+		CompanyName:            getEnv("COMPANY_NAME", ""),
+		CompanyNPWP:            getEnv("COMPANY_NPWP", ""),
+		TaxSignerName:          getEnv("TAX_SIGNER_NAME", ""),
+		TaxSignerNPWP:          getEnv("TAX_SIGNER_NPWP", ""),
+		AttendancePairingRule:  getEnv("ATTENDANCE_PAIRING_RULE", "FIRST_IN_LAST_OUT"),
+
+		AbsenceJobEnabled:      getEnvBool("ABSENCE_JOB_ENABLED", true),
+		AbsenceJobTime:         getEnv("ABSENCE_JOB_TIME", "01:00"),
+		AbsenceJobLookbackDays: getEnvInt("ABSENCE_JOB_LOOKBACK_DAYS", 7),
+
+		UploadDir:        getEnv("UPLOAD_DIR", "uploads"),
+		KioskTokenSecret: getEnv("KIOSK_TOKEN_SECRET", ""),
+		AdminAPIKey:      getEnv("ADMIN_API_KEY", ""),
+
+		Timezone:         getEnv("TIMEZONE", "Asia/Jakarta"),
+		WorkStartTime:    getEnv("WORK_START_TIME", "09:00"),
+		WorkHoursPerDay:  getEnvFloat("WORK_HOURS_PER_DAY", 8),
+		LateGraceMinutes: getEnvInt("LATE_GRACE_MINUTES", 0),
 	}
 }
 
@@ -39,3 +143,71 @@ func getEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+// getEnvBool membaca environment variable boolean, atau default jika kosong/tidak valid
+func getEnvBool(key string, fallback bool) bool {
+	if value, ok := os.LookupEnv(key); ok {
+		if parsed, err := strconv.ParseBool(value); err == nil {
+			return parsed
+		}
+		log.Printf("Invalid boolean for %s, using default %v", key, fallback)
+	}
+	return fallback
+}
+
+// getEnvInt membaca environment variable integer, atau default jika kosong/tidak valid
+func getEnvInt(key string, fallback int) int {
+	if value, ok := os.LookupEnv(key); ok {
+		if parsed, err := strconv.Atoi(value); err == nil {
+			return parsed
+		}
+		log.Printf("Invalid integer for %s, using default %d", key, fallback)
+	}
+	return fallback
+}
+
+// getEnvFloat membaca environment variable desimal, atau default jika kosong/tidak valid
+func getEnvFloat(key string, fallback float64) float64 {
+	if value, ok := os.LookupEnv(key); ok {
+		if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+			return parsed
+		}
+		log.Printf("Invalid number for %s, using default %v", key, fallback)
+	}
+	return fallback
+}
+
+// getEnvDuration membaca environment variable durasi (mis. 30s, 2m), atau default jika kosong/tidak valid
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	if value, ok := os.LookupEnv(key); ok {
+		if parsed, err := time.ParseDuration(value); err == nil {
+			return parsed
+		}
+		log.Printf("Invalid duration for %s, using default %v", key, fallback)
+	}
+	return fallback
+}
+
+// getEnvDurationMap membaca daftar "kunci=durasi" dipisah koma (mis. "POST /api/v1/payroll/generate=1m").
+// Jika environment variable diisi, daftar tersebut menggantikan default; entri tidak valid dilewati.
+func getEnvDurationMap(key string, fallback map[string]time.Duration) map[string]time.Duration {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	result := map[string]time.Duration{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, durationStr, found := strings.Cut(entry, "=")
+		duration, err := time.ParseDuration(strings.TrimSpace(durationStr))
+		if !found || err != nil {
+			log.Printf("Invalid entry %q in %s, skipping", entry, key)
+			continue
+		}
+		result[strings.TrimSpace(name)] = duration
+	}
+	return result
+}
@@ -5,23 +5,232 @@ import (
 	"hr-payroll/config"
 	"hr-payroll/internal/domain"
 	"log"
+	"os"
+	"strings"
 
+	"github.com/glebarez/sqlite"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
 )
 
+// Driver database yang didukung (DB_DRIVER)
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
+// sqliteBusyTimeout adalah lama menunggu (ms) saat file SQLite sedang dikunci proses lain
+const sqliteBusyTimeout = 5000
+
+// InitDB menginisialisasi koneksi GORM sesuai DB_DRIVER (PostgreSQL atau SQLite) dan menjalankan AutoMigrate
 func InitDB(cfg *config.Config) *gorm.DB {
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=Asia/Jakarta",
-		cfg.DBHost, cfg.DBUser, cfg.DBPassword, cfg.DBName, cfg.DBPort)
+	dialector, err := openDialector(cfg)
+	if err != nil {
+		log.Fatalf("Failed to configure database: %v", err)
+	}
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(dialector, &gorm.Config{})
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		log.Fatalf("Failed to connect to database (%s): %v", dialector.Name(), err)
 	}
 
-	// Auto-migrate schema (ONLY for development!)
-	// db.AutoMigrate(&domain.Employee{}, &domain.Attendance{}, &domain.Payroll{})
-	db.AutoMigrate(&domain.Employee{})
+	if dialector.Name() == DriverSQLite {
+		// SQLite hanya mengizinkan satu penulis; satu koneksi membuat transaksi antre alih-alih gagal
+		// "database is locked", dan menjaga database ":memory:" tetap sama untuk semua query
+		sqlDB, err := db.DB()
+		if err != nil {
+			log.Fatalf("Failed to configure SQLite connection pool: %v", err)
+		}
+		sqlDB.SetMaxOpenConns(1)
+	}
+
+	AutoMigrate(db)
 
 	return db
 }
+
+// openDialector memilih dialect GORM dari konfigurasi
+func openDialector(cfg *config.Config) (gorm.Dialector, error) {
+	switch cfg.DBDriver {
+	case DriverPostgres, "postgresql", "":
+		return postgres.Open(postgresDSN(cfg)), nil
+	case DriverSQLite, "sqlite3":
+		return sqlite.Open(sqliteDSN(cfg.DBPath)), nil
+	default:
+		return nil, fmt.Errorf("unknown DB_DRIVER %q (use %s or %s)", cfg.DBDriver, DriverPostgres, DriverSQLite)
+	}
+}
+
+// postgresDSN mengutamakan DSN lengkap (DATABASE_URL atau POSTGRES_DSN), jika tidak dibangun dari DB_HOST dkk.
+func postgresDSN(cfg *config.Config) string {
+	if dsnEnv := os.Getenv("DATABASE_URL"); dsnEnv != "" {
+		return dsnEnv
+	}
+	if dsnEnv := os.Getenv("POSTGRES_DSN"); dsnEnv != "" {
+		return dsnEnv
+	}
+	return fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=Asia/Jakarta",
+		cfg.DBHost, cfg.DBUser, cfg.DBPassword, cfg.DBName, cfg.DBPort)
+}
+
+// sqliteDSN menambahkan busy timeout dan foreign key (mati secara default di SQLite) ke path database
+func sqliteDSN(path string) string {
+	if strings.TrimSpace(path) == "" {
+		path = ":memory:"
+	}
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	return fmt.Sprintf("%s%s_pragma=busy_timeout(%d)&_pragma=foreign_keys(1)", path, separator, sqliteBusyTimeout)
+}
+
+// AutoMigrate membuat/memperbarui skema tabel (Hanya untuk development!)
+func AutoMigrate(db *gorm.DB) {
+	if err := db.AutoMigrate(
+		&domain.Employee{},
+		&domain.Attendance{},
+		&domain.Payroll{},
+		&domain.Holiday{},
+		&domain.SalaryHistory{},
+		&domain.PayrollItem{},
+		&domain.Punch{},
+		&domain.AttendanceCorrection{},
+		&domain.Office{},
+		&domain.KioskTokenUse{},
+		&domain.AttendanceStatus{},
+		&domain.AttendancePeriod{},
+		&domain.AttendancePeriodUnlock{},
+		&domain.THRSchedule{},
+		&domain.Loan{},
+		&domain.LoanTransaction{},
+		&domain.ReimbursementCategory{},
+		&domain.ReimbursementClaim{},
+		&domain.PayrollAdjustment{},
+		&domain.AuditLog{},
+		&domain.IdempotencyRecord{},
+	); err != nil {
+		log.Fatalf("Failed to migrate database: %v", err)
+	}
+	if err := MigrateIndexes(db); err != nil {
+		log.Fatalf("Failed to migrate indexes: %v", err)
+	}
+	SeedAttendanceStatuses(db)
+	SeedReimbursementCategories(db)
+}
+
+// SeedAttendanceStatuses mengisi katalog status absensi bawaan yang belum ada (perubahan admin tidak ditimpa)
+func SeedAttendanceStatuses(db *gorm.DB) {
+	for _, status := range domain.DefaultAttendanceStatuses {
+		status := status
+		if err := db.Where("code = ?", status.Code).FirstOrCreate(&status).Error; err != nil {
+			log.Fatalf("Failed to seed attendance status %s: %v", status.Code, err)
+		}
+	}
+}
+
+// SeedReimbursementCategories mengisi kategori reimbursement bawaan yang belum ada (batas yang diatur admin tidak ditimpa)
+func SeedReimbursementCategories(db *gorm.DB) {
+	for _, category := range domain.DefaultReimbursementCategories {
+		category := category
+		if err := db.Where("code = ?", category.Code).FirstOrCreate(&category).Error; err != nil {
+			log.Fatalf("Failed to seed reimbursement category %s: %v", category.Code, err)
+		}
+	}
+}
+
+// indexMigration adalah index yang definisinya berubah sejak dibuat oleh AutoMigrate versi lama
+type indexMigration struct {
+	Model any
+	Name  string
+}
+
+// changedIndexes: AutoMigrate melewati index yang namanya sudah ada, sehingga perubahan definisi harus dimigrasi sendiri
+var changedIndexes = []indexMigration{
+	// Dulu unique penuh (employee_id, period); kini partial (status <> 'VOID') agar slip bisa di-void lalu diterbitkan ulang,
+	// dan mencakup type agar slip THR boleh berada di periode yang sama dengan slip REGULAR
+	{Model: &domain.Payroll{}, Name: "idx_employee_period"},
+	// Dulu unique hanya (date), sehingga hanya satu karyawan yang bisa absen per hari; kini (employee_id, date)
+	{Model: &domain.Attendance{}, Name: "idx_employee_date"},
+}
+
+// MigrateIndexes membuat ulang index pada changedIndexes yang definisinya di database berbeda dari tag model
+func MigrateIndexes(db *gorm.DB) error {
+	for _, migration := range changedIndexes {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(migration.Model); err != nil {
+			return err
+		}
+		want := stmt.Schema.LookIndex(migration.Name)
+		if want == nil {
+			return fmt.Errorf("index %s is not declared on %s", migration.Name, stmt.Table)
+		}
+
+		definition, err := indexDefinition(db, stmt.Table, migration.Name)
+		if err != nil {
+			return err
+		}
+		if definition == "" || !indexOutdated(definition, want) {
+			continue
+		}
+
+		log.Printf("Recreating index %s on %s", migration.Name, stmt.Table)
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropIndex(migration.Model, migration.Name); err != nil {
+				return err
+			}
+			return tx.Migrator().CreateIndex(migration.Model, migration.Name)
+		})
+		if err != nil {
+			return fmt.Errorf("recreate index %s: %w", migration.Name, err)
+		}
+	}
+	return nil
+}
+
+// indexDefinition mengembalikan perintah CREATE INDEX yang tersimpan di database, kosong jika index belum ada
+func indexDefinition(db *gorm.DB, table, name string) (string, error) {
+	var definition string
+	var err error
+	switch db.Dialector.Name() {
+	case DriverPostgres:
+		err = db.Raw("SELECT indexdef FROM pg_indexes WHERE schemaname = CURRENT_SCHEMA() AND tablename = ? AND indexname = ?", table, name).Scan(&definition).Error
+	case DriverSQLite:
+		err = db.Raw("SELECT sql FROM sqlite_master WHERE type = 'index' AND tbl_name = ? AND name = ?", table, name).Scan(&definition).Error
+	default:
+		return "", fmt.Errorf("index migration is not supported for %s", db.Dialector.Name())
+	}
+	return definition, err
+}
+
+// indexOutdated membandingkan definisi di database dengan tag model: urutan kolom dan ada/tidaknya filter WHERE
+func indexOutdated(definition string, want *schema.Index) bool {
+	hasWhere := strings.Contains(strings.ToUpper(definition), " WHERE ")
+	if hasWhere != (want.Where != "") {
+		return true
+	}
+	columns := make([]string, 0, len(want.Fields))
+	for _, field := range want.Fields {
+		columns = append(columns, field.DBName)
+	}
+	return strings.Join(indexColumns(definition), ",") != strings.Join(columns, ",")
+}
+
+// indexColumns mengambil daftar kolom dari perintah CREATE INDEX PostgreSQL atau SQLite
+func indexColumns(definition string) []string {
+	on := strings.Index(strings.ToUpper(definition), " ON ")
+	if on < 0 {
+		return nil
+	}
+	rest := definition[on:]
+	open, end := strings.Index(rest, "("), strings.Index(rest, ")")
+	if open < 0 || end < open {
+		return nil
+	}
+	var columns []string
+	for _, column := range strings.Split(rest[open+1:end], ",") {
+		columns = append(columns, strings.ToLower(strings.Trim(strings.TrimSpace(column), "\"`")))
+	}
+	return columns
+}
@@ -0,0 +1,449 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"hr-payroll/config"
+	"hr-payroll/internal/domain"
+	"hr-payroll/internal/repository"
+	"hr-payroll/internal/service"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// newSQLiteDB membuat database SQLite di memori yang sudah dimigrasi, terpisah untuk tiap test
+func newSQLiteDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db := InitDB(&config.Config{DBDriver: DriverSQLite, DBPath: ":memory:"})
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
+
+func TestOpenDialector(t *testing.T) {
+	tests := []struct {
+		driver  string
+		want    string
+		wantErr bool
+	}{
+		{driver: "", want: DriverPostgres},
+		{driver: "postgres", want: DriverPostgres},
+		{driver: "sqlite", want: DriverSQLite},
+		{driver: "sqlite3", want: DriverSQLite},
+		{driver: "mysql", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.driver, func(t *testing.T) {
+			dialector, err := openDialector(&config.Config{DBDriver: tt.driver, DBPath: ":memory:"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("openDialector() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && dialector.Name() != tt.want {
+				t.Errorf("dialector = %s, want %s", dialector.Name(), tt.want)
+			}
+		})
+	}
+}
+
+func TestSQLiteDSN(t *testing.T) {
+	tests := map[string]string{
+		":memory:":              ":memory:?_pragma=busy_timeout(5000)&_pragma=foreign_keys(1)",
+		"":                      ":memory:?_pragma=busy_timeout(5000)&_pragma=foreign_keys(1)",
+		"data/hr_payroll.db":    "data/hr_payroll.db?_pragma=busy_timeout(5000)&_pragma=foreign_keys(1)",
+		"file:demo.db?mode=rwc": "file:demo.db?mode=rwc&_pragma=busy_timeout(5000)&_pragma=foreign_keys(1)",
+	}
+	for path, want := range tests {
+		if got := sqliteDSN(path); got != want {
+			t.Errorf("sqliteDSN(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestSQLitePragmas(t *testing.T) {
+	db := newSQLiteDB(t)
+
+	// Parameter _pragma pada DSN harus benar-benar diterapkan oleh driver
+	for pragma, want := range map[string]int{"foreign_keys": 1, "busy_timeout": sqliteBusyTimeout} {
+		var got int
+		if err := db.Raw("PRAGMA " + pragma).Scan(&got).Error; err != nil {
+			t.Fatalf("PRAGMA %s: %v", pragma, err)
+		}
+		if got != want {
+			t.Errorf("PRAGMA %s = %d, want %d", pragma, got, want)
+		}
+	}
+}
+
+func TestAutoMigrateSQLite(t *testing.T) {
+	db := newSQLiteDB(t)
+
+	for _, idx := range []struct {
+		model any
+		name  string
+	}{
+		{&domain.Attendance{}, "idx_employee_date"},
+		{&domain.Payroll{}, "idx_employee_period"},
+		{&domain.Punch{}, "idx_punch_employee_time"},
+		{&domain.THRSchedule{}, "idx_thr_schedule"},
+	} {
+		if !db.Migrator().HasIndex(idx.model, idx.name) {
+			t.Errorf("index %s was not created", idx.name)
+		}
+	}
+
+	// Migrasi ulang (restart aplikasi) tidak boleh gagal atau menggandakan data awal
+	AutoMigrate(db)
+	var statuses int64
+	db.Model(&domain.AttendanceStatus{}).Count(&statuses)
+	if int(statuses) != len(domain.DefaultAttendanceStatuses) {
+		t.Errorf("attendance statuses = %d, want %d", statuses, len(domain.DefaultAttendanceStatuses))
+	}
+}
+
+func TestSQLiteUniqueIndexes(t *testing.T) {
+	period := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+	date := time.Date(2025, 11, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		first   any
+		second  any
+		wantErr bool
+	}{
+		{
+			name:    "attendance for the same employee and date",
+			first:   &domain.Attendance{EmployeeID: 1, Date: date, Status: domain.AttendanceStatusPresent},
+			second:  &domain.Attendance{EmployeeID: 1, Date: date, Status: domain.AttendanceStatusLeave},
+			wantErr: true,
+		},
+		{
+			name:   "attendance for another date",
+			first:  &domain.Attendance{EmployeeID: 1, Date: date, Status: domain.AttendanceStatusPresent},
+			second: &domain.Attendance{EmployeeID: 1, Date: date.AddDate(0, 0, 1), Status: domain.AttendanceStatusPresent},
+		},
+		{
+			name:    "second regular slip in the same period",
+			first:   &domain.Payroll{EmployeeID: 1, Period: period},
+			second:  &domain.Payroll{EmployeeID: 1, Period: period},
+			wantErr: true,
+		},
+		{
+			name:   "reissue after the slip was voided",
+			first:  &domain.Payroll{EmployeeID: 1, Period: period, Status: domain.PayrollStatusVoid},
+			second: &domain.Payroll{EmployeeID: 1, Period: period},
+		},
+		{
+			name:   "THR slip next to the regular slip",
+			first:  &domain.Payroll{EmployeeID: 1, Period: period},
+			second: &domain.Payroll{EmployeeID: 1, Period: period, Type: domain.PayrollTypeTHR},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newSQLiteDB(t)
+			if err := db.Create(tt.first).Error; err != nil {
+				t.Fatalf("create first row: %v", err)
+			}
+			err := db.Create(tt.second).Error
+			if (err != nil) != tt.wantErr {
+				t.Errorf("create second row error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMigrateIndexesLegacySQLite(t *testing.T) {
+	period := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+	date := time.Date(2025, 11, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		legacy    []string // Index buatan AutoMigrate versi lama
+		rows      []any    // Harus bisa disimpan setelah migrasi
+		duplicate any      // Harus tetap ditolak setelah migrasi
+	}{
+		{
+			name:      "reissue a voided slip",
+			legacy:    []string{"DROP INDEX idx_employee_period", "CREATE UNIQUE INDEX idx_employee_period ON payrolls(employee_id, period)"},
+			rows:      []any{&domain.Payroll{EmployeeID: 1, Period: period, Status: domain.PayrollStatusVoid}, &domain.Payroll{EmployeeID: 1, Period: period}},
+			duplicate: &domain.Payroll{EmployeeID: 1, Period: period},
+		},
+		{
+			name:      "THR slip next to the regular slip",
+			legacy:    []string{"DROP INDEX idx_employee_period", "CREATE UNIQUE INDEX idx_employee_period ON payrolls(employee_id, period) WHERE status <> 'VOID'"},
+			rows:      []any{&domain.Payroll{EmployeeID: 1, Period: period}, &domain.Payroll{EmployeeID: 1, Period: period, Type: domain.PayrollTypeTHR}},
+			duplicate: &domain.Payroll{EmployeeID: 1, Period: period, Type: domain.PayrollTypeTHR},
+		},
+		{
+			name:      "two employees on the same date",
+			legacy:    []string{"DROP INDEX idx_employee_date", "CREATE UNIQUE INDEX idx_employee_date ON attendances(date)"},
+			rows:      []any{&domain.Attendance{EmployeeID: 1, Date: date, Status: domain.AttendanceStatusPresent}, &domain.Attendance{EmployeeID: 2, Date: date, Status: domain.AttendanceStatusPresent}},
+			duplicate: &domain.Attendance{EmployeeID: 1, Date: date, Status: domain.AttendanceStatusLeave},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newSQLiteDB(t)
+			for _, stmt := range tt.legacy {
+				if err := db.Exec(stmt).Error; err != nil {
+					t.Fatalf("prepare legacy index: %v", err)
+				}
+			}
+
+			// Migrasi dijalankan dua kali: yang kedua tidak boleh mengubah apa pun
+			for i := 0; i < 2; i++ {
+				if err := MigrateIndexes(db); err != nil {
+					t.Fatalf("MigrateIndexes() error = %v", err)
+				}
+			}
+			for _, row := range tt.rows {
+				if err := db.Create(row).Error; err != nil {
+					t.Fatalf("create %+v after migration: %v", row, err)
+				}
+			}
+			if err := db.Create(tt.duplicate).Error; err == nil {
+				t.Errorf("duplicate %+v was accepted, want unique violation", tt.duplicate)
+			}
+		})
+	}
+}
+
+func TestIndexColumns(t *testing.T) {
+	tests := map[string][]string{
+		`CREATE UNIQUE INDEX idx_employee_period ON public.payrolls USING btree (employee_id, period, type) WHERE ((status)::text <> 'VOID'::text)`: {"employee_id", "period", "type"},
+		"CREATE UNIQUE INDEX `idx_employee_period` ON `payrolls`(`employee_id`,`period`,`type`) WHERE status <> 'VOID'":                             {"employee_id", "period", "type"},
+		`CREATE UNIQUE INDEX "idx_employee_date" ON "attendances" ("date")`:                                                                         {"date"},
+	}
+	for definition, want := range tests {
+		if got := indexColumns(definition); strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("indexColumns(%q) = %v, want %v", definition, got, want)
+		}
+	}
+}
+
+func TestSQLitePayrollTransaction(t *testing.T) {
+	ctx := context.Background()
+	db := newSQLiteDB(t)
+
+	employeeRepo := repository.NewEmployeeGormRepository(db)
+	attendanceRepo := repository.NewAttendanceGormRepository(db)
+	payrollRepo := repository.NewPayrollGormRepository(db)
+	txManager := repository.NewGormTxManager(db, repository.TxManagerConfig{Isolation: sql.LevelSerializable, MaxRetries: 3})
+	payrollService := service.NewPayrollServiceImpl(employeeRepo, attendanceRepo, payrollRepo,
+		repository.NewHolidayGormRepository(db), repository.NewSalaryHistoryGormRepository(db), repository.NewAttendanceStatusGormRepository(db),
+		repository.NewLoanGormRepository(db), repository.NewReimbursementGormRepository(db), repository.NewPayrollAdjustmentGormRepository(db),
+		txManager, service.PayrollConfig{})
+
+	emp := &domain.Employee{Name: "Budi", BaseSalary: 4400000, Allowance: 600000}
+	if err := employeeRepo.Save(ctx, emp); err != nil {
+		t.Fatalf("save employee: %v", err)
+	}
+	if err := attendanceRepo.Save(ctx, &domain.Attendance{EmployeeID: emp.ID, Date: time.Date(2025, 11, 3, 0, 0, 0, 0, time.UTC), Status: domain.AttendanceStatusAbsent}); err != nil {
+		t.Fatalf("save attendance: %v", err)
+	}
+
+	period := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+	payroll, err := payrollService.GenerateMonthlyPayroll(ctx, emp.ID, period)
+	if err != nil {
+		t.Fatalf("GenerateMonthlyPayroll() error = %v", err)
+	}
+	if payroll.AbsenceDeduction != 200000 || payroll.TakeHomePay != 4800000 {
+		t.Errorf("payroll = %+v, want deduction 200000 and take-home 4800000", payroll)
+	}
+	if _, err := payrollService.GenerateMonthlyPayroll(ctx, emp.ID, period); !errors.Is(err, domain.ErrPayrollAlreadyGenerated) {
+		t.Errorf("second GenerateMonthlyPayroll() error = %v, want %v", err, domain.ErrPayrollAlreadyGenerated)
+	}
+}
+
+func TestSQLiteKioskScanTransaction(t *testing.T) {
+	ctx := context.Background()
+	db := newSQLiteDB(t)
+
+	employeeRepo := repository.NewEmployeeGormRepository(db)
+	officeRepo := repository.NewOfficeGormRepository(db)
+	txManager := repository.NewGormTxManager(db, repository.TxManagerConfig{Isolation: sql.LevelSerializable, MaxRetries: 3})
+	attendanceService := service.NewAttendanceServiceImpl(repository.NewAttendanceGormRepository(db), repository.NewPunchGormRepository(db),
+		repository.NewAttendanceStatusGormRepository(db), repository.NewAttendancePeriodGormRepository(db), repository.NewPayrollGormRepository(db), txManager, service.AttendanceConfig{})
+	kioskService := service.NewKioskServiceImpl(attendanceService, officeRepo, employeeRepo, repository.NewKioskTokenUseGormRepository(db), txManager,
+		service.KioskConfig{Secret: []byte("test-secret")})
+
+	office := &domain.Office{Name: "Kantor Pusat", GeofenceType: domain.GeofenceRadius, RadiusMeters: 150}
+	if err := officeRepo.Save(ctx, office); err != nil {
+		t.Fatalf("save office: %v", err)
+	}
+	emp := &domain.Employee{Name: "Budi", BaseSalary: 4400000}
+	if err := employeeRepo.Save(ctx, emp); err != nil {
+		t.Fatalf("save employee: %v", err)
+	}
+	key, err := kioskService.IssueKioskKey(ctx, office.ID)
+	if err != nil {
+		t.Fatalf("IssueKioskKey() error = %v", err)
+	}
+	token, err := kioskService.CurrentToken(ctx, office.ID, key.Key)
+	if err != nil {
+		t.Fatalf("CurrentToken() error = %v", err)
+	}
+	scan := func(action string) (*domain.Attendance, error) {
+		return kioskService.Scan(ctx, domain.KioskScanRequest{EmployeeID: emp.ID, Token: token.Token, Action: action})
+	}
+
+	// Check-out tanpa check-in gagal dan tidak boleh menghabiskan token
+	if _, err := scan(domain.KioskActionCheckOut); err == nil {
+		t.Fatal("CHECK_OUT without check-in: error = nil, want failure")
+	}
+	// Hari yang sudah punya punch (mis. dari mesin) tetap bisa check-in lewat kiosk
+	if _, err := attendanceService.RecordPunch(ctx, &domain.Punch{EmployeeID: emp.ID, Timestamp: time.Now().Add(-time.Second)}); err != nil {
+		t.Fatalf("RecordPunch() error = %v", err)
+	}
+	if _, err := scan(domain.KioskActionCheckIn); err != nil {
+		t.Fatalf("CHECK_IN with the same token error = %v, want success", err)
+	}
+	if _, err := scan(domain.KioskActionCheckIn); !errors.Is(err, domain.ErrKioskTokenReplayed) {
+		t.Errorf("replayed CHECK_IN error = %v, want %v", err, domain.ErrKioskTokenReplayed)
+	}
+}
+
+// failingLoanUpdate mensimulasikan kegagalan database saat saldo pinjaman disimpan
+type failingLoanUpdate struct {
+	domain.LoanRepository
+}
+
+func (failingLoanUpdate) Update(ctx context.Context, loan *domain.Loan) error {
+	return errors.New("connection reset")
+}
+
+func TestSQLiteSettleLoanTransaction(t *testing.T) {
+	ctx := context.Background()
+	db := newSQLiteDB(t)
+
+	employeeRepo := repository.NewEmployeeGormRepository(db)
+	loanRepo := repository.NewLoanGormRepository(db)
+	txManager := repository.NewGormTxManager(db, repository.TxManagerConfig{Isolation: sql.LevelSerializable, MaxRetries: 3})
+
+	emp := &domain.Employee{Name: "Budi", BaseSalary: 4400000}
+	if err := employeeRepo.Save(ctx, emp); err != nil {
+		t.Fatalf("save employee: %v", err)
+	}
+	loan, err := service.NewLoanServiceImpl(loanRepo, employeeRepo, txManager).CreateLoan(ctx,
+		&domain.Loan{EmployeeID: emp.ID, Principal: 3000000, InstallmentCount: 6, StartPeriod: time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)})
+	if err != nil {
+		t.Fatalf("CreateLoan() error = %v", err)
+	}
+
+	// Saldo gagal disimpan: transaksi pelunasan ikut dibatalkan
+	failing := service.NewLoanServiceImpl(failingLoanUpdate{loanRepo}, employeeRepo, txManager)
+	if _, err := failing.SettleLoan(ctx, loan.ID, domain.LoanSettlementRequest{Note: "Pelunasan"}); err == nil {
+		t.Fatal("SettleLoan() error = nil, want the update error")
+	}
+	stored, err := loanRepo.FindByID(ctx, loan.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if stored.Status != domain.LoanStatusActive || len(stored.Transactions) != 0 {
+		t.Fatalf("loan after failed settlement = %s with %d transaction(s), want ACTIVE without transactions", stored.Status, len(stored.Transactions))
+	}
+
+	settled, err := service.NewLoanServiceImpl(loanRepo, employeeRepo, txManager).SettleLoan(ctx, loan.ID, domain.LoanSettlementRequest{Note: "Pelunasan"})
+	if err != nil {
+		t.Fatalf("SettleLoan() error = %v", err)
+	}
+	if settled.Status != domain.LoanStatusSettled || settled.OutstandingBalance != 0 || len(settled.Transactions) != 1 {
+		t.Errorf("settled loan = %s, balance %v, %d transaction(s); want SETTLED, 0, 1", settled.Status, settled.OutstandingBalance, len(settled.Transactions))
+	}
+}
+
+// failingPunchSave mensimulasikan kegagalan database saat punch disimpan
+type failingPunchSave struct {
+	domain.PunchRepository
+}
+
+func (failingPunchSave) Save(ctx context.Context, punch *domain.Punch) error {
+	return errors.New("disk full")
+}
+
+func TestSQLiteRecordAttendanceTransaction(t *testing.T) {
+	ctx := context.Background()
+	db := newSQLiteDB(t)
+
+	employeeRepo := repository.NewEmployeeGormRepository(db)
+	attendanceRepo := repository.NewAttendanceGormRepository(db)
+	punchRepo := repository.NewPunchGormRepository(db)
+	txManager := repository.NewGormTxManager(db, repository.TxManagerConfig{Isolation: sql.LevelSerializable, MaxRetries: 3})
+	newService := func(punches domain.PunchRepository) domain.AttendanceService {
+		return service.NewAttendanceServiceImpl(attendanceRepo, punches, repository.NewAttendanceStatusGormRepository(db),
+			repository.NewAttendancePeriodGormRepository(db), repository.NewPayrollGormRepository(db), txManager, service.AttendanceConfig{})
+	}
+
+	emp := &domain.Employee{Name: "Budi", BaseSalary: 4400000}
+	if err := employeeRepo.Save(ctx, emp); err != nil {
+		t.Fatalf("save employee: %v", err)
+	}
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	checkIn := today.Add(8 * time.Hour)
+	record := func() *domain.Attendance {
+		return &domain.Attendance{EmployeeID: emp.ID, Date: today, Status: domain.AttendanceStatusPresent, CheckIn: &checkIn}
+	}
+
+	// Punch gagal disimpan: ringkasan harian ikut dibatalkan
+	if _, err := newService(failingPunchSave{punchRepo}).RecordAttendance(ctx, record()); err == nil {
+		t.Fatal("RecordAttendance() error = nil, want the punch error")
+	}
+	if stored, err := attendanceRepo.FindByEmployeeAndDate(ctx, emp.ID, today); err != nil || stored != nil {
+		t.Fatalf("attendance after failed punch = %+v (err %v), want none", stored, err)
+	}
+
+	if _, err := newService(punchRepo).RecordAttendance(ctx, record()); err != nil {
+		t.Fatalf("RecordAttendance() error = %v", err)
+	}
+
+	// Punch OUT gagal disimpan: jam pulang tidak tersimpan sehingga checkout bisa diulang
+	if _, err := newService(failingPunchSave{punchRepo}).RecordCheckout(ctx, emp.ID, today.Add(17*time.Hour)); err == nil {
+		t.Fatal("RecordCheckout() error = nil, want the punch error")
+	}
+	stored, err := attendanceRepo.FindByEmployeeAndDate(ctx, emp.ID, today)
+	if err != nil || stored == nil || stored.CheckOut != nil {
+		t.Fatalf("attendance after failed checkout = %+v (err %v), want no check-out", stored, err)
+	}
+	if _, err := newService(punchRepo).RecordCheckout(ctx, emp.ID, today.Add(17*time.Hour)); err != nil {
+		t.Errorf("RecordCheckout() retry error = %v", err)
+	}
+}
+
+func TestSQLiteIdempotencyReserve(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewIdempotencyGormRepository(newSQLiteDB(t))
+	expiresAt := time.Now().Add(time.Hour)
+
+	existing, err := repo.Reserve(ctx, &domain.IdempotencyRecord{Key: "payroll-2025-11", Fingerprint: "a", ExpiresAt: expiresAt})
+	if err != nil || existing != nil {
+		t.Fatalf("first Reserve() = %+v, %v; want new reservation", existing, err)
+	}
+	existing, err = repo.Reserve(ctx, &domain.IdempotencyRecord{Key: "payroll-2025-11", Fingerprint: "b", ExpiresAt: expiresAt})
+	if err != nil || existing == nil || existing.Fingerprint != "a" {
+		t.Fatalf("second Reserve() = %+v, %v; want the first record", existing, err)
+	}
+
+	// Complete memperpanjang lease menjadi masa berlaku respons
+	completedAt := time.Now()
+	if _, err := repo.Reserve(ctx, &domain.IdempotencyRecord{Key: "attendance-1", Fingerprint: "a", ExpiresAt: time.Now().Add(time.Minute)}); err != nil {
+		t.Fatalf("Reserve(attendance-1) error = %v", err)
+	}
+	if err := repo.Complete(ctx, &domain.IdempotencyRecord{Key: "attendance-1", StatusCode: 201, CompletedAt: &completedAt, ExpiresAt: expiresAt}); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	existing, err = repo.Reserve(ctx, &domain.IdempotencyRecord{Key: "attendance-1", Fingerprint: "a", ExpiresAt: time.Now().Add(time.Minute)})
+	if err != nil || existing == nil || existing.StatusCode != 201 || !existing.ExpiresAt.After(time.Now().Add(30*time.Minute)) {
+		t.Errorf("Reserve() after Complete = %+v, %v; want the stored response until %v", existing, err, expiresAt)
+	}
+}
@@ -15,6 +15,140 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/attendance-statuses": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendance Statuses"
+                ],
+                "summary": "List the attendance status catalogue",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.AttendanceStatus"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "deduction_weight is the share of a daily salary deducted for the day (0, 0.5 or 1).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendance Statuses"
+                ],
+                "summary": "Add an attendance status to the catalogue",
+                "parameters": [
+                    {
+                        "description": "Attendance status",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.AttendanceStatus"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.AttendanceStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/attendance-statuses/{code}": {
+            "put": {
+                "description": "The code cannot be changed. Built-in statuses (PRESENT, ABSENT, LEAVE) cannot be deactivated.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendance Statuses"
+                ],
+                "summary": "Update the rules of an attendance status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Status code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Attendance status",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.AttendanceStatus"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.AttendanceStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
         "/attendances": {
             "get": {
                 "consumes": [
@@ -100,6 +234,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/domain.Attendance"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Retries with the same key and payload replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -108,6 +248,66 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/domain.Attendance"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/attendances/absences/mark": {
+            "post": {
+                "description": "For each past working day in the range (Mon-Fri, not a holiday), creates an ABSENT record for every active employee with no attendance or leave on that day. Safe to run repeatedly; the daily job does the same for the last ABSENCE_JOB_LOOKBACK_DAYS days.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendances"
+                ],
+                "summary": "Mark ABSENT for working days without any attendance",
+                "parameters": [
+                    {
+                        "description": "Date range",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.MarkAbsencesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.AbsenceMarkingResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             }
@@ -133,6 +333,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/domain.Attendance"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Retries with the same key and payload replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -141,11 +347,29 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/domain.Attendance"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/employees": {
+        "/attendances/corrections": {
             "get": {
                 "consumes": [
                     "application/json"
@@ -154,16 +378,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Employees"
+                    "Attendance Corrections"
+                ],
+                "summary": "List attendance correction requests",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Employee ID",
+                        "name": "employee_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "PENDING, APPROVED or REJECTED",
+                        "name": "status",
+                        "in": "query"
+                    }
                 ],
-                "summary": "List all employees",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/domain.Employee"
+                                "$ref": "#/definitions/domain.AttendanceCorrection"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
@@ -179,6 +426,7 @@ const docTemplate = `{
                 }
             },
             "post": {
+                "description": "Employees request a status change and/or corrected check-in/check-out times with a reason and optional document link. Rejected when the attendance period is locked or the payroll for that month is already PAID.",
                 "consumes": [
                     "application/json"
                 ],
@@ -186,17 +434,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Employees"
+                    "Attendance Corrections"
                 ],
-                "summary": "Create a new employee",
+                "summary": "Submit an attendance correction request",
                 "parameters": [
                     {
-                        "description": "Employee object",
-                        "name": "employee",
+                        "description": "Correction request",
+                        "name": "correction",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.Employee"
+                            "$ref": "#/definitions/domain.AttendanceCorrection"
                         }
                     }
                 ],
@@ -204,7 +452,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/domain.Employee"
+                            "$ref": "#/definitions/domain.AttendanceCorrection"
                         }
                     },
                     "400": {
@@ -216,8 +464,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -228,7 +476,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/employees/{id}": {
+        "/attendances/corrections/{id}": {
             "get": {
                 "consumes": [
                     "application/json"
@@ -237,13 +485,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Employees"
+                    "Attendance Corrections"
                 ],
-                "summary": "Get employee by ID",
+                "summary": "Get an attendance correction request with its before/after values",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Employee ID",
+                        "description": "Correction ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -253,7 +501,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Employee"
+                            "$ref": "#/definitions/domain.AttendanceCorrection"
                         }
                     },
                     "400": {
@@ -273,19 +521,12 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/attendances/corrections/{id}/approve": {
+            "post": {
                 "consumes": [
                     "application/json"
                 ],
@@ -293,24 +534,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Employees"
+                    "Attendance Corrections"
                 ],
-                "summary": "Update an existing employee",
+                "summary": "Approve a correction request and apply it to the attendance record",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Employee ID",
+                        "description": "Correction ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Employee object",
-                        "name": "employee",
+                        "description": "Reviewer and note",
+                        "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.Employee"
+                            "$ref": "#/definitions/handler.ReviewCorrectionRequest"
                         }
                     }
                 ],
@@ -318,7 +559,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Employee"
+                            "$ref": "#/definitions/domain.AttendanceCorrection"
                         }
                     },
                     "400": {
@@ -339,8 +580,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -351,7 +592,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/payroll/generate": {
+        "/attendances/corrections/{id}/reject": {
             "post": {
                 "consumes": [
                     "application/json"
@@ -360,25 +601,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Payroll"
+                    "Attendance Corrections"
                 ],
-                "summary": "Generate monthly payroll for an employee",
+                "summary": "Reject a correction request",
                 "parameters": [
                     {
-                        "description": "Payroll request",
+                        "type": "integer",
+                        "description": "Correction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reviewer and note",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.GeneratePayrollRequest"
+                            "$ref": "#/definitions/handler.ReviewCorrectionRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/domain.Payroll"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.AttendanceCorrection"
                         }
                     },
                     "400": {
@@ -390,8 +638,17 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -402,7 +659,242 @@ const docTemplate = `{
                 }
             }
         },
-        "/payroll/slips": {
+        "/attendances/import": {
+            "post": {
+                "description": "Accepts CSV, ZKTeco/Solution ATTLOG .dat and XLSX exports. Raw punches are appended to the punch log and the daily attendance is derived per employee per day using the configured pairing rule (FIRST_IN_LAST_OUT or PAIRED_SESSIONS) and device user IDs are mapped through employee.device_user_id. With dry_run=true nothing is saved.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendances"
+                ],
+                "summary": "Bulk import attendance from fingerprint / time-clock exports",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Device export file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "CSV, ATTLOG or XLSX (default: from file extension)",
+                        "name": "format",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate only, do not save",
+                        "name": "dry_run",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.AttendanceImportResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/attendances/kiosk/scan": {
+            "post": {
+                "description": "Expired, forged or already-used tokens are rejected. CHECK_IN records a PRESENT attendance, CHECK_OUT records the checkout time.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kiosk"
+                ],
+                "summary": "Check in or out by scanning the kiosk QR code",
+                "parameters": [
+                    {
+                        "description": "Scanned token",
+                        "name": "scan",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.KioskScanRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Retries with the same key and payload replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Attendance"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/attendances/mobile/punches": {
+            "post": {
+                "description": "The location is validated against the employee's office geofence (or every office when the employee has none). Out-of-fence or low-accuracy punches are rejected (403) or stored with flagged=true, depending on the office policy. Flagged punches do not count towards the daily attendance until approved (attendance is null when the day has no other punches). The server clock is used as the punch time.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendances"
+                ],
+                "summary": "Check in or out from the mobile app with GPS and optional selfie",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Employee ID",
+                        "name": "employee_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "IN or OUT",
+                        "name": "direction",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Latitude",
+                        "name": "latitude",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Longitude",
+                        "name": "longitude",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "GPS accuracy in meters",
+                        "name": "accuracy",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Selfie (JPG/PNG, max 5 MB)",
+                        "name": "selfie",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Retries with the same key and payload replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handler.MobilePunchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/attendances/periods": {
             "get": {
                 "consumes": [
                     "application/json"
@@ -411,16 +903,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Payroll"
+                    "Attendance Periods"
                 ],
-                "summary": "List payroll slips",
+                "summary": "List attendance periods that have been locked or unlocked",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/domain.Payroll"
+                                "$ref": "#/definitions/domain.AttendancePeriod"
                             }
                         }
                     },
@@ -436,8 +928,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/payroll/slips/{id}": {
-            "get": {
+        "/attendances/periods/{period}/lock": {
+            "post": {
+                "description": "After locking, recording, punching, recomputing and correcting attendance in that month is rejected with 409.",
                 "consumes": [
                     "application/json"
                 ],
@@ -445,23 +938,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Payroll"
+                    "Attendance Periods"
                 ],
-                "summary": "Get payroll detail by ID",
+                "summary": "Close and lock an attendance period",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Payroll ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "Month (YYYY-MM)",
+                        "name": "period",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Who locks the period",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.LockPeriodRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Payroll"
+                            "$ref": "#/definitions/domain.AttendancePeriod"
                         }
                     },
                     "400": {
@@ -472,9 +974,59 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    }
+                }
+            }
+        },
+        "/attendances/periods/{period}/unlock": {
+            "post": {
+                "description": "Reopens an explicitly locked month, or a month locked because payroll slips were PAID. A reason is required and every unlock is recorded.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendance Periods"
+                ],
+                "summary": "Unlock an attendance period",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Month (YYYY-MM)",
+                        "name": "period",
+                        "in": "path",
+                        "required": true
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    {
+                        "description": "Who unlocks the period and why",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UnlockPeriodRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.AttendancePeriod"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -484,124 +1036,5342 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "domain.Attendance": {
-            "type": "object",
-            "properties": {
-                "check_in": {
-                    "type": "string",
+        },
+        "/attendances/periods/{period}/unlocks": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendance Periods"
+                ],
+                "summary": "List the unlock log of an attendance period",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Month (YYYY-MM)",
+                        "name": "period",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.AttendancePeriodUnlock"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/attendances/punches": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendances"
+                ],
+                "summary": "Get raw punches by period for an employee",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Employee ID",
+                        "name": "employee_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "From date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "To date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Punch"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Appends a punch to the immutable punch log and returns the re-derived daily attendance.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendances"
+                ],
+                "summary": "Record a raw punch (clock in/out tap)",
+                "parameters": [
+                    {
+                        "description": "Punch",
+                        "name": "punch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.RecordPunchRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Retries with the same key and payload replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Attendance"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/attendances/punches/flagged": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendances"
+                ],
+                "summary": "List punches flagged for review (outside geofence / low GPS accuracy)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "From date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "To date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Punch"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/attendances/punches/{id}/approve": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendances"
+                ],
+                "summary": "Approve a flagged punch so it counts towards the daily attendance",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Punch ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reviewer and note",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ReviewPunchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Punch"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/attendances/punches/{id}/reject": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendances"
+                ],
+                "summary": "Reject a flagged punch; it stays in the punch log but never counts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Punch ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reviewer and note",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ReviewPunchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Punch"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/attendances/punches/{id}/selfie": {
+            "get": {
+                "produces": [
+                    "image/jpeg",
+                    "image/png"
+                ],
+                "tags": [
+                    "Attendances"
+                ],
+                "summary": "Download the selfie stored with a punch",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Punch ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/attendances/recompute": {
+            "post": {
+                "description": "Useful after changing ATTENDANCE_PAIRING_RULE.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendances"
+                ],
+                "summary": "Re-derive a daily attendance from its punch log",
+                "parameters": [
+                    {
+                        "description": "Employee and date",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.RecomputeAttendanceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Attendance"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/attendances/timesheet": {
+            "get": {
+                "description": "Employees as rows, days of the month as columns with status codes, plus totals of present, absent, leave, late minutes, overtime hours and worked hours.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "Attendances"
+                ],
+                "summary": "Monthly attendance timesheet for all employees",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Month (YYYY-MM or YYYY-MM-DD)",
+                        "name": "period",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Department filter",
+                        "name": "department",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "json (default), csv or xlsx",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Timesheet"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/audit-logs": {
+            "get": {
+                "description": "Newest first. Each entry holds the actor (X-Actor header as sent by the client, not verified; \"unknown\" when missing, \"system\" for jobs), action, entity, per-field before/after diff, request ID and client IP.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Audit"
+                ],
+                "summary": "List audit log entries for employees, attendance and payroll",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "employee, attendance or payroll",
+                        "name": "entity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Entity ID (requires entity)",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Actor",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "From date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To date (YYYY-MM-DD, inclusive)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.AuditLog"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/employees": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Employees"
+                ],
+                "summary": "List all employees",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Employee"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Employees"
+                ],
+                "summary": "Create a new employee",
+                "parameters": [
+                    {
+                        "description": "Employee object",
+                        "name": "employee",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Employee"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Employee"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{id}": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Employees"
+                ],
+                "summary": "Get employee by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Employee ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Employee"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Only fields present in the body are changed; omitted fields (join date, tax status, office, device ID, ...) keep their stored value. Send null to clear a date.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Employees"
+                ],
+                "summary": "Update an existing employee",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Employee ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Employee object",
+                        "name": "employee",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Employee"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Employee"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{id}/salaries": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Employees"
+                ],
+                "summary": "Get salary history of an employee",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Employee ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.SalaryHistory"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Back-dated changes into already generated periods are paid as a retroactive adjustment (rapel) on the next payroll.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Employees"
+                ],
+                "summary": "Record an effective-dated salary change",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Employee ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Salary change",
+                        "name": "salary",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.AddSalaryChangeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SalaryHistory"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/holidays": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Holidays"
+                ],
+                "summary": "List holidays within a date range",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "From date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "To date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Holiday"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Holidays"
+                ],
+                "summary": "Add a holiday to the working calendar",
+                "parameters": [
+                    {
+                        "description": "Holiday object",
+                        "name": "holiday",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Holiday"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Holiday"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/holidays/{id}": {
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Holidays"
+                ],
+                "summary": "Remove a holiday from the working calendar",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Holiday ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/loans": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Loans"
+                ],
+                "summary": "List loans",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Employee ID",
+                        "name": "employee_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Loan"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Set either installment_amount or installment_count. Installments are deducted by payroll generation from start_period onwards without pushing take-home pay below the configured floor.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Loans"
+                ],
+                "summary": "Create an employee loan or cash advance",
+                "parameters": [
+                    {
+                        "description": "Loan",
+                        "name": "loan",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Loan"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Loan"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/loans/{id}": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Loans"
+                ],
+                "summary": "Get a loan with its balance transactions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Loan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Loan"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/loans/{id}/settle": {
+            "post": {
+                "description": "GENERATED slips that already contain an installment of this loan must be recalculated before they can be paid.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Loans"
+                ],
+                "summary": "Settle the outstanding balance of a loan early",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Loan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Settlement note",
+                        "name": "payload",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/domain.LoanSettlementRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Loan"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/offices": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Offices"
+                ],
+                "summary": "List offices",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Office"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "geofence_type RADIUS uses latitude/longitude/radius_meters, POLYGON uses polygon (at least 3 points). policy decides what happens to out-of-fence punches: REJECT or FLAG for review.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Offices"
+                ],
+                "summary": "Create an office with its check-in geofence",
+                "parameters": [
+                    {
+                        "description": "Office object",
+                        "name": "office",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Office"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Office"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/offices/{id}": {
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Offices"
+                ],
+                "summary": "Update an office and its geofence",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Office ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Office object",
+                        "name": "office",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Office"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Office"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/offices/{id}/kiosk-key": {
+            "post": {
+                "description": "Admin only (X-Admin-Key must match ADMIN_API_KEY; the endpoint is disabled when it is not set). The key is returned only once. The kiosk sends it as X-Kiosk-Key when fetching QR tokens; issuing a new key invalidates the old one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kiosk"
+                ],
+                "summary": "Issue (or rotate) the secret key for an office's kiosk tablet",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Office ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Admin API key (ADMIN_API_KEY)",
+                        "name": "X-Admin-Key",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.KioskKey"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/offices/{id}/kiosk-token": {
+            "get": {
+                "description": "Tokens are HMAC-signed and rotate every 30 seconds; the kiosk should refresh after expires_at.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kiosk"
+                ],
+                "summary": "Get the current rotating QR token for an office kiosk",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Office ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kiosk key",
+                        "name": "X-Kiosk-Key",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.KioskToken"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/payroll/adjustments": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payroll Adjustments"
+                ],
+                "summary": "List payroll adjustments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Employee ID",
+                        "name": "employee_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Period (YYYY-MM)",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "PENDING, APPLIED or CANCELLED",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.PayrollAdjustment"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "The adjustment stays PENDING until the slip of that period is paid. Taxable components are withheld PPh 21 as irregular income, or added to the month's gross for TER when PAYROLL_TAX_WITHHOLDING=TER. Rejected (409) when the slip of that period is already PAID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payroll Adjustments"
+                ],
+                "summary": "Add a one-off bonus, commission, incentive or penalty to an employee's monthly slip",
+                "parameters": [
+                    {
+                        "description": "Adjustment",
+                        "name": "adjustment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateAdjustmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.PayrollAdjustment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/payroll/adjustments/components": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payroll Adjustments"
+                ],
+                "summary": "List adjustment components with their slip side and tax treatment",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.AdjustmentComponent"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/payroll/adjustments/import": {
+            "post": {
+                "description": "Header: employee_id,period,component,amount,note (period as YYYY-MM). Valid rows are saved and invalid rows are reported per row. With dry_run=true nothing is saved.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payroll Adjustments"
+                ],
+                "summary": "Bulk upload adjustments from a CSV file",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Who entered the adjustments",
+                        "name": "entered_by",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate only, do not save",
+                        "name": "dry_run",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.AdjustmentImportResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/payroll/adjustments/{id}": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payroll Adjustments"
+                ],
+                "summary": "Get a payroll adjustment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Adjustment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.PayrollAdjustment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/payroll/adjustments/{id}/cancel": {
+            "post": {
+                "description": "A GENERATED slip that already includes the adjustment must be recalculated before it can be paid.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payroll Adjustments"
+                ],
+                "summary": "Cancel a pending adjustment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Adjustment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Who cancels",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CancelAdjustmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.PayrollAdjustment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/payroll/generate": {
+            "post": {
+                "description": "take_home_pay = (base_salary + allowance) x proration - absence_deduction + retro + reimbursement + adjustments - tax - loan_deduction. With PAYROLL_TAX_WITHHOLDING=NONE (default) tax only covers taxable adjustments (irregular income method); with TER it is the monthly PP 58/2023 effective rate on the month's gross, reconciled to the annual Pasal 17 tax in December or the resignation month (a negative tax refunds over-withholding).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payroll"
+                ],
+                "summary": "Generate monthly payroll for an employee",
+                "parameters": [
+                    {
+                        "description": "Payroll request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.GeneratePayrollRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Retries with the same key and payload replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Payroll"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/payroll/preview": {
+            "post": {
+                "description": "Runs the monthly payroll calculation for one employee, a department or everyone (when neither is given), applying optional what-if overrides. Nothing is persisted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payroll"
+                ],
+                "summary": "Preview payroll without saving",
+                "parameters": [
+                    {
+                        "description": "Preview request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PreviewPayrollRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.PayrollPreview"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/payroll/reports/variance": {
+            "get": {
+                "description": "Compares stored (non-void) slips of two periods per employee and in aggregate. New and departed employees are always listed; others only when base salary, allowance or absence deduction changed, or the take-home pay delta exceeds the threshold.",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Payroll"
+                ],
+                "summary": "Period-over-period payroll variance report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Base period (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Compared period (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum absolute take-home pay delta to report (default 0)",
+                        "name": "threshold",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "json (default) or csv",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.PayrollVarianceReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/payroll/slips": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payroll"
+                ],
+                "summary": "List payroll slips",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Payroll"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/payroll/slips/{id}": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payroll"
+                ],
+                "summary": "Get payroll detail by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payroll ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Payroll"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/payroll/slips/{id}/pay": {
+            "post": {
+                "description": "Finalises a GENERATED slip, deducts its loan installments from the loan balances and marks its reimbursement claims PAID and its adjustments APPLIED. Returns 409 when adjustments were cancelled or added since the slip was calculated. Paid slips can only be corrected through void-and-reissue, which rolls these back.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payroll"
+                ],
+                "summary": "Mark a payroll slip as paid",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payroll ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Payroll"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/payroll/slips/{id}/recalculate": {
+            "post": {
+                "description": "Recomputes a GENERATED slip in place from the latest attendance and salary data.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payroll"
+                ],
+                "summary": "Recalculate an unpaid payroll slip",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payroll ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Payroll"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/payroll/slips/{id}/void": {
+            "post": {
+                "description": "The voided slip stays stored with its reason and a link to the replacement slip.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payroll"
+                ],
+                "summary": "Void a paid payroll slip and issue a replacement",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payroll ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Void reason",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.VoidPayrollRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Payroll"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/payroll/tax-certificates": {
+            "get": {
+                "description": "Aggregates the year's PAID slips per employee (regular and THR). format=pdf renders one page per employee; format=ebupot returns the semicolon-separated 1721-A1 import file for the e-SPT / e-Bupot PPh 21 application.",
+                "produces": [
+                    "application/json",
+                    "application/pdf",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Tax Certificates"
+                ],
+                "summary": "Form 1721-A1 for every employee with paid slips in a tax year",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tax year",
+                        "name": "year",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "json (default), pdf or ebupot",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.TaxCertificate"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/payroll/tax-certificates/{employee_id}": {
+            "get": {
+                "produces": [
+                    "application/json",
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Tax Certificates"
+                ],
+                "summary": "Form 1721-A1 for one employee",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Employee ID",
+                        "name": "employee_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tax year",
+                        "name": "year",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "json (default) or pdf",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TaxCertificate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/payroll/thr/run": {
+            "post": {
+                "description": "One month's wage for 12+ months of service, service months / 12 below that, nothing under one month (Permenaker 6/2016). Slips have type THR; PPh 21 is withheld as irregular income, or as the TER difference with and without THR when PAYROLL_TAX_WITHHOLDING=TER. Employees that already have a THR slip for the period are skipped.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "THR"
+                ],
+                "summary": "Calculate THR slips for all eligible employees",
+                "parameters": [
+                    {
+                        "description": "Year, optional religion and dry run flag",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.THRRunRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.THRRunResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/payroll/thr/schedules": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "THR"
+                ],
+                "summary": "List THR schedules of a year",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Year",
+                        "name": "year",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.THRSchedule"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "The year is taken from holiday_date. The payout date must be at least 7 days before the holiday and determines the period of the THR slips.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "THR"
+                ],
+                "summary": "Create or replace the THR schedule of a religion for a year",
+                "parameters": [
+                    {
+                        "description": "THR schedule",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.THRSchedule"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.THRSchedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/reimbursements": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reimbursements"
+                ],
+                "summary": "List reimbursement claims",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Employee ID",
+                        "name": "employee_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "SUBMITTED, MANAGER_APPROVED, APPROVED, PAID or REJECTED",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.ReimbursementClaim"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "The claim must fit in the category's yearly limit for the year of expense_date (SUBMITTED and approved claims count, rejected ones do not). It then needs manager approval followed by finance approval.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reimbursements"
+                ],
+                "summary": "Submit a reimbursement claim with its receipt",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Employee ID",
+                        "name": "employee_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Category code, e.g. MEDICAL",
+                        "name": "category_code",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Expense date (YYYY-MM-DD)",
+                        "name": "expense_date",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Claimed amount",
+                        "name": "amount",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Description",
+                        "name": "description",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Receipt (JPG/PNG/PDF, max 10 MB)",
+                        "name": "receipt",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ReimbursementClaim"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/reimbursements/categories": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reimbursements"
+                ],
+                "summary": "List reimbursement categories and their yearly limits",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.ReimbursementCategory"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "yearly_limit is the maximum an employee may claim per calendar year in this category (0 = unlimited).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reimbursements"
+                ],
+                "summary": "Add a reimbursement category",
+                "parameters": [
+                    {
+                        "description": "Reimbursement category",
+                        "name": "category",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ReimbursementCategory"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ReimbursementCategory"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/reimbursements/categories/{code}": {
+            "put": {
+                "description": "The code cannot be changed. Inactive categories cannot receive new claims.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reimbursements"
+                ],
+                "summary": "Update the name, yearly limit or active flag of a reimbursement category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Category code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reimbursement category",
+                        "name": "category",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ReimbursementCategory"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ReimbursementCategory"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/reimbursements/{id}": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reimbursements"
+                ],
+                "summary": "Get a reimbursement claim with its approval trail",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Claim ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ReimbursementClaim"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/reimbursements/{id}/approve": {
+            "post": {
+                "description": "A SUBMITTED claim becomes MANAGER_APPROVED; a MANAGER_APPROVED claim becomes APPROVED by finance (a different reviewer, yearly limit re-checked). APPROVED claims are paid as a non-taxable earning on the employee's next generated payroll.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reimbursements"
+                ],
+                "summary": "Approve the next stage of a reimbursement claim",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Claim ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reviewer and note",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ReviewReimbursementRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ReimbursementClaim"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/reimbursements/{id}/receipt": {
+            "get": {
+                "produces": [
+                    "image/jpeg",
+                    "image/png",
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Reimbursements"
+                ],
+                "summary": "Download the receipt attached to a claim",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Claim ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/reimbursements/{id}/reject": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reimbursements"
+                ],
+                "summary": "Reject a reimbursement claim awaiting manager or finance approval",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Claim ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reviewer and reason",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ReviewReimbursementRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ReimbursementClaim"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.AbsenceMarkingResult": {
+            "type": "object",
+            "properties": {
+                "checked": {
+                    "description": "Kombinasi karyawan aktif x hari kerja",
+                    "type": "integer",
+                    "example": 200
+                },
+                "created": {
+                    "description": "Record ABSENT baru",
+                    "type": "integer",
+                    "example": 3
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2025-11-01T00:00:00Z"
+                },
+                "records": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Attendance"
+                    }
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2025-11-30T00:00:00Z"
+                },
+                "working_days": {
+                    "description": "Hari kerja (Senin-Jumat, bukan libur) yang diperiksa",
+                    "type": "integer",
+                    "example": 20
+                }
+            }
+        },
+        "domain.AdjustmentComponent": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "BONUS"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Bonus"
+                },
+                "taxable": {
+                    "description": "Dikenai PPh 21 (metode selisih, atau masuk bruto TER)",
+                    "type": "boolean",
+                    "example": true
+                },
+                "type": {
+                    "description": "EARNING atau DEDUCTION",
+                    "type": "string",
+                    "example": "EARNING"
+                }
+            }
+        },
+        "domain.AdjustmentImportResult": {
+            "type": "object",
+            "properties": {
+                "adjustments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.PayrollAdjustment"
+                    }
+                },
+                "dry_run": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "imported": {
+                    "type": "integer",
+                    "example": 9
+                },
+                "row_errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.AdjustmentImportRowError"
+                    }
+                },
+                "rows": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "domain.AdjustmentImportRowError": {
+            "type": "object",
+            "properties": {
+                "employee_id": {
+                    "type": "string",
+                    "example": "1"
+                },
+                "error": {
+                    "type": "string",
+                    "example": "unknown adjustment component"
+                },
+                "row": {
+                    "description": "Nomor baris di file (1 = header)",
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "domain.Attendance": {
+            "type": "object",
+            "properties": {
+                "check_in": {
+                    "type": "string",
                     "example": "2025-11-10T09:00:00Z"
                 },
-                "check_out": {
-                    "type": "string",
-                    "example": "2025-11-10T17:00:00Z"
+                "check_out": {
+                    "type": "string",
+                    "example": "2025-11-10T17:00:00Z"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "description": "Memastikan unik per employee per hari",
+                    "type": "string",
+                    "example": "2025-11-10T00:00:00Z"
+                },
+                "document_url": {
+                    "description": "Dokumen pendukung untuk status yang mewajibkannya",
+                    "type": "string",
+                    "example": ""
+                },
+                "employee_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "status": {
+                    "description": "Kode dari katalog attendance_statuses",
+                    "type": "string",
+                    "example": "PRESENT"
+                },
+                "worked_minutes": {
+                    "description": "Menit kerja hasil pairing punch",
+                    "type": "integer",
+                    "example": 480
+                }
+            }
+        },
+        "domain.AttendanceCorrection": {
+            "type": "object",
+            "properties": {
+                "after_check_in": {
+                    "type": "string"
+                },
+                "after_check_out": {
+                    "type": "string"
+                },
+                "after_status": {
+                    "type": "string",
+                    "example": "PRESENT"
+                },
+                "attendance_id": {
+                    "description": "Record absensi yang dikoreksi",
+                    "type": "integer",
+                    "example": 1
+                },
+                "before_check_in": {
+                    "type": "string"
+                },
+                "before_check_out": {
+                    "type": "string"
+                },
+                "before_status": {
+                    "type": "string",
+                    "example": "ABSENT"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2025-11-10T00:00:00Z"
+                },
+                "document_url": {
+                    "type": "string",
+                    "example": "https://files.example.com/surat-dokter.pdf"
+                },
+                "employee_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Lupa check-in, mesin fingerprint error"
+                },
+                "requested_check_in": {
+                    "type": "string",
+                    "example": "2025-11-10T08:55:00Z"
+                },
+                "requested_check_out": {
+                    "type": "string",
+                    "example": "2025-11-10T17:05:00Z"
+                },
+                "requested_status": {
+                    "description": "Kosong = status tidak diubah",
+                    "type": "string",
+                    "example": "PRESENT"
+                },
+                "review_note": {
+                    "type": "string",
+                    "example": ""
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "reviewed_by": {
+                    "type": "string",
+                    "example": "manager@example.com"
+                },
+                "status": {
+                    "description": "PENDING, APPROVED, REJECTED",
+                    "type": "string",
+                    "example": "PENDING"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.AttendanceImportResult": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "description": "Jumlah pasangan karyawan-hari",
+                    "type": "integer",
+                    "example": 60
+                },
+                "dry_run": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "failed": {
+                    "description": "Karyawan-hari yang gagal",
+                    "type": "integer",
+                    "example": 2
+                },
+                "format": {
+                    "type": "string",
+                    "example": "ATTLOG"
+                },
+                "imported": {
+                    "description": "Attendance yang tersimpan (atau akan tersimpan saat dry run)",
+                    "type": "integer",
+                    "example": 58
+                },
+                "punches": {
+                    "description": "Jumlah punch mentah yang terbaca",
+                    "type": "integer",
+                    "example": 120
+                },
+                "records": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Attendance"
+                    }
+                },
+                "row_errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.AttendanceImportRowError"
+                    }
+                }
+            }
+        },
+        "domain.AttendanceImportRowError": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2025-11-10"
+                },
+                "device_user_id": {
+                    "type": "string",
+                    "example": "1001"
+                },
+                "error": {
+                    "type": "string",
+                    "example": "device user ID is not mapped to any employee"
+                },
+                "row": {
+                    "description": "Nomor baris di file sumber (1 = baris pertama)",
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "domain.AttendancePeriod": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "locked_at": {
+                    "type": "string"
+                },
+                "locked_by": {
+                    "type": "string",
+                    "example": "payroll@example.com"
+                },
+                "period": {
+                    "description": "Tanggal 1 bulan tersebut",
+                    "type": "string",
+                    "example": "2025-11-01T00:00:00Z"
+                },
+                "status": {
+                    "description": "OPEN, LOCKED",
+                    "type": "string",
+                    "example": "LOCKED"
+                },
+                "unlocked_at": {
+                    "description": "Slip PAID sebelum waktu ini tidak lagi mengunci absensi",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.AttendancePeriodUnlock": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "period": {
+                    "type": "string",
+                    "example": "2025-11-01T00:00:00Z"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Koreksi cuti sakit yang terlambat diinput"
+                },
+                "unlocked_by": {
+                    "type": "string",
+                    "example": "hr.admin@example.com"
+                }
+            }
+        },
+        "domain.AttendanceStatus": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "check_in_required": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "code": {
+                    "type": "string",
+                    "example": "HALF_DAY"
+                },
+                "counts_as_present": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deduction_weight": {
+                    "description": "Porsi potongan gaji harian: 0, 0.5, atau 1",
+                    "type": "number",
+                    "example": 0.5
+                },
+                "document_required": {
+                    "description": "Mis. surat dokter untuk SICK",
+                    "type": "boolean",
+                    "example": false
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Setengah hari"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.AuditLog": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "CREATE, UPDATE, DELETE",
+                    "type": "string",
+                    "example": "UPDATE"
+                },
+                "actor": {
+                    "description": "Header X-Actor (tidak diverifikasi), \"unknown\" jika kosong, \"system\" untuk job",
+                    "type": "string",
+                    "example": "hr.admin@example.com"
+                },
+                "changes": {
+                    "type": "object"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "entity": {
+                    "type": "string",
+                    "example": "employee"
+                },
+                "entity_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "ip": {
+                    "type": "string",
+                    "example": "10.0.0.12"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "5f2c9a7e1b3d4c60"
+                }
+            }
+        },
+        "domain.Employee": {
+            "type": "object",
+            "properties": {
+                "allowance": {
+                    "type": "number",
+                    "example": 5000
+                },
+                "base_salary": {
+                    "type": "number",
+                    "example": 50000
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "department": {
+                    "type": "string",
+                    "example": "Engineering"
+                },
+                "device_user_id": {
+                    "description": "User ID di mesin fingerprint",
+                    "type": "string",
+                    "example": "1001"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "join_date": {
+                    "description": "Tanggal mulai bekerja, nil = dianggap aktif sejak awal",
+                    "type": "string",
+                    "example": "2025-01-06T00:00:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "nik": {
+                    "description": "NIK KTP (16 digit)",
+                    "type": "string",
+                    "example": "3171012345670001"
+                },
+                "npwp": {
+                    "description": "NPWP (15 atau 16 digit) untuk bukti potong 1721-A1",
+                    "type": "string",
+                    "example": "0123456789012345"
+                },
+                "office_id": {
+                    "description": "Kantor untuk check-in mobile, nil = kantor mana pun",
+                    "type": "integer",
+                    "example": 1
+                },
+                "position": {
+                    "type": "string",
+                    "example": "Software Engineer"
+                },
+                "religion": {
+                    "description": "Menentukan jadwal pembayaran THR",
+                    "type": "string",
+                    "example": "ISLAM"
+                },
+                "resign_date": {
+                    "description": "Hari kerja terakhir, nil = masih aktif",
+                    "type": "string",
+                    "example": "2025-12-31T00:00:00Z"
+                },
+                "tax_status": {
+                    "description": "Status PTKP (TK/0 s.d. K/3), kosong = TK/0",
+                    "type": "string",
+                    "example": "K/1"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.GeoPoint": {
+            "type": "object",
+            "properties": {
+                "latitude": {
+                    "type": "number",
+                    "example": -6.2088
+                },
+                "longitude": {
+                    "type": "number",
+                    "example": 106.8456
+                }
+            }
+        },
+        "domain.Holiday": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2025-12-25T00:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Hari Raya Natal"
+                }
+            }
+        },
+        "domain.KioskKey": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string",
+                    "example": "3f1c9a..."
+                },
+                "office_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "domain.KioskScanRequest": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "CHECK_IN, CHECK_OUT",
+                    "type": "string",
+                    "example": "CHECK_IN"
+                },
+                "employee_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "token": {
+                    "type": "string",
+                    "example": "1.58871234.Hq3v..."
+                }
+            }
+        },
+        "domain.KioskToken": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "description": "Kiosk mengambil token baru setelah waktu ini",
+                    "type": "string"
+                },
+                "issued_at": {
+                    "type": "string"
+                },
+                "office_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "token": {
+                    "type": "string",
+                    "example": "1.58871234.Hq3v..."
+                }
+            }
+        },
+        "domain.Loan": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "employee_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "installment_amount": {
+                    "description": "Diisi langsung, atau dihitung dari installment_count",
+                    "type": "number",
+                    "example": 500000
+                },
+                "installment_count": {
+                    "type": "integer",
+                    "example": 6
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Kasbon biaya sekolah"
+                },
+                "outstanding_balance": {
+                    "type": "number",
+                    "example": 3000000
+                },
+                "principal": {
+                    "type": "number",
+                    "example": 3000000
+                },
+                "settled_at": {
+                    "type": "string"
+                },
+                "start_period": {
+                    "description": "Bulan cicilan pertama",
+                    "type": "string",
+                    "example": "2025-11-01T00:00:00Z"
+                },
+                "status": {
+                    "description": "ACTIVE, SETTLED",
+                    "type": "string",
+                    "example": "ACTIVE"
+                },
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.LoanTransaction"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.LoanSettlementRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "example": "Dilunasi tunai"
+                }
+            }
+        },
+        "domain.LoanTransaction": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Positif mengurangi saldo, negatif (reversal) menambah",
+                    "type": "number",
+                    "example": 500000
+                },
+                "balance_after": {
+                    "type": "number",
+                    "example": 2500000
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "loan_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "note": {
+                    "type": "string",
+                    "example": ""
+                },
+                "payroll_id": {
+                    "description": "Slip sumber cicilan / reversal",
+                    "type": "integer",
+                    "example": 10
+                },
+                "type": {
+                    "type": "string",
+                    "example": "INSTALLMENT"
+                }
+            }
+        },
+        "domain.Office": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "geofence_type": {
+                    "description": "RADIUS, POLYGON",
+                    "type": "string",
+                    "example": "RADIUS"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "latitude": {
+                    "description": "Pusat lingkaran (RADIUS)",
+                    "type": "number",
+                    "example": -6.2088
+                },
+                "longitude": {
+                    "type": "number",
+                    "example": 106.8456
+                },
+                "max_accuracy_meters": {
+                    "description": "0 = tidak dibatasi",
+                    "type": "number",
+                    "example": 100
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Kantor Pusat"
+                },
+                "policy": {
+                    "description": "REJECT, FLAG",
+                    "type": "string",
+                    "example": "REJECT"
+                },
+                "polygon": {
+                    "description": "Titik sudut (POLYGON)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.GeoPoint"
+                    }
+                },
+                "radius_meters": {
+                    "type": "number",
+                    "example": 150
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Payroll": {
+            "type": "object",
+            "properties": {
+                "absence_deduction": {
+                    "type": "number",
+                    "example": 1000
+                },
+                "active_from": {
+                    "description": "Hari pertama yang dihitung",
+                    "type": "string",
+                    "example": "2025-11-01T00:00:00Z"
+                },
+                "active_to": {
+                    "description": "Hari terakhir yang dihitung",
+                    "type": "string",
+                    "example": "2025-11-30T00:00:00Z"
+                },
+                "adjustment": {
+                    "description": "Total bersih bonus/komisi/insentif dikurangi denda",
+                    "type": "number",
+                    "example": 0
+                },
+                "allowance": {
+                    "type": "number",
+                    "example": 5000
+                },
+                "base_salary": {
+                    "type": "number",
+                    "example": 50000
+                },
+                "days_counted": {
+                    "description": "Hari aktif yang dihitung sesuai metode pro-rata",
+                    "type": "integer",
+                    "example": 30
+                },
+                "days_in_period": {
+                    "description": "Pembagi sesuai metode pro-rata",
+                    "type": "integer",
+                    "example": 30
+                },
+                "employee_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "generated_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.PayrollItem"
+                    }
+                },
+                "loan_deduction": {
+                    "description": "Total cicilan pinjaman yang dipotong",
+                    "type": "number",
+                    "example": 0
+                },
+                "paid_at": {
+                    "type": "string"
+                },
+                "period": {
+                    "description": "Biasanya awal bulan",
+                    "type": "string",
+                    "example": "2025-11-01T00:00:00Z"
+                },
+                "prorated_allowance": {
+                    "type": "number",
+                    "example": 5000
+                },
+                "prorated_base": {
+                    "type": "number",
+                    "example": 50000
+                },
+                "proration_factor": {
+                    "type": "number",
+                    "example": 1
+                },
+                "proration_method": {
+                    "type": "string",
+                    "example": "CALENDAR_DAYS"
+                },
+                "reimbursement": {
+                    "description": "Total klaim reimbursement yang dibayar (tidak kena pajak)",
+                    "type": "number",
+                    "example": 0
+                },
+                "replaced_by_id": {
+                    "description": "Slip pengganti (untuk slip VOID)",
+                    "type": "integer",
+                    "example": 2
+                },
+                "replaces_id": {
+                    "description": "Slip VOID yang digantikan slip ini",
+                    "type": "integer",
+                    "example": 1
+                },
+                "retro_adjustment": {
+                    "description": "Total rapel dari periode sebelumnya",
+                    "type": "number",
+                    "example": 0
+                },
+                "service_months": {
+                    "description": "Masa kerja dalam bulan penuh (slip THR)",
+                    "type": "integer",
+                    "example": 0
+                },
+                "status": {
+                    "description": "GENERATED, PAID, VOID",
+                    "type": "string",
+                    "example": "GENERATED"
+                },
+                "take_home_pay": {
+                    "type": "number",
+                    "example": 54000
+                },
+                "tax": {
+                    "description": "PPh 21 yang dipotong (negatif = kelebihan potong dikembalikan di masa pajak terakhir)",
+                    "type": "number",
+                    "example": 0
+                },
+                "tax_method": {
+                    "description": "Kosong = slip tidak memotong pajak",
+                    "type": "string",
+                    "example": ""
+                },
+                "tax_status": {
+                    "description": "Status PTKP awal tahun pajak yang dipakai slip ini",
+                    "type": "string",
+                    "example": "K/1"
+                },
+                "total_absent": {
+                    "description": "Hari potongan (jumlah bobot status, mis. 1.5)",
+                    "type": "number",
+                    "example": 2
+                },
+                "type": {
+                    "description": "REGULAR, THR",
+                    "type": "string",
+                    "example": "REGULAR"
+                },
+                "void_reason": {
+                    "type": "string",
+                    "example": ""
+                },
+                "voided_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.PayrollAdjustment": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 1500000
+                },
+                "applied_at": {
+                    "type": "string"
+                },
+                "cancelled_at": {
+                    "type": "string"
+                },
+                "cancelled_by": {
+                    "type": "string",
+                    "example": ""
+                },
+                "component": {
+                    "type": "string",
+                    "example": "BONUS"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "employee_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "entered_by": {
+                    "type": "string",
+                    "example": "hr.admin@example.com"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Bonus target Q3"
+                },
+                "payroll_id": {
+                    "description": "Slip yang memuat penyesuaian ini",
+                    "type": "integer",
+                    "example": 10
+                },
+                "period": {
+                    "description": "Awal bulan slip yang memuatnya",
+                    "type": "string",
+                    "example": "2025-11-01T00:00:00Z"
+                },
+                "source": {
+                    "description": "API atau CSV",
+                    "type": "string",
+                    "example": "API"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "PENDING"
+                },
+                "taxable": {
+                    "description": "Disalin dari komponen saat dicatat",
+                    "type": "boolean",
+                    "example": true
+                },
+                "type": {
+                    "description": "Disalin dari komponen saat dicatat",
+                    "type": "string",
+                    "example": "EARNING"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.PayrollDiff": {
+            "type": "object",
+            "properties": {
+                "absence_deduction": {
+                    "type": "number",
+                    "example": 2727.27
+                },
+                "allowance": {
+                    "type": "number",
+                    "example": 0
+                },
+                "base_salary": {
+                    "type": "number",
+                    "example": 10000
+                },
+                "retro_adjustment": {
+                    "type": "number",
+                    "example": 0
+                },
+                "take_home_pay": {
+                    "type": "number",
+                    "example": 7272.73
+                },
+                "total_absent": {
+                    "type": "number",
+                    "example": 1
+                }
+            }
+        },
+        "domain.PayrollItem": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 2500
+                },
+                "code": {
+                    "type": "string",
+                    "example": "RAPEL"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Rapel gaji periode 2025-10"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "payroll_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "ref_id": {
+                    "description": "ID sumber baris (mis. pinjaman untuk LOAN)",
+                    "type": "integer",
+                    "example": 1
+                },
+                "ref_period": {
+                    "description": "Periode yang dikoreksi (untuk RAPEL)",
+                    "type": "string",
+                    "example": "2025-10-01T00:00:00Z"
+                },
+                "taxable": {
+                    "description": "Penerimaan yang dikenai PPh 21 (THR, bonus, komisi)",
+                    "type": "boolean",
+                    "example": false
+                },
+                "type": {
+                    "description": "EARNING, DEDUCTION",
+                    "type": "string",
+                    "example": "EARNING"
+                }
+            }
+        },
+        "domain.PayrollLine": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 50000
+                },
+                "code": {
+                    "type": "string",
+                    "example": "BASE_SALARY"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Gaji pokok (pro-rata)"
+                },
+                "type": {
+                    "description": "EARNING, DEDUCTION",
+                    "type": "string",
+                    "example": "EARNING"
+                }
+            }
+        },
+        "domain.PayrollOverrides": {
+            "type": "object",
+            "properties": {
+                "allowance": {
+                    "type": "number",
+                    "example": 7500
+                },
+                "base_salary": {
+                    "type": "number",
+                    "example": 60000
+                },
+                "extra_absences": {
+                    "description": "Ditambahkan ke hari potongan dari data absensi",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "domain.PayrollPreview": {
+            "type": "object",
+            "properties": {
+                "diff": {
+                    "$ref": "#/definitions/domain.PayrollDiff"
+                },
+                "employee_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "employee_name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "error": {
+                    "description": "Diisi jika karyawan ini tidak bisa dihitung",
+                    "type": "string"
+                },
+                "last_slip": {
+                    "description": "Slip terakhir (tidak VOID) sampai periode simulasi",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.Payroll"
+                        }
+                    ]
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.PayrollLine"
+                    }
+                },
+                "payroll": {
+                    "$ref": "#/definitions/domain.Payroll"
+                }
+            }
+        },
+        "domain.PayrollVarianceReport": {
+            "type": "object",
+            "properties": {
+                "period_from": {
+                    "type": "string",
+                    "example": "2025-10-01T00:00:00Z"
+                },
+                "period_to": {
+                    "type": "string",
+                    "example": "2025-11-01T00:00:00Z"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.PayrollVarianceRow"
+                    }
+                },
+                "summary": {
+                    "$ref": "#/definitions/domain.PayrollVarianceSummary"
+                },
+                "threshold": {
+                    "description": "Selisih take-home pay minimum agar dilaporkan",
+                    "type": "number",
+                    "example": 100000
+                }
+            }
+        },
+        "domain.PayrollVarianceRow": {
+            "type": "object",
+            "properties": {
+                "absence_deduction_delta": {
+                    "type": "number",
+                    "example": 2500
+                },
+                "absence_deduction_from": {
+                    "type": "number",
+                    "example": 0
+                },
+                "absence_deduction_to": {
+                    "type": "number",
+                    "example": 2500
+                },
+                "allowance_delta": {
+                    "type": "number",
+                    "example": 0
+                },
+                "allowance_from": {
+                    "type": "number",
+                    "example": 5000
+                },
+                "allowance_to": {
+                    "type": "number",
+                    "example": 5000
+                },
+                "base_salary_delta": {
+                    "type": "number",
+                    "example": 5000
+                },
+                "base_salary_from": {
+                    "type": "number",
+                    "example": 50000
+                },
+                "base_salary_to": {
+                    "type": "number",
+                    "example": 55000
+                },
+                "change": {
+                    "description": "NEW, DEPARTED, CHANGED",
+                    "type": "string",
+                    "example": "CHANGED"
+                },
+                "employee_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "employee_name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "reasons": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "BASE_SALARY",
+                        "TAKE_HOME_PAY"
+                    ]
+                },
+                "take_home_pay_delta": {
+                    "type": "number",
+                    "example": 2500
+                },
+                "take_home_pay_from": {
+                    "type": "number",
+                    "example": 55000
+                },
+                "take_home_pay_to": {
+                    "type": "number",
+                    "example": 57500
+                }
+            }
+        },
+        "domain.PayrollVarianceSummary": {
+            "type": "object",
+            "properties": {
+                "departed_employees": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "employees_from": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "employees_to": {
+                    "type": "integer",
+                    "example": 11
+                },
+                "new_employees": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total_absence_deduction_from": {
+                    "type": "number",
+                    "example": 0
+                },
+                "total_absence_deduction_to": {
+                    "type": "number",
+                    "example": 2500
+                },
+                "total_allowance_from": {
+                    "type": "number",
+                    "example": 50000
+                },
+                "total_allowance_to": {
+                    "type": "number",
+                    "example": 55000
+                },
+                "total_base_salary_from": {
+                    "type": "number",
+                    "example": 500000
+                },
+                "total_base_salary_to": {
+                    "type": "number",
+                    "example": 555000
+                },
+                "total_take_home_pay_delta": {
+                    "type": "number",
+                    "example": 57500
+                },
+                "total_take_home_pay_from": {
+                    "type": "number",
+                    "example": 550000
+                },
+                "total_take_home_pay_to": {
+                    "type": "number",
+                    "example": 607500
+                }
+            }
+        },
+        "domain.Punch": {
+            "type": "object",
+            "properties": {
+                "accuracy_meters": {
+                    "type": "number",
+                    "example": 12.5
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "device_id": {
+                    "type": "string",
+                    "example": "ZK-LOBBY-01"
+                },
+                "direction": {
+                    "description": "IN, OUT, atau kosong",
+                    "type": "string",
+                    "example": "IN"
+                },
+                "distance_meters": {
+                    "description": "Jarak ke pusat / tepi geofence kantor",
+                    "type": "number",
+                    "example": 35.2
+                },
+                "employee_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "flagged": {
+                    "description": "Perlu direview (kebijakan FLAG)",
+                    "type": "boolean",
+                    "example": false
+                },
+                "geofence_status": {
+                    "description": "INSIDE, OUTSIDE, LOW_ACCURACY",
+                    "type": "string",
+                    "example": "INSIDE"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "latitude": {
+                    "description": "Data check-in mobile (kosong untuk punch dari mesin/impor)",
+                    "type": "number",
+                    "example": -6.2088
+                },
+                "location": {
+                    "type": "string",
+                    "example": "Kantor Pusat"
+                },
+                "longitude": {
+                    "type": "number",
+                    "example": 106.8456
+                },
+                "office_id": {
+                    "description": "Kantor yang geofence-nya cocok / terdekat",
+                    "type": "integer",
+                    "example": 1
+                },
+                "review_note": {
+                    "type": "string",
+                    "example": ""
+                },
+                "review_status": {
+                    "description": "Review punch yang ditandai; kosong untuk punch yang tidak ditandai",
+                    "type": "string",
+                    "example": "PENDING"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "reviewed_by": {
+                    "type": "string",
+                    "example": "manager@example.com"
+                },
+                "selfie_path": {
+                    "description": "Path di FileStorage",
+                    "type": "string",
+                    "example": "selfies/1/abc.jpg"
+                },
+                "source": {
+                    "description": "MANUAL, DEVICE, IMPORT, MOBILE, CORRECTION",
+                    "type": "string",
+                    "example": "DEVICE"
+                },
+                "timestamp": {
+                    "type": "string",
+                    "example": "2025-11-10T08:01:23Z"
+                }
+            }
+        },
+        "domain.ReimbursementCategory": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "code": {
+                    "type": "string",
+                    "example": "MEDICAL"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Pengobatan"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "yearly_limit": {
+                    "description": "0 = tanpa batas",
+                    "type": "number",
+                    "example": 5000000
+                }
+            }
+        },
+        "domain.ReimbursementClaim": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 350000
+                },
+                "category_code": {
+                    "type": "string",
+                    "example": "MEDICAL"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Konsultasi dokter umum"
+                },
+                "employee_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "expense_date": {
+                    "description": "Tanggal pengeluaran, menentukan tahun batas klaim",
+                    "type": "string",
+                    "example": "2025-11-03T00:00:00Z"
+                },
+                "finance_at": {
+                    "type": "string"
+                },
+                "finance_by": {
+                    "type": "string",
+                    "example": "finance@example.com"
+                },
+                "finance_note": {
+                    "type": "string",
+                    "example": ""
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "manager_at": {
+                    "type": "string"
+                },
+                "manager_by": {
+                    "type": "string",
+                    "example": "manager@example.com"
+                },
+                "manager_note": {
+                    "type": "string",
+                    "example": ""
+                },
+                "paid_at": {
+                    "type": "string"
+                },
+                "payroll_id": {
+                    "description": "Slip yang membayar klaim ini",
+                    "type": "integer",
+                    "example": 10
+                },
+                "receipt_path": {
+                    "type": "string",
+                    "example": "receipts/1/20251103-101500-a1b2c3d4.jpg"
+                },
+                "rejected_by": {
+                    "type": "string",
+                    "example": ""
+                },
+                "rejection_reason": {
+                    "type": "string",
+                    "example": ""
+                },
+                "status": {
+                    "type": "string",
+                    "example": "SUBMITTED"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.SalaryHistory": {
+            "type": "object",
+            "properties": {
+                "allowance": {
+                    "type": "number",
+                    "example": 5000
+                },
+                "base_salary": {
+                    "type": "number",
+                    "example": 55000
+                },
+                "baseline": {
+                    "description": "Gaji awal yang dicatat otomatis, tidak memicu rapel",
+                    "type": "boolean",
+                    "example": false
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "effective_from": {
+                    "description": "Berlaku mulai tanggal ini",
+                    "type": "string",
+                    "example": "2025-11-01T00:00:00Z"
+                },
+                "employee_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Kenaikan gaji tahunan"
+                }
+            }
+        },
+        "domain.THRRunRequest": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "description": "true = hitung saja, slip tidak disimpan",
+                    "type": "boolean",
+                    "example": true
+                },
+                "religion": {
+                    "description": "Kosong = semua agama yang punya jadwal",
+                    "type": "string",
+                    "example": "ISLAM"
+                },
+                "year": {
+                    "type": "integer",
+                    "example": 2026
+                }
+            }
+        },
+        "domain.THRRunResult": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "skipped": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.THRSkip"
+                    }
+                },
+                "slips": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Payroll"
+                    }
+                },
+                "year": {
+                    "type": "integer",
+                    "example": 2026
+                }
+            }
+        },
+        "domain.THRSchedule": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "holiday_date": {
+                    "description": "Masa kerja dihitung sampai tanggal ini",
+                    "type": "string",
+                    "example": "2026-03-20T00:00:00Z"
+                },
+                "holiday_name": {
+                    "type": "string",
+                    "example": "Idul Fitri 1447 H"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "payout_date": {
+                    "description": "Menentukan periode slip THR",
+                    "type": "string",
+                    "example": "2026-03-10T00:00:00Z"
+                },
+                "religion": {
+                    "type": "string",
+                    "example": "ISLAM"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer",
+                    "example": 2026
+                }
+            }
+        },
+        "domain.THRSkip": {
+            "type": "object",
+            "properties": {
+                "employee_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "less than one month of service"
+                }
+            }
+        },
+        "domain.TaxCertificate": {
+            "type": "object",
+            "properties": {
+                "annual_net_income": {
+                    "description": "14. Penghasilan neto setahun",
+                    "type": "number",
+                    "example": 137000000
+                },
+                "annual_tax": {
+                    "description": "17. PPh 21 atas PKP setahun",
+                    "type": "number",
+                    "example": 5100000
+                },
+                "benefits_in_kind": {
+                    "description": "6. Natura dan kenikmatan lainnya",
+                    "type": "number",
+                    "example": 0
+                },
+                "bonus_and_thr": {
+                    "description": "7. Tantiem, bonus, gratifikasi, jasa produksi dan THR",
+                    "type": "number",
+                    "example": 11000000
+                },
+                "dependents": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "employee_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "employee_name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "gross_income": {
+                    "description": "8. Jumlah penghasilan bruto (1 s.d. 7)",
+                    "type": "number",
+                    "example": 143000000
+                },
+                "honorarium": {
+                    "description": "4. Honorarium dan imbalan lain sejenis",
+                    "type": "number",
+                    "example": 0
+                },
+                "insurance_premium": {
+                    "description": "5. Premi asuransi yang dibayar pemberi kerja",
+                    "type": "number",
+                    "example": 0
                 },
-                "created_at": {
+                "issued_at": {
                     "type": "string"
                 },
-                "date": {
-                    "description": "Memastikan unik per employee per hari",
+                "job_expense": {
+                    "description": "9. Biaya jabatan",
+                    "type": "number",
+                    "example": 6000000
+                },
+                "months_count": {
+                    "description": "Jumlah bulan dengan slip gaji",
+                    "type": "integer",
+                    "example": 12
+                },
+                "net_income": {
+                    "description": "12. Penghasilan neto (8 - 11)",
+                    "type": "number",
+                    "example": 137000000
+                },
+                "nik": {
                     "type": "string",
-                    "example": "2025-11-10T00:00:00Z"
+                    "example": "3171012345670001"
                 },
-                "employee_id": {
+                "npwp": {
+                    "type": "string",
+                    "example": "0123456789012345"
+                },
+                "number": {
+                    "description": "Nomor bukti potong: 1.1-\u003cbulan akhir\u003e.\u003ctahun\u003e-\u003curut\u003e",
+                    "type": "string",
+                    "example": "1.1-12.25-0000001"
+                },
+                "other_allowances": {
+                    "description": "3. Tunjangan lainnya, uang lembur",
+                    "type": "number",
+                    "example": 12000000
+                },
+                "payroll_ids": {
+                    "description": "Slip PAID yang dijumlahkan",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "pension_contribution": {
+                    "description": "10. Iuran pensiun atau JHT",
+                    "type": "number",
+                    "example": 0
+                },
+                "period_from": {
+                    "description": "Masa perolehan awal (bulan)",
                     "type": "integer",
                     "example": 1
                 },
-                "id": {
+                "period_to": {
+                    "description": "Masa perolehan akhir (bulan)",
                     "type": "integer",
-                    "example": 1
+                    "example": 12
                 },
-                "status": {
-                    "description": "PRESENT, ABSENT, LEAVE",
+                "position": {
                     "type": "string",
-                    "example": "PRESENT"
+                    "example": "Software Engineer"
+                },
+                "previous_net_income": {
+                    "description": "13. Penghasilan neto masa sebelumnya",
+                    "type": "number",
+                    "example": 0
+                },
+                "previous_withheld_tax": {
+                    "description": "18. PPh 21 yang telah dipotong masa sebelumnya",
+                    "type": "number",
+                    "example": 0
+                },
+                "ptkp": {
+                    "description": "15. PTKP",
+                    "type": "number",
+                    "example": 63000000
+                },
+                "salary": {
+                    "description": "1. Gaji (pro-rata, setelah potongan absen, termasuk rapel)",
+                    "type": "number",
+                    "example": 120000000
+                },
+                "tax_allowance": {
+                    "description": "2. Tunjangan PPh",
+                    "type": "number",
+                    "example": 0
+                },
+                "tax_due": {
+                    "description": "19. PPh 21 terutang",
+                    "type": "number",
+                    "example": 5100000
+                },
+                "tax_object_code": {
+                    "type": "string",
+                    "example": "21-100-01"
+                },
+                "tax_status": {
+                    "type": "string",
+                    "example": "K/1"
+                },
+                "tax_underpaid": {
+                    "description": "Selisih 19 - 20 (negatif = lebih potong)",
+                    "type": "number",
+                    "example": 4000000
+                },
+                "taxable_income": {
+                    "description": "16. PKP setahun (dibulatkan ke bawah ribuan)",
+                    "type": "number",
+                    "example": 74000000
+                },
+                "total_deductions": {
+                    "description": "11. Jumlah pengurangan (9 + 10)",
+                    "type": "number",
+                    "example": 6000000
+                },
+                "withheld_tax": {
+                    "description": "20. PPh 21 yang telah dipotong dan dilunasi lewat slip",
+                    "type": "number",
+                    "example": 1100000
+                },
+                "withholder": {
+                    "$ref": "#/definitions/domain.TaxWithholder"
+                },
+                "year": {
+                    "type": "integer",
+                    "example": 2025
                 }
             }
         },
-        "domain.Employee": {
+        "domain.TaxWithholder": {
             "type": "object",
             "properties": {
-                "allowance": {
-                    "type": "number",
-                    "example": 5000
+                "name": {
+                    "type": "string",
+                    "example": "PT Contoh Sejahtera"
                 },
-                "base_salary": {
-                    "type": "number",
-                    "example": 50000
+                "npwp": {
+                    "type": "string",
+                    "example": "0123456789012000"
                 },
-                "created_at": {
-                    "type": "string"
+                "signer_name": {
+                    "type": "string",
+                    "example": "Budi Santoso"
                 },
-                "id": {
+                "signer_npwp": {
+                    "type": "string",
+                    "example": "0987654321098000"
+                }
+            }
+        },
+        "domain.Timesheet": {
+            "type": "object",
+            "properties": {
+                "days_in_month": {
                     "type": "integer",
-                    "example": 1
+                    "example": 30
                 },
-                "name": {
+                "department": {
                     "type": "string",
-                    "example": "John Doe"
+                    "example": "Engineering"
                 },
-                "position": {
+                "period": {
                     "type": "string",
-                    "example": "Software Engineer"
+                    "example": "2025-11-01T00:00:00Z"
                 },
-                "updated_at": {
-                    "type": "string"
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.TimesheetRow"
+                    }
+                },
+                "work_hours": {
+                    "description": "Jam kerja normal per hari, kelebihannya dihitung lembur",
+                    "type": "number",
+                    "example": 8
+                },
+                "work_start": {
+                    "description": "Jam masuk untuk menghitung terlambat",
+                    "type": "string",
+                    "example": "09:00"
                 }
             }
         },
-        "domain.Payroll": {
+        "domain.TimesheetRow": {
             "type": "object",
             "properties": {
-                "absence_deduction": {
+                "absent": {
+                    "description": "Status ABSENT",
+                    "type": "integer",
+                    "example": 1
+                },
+                "days": {
+                    "description": "Kode status per tanggal (indeks 0 = tanggal 1), kosong = tidak ada record",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "PRESENT",
+                        "PRESENT",
+                        "",
+                        "ABSENT"
+                    ]
+                },
+                "department": {
+                    "type": "string",
+                    "example": "Engineering"
+                },
+                "employee_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "employee_name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "late_minutes": {
+                    "type": "integer",
+                    "example": 35
+                },
+                "leave": {
+                    "description": "Status tidak hadir lainnya (cuti, sakit, ...)",
+                    "type": "integer",
+                    "example": 1
+                },
+                "overtime_hours": {
                     "type": "number",
-                    "example": 1000
+                    "example": 4.5
+                },
+                "present": {
+                    "description": "Status yang dihitung hadir",
+                    "type": "integer",
+                    "example": 20
                 },
+                "worked_hours": {
+                    "type": "number",
+                    "example": 168.25
+                }
+            }
+        },
+        "handler.AddSalaryChangeRequest": {
+            "type": "object",
+            "properties": {
                 "allowance": {
                     "type": "number",
                     "example": 5000
                 },
                 "base_salary": {
                     "type": "number",
-                    "example": 50000
+                    "example": 55000
+                },
+                "effective_from": {
+                    "description": "YYYY-MM-DD, boleh mundur (rapel)",
+                    "type": "string",
+                    "example": "2025-11-01"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Kenaikan gaji tahunan"
+                }
+            }
+        },
+        "handler.CancelAdjustmentRequest": {
+            "type": "object",
+            "properties": {
+                "cancelled_by": {
+                    "type": "string",
+                    "example": "hr.admin@example.com"
+                }
+            }
+        },
+        "handler.CreateAdjustmentRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "component",
+                "employee_id",
+                "entered_by",
+                "period"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 1500000
+                },
+                "component": {
+                    "type": "string",
+                    "example": "BONUS"
                 },
                 "employee_id": {
                     "type": "integer",
                     "example": 1
                 },
-                "generated_at": {
+                "entered_by": {
+                    "type": "string",
+                    "example": "hr.admin@example.com"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Bonus target Q3"
+                },
+                "period": {
+                    "description": "YYYY-MM",
+                    "type": "string",
+                    "example": "2025-11"
+                }
+            }
+        },
+        "handler.GeneratePayrollRequest": {
+            "type": "object",
+            "properties": {
+                "employee_id": {
+                    "type": "integer"
+                },
+                "period": {
+                    "description": "expect YYYY-MM-DD, normalized to the start of month",
                     "type": "string"
+                }
+            }
+        },
+        "handler.LockPeriodRequest": {
+            "type": "object",
+            "properties": {
+                "locked_by": {
+                    "type": "string",
+                    "example": "payroll@example.com"
+                }
+            }
+        },
+        "handler.MarkAbsencesRequest": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "description": "YYYY-MM-DD, default: kemarin",
+                    "type": "string",
+                    "example": "2025-11-01"
                 },
-                "id": {
+                "to": {
+                    "description": "YYYY-MM-DD, default: kemarin",
+                    "type": "string",
+                    "example": "2025-11-30"
+                }
+            }
+        },
+        "handler.MobilePunchResponse": {
+            "type": "object",
+            "properties": {
+                "attendance": {
+                    "$ref": "#/definitions/domain.Attendance"
+                },
+                "punch": {
+                    "$ref": "#/definitions/domain.Punch"
+                }
+            }
+        },
+        "handler.PreviewPayrollRequest": {
+            "type": "object",
+            "properties": {
+                "department": {
+                    "description": "Optional, all employees in a department",
+                    "type": "string",
+                    "example": "Engineering"
+                },
+                "employee_id": {
+                    "description": "Optional, one employee",
                     "type": "integer",
                     "example": 1
                 },
+                "overrides": {
+                    "description": "Optional what-if values",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.PayrollOverrides"
+                        }
+                    ]
+                },
                 "period": {
-                    "description": "Biasanya awal bulan",
+                    "description": "YYYY-MM-DD, normalized to the start of month",
                     "type": "string",
-                    "example": "2025-11-01T00:00:00Z"
-                },
-                "take_home_pay": {
-                    "type": "number",
-                    "example": 54000
+                    "example": "2025-11-01"
+                }
+            }
+        },
+        "handler.RecomputeAttendanceRequest": {
+            "type": "object",
+            "required": [
+                "date",
+                "employee_id"
+            ],
+            "properties": {
+                "date": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string",
+                    "example": "2025-11-10"
                 },
-                "total_absent": {
+                "employee_id": {
                     "type": "integer",
-                    "example": 2
+                    "example": 1
                 }
             }
         },
-        "handler.GeneratePayrollRequest": {
+        "handler.RecordPunchRequest": {
             "type": "object",
+            "required": [
+                "employee_id"
+            ],
             "properties": {
+                "device_id": {
+                    "type": "string",
+                    "example": "ZK-LOBBY-01"
+                },
+                "direction": {
+                    "description": "IN, OUT, atau kosong",
+                    "type": "string",
+                    "example": "IN"
+                },
                 "employee_id": {
-                    "type": "integer"
+                    "type": "integer",
+                    "example": 1
                 },
-                "period": {
-                    "description": "expect YYYY-MM-DD (start of month)",
-                    "type": "string"
+                "location": {
+                    "type": "string",
+                    "example": "Kantor Pusat"
+                },
+                "timestamp": {
+                    "description": "Default: sekarang",
+                    "type": "string",
+                    "example": "2025-11-10T08:01:23Z"
+                }
+            }
+        },
+        "handler.ReviewCorrectionRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "example": "Sesuai log CCTV"
+                },
+                "reviewer": {
+                    "type": "string",
+                    "example": "manager@example.com"
+                }
+            }
+        },
+        "handler.ReviewPunchRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "example": "Meeting di kantor klien"
+                },
+                "reviewer": {
+                    "type": "string",
+                    "example": "manager@example.com"
+                }
+            }
+        },
+        "handler.ReviewReimbursementRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "description": "Wajib diisi untuk penolakan",
+                    "type": "string",
+                    "example": "Sesuai kuitansi"
+                },
+                "reviewed_by": {
+                    "type": "string",
+                    "example": "manager@example.com"
+                }
+            }
+        },
+        "handler.UnlockPeriodRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "Koreksi cuti sakit yang terlambat diinput"
+                },
+                "unlocked_by": {
+                    "type": "string",
+                    "example": "hr.admin@example.com"
+                }
+            }
+        },
+        "handler.VoidPayrollRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "Absensi tanggal 10 salah dicatat ABSENT"
                 }
             }
         }
@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"hr-payroll/internal/domain"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AttendanceCorrectionHandler mengurus endpoint HTTP untuk pengajuan koreksi absensi
+type AttendanceCorrectionHandler struct {
+	Service domain.AttendanceCorrectionService
+}
+
+func NewAttendanceCorrectionHandler(s domain.AttendanceCorrectionService) *AttendanceCorrectionHandler {
+	return &AttendanceCorrectionHandler{Service: s}
+}
+
+// ReviewCorrectionRequest represents the payload to approve or reject a correction request
+type ReviewCorrectionRequest struct {
+	Reviewer string `json:"reviewer" example:"manager@example.com"`
+	Note     string `json:"note" example:"Sesuai log CCTV"`
+}
+
+// SubmitCorrection handles POST /attendances/corrections
+// @Summary Submit an attendance correction request
+// @Description Employees request a status change and/or corrected check-in/check-out times with a reason and optional document link. Rejected when the attendance period is locked or the payroll for that month is already PAID.
+// @Tags Attendance Corrections
+// @Accept json
+// @Produce json
+// @Param correction body domain.AttendanceCorrection true "Correction request"
+// @Success 201 {object} domain.AttendanceCorrection
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /attendances/corrections [post]
+func (h *AttendanceCorrectionHandler) SubmitCorrection(c *gin.Context) {
+	var req domain.AttendanceCorrection
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	correction, err := h.Service.SubmitCorrection(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(correctionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, correction)
+}
+
+// GetCorrections handles GET /attendances/corrections
+// @Summary List attendance correction requests
+// @Tags Attendance Corrections
+// @Accept json
+// @Produce json
+// @Param employee_id query int false "Employee ID"
+// @Param status query string false "PENDING, APPROVED or REJECTED"
+// @Success 200 {array} domain.AttendanceCorrection
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /attendances/corrections [get]
+func (h *AttendanceCorrectionHandler) GetCorrections(c *gin.Context) {
+	var employeeID uint64
+	if employeeIDStr := c.Query("employee_id"); employeeIDStr != "" {
+		var err error
+		employeeID, err = strconv.ParseUint(employeeIDStr, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid employee_id format"})
+			return
+		}
+	}
+
+	corrections, err := h.Service.GetCorrections(c.Request.Context(), uint(employeeID), c.Query("status"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve correction requests"})
+		return
+	}
+
+	c.JSON(http.StatusOK, corrections)
+}
+
+// GetCorrection handles GET /attendances/corrections/:id
+// @Summary Get an attendance correction request with its before/after values
+// @Tags Attendance Corrections
+// @Accept json
+// @Produce json
+// @Param id path int true "Correction ID"
+// @Success 200 {object} domain.AttendanceCorrection
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /attendances/corrections/{id} [get]
+func (h *AttendanceCorrectionHandler) GetCorrection(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	correction, err := h.Service.GetCorrection(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(correctionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, correction)
+}
+
+// ApproveCorrection handles POST /attendances/corrections/:id/approve
+// @Summary Approve a correction request and apply it to the attendance record
+// @Tags Attendance Corrections
+// @Accept json
+// @Produce json
+// @Param id path int true "Correction ID"
+// @Param payload body ReviewCorrectionRequest true "Reviewer and note"
+// @Success 200 {object} domain.AttendanceCorrection
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /attendances/corrections/{id}/approve [post]
+func (h *AttendanceCorrectionHandler) ApproveCorrection(c *gin.Context) {
+	h.review(c, h.Service.ApproveCorrection)
+}
+
+// RejectCorrection handles POST /attendances/corrections/:id/reject
+// @Summary Reject a correction request
+// @Tags Attendance Corrections
+// @Accept json
+// @Produce json
+// @Param id path int true "Correction ID"
+// @Param payload body ReviewCorrectionRequest true "Reviewer and note"
+// @Success 200 {object} domain.AttendanceCorrection
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /attendances/corrections/{id}/reject [post]
+func (h *AttendanceCorrectionHandler) RejectCorrection(c *gin.Context) {
+	h.review(c, h.Service.RejectCorrection)
+}
+
+func (h *AttendanceCorrectionHandler) review(c *gin.Context, action func(ctx context.Context, id uint, reviewer string, note string) (*domain.AttendanceCorrection, error)) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req ReviewCorrectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.Reviewer == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A reviewer is required"})
+		return
+	}
+
+	correction, err := action(c.Request.Context(), uint(id), req.Reviewer, req.Note)
+	if err != nil {
+		c.JSON(correctionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, correction)
+}
+
+func correctionErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrCorrectionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrCorrectionNotPending):
+		return http.StatusConflict
+	default:
+		return attendancePeriodErrorStatus(err, http.StatusBadRequest)
+	}
+}
@@ -10,11 +10,13 @@ import (
 )
 
 type AttendanceHandler struct {
-	Service domain.AttendanceService
+	Service        domain.AttendanceService
+	ImportService  domain.AttendanceImportService
+	AbsenceService domain.AbsenceMarkingService
 }
 
-func NewAttendanceHandler(s domain.AttendanceService) *AttendanceHandler {
-	return &AttendanceHandler{Service: s}
+func NewAttendanceHandler(s domain.AttendanceService, is domain.AttendanceImportService, as domain.AbsenceMarkingService) *AttendanceHandler {
+	return &AttendanceHandler{Service: s, ImportService: is, AbsenceService: as}
 }
 
 // RecordAttendance handles POST /attendances
@@ -23,7 +25,10 @@ func NewAttendanceHandler(s domain.AttendanceService) *AttendanceHandler {
 // @Accept json
 // @Produce json
 // @Param attendance body domain.Attendance true "Attendance object"
+// @Param Idempotency-Key header string false "Retries with the same key and payload replay the first response"
 // @Success 201 {object} domain.Attendance
+// @Failure 409 {object} map[string]string
+// @Failure 422 {object} map[string]string
 // @Router /attendances [post]
 func (h *AttendanceHandler) RecordAttendance(c *gin.Context) {
 	var req domain.Attendance
@@ -35,7 +40,7 @@ func (h *AttendanceHandler) RecordAttendance(c *gin.Context) {
 	// Pastikan Date di-set ke awal hari untuk validasi unik yang benar
 	req.Date = time.Date(req.Date.Year(), req.Date.Month(), req.Date.Day(), 0, 0, 0, 0, time.UTC)
 
-	attendance, err := h.Service.RecordAttendance(&req)
+	attendance, err := h.Service.RecordAttendance(c.Request.Context(), &req)
 	if err != nil {
 		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 		return
@@ -50,7 +55,10 @@ func (h *AttendanceHandler) RecordAttendance(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param checkout body domain.Attendance true "Checkout object"
+// @Param Idempotency-Key header string false "Retries with the same key and payload replay the first response"
 // @Success 200 {object} domain.Attendance
+// @Failure 409 {object} map[string]string
+// @Failure 422 {object} map[string]string
 // @Router /attendances/checkout [put]
 func (h *AttendanceHandler) RecordCheckout(c *gin.Context) {
 	var req struct {
@@ -62,7 +70,7 @@ func (h *AttendanceHandler) RecordCheckout(c *gin.Context) {
 	}
 
 	now := time.Now()
-	attendance, err := h.Service.RecordCheckout(req.EmployeeID, now)
+	attendance, err := h.Service.RecordCheckout(c.Request.Context(), req.EmployeeID, now)
 	if err != nil {
 		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 		return
@@ -106,7 +114,7 @@ func (h *AttendanceHandler) GetAttendanceByPeriod(c *gin.Context) {
 		return
 	}
 
-	attendances, err := h.Service.GetAttendanceByPeriod(uint(employeeID), from, to)
+	attendances, err := h.Service.GetAttendanceByPeriod(c.Request.Context(), uint(employeeID), from, to)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve attendances"})
 		return
@@ -114,3 +122,233 @@ func (h *AttendanceHandler) GetAttendanceByPeriod(c *gin.Context) {
 
 	c.JSON(http.StatusOK, attendances)
 }
+
+// ImportAttendance handles POST /attendances/import
+// @Summary Bulk import attendance from fingerprint / time-clock exports
+// @Description Accepts CSV, ZKTeco/Solution ATTLOG .dat and XLSX exports. Raw punches are appended to the punch log and the daily attendance is derived per employee per day using the configured pairing rule (FIRST_IN_LAST_OUT or PAIRED_SESSIONS) and device user IDs are mapped through employee.device_user_id. With dry_run=true nothing is saved.
+// @Tags Attendances
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "Device export file"
+// @Param format formData string false "CSV, ATTLOG or XLSX (default: from file extension)"
+// @Param dry_run formData bool false "Validate only, do not save"
+// @Success 200 {object} domain.AttendanceImportResult
+// @Failure 400 {object} map[string]string
+// @Router /attendances/import [post]
+func (h *AttendanceHandler) ImportAttendance(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A file is required"})
+		return
+	}
+
+	format := c.PostForm("format")
+	if format == "" {
+		format = domain.ImportFormatFromFilename(fileHeader.Filename)
+	}
+	if format == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown file format, set format to CSV, ATTLOG or XLSX"})
+		return
+	}
+
+	dryRun := false
+	if dryRunStr := c.PostForm("dry_run"); dryRunStr != "" {
+		dryRun, err = strconv.ParseBool(dryRunStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid dry_run value"})
+			return
+		}
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read uploaded file"})
+		return
+	}
+	defer file.Close()
+
+	result, err := h.ImportService.Import(c.Request.Context(), file, format, dryRun)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// RecordPunchRequest adalah body untuk POST /attendances/punches
+type RecordPunchRequest struct {
+	EmployeeID uint       `json:"employee_id" binding:"required" example:"1"`
+	Timestamp  *time.Time `json:"timestamp" example:"2025-11-10T08:01:23Z"` // Default: sekarang
+	Direction  string     `json:"direction" example:"IN"`                   // IN, OUT, atau kosong
+	DeviceID   string     `json:"device_id" example:"ZK-LOBBY-01"`
+	Location   string     `json:"location" example:"Kantor Pusat"`
+}
+
+// RecomputeAttendanceRequest adalah body untuk POST /attendances/recompute
+type RecomputeAttendanceRequest struct {
+	EmployeeID uint   `json:"employee_id" binding:"required" example:"1"`
+	Date       string `json:"date" binding:"required" example:"2025-11-10"` // YYYY-MM-DD
+}
+
+// RecordPunch handles POST /attendances/punches
+// @Summary Record a raw punch (clock in/out tap)
+// @Description Appends a punch to the immutable punch log and returns the re-derived daily attendance.
+// @Tags Attendances
+// @Accept json
+// @Produce json
+// @Param punch body RecordPunchRequest true "Punch"
+// @Param Idempotency-Key header string false "Retries with the same key and payload replay the first response"
+// @Success 201 {object} domain.Attendance
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Router /attendances/punches [post]
+func (h *AttendanceHandler) RecordPunch(c *gin.Context) {
+	var req RecordPunchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	punch := domain.Punch{
+		EmployeeID: req.EmployeeID,
+		Direction:  req.Direction,
+		Source:     domain.PunchSourceDevice,
+		DeviceID:   req.DeviceID,
+		Location:   req.Location,
+	}
+	if req.Timestamp != nil {
+		punch.Timestamp = *req.Timestamp
+	}
+
+	attendance, err := h.Service.RecordPunch(c.Request.Context(), &punch)
+	if err != nil {
+		c.JSON(attendancePeriodErrorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, attendance)
+}
+
+// GetPunchesByPeriod handles GET /attendances/punches
+// @Summary Get raw punches by period for an employee
+// @Tags Attendances
+// @Accept json
+// @Produce json
+// @Param employee_id query int true "Employee ID"
+// @Param from query string true "From date (YYYY-MM-DD)"
+// @Param to query string true "To date (YYYY-MM-DD)"
+// @Success 200 {array} domain.Punch
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /attendances/punches [get]
+func (h *AttendanceHandler) GetPunchesByPeriod(c *gin.Context) {
+	employeeID, err := strconv.ParseUint(c.Query("employee_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid employee_id format"})
+		return
+	}
+
+	from, err := time.Parse("2006-01-02", c.Query("from"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'from' date format, use YYYY-MM-DD"})
+		return
+	}
+
+	to, err := time.Parse("2006-01-02", c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'to' date format, use YYYY-MM-DD"})
+		return
+	}
+
+	punches, err := h.Service.GetPunchesByPeriod(c.Request.Context(), uint(employeeID), from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve punches"})
+		return
+	}
+
+	c.JSON(http.StatusOK, punches)
+}
+
+// RecomputeAttendance handles POST /attendances/recompute
+// @Summary Re-derive a daily attendance from its punch log
+// @Description Useful after changing ATTENDANCE_PAIRING_RULE.
+// @Tags Attendances
+// @Accept json
+// @Produce json
+// @Param request body RecomputeAttendanceRequest true "Employee and date"
+// @Success 200 {object} domain.Attendance
+// @Failure 400 {object} map[string]string
+// @Router /attendances/recompute [post]
+func (h *AttendanceHandler) RecomputeAttendance(c *gin.Context) {
+	var req RecomputeAttendanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date format, use YYYY-MM-DD"})
+		return
+	}
+
+	attendance, err := h.Service.RecomputeAttendance(c.Request.Context(), req.EmployeeID, date)
+	if err != nil {
+		c.JSON(attendancePeriodErrorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, attendance)
+}
+
+// MarkAbsencesRequest adalah body untuk POST /attendances/absences/mark
+type MarkAbsencesRequest struct {
+	From string `json:"from" example:"2025-11-01"` // YYYY-MM-DD, default: kemarin
+	To   string `json:"to" example:"2025-11-30"`   // YYYY-MM-DD, default: kemarin
+}
+
+// MarkAbsences handles POST /attendances/absences/mark
+// @Summary Mark ABSENT for working days without any attendance
+// @Description For each past working day in the range (Mon-Fri, not a holiday), creates an ABSENT record for every active employee with no attendance or leave on that day. Safe to run repeatedly; the daily job does the same for the last ABSENCE_JOB_LOOKBACK_DAYS days.
+// @Tags Attendances
+// @Accept json
+// @Produce json
+// @Param request body MarkAbsencesRequest false "Date range"
+// @Success 200 {object} domain.AbsenceMarkingResult
+// @Failure 400 {object} map[string]string
+// @Router /attendances/absences/mark [post]
+func (h *AttendanceHandler) MarkAbsences(c *gin.Context) {
+	var req MarkAbsencesRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+			return
+		}
+	}
+
+	yesterday := time.Now().AddDate(0, 0, -1)
+	from, to := yesterday, yesterday
+	var err error
+	if req.From != "" {
+		if from, err = time.Parse("2006-01-02", req.From); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'from' date format, use YYYY-MM-DD"})
+			return
+		}
+	}
+	if req.To != "" {
+		if to, err = time.Parse("2006-01-02", req.To); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'to' date format, use YYYY-MM-DD"})
+			return
+		}
+	}
+
+	result, err := h.AbsenceService.MarkAbsences(c.Request.Context(), from, to)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"errors"
+	"hr-payroll/internal/domain"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AttendancePeriodHandler mengurus endpoint HTTP untuk penguncian periode absensi
+type AttendancePeriodHandler struct {
+	Service domain.AttendancePeriodService
+}
+
+func NewAttendancePeriodHandler(s domain.AttendancePeriodService) *AttendancePeriodHandler {
+	return &AttendancePeriodHandler{Service: s}
+}
+
+// LockPeriodRequest represents the payload to lock an attendance period
+type LockPeriodRequest struct {
+	LockedBy string `json:"locked_by" example:"payroll@example.com"`
+}
+
+// UnlockPeriodRequest represents the payload to unlock an attendance period
+type UnlockPeriodRequest struct {
+	UnlockedBy string `json:"unlocked_by" example:"hr.admin@example.com"`
+	Reason     string `json:"reason" example:"Koreksi cuti sakit yang terlambat diinput"`
+}
+
+// GetPeriods handles GET /attendances/periods
+// @Summary List attendance periods that have been locked or unlocked
+// @Tags Attendance Periods
+// @Accept json
+// @Produce json
+// @Success 200 {array} domain.AttendancePeriod
+// @Failure 500 {object} map[string]string
+// @Router /attendances/periods [get]
+func (h *AttendancePeriodHandler) GetPeriods(c *gin.Context) {
+	periods, err := h.Service.GetPeriods(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve attendance periods"})
+		return
+	}
+
+	c.JSON(http.StatusOK, periods)
+}
+
+// LockPeriod handles POST /attendances/periods/:period/lock
+// @Summary Close and lock an attendance period
+// @Description After locking, recording, punching, recomputing and correcting attendance in that month is rejected with 409.
+// @Tags Attendance Periods
+// @Accept json
+// @Produce json
+// @Param period path string true "Month (YYYY-MM)"
+// @Param payload body LockPeriodRequest true "Who locks the period"
+// @Success 200 {object} domain.AttendancePeriod
+// @Failure 400 {object} map[string]string
+// @Router /attendances/periods/{period}/lock [post]
+func (h *AttendancePeriodHandler) LockPeriod(c *gin.Context) {
+	period, err := time.Parse("2006-01", c.Param("period"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid period format, use YYYY-MM"})
+		return
+	}
+
+	var req LockPeriodRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	locked, err := h.Service.LockPeriod(c.Request.Context(), period, req.LockedBy)
+	if err != nil {
+		c.JSON(attendancePeriodErrorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, locked)
+}
+
+// UnlockPeriod handles POST /attendances/periods/:period/unlock
+// @Summary Unlock an attendance period
+// @Description Reopens an explicitly locked month, or a month locked because payroll slips were PAID. A reason is required and every unlock is recorded.
+// @Tags Attendance Periods
+// @Accept json
+// @Produce json
+// @Param period path string true "Month (YYYY-MM)"
+// @Param payload body UnlockPeriodRequest true "Who unlocks the period and why"
+// @Success 200 {object} domain.AttendancePeriod
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /attendances/periods/{period}/unlock [post]
+func (h *AttendancePeriodHandler) UnlockPeriod(c *gin.Context) {
+	period, err := time.Parse("2006-01", c.Param("period"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid period format, use YYYY-MM"})
+		return
+	}
+
+	var req UnlockPeriodRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	opened, err := h.Service.UnlockPeriod(c.Request.Context(), period, req.UnlockedBy, req.Reason)
+	if err != nil {
+		c.JSON(attendancePeriodErrorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, opened)
+}
+
+// GetUnlocks handles GET /attendances/periods/:period/unlocks
+// @Summary List the unlock log of an attendance period
+// @Tags Attendance Periods
+// @Accept json
+// @Produce json
+// @Param period path string true "Month (YYYY-MM)"
+// @Success 200 {array} domain.AttendancePeriodUnlock
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /attendances/periods/{period}/unlocks [get]
+func (h *AttendancePeriodHandler) GetUnlocks(c *gin.Context) {
+	period, err := time.Parse("2006-01", c.Param("period"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid period format, use YYYY-MM"})
+		return
+	}
+
+	unlocks, err := h.Service.GetUnlocks(c.Request.Context(), period)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve unlock log"})
+		return
+	}
+
+	c.JSON(http.StatusOK, unlocks)
+}
+
+// attendancePeriodErrorStatus memetakan error periode terkunci ke 409, selain itu ke fallback
+func attendancePeriodErrorStatus(err error, fallback int) int {
+	switch {
+	case errors.Is(err, domain.ErrAttendancePeriodLocked), errors.Is(err, domain.ErrPayrollPeriodLocked), errors.Is(err, domain.ErrAttendancePeriodNotLocked):
+		return http.StatusConflict
+	default:
+		return fallback
+	}
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"errors"
+	"hr-payroll/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AttendanceStatusHandler mengurus endpoint HTTP untuk katalog status absensi
+type AttendanceStatusHandler struct {
+	Service domain.AttendanceStatusService
+}
+
+func NewAttendanceStatusHandler(s domain.AttendanceStatusService) *AttendanceStatusHandler {
+	return &AttendanceStatusHandler{Service: s}
+}
+
+// GetStatuses godoc
+// @Summary List the attendance status catalogue
+// @Tags Attendance Statuses
+// @Accept json
+// @Produce json
+// @Success 200 {array} domain.AttendanceStatus
+// @Failure 500 {object} map[string]string
+// @Router /attendance-statuses [get]
+func (h *AttendanceStatusHandler) GetStatuses(c *gin.Context) {
+	statuses, err := h.Service.GetStatuses(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve attendance statuses"})
+		return
+	}
+
+	c.JSON(http.StatusOK, statuses)
+}
+
+// CreateStatus godoc
+// @Summary Add an attendance status to the catalogue
+// @Description deduction_weight is the share of a daily salary deducted for the day (0, 0.5 or 1).
+// @Tags Attendance Statuses
+// @Accept json
+// @Produce json
+// @Param status body domain.AttendanceStatus true "Attendance status"
+// @Success 201 {object} domain.AttendanceStatus
+// @Failure 400 {object} map[string]string
+// @Router /attendance-statuses [post]
+func (h *AttendanceStatusHandler) CreateStatus(c *gin.Context) {
+	var req domain.AttendanceStatus
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	status, err := h.Service.CreateStatus(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, status)
+}
+
+// UpdateStatus godoc
+// @Summary Update the rules of an attendance status
+// @Description The code cannot be changed. Built-in statuses (PRESENT, ABSENT, LEAVE) cannot be deactivated.
+// @Tags Attendance Statuses
+// @Accept json
+// @Produce json
+// @Param code path string true "Status code"
+// @Param status body domain.AttendanceStatus true "Attendance status"
+// @Success 200 {object} domain.AttendanceStatus
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /attendance-statuses/{code} [put]
+func (h *AttendanceStatusHandler) UpdateStatus(c *gin.Context) {
+	req := domain.AttendanceStatus{Active: true} // active tidak dikirim = tetap aktif
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	status, err := h.Service.UpdateStatus(c.Request.Context(), c.Param("code"), &req)
+	if err != nil {
+		code := http.StatusBadRequest
+		if errors.Is(err, domain.ErrAttendanceStatusNotFound) {
+			code = http.StatusNotFound
+		}
+		c.JSON(code, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"errors"
+	"hr-payroll/internal/domain"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuditLogHandler mengurus endpoint HTTP untuk membaca audit log
+type AuditLogHandler struct {
+	Service domain.AuditLogService
+}
+
+func NewAuditLogHandler(s domain.AuditLogService) *AuditLogHandler {
+	return &AuditLogHandler{Service: s}
+}
+
+// GetAuditLogs godoc
+// @Summary List audit log entries for employees, attendance and payroll
+// @Description Newest first. Each entry holds the actor (X-Actor header as sent by the client, not verified; "unknown" when missing, "system" for jobs), action, entity, per-field before/after diff, request ID and client IP.
+// @Tags Audit
+// @Produce json
+// @Param entity query string false "employee, attendance or payroll"
+// @Param entity_id query int false "Entity ID (requires entity)"
+// @Param actor query string false "Actor"
+// @Param from query string false "From date (YYYY-MM-DD)"
+// @Param to query string false "To date (YYYY-MM-DD, inclusive)"
+// @Success 200 {array} domain.AuditLog
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /audit-logs [get]
+func (h *AuditLogHandler) GetAuditLogs(c *gin.Context) {
+	filter := domain.AuditLogFilter{
+		Entity: c.Query("entity"),
+		Actor:  c.Query("actor"),
+	}
+	if idStr := c.Query("entity_id"); idStr != "" {
+		id, err := strconv.ParseUint(idStr, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid entity_id"})
+			return
+		}
+		if filter.Entity == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "entity is required when filtering by entity_id"})
+			return
+		}
+		filter.EntityID = uint(id)
+	}
+	if fromStr := c.Query("from"); fromStr != "" {
+		from, err := time.Parse("2006-01-02", fromStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'from' date format, use YYYY-MM-DD"})
+			return
+		}
+		filter.From = from
+	}
+	if toStr := c.Query("to"); toStr != "" {
+		to, err := time.Parse("2006-01-02", toStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'to' date format, use YYYY-MM-DD"})
+			return
+		}
+		filter.To = to
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.To.Before(filter.From) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "'to' must not be before 'from'"})
+		return
+	}
+
+	logs, err := h.Service.GetAuditLogs(c.Request.Context(), filter)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidAuditEntity) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve audit logs"})
+		return
+	}
+
+	c.JSON(http.StatusOK, logs)
+}
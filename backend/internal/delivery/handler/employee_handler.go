@@ -1,9 +1,11 @@
 package handler
 
 import (
+	"errors"
 	"hr-payroll/internal/domain"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -36,8 +38,12 @@ func (h *EmployeeHandler) CreateEmployee(c *gin.Context) {
 	}
 
 	// Panggil Service
-	employee, err := h.Service.CreateEmployee(&req)
+	employee, err := h.Service.CreateEmployee(c.Request.Context(), &req)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidEmployee) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create employee"})
 		return
 	}
@@ -66,7 +72,7 @@ func (h *EmployeeHandler) GetEmployeeByID(c *gin.Context) {
 	}
 
 	// Panggil Service
-	employee, err := h.Service.GetEmployeeByID(uint(id))
+	employee, err := h.Service.GetEmployeeByID(c.Request.Context(), uint(id))
 	if err != nil {
 		// Logika sederhana untuk Not Found
 		if err.Error() == "record not found" {
@@ -90,7 +96,7 @@ func (h *EmployeeHandler) GetEmployeeByID(c *gin.Context) {
 // @Failure 500 {object} map[string]string
 // @Router /employees [get]
 func (h *EmployeeHandler) GetAllEmployees(c *gin.Context) {
-	employees, err := h.Service.GetAllEmployees()
+	employees, err := h.Service.GetAllEmployees(c.Request.Context())
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve employees"})
 		return
@@ -100,6 +106,7 @@ func (h *EmployeeHandler) GetAllEmployees(c *gin.Context) {
 
 // UpdateEmployee handles PUT /employees/:id
 // @Summary Update an existing employee
+// @Description Only fields present in the body are changed; omitted fields (join date, tax status, office, device ID, ...) keep their stored value. Send null to clear a date.
 // @Tags Employees
 // @Accept json
 // @Produce json
@@ -118,21 +125,123 @@ func (h *EmployeeHandler) UpdateEmployee(c *gin.Context) {
 		return
 	}
 
-	var req domain.Employee
-	if err := c.ShouldBindJSON(&req); err != nil {
+	// Body di-decode di atas data tersimpan agar field yang tidak dikirim (mis. form UI yang hanya
+	// mengirim nama, gaji dan jabatan) tidak ikut terhapus
+	req, err := h.Service.GetEmployeeByID(c.Request.Context(), uint(id))
+	if err != nil {
+		if err.Error() == "record not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Employee not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update employee"})
+		return
+	}
+	if err := c.ShouldBindJSON(req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
 		return
 	}
 
-	updatedEmployee, err := h.Service.UpdateEmployee(uint(id), &req)
+	updatedEmployee, err := h.Service.UpdateEmployee(c.Request.Context(), uint(id), req)
 	if err != nil {
 		if err.Error() == "record not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Employee not found"})
 			return
 		}
+		if errors.Is(err, domain.ErrInvalidEmployee) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update employee"})
 		return
 	}
 
 	c.JSON(http.StatusOK, updatedEmployee)
 }
+
+// AddSalaryChangeRequest represents an effective-dated salary change
+type AddSalaryChangeRequest struct {
+	BaseSalary    float64 `json:"base_salary" example:"55000"`
+	Allowance     float64 `json:"allowance" example:"5000"`
+	EffectiveFrom string  `json:"effective_from" example:"2025-11-01"` // YYYY-MM-DD, boleh mundur (rapel)
+	Note          string  `json:"note" example:"Kenaikan gaji tahunan"`
+}
+
+// AddSalaryChange handles POST /employees/:id/salaries
+// @Summary Record an effective-dated salary change
+// @Description Back-dated changes into already generated periods are paid as a retroactive adjustment (rapel) on the next payroll.
+// @Tags Employees
+// @Accept json
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Param salary body AddSalaryChangeRequest true "Salary change"
+// @Success 201 {object} domain.SalaryHistory
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /employees/{id}/salaries [post]
+func (h *EmployeeHandler) AddSalaryChange(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req AddSalaryChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	effectiveFrom, err := time.Parse("2006-01-02", req.EffectiveFrom)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid effective_from format. Use YYYY-MM-DD"})
+		return
+	}
+
+	history, err := h.Service.AddSalaryChange(c.Request.Context(), uint(id), &domain.SalaryHistory{
+		BaseSalary:    req.BaseSalary,
+		Allowance:     req.Allowance,
+		EffectiveFrom: effectiveFrom,
+		Note:          req.Note,
+	})
+	if err != nil {
+		if err.Error() == "record not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Employee not found"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, history)
+}
+
+// GetSalaryHistory handles GET /employees/:id/salaries
+// @Summary Get salary history of an employee
+// @Tags Employees
+// @Produce json
+// @Param id path int true "Employee ID"
+// @Success 200 {array} domain.SalaryHistory
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /employees/{id}/salaries [get]
+func (h *EmployeeHandler) GetSalaryHistory(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	histories, err := h.Service.GetSalaryHistory(c.Request.Context(), uint(id))
+	if err != nil {
+		if err.Error() == "record not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Employee not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve salary history"})
+		return
+	}
+
+	c.JSON(http.StatusOK, histories)
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"hr-payroll/internal/domain"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HolidayHandler mengurus endpoint HTTP untuk kalender hari libur
+type HolidayHandler struct {
+	Service domain.HolidayService
+}
+
+func NewHolidayHandler(s domain.HolidayService) *HolidayHandler {
+	return &HolidayHandler{Service: s}
+}
+
+// CreateHoliday godoc
+// @Summary Add a holiday to the working calendar
+// @Tags Holidays
+// @Accept json
+// @Produce json
+// @Param holiday body domain.Holiday true "Holiday object"
+// @Success 201 {object} domain.Holiday
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /holidays [post]
+func (h *HolidayHandler) CreateHoliday(c *gin.Context) {
+	var req domain.Holiday
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	holiday, err := h.Service.CreateHoliday(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, holiday)
+}
+
+// GetHolidaysByPeriod godoc
+// @Summary List holidays within a date range
+// @Tags Holidays
+// @Accept json
+// @Produce json
+// @Param from query string true "From date (YYYY-MM-DD)"
+// @Param to query string true "To date (YYYY-MM-DD)"
+// @Success 200 {array} domain.Holiday
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /holidays [get]
+func (h *HolidayHandler) GetHolidaysByPeriod(c *gin.Context) {
+	from, err := time.Parse("2006-01-02", c.Query("from"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'from' date format, use YYYY-MM-DD"})
+		return
+	}
+
+	to, err := time.Parse("2006-01-02", c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'to' date format, use YYYY-MM-DD"})
+		return
+	}
+
+	holidays, err := h.Service.GetHolidaysByPeriod(c.Request.Context(), from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve holidays"})
+		return
+	}
+
+	c.JSON(http.StatusOK, holidays)
+}
+
+// DeleteHoliday godoc
+// @Summary Remove a holiday from the working calendar
+// @Tags Holidays
+// @Produce json
+// @Param id path int true "Holiday ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /holidays/{id} [delete]
+func (h *HolidayHandler) DeleteHoliday(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	if err := h.Service.DeleteHoliday(c.Request.Context(), uint(id)); err != nil {
+		if err.Error() == "record not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Holiday not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete holiday"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"errors"
+	"hr-payroll/internal/domain"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// KioskHandler mengurus endpoint HTTP untuk check-in lewat QR kiosk
+type KioskHandler struct {
+	Service domain.KioskService
+}
+
+func NewKioskHandler(s domain.KioskService) *KioskHandler {
+	return &KioskHandler{Service: s}
+}
+
+// IssueKioskKey handles POST /offices/:id/kiosk-key
+// @Summary Issue (or rotate) the secret key for an office's kiosk tablet
+// @Description Admin only (X-Admin-Key must match ADMIN_API_KEY; the endpoint is disabled when it is not set). The key is returned only once. The kiosk sends it as X-Kiosk-Key when fetching QR tokens; issuing a new key invalidates the old one.
+// @Tags Kiosk
+// @Accept json
+// @Produce json
+// @Param id path int true "Office ID"
+// @Param X-Admin-Key header string true "Admin API key (ADMIN_API_KEY)"
+// @Success 201 {object} domain.KioskKey
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /offices/{id}/kiosk-key [post]
+func (h *KioskHandler) IssueKioskKey(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	key, err := h.Service.IssueKioskKey(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(kioskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, key)
+}
+
+// GetKioskToken handles GET /offices/:id/kiosk-token
+// @Summary Get the current rotating QR token for an office kiosk
+// @Description Tokens are HMAC-signed and rotate every 30 seconds; the kiosk should refresh after expires_at.
+// @Tags Kiosk
+// @Accept json
+// @Produce json
+// @Param id path int true "Office ID"
+// @Param X-Kiosk-Key header string true "Kiosk key"
+// @Success 200 {object} domain.KioskToken
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /offices/{id}/kiosk-token [get]
+func (h *KioskHandler) GetKioskToken(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	token, err := h.Service.CurrentToken(c.Request.Context(), uint(id), c.GetHeader("X-Kiosk-Key"))
+	if err != nil {
+		c.JSON(kioskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, token)
+}
+
+// ScanKioskToken handles POST /attendances/kiosk/scan
+// @Summary Check in or out by scanning the kiosk QR code
+// @Description Expired, forged or already-used tokens are rejected. CHECK_IN records a PRESENT attendance, CHECK_OUT records the checkout time.
+// @Tags Kiosk
+// @Accept json
+// @Produce json
+// @Param scan body domain.KioskScanRequest true "Scanned token"
+// @Param Idempotency-Key header string false "Retries with the same key and payload replay the first response"
+// @Success 200 {object} domain.Attendance
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Router /attendances/kiosk/scan [post]
+func (h *KioskHandler) ScanKioskToken(c *gin.Context) {
+	var req domain.KioskScanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	attendance, err := h.Service.Scan(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(kioskErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, attendance)
+}
+
+func kioskErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrOfficeNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrKioskKeyInvalid), errors.Is(err, domain.ErrKioskTokenInvalid), errors.Is(err, domain.ErrKioskTokenExpired):
+		return http.StatusUnauthorized
+	case errors.Is(err, domain.ErrKioskTokenReplayed):
+		return http.StatusConflict
+	default:
+		return attendancePeriodErrorStatus(err, http.StatusBadRequest)
+	}
+}
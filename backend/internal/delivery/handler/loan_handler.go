@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"errors"
+	"hr-payroll/internal/domain"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LoanHandler mengurus endpoint HTTP untuk pinjaman / kasbon karyawan
+type LoanHandler struct {
+	Service domain.LoanService
+}
+
+func NewLoanHandler(s domain.LoanService) *LoanHandler {
+	return &LoanHandler{Service: s}
+}
+
+// CreateLoan handles POST /loans
+// @Summary Create an employee loan or cash advance
+// @Description Set either installment_amount or installment_count. Installments are deducted by payroll generation from start_period onwards without pushing take-home pay below the configured floor.
+// @Tags Loans
+// @Accept json
+// @Produce json
+// @Param loan body domain.Loan true "Loan"
+// @Success 201 {object} domain.Loan
+// @Failure 400 {object} map[string]string
+// @Router /loans [post]
+func (h *LoanHandler) CreateLoan(c *gin.Context) {
+	var req domain.Loan
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	loan, err := h.Service.CreateLoan(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, loan)
+}
+
+// GetLoans handles GET /loans
+// @Summary List loans
+// @Tags Loans
+// @Accept json
+// @Produce json
+// @Param employee_id query int false "Employee ID"
+// @Success 200 {array} domain.Loan
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /loans [get]
+func (h *LoanHandler) GetLoans(c *gin.Context) {
+	var employeeID uint64
+	if employeeIDStr := c.Query("employee_id"); employeeIDStr != "" {
+		var err error
+		employeeID, err = strconv.ParseUint(employeeIDStr, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid employee_id format"})
+			return
+		}
+	}
+
+	loans, err := h.Service.GetLoans(c.Request.Context(), uint(employeeID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve loans"})
+		return
+	}
+
+	c.JSON(http.StatusOK, loans)
+}
+
+// GetLoan handles GET /loans/:id
+// @Summary Get a loan with its balance transactions
+// @Tags Loans
+// @Accept json
+// @Produce json
+// @Param id path int true "Loan ID"
+// @Success 200 {object} domain.Loan
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /loans/{id} [get]
+func (h *LoanHandler) GetLoan(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	loan, err := h.Service.GetLoan(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(loanErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, loan)
+}
+
+// SettleLoan handles POST /loans/:id/settle
+// @Summary Settle the outstanding balance of a loan early
+// @Description GENERATED slips that already contain an installment of this loan must be recalculated before they can be paid.
+// @Tags Loans
+// @Accept json
+// @Produce json
+// @Param id path int true "Loan ID"
+// @Param payload body domain.LoanSettlementRequest false "Settlement note"
+// @Success 200 {object} domain.Loan
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /loans/{id}/settle [post]
+func (h *LoanHandler) SettleLoan(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req domain.LoanSettlementRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+			return
+		}
+	}
+
+	loan, err := h.Service.SettleLoan(c.Request.Context(), uint(id), req)
+	if err != nil {
+		c.JSON(loanErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, loan)
+}
+
+func loanErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrLoanNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrLoanNotActive):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,244 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"hr-payroll/internal/domain"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxSelfieSize adalah batas ukuran file selfie (5 MB)
+const maxSelfieSize = 5 << 20
+
+// MobileAttendanceHandler mengurus check-in/check-out dari aplikasi mobile
+type MobileAttendanceHandler struct {
+	Service domain.MobileAttendanceService
+}
+
+func NewMobileAttendanceHandler(s domain.MobileAttendanceService) *MobileAttendanceHandler {
+	return &MobileAttendanceHandler{Service: s}
+}
+
+// MobilePunchResponse adalah hasil check-in/check-out mobile
+type MobilePunchResponse struct {
+	Attendance *domain.Attendance `json:"attendance"`
+	Punch      *domain.Punch      `json:"punch"`
+}
+
+// ReviewPunchRequest adalah payload approve/reject punch yang ditandai
+type ReviewPunchRequest struct {
+	Reviewer string `json:"reviewer" example:"manager@example.com"`
+	Note     string `json:"note" example:"Meeting di kantor klien"`
+}
+
+// RecordMobilePunch handles POST /attendances/mobile/punches
+// @Summary Check in or out from the mobile app with GPS and optional selfie
+// @Description The location is validated against the employee's office geofence (or every office when the employee has none). Out-of-fence or low-accuracy punches are rejected (403) or stored with flagged=true, depending on the office policy. Flagged punches do not count towards the daily attendance until approved (attendance is null when the day has no other punches). The server clock is used as the punch time.
+// @Tags Attendances
+// @Accept multipart/form-data
+// @Produce json
+// @Param employee_id formData int true "Employee ID"
+// @Param direction formData string false "IN or OUT"
+// @Param latitude formData number true "Latitude"
+// @Param longitude formData number true "Longitude"
+// @Param accuracy formData number false "GPS accuracy in meters"
+// @Param selfie formData file false "Selfie (JPG/PNG, max 5 MB)"
+// @Param Idempotency-Key header string false "Retries with the same key and payload replay the first response"
+// @Success 201 {object} MobilePunchResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Router /attendances/mobile/punches [post]
+func (h *MobileAttendanceHandler) RecordMobilePunch(c *gin.Context) {
+	employeeID, err := strconv.ParseUint(c.PostForm("employee_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid employee_id format"})
+		return
+	}
+	latitude, errLat := strconv.ParseFloat(c.PostForm("latitude"), 64)
+	longitude, errLng := strconv.ParseFloat(c.PostForm("longitude"), 64)
+	if errLat != nil || errLng != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "latitude and longitude are required"})
+		return
+	}
+	accuracy := 0.0
+	if accuracyStr := c.PostForm("accuracy"); accuracyStr != "" {
+		if accuracy, err = strconv.ParseFloat(accuracyStr, 64); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid accuracy value"})
+			return
+		}
+	}
+
+	req := domain.MobilePunchRequest{
+		EmployeeID:     uint(employeeID),
+		Direction:      c.PostForm("direction"),
+		Latitude:       latitude,
+		Longitude:      longitude,
+		AccuracyMeters: accuracy,
+	}
+
+	if fileHeader, err := c.FormFile("selfie"); err == nil {
+		if fileHeader.Size > maxSelfieSize {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Selfie is larger than 5 MB"})
+			return
+		}
+		file, err := fileHeader.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read selfie"})
+			return
+		}
+		defer file.Close()
+		req.Selfie = file
+		req.SelfieName = fileHeader.Filename
+	}
+
+	att, punch, err := h.Service.RecordMobilePunch(c.Request.Context(), req)
+	if err != nil {
+		status := attendancePeriodErrorStatus(err, http.StatusBadRequest)
+		if errors.Is(err, domain.ErrOutsideGeofence) || errors.Is(err, domain.ErrGPSAccuracyTooLow) {
+			status = http.StatusForbidden
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, MobilePunchResponse{Attendance: att, Punch: punch})
+}
+
+// GetFlaggedPunches handles GET /attendances/punches/flagged
+// @Summary List punches flagged for review (outside geofence / low GPS accuracy)
+// @Tags Attendances
+// @Accept json
+// @Produce json
+// @Param from query string true "From date (YYYY-MM-DD)"
+// @Param to query string true "To date (YYYY-MM-DD)"
+// @Success 200 {array} domain.Punch
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /attendances/punches/flagged [get]
+func (h *MobileAttendanceHandler) GetFlaggedPunches(c *gin.Context) {
+	from, err := time.Parse("2006-01-02", c.Query("from"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'from' date format, use YYYY-MM-DD"})
+		return
+	}
+
+	to, err := time.Parse("2006-01-02", c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'to' date format, use YYYY-MM-DD"})
+		return
+	}
+
+	punches, err := h.Service.GetFlaggedPunches(c.Request.Context(), from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve flagged punches"})
+		return
+	}
+
+	c.JSON(http.StatusOK, punches)
+}
+
+// ApproveFlaggedPunch handles POST /attendances/punches/:id/approve
+// @Summary Approve a flagged punch so it counts towards the daily attendance
+// @Tags Attendances
+// @Accept json
+// @Produce json
+// @Param id path int true "Punch ID"
+// @Param payload body ReviewPunchRequest true "Reviewer and note"
+// @Success 200 {object} domain.Punch
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /attendances/punches/{id}/approve [post]
+func (h *MobileAttendanceHandler) ApproveFlaggedPunch(c *gin.Context) {
+	h.review(c, h.Service.ApproveFlaggedPunch)
+}
+
+// RejectFlaggedPunch handles POST /attendances/punches/:id/reject
+// @Summary Reject a flagged punch; it stays in the punch log but never counts
+// @Tags Attendances
+// @Accept json
+// @Produce json
+// @Param id path int true "Punch ID"
+// @Param payload body ReviewPunchRequest true "Reviewer and note"
+// @Success 200 {object} domain.Punch
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /attendances/punches/{id}/reject [post]
+func (h *MobileAttendanceHandler) RejectFlaggedPunch(c *gin.Context) {
+	h.review(c, h.Service.RejectFlaggedPunch)
+}
+
+// review menjalankan approve/reject punch yang ditandai
+func (h *MobileAttendanceHandler) review(c *gin.Context, action func(ctx context.Context, punchID uint, reviewer string, note string) (*domain.Punch, error)) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req ReviewPunchRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.Reviewer == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A reviewer is required"})
+		return
+	}
+
+	punch, err := action(c.Request.Context(), uint(id), req.Reviewer, req.Note)
+	if err != nil {
+		status := attendancePeriodErrorStatus(err, http.StatusBadRequest)
+		switch {
+		case errors.Is(err, domain.ErrPunchNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, domain.ErrPunchNotPendingReview):
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, punch)
+}
+
+// GetPunchSelfie handles GET /attendances/punches/:id/selfie
+// @Summary Download the selfie stored with a punch
+// @Tags Attendances
+// @Produce image/jpeg
+// @Produce image/png
+// @Param id path int true "Punch ID"
+// @Success 200 {file} file
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /attendances/punches/{id}/selfie [get]
+func (h *MobileAttendanceHandler) GetPunchSelfie(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	selfie, err := h.Service.OpenSelfie(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Selfie not found"})
+		return
+	}
+	defer selfie.Close()
+
+	contentType := "application/octet-stream"
+	if name, ok := selfie.(interface{ Name() string }); ok {
+		if byExt := mime.TypeByExtension(filepath.Ext(name.Name())); byExt != "" {
+			contentType = byExt
+		}
+	}
+	c.Header("Content-Type", contentType)
+	c.Status(http.StatusOK)
+	_, _ = io.Copy(c.Writer, selfie)
+}
@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"errors"
+	"hr-payroll/internal/domain"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OfficeHandler mengurus endpoint HTTP untuk kantor dan geofence-nya
+type OfficeHandler struct {
+	Service domain.OfficeService
+}
+
+func NewOfficeHandler(s domain.OfficeService) *OfficeHandler {
+	return &OfficeHandler{Service: s}
+}
+
+// CreateOffice godoc
+// @Summary Create an office with its check-in geofence
+// @Description geofence_type RADIUS uses latitude/longitude/radius_meters, POLYGON uses polygon (at least 3 points). policy decides what happens to out-of-fence punches: REJECT or FLAG for review.
+// @Tags Offices
+// @Accept json
+// @Produce json
+// @Param office body domain.Office true "Office object"
+// @Success 201 {object} domain.Office
+// @Failure 400 {object} map[string]string
+// @Router /offices [post]
+func (h *OfficeHandler) CreateOffice(c *gin.Context) {
+	var req domain.Office
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	office, err := h.Service.CreateOffice(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, office)
+}
+
+// GetAllOffices godoc
+// @Summary List offices
+// @Tags Offices
+// @Accept json
+// @Produce json
+// @Success 200 {array} domain.Office
+// @Failure 500 {object} map[string]string
+// @Router /offices [get]
+func (h *OfficeHandler) GetAllOffices(c *gin.Context) {
+	offices, err := h.Service.GetAllOffices(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve offices"})
+		return
+	}
+
+	c.JSON(http.StatusOK, offices)
+}
+
+// UpdateOffice godoc
+// @Summary Update an office and its geofence
+// @Tags Offices
+// @Accept json
+// @Produce json
+// @Param id path int true "Office ID"
+// @Param office body domain.Office true "Office object"
+// @Success 200 {object} domain.Office
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /offices/{id} [put]
+func (h *OfficeHandler) UpdateOffice(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req domain.Office
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	office, err := h.Service.UpdateOffice(c.Request.Context(), uint(id), &req)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, domain.ErrOfficeNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, office)
+}
@@ -0,0 +1,241 @@
+package handler
+
+import (
+	"errors"
+	"hr-payroll/internal/domain"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PayrollAdjustmentHandler mengurus endpoint HTTP untuk bonus, komisi, insentif dan denda satu kali
+type PayrollAdjustmentHandler struct {
+	Service domain.PayrollAdjustmentService
+}
+
+func NewPayrollAdjustmentHandler(s domain.PayrollAdjustmentService) *PayrollAdjustmentHandler {
+	return &PayrollAdjustmentHandler{Service: s}
+}
+
+// CreateAdjustmentRequest represents the payload to add a one-off payroll adjustment
+type CreateAdjustmentRequest struct {
+	EmployeeID uint    `json:"employee_id" binding:"required" example:"1"`
+	Period     string  `json:"period" binding:"required" example:"2025-11"` // YYYY-MM
+	Component  string  `json:"component" binding:"required" example:"BONUS"`
+	Amount     float64 `json:"amount" binding:"required" example:"1500000"`
+	Note       string  `json:"note" example:"Bonus target Q3"`
+	EnteredBy  string  `json:"entered_by" binding:"required" example:"hr.admin@example.com"`
+}
+
+// CancelAdjustmentRequest represents the payload to cancel a pending adjustment
+type CancelAdjustmentRequest struct {
+	CancelledBy string `json:"cancelled_by" example:"hr.admin@example.com"`
+}
+
+// GetComponents handles GET /payroll/adjustments/components
+// @Summary List adjustment components with their slip side and tax treatment
+// @Tags Payroll Adjustments
+// @Produce json
+// @Success 200 {array} domain.AdjustmentComponent
+// @Router /payroll/adjustments/components [get]
+func (h *PayrollAdjustmentHandler) GetComponents(c *gin.Context) {
+	c.JSON(http.StatusOK, domain.AdjustmentComponents)
+}
+
+// CreateAdjustment handles POST /payroll/adjustments
+// @Summary Add a one-off bonus, commission, incentive or penalty to an employee's monthly slip
+// @Description The adjustment stays PENDING until the slip of that period is paid. Taxable components are withheld PPh 21 as irregular income, or added to the month's gross for TER when PAYROLL_TAX_WITHHOLDING=TER. Rejected (409) when the slip of that period is already PAID.
+// @Tags Payroll Adjustments
+// @Accept json
+// @Produce json
+// @Param adjustment body CreateAdjustmentRequest true "Adjustment"
+// @Success 201 {object} domain.PayrollAdjustment
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /payroll/adjustments [post]
+func (h *PayrollAdjustmentHandler) CreateAdjustment(c *gin.Context) {
+	var req CreateAdjustmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+	period, err := time.Parse("2006-01", req.Period)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid period format, use YYYY-MM"})
+		return
+	}
+
+	adjustment, err := h.Service.CreateAdjustment(c.Request.Context(), &domain.PayrollAdjustment{
+		EmployeeID: req.EmployeeID,
+		Period:     period,
+		Component:  req.Component,
+		Amount:     req.Amount,
+		Note:       req.Note,
+		EnteredBy:  req.EnteredBy,
+	})
+	if err != nil {
+		c.JSON(adjustmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, adjustment)
+}
+
+// ImportAdjustments handles POST /payroll/adjustments/import
+// @Summary Bulk upload adjustments from a CSV file
+// @Description Header: employee_id,period,component,amount,note (period as YYYY-MM). Valid rows are saved and invalid rows are reported per row. With dry_run=true nothing is saved.
+// @Tags Payroll Adjustments
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV file"
+// @Param entered_by formData string true "Who entered the adjustments"
+// @Param dry_run formData bool false "Validate only, do not save"
+// @Success 200 {object} domain.AdjustmentImportResult
+// @Failure 400 {object} map[string]string
+// @Router /payroll/adjustments/import [post]
+func (h *PayrollAdjustmentHandler) ImportAdjustments(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A file is required"})
+		return
+	}
+
+	dryRun := false
+	if dryRunStr := c.PostForm("dry_run"); dryRunStr != "" {
+		dryRun, err = strconv.ParseBool(dryRunStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid dry_run value"})
+			return
+		}
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read uploaded file"})
+		return
+	}
+	defer file.Close()
+
+	result, err := h.Service.ImportCSV(c.Request.Context(), file, c.PostForm("entered_by"), dryRun)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetAdjustments handles GET /payroll/adjustments
+// @Summary List payroll adjustments
+// @Tags Payroll Adjustments
+// @Accept json
+// @Produce json
+// @Param employee_id query int false "Employee ID"
+// @Param period query string false "Period (YYYY-MM)"
+// @Param status query string false "PENDING, APPLIED or CANCELLED"
+// @Success 200 {array} domain.PayrollAdjustment
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /payroll/adjustments [get]
+func (h *PayrollAdjustmentHandler) GetAdjustments(c *gin.Context) {
+	var employeeID uint64
+	if employeeIDStr := c.Query("employee_id"); employeeIDStr != "" {
+		var err error
+		employeeID, err = strconv.ParseUint(employeeIDStr, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid employee_id format"})
+			return
+		}
+	}
+	var period time.Time
+	if periodStr := c.Query("period"); periodStr != "" {
+		var err error
+		period, err = time.Parse("2006-01", periodStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid period format, use YYYY-MM"})
+			return
+		}
+	}
+
+	adjustments, err := h.Service.GetAdjustments(c.Request.Context(), uint(employeeID), period, c.Query("status"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve payroll adjustments"})
+		return
+	}
+
+	c.JSON(http.StatusOK, adjustments)
+}
+
+// GetAdjustment handles GET /payroll/adjustments/:id
+// @Summary Get a payroll adjustment
+// @Tags Payroll Adjustments
+// @Accept json
+// @Produce json
+// @Param id path int true "Adjustment ID"
+// @Success 200 {object} domain.PayrollAdjustment
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /payroll/adjustments/{id} [get]
+func (h *PayrollAdjustmentHandler) GetAdjustment(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	adjustment, err := h.Service.GetAdjustment(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(adjustmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, adjustment)
+}
+
+// CancelAdjustment handles POST /payroll/adjustments/:id/cancel
+// @Summary Cancel a pending adjustment
+// @Description A GENERATED slip that already includes the adjustment must be recalculated before it can be paid.
+// @Tags Payroll Adjustments
+// @Accept json
+// @Produce json
+// @Param id path int true "Adjustment ID"
+// @Param payload body CancelAdjustmentRequest true "Who cancels"
+// @Success 200 {object} domain.PayrollAdjustment
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /payroll/adjustments/{id}/cancel [post]
+func (h *PayrollAdjustmentHandler) CancelAdjustment(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req CancelAdjustmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.CancelledBy == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "cancelled_by is required"})
+		return
+	}
+
+	adjustment, err := h.Service.CancelAdjustment(c.Request.Context(), uint(id), req.CancelledBy)
+	if err != nil {
+		c.JSON(adjustmentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, adjustment)
+}
+
+func adjustmentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrAdjustmentNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrAdjustmentNotPending), errors.Is(err, domain.ErrAdjustmentPeriodClosed):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
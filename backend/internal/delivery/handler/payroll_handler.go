@@ -1,9 +1,14 @@
 package handler
 
 import (
+	"encoding/csv"
+	"errors"
+	"fmt"
 	"hr-payroll/internal/domain"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -21,23 +26,27 @@ func NewPayrollHandler(s domain.PayrollService) *PayrollHandler {
 // GeneratePayrollRequest represents the payload to generate payroll
 type GeneratePayrollRequest struct {
 	EmployeeID uint   `json:"employee_id"`
-	Period     string `json:"period"` // expect YYYY-MM-DD (start of month)
+	Period     string `json:"period"` // expect YYYY-MM-DD, normalized to the start of month
 }
 
 // GeneratePayroll godoc
 // @Summary Generate monthly payroll for an employee
+// @Description take_home_pay = (base_salary + allowance) x proration - absence_deduction + retro + reimbursement + adjustments - tax - loan_deduction. With PAYROLL_TAX_WITHHOLDING=NONE (default) tax only covers taxable adjustments (irregular income method); with TER it is the monthly PP 58/2023 effective rate on the month's gross, reconciled to the annual Pasal 17 tax in December or the resignation month (a negative tax refunds over-withholding).
 // @Tags Payroll
 // @Accept json
 // @Produce json
 // @Param payload body GeneratePayrollRequest true "Payroll request"
+// @Param Idempotency-Key header string false "Retries with the same key and payload replay the first response"
 // @Success 201 {object} domain.Payroll
 // @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 422 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /payroll/generate [post]
 func (h *PayrollHandler) GeneratePayroll(c *gin.Context) {
 	var req struct {
 		EmployeeID uint   `json:"employee_id"`
-		Period     string `json:"period"` // expect YYYY-MM-DD (start of month)
+		Period     string `json:"period"` // expect YYYY-MM-DD, normalized to the start of month
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
@@ -51,9 +60,9 @@ func (h *PayrollHandler) GeneratePayroll(c *gin.Context) {
 		return
 	}
 
-	payroll, err := h.Service.GenerateMonthlyPayroll(req.EmployeeID, period)
+	payroll, err := h.Service.GenerateMonthlyPayroll(c.Request.Context(), req.EmployeeID, period)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(payrollErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -70,7 +79,7 @@ func (h *PayrollHandler) GeneratePayroll(c *gin.Context) {
 // @Failure 500 {object} map[string]string
 // @Router /payroll/slips [get]
 func (h *PayrollHandler) GetPayrollSlips(c *gin.Context) {
-	slips, err := h.Service.GetPayrollSlips()
+	slips, err := h.Service.GetPayrollSlips(c.Request.Context())
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve payroll slips"})
 		return
@@ -97,7 +106,7 @@ func (h *PayrollHandler) GetPayrollDetail(c *gin.Context) {
 		return
 	}
 
-	payroll, err := h.Service.GetPayrollDetail(uint(id))
+	payroll, err := h.Service.GetPayrollDetail(c.Request.Context(), uint(id))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve payroll detail"})
 		return
@@ -105,3 +114,260 @@ func (h *PayrollHandler) GetPayrollDetail(c *gin.Context) {
 
 	c.JSON(http.StatusOK, payroll)
 }
+
+// RecalculatePayroll handles POST /payroll/slips/:id/recalculate
+// @Summary Recalculate an unpaid payroll slip
+// @Description Recomputes a GENERATED slip in place from the latest attendance and salary data.
+// @Tags Payroll
+// @Produce json
+// @Param id path int true "Payroll ID"
+// @Success 200 {object} domain.Payroll
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /payroll/slips/{id}/recalculate [post]
+func (h *PayrollHandler) RecalculatePayroll(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	payroll, err := h.Service.RecalculatePayroll(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(payrollErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, payroll)
+}
+
+// MarkPayrollPaid handles POST /payroll/slips/:id/pay
+// @Summary Mark a payroll slip as paid
+// @Description Finalises a GENERATED slip, deducts its loan installments from the loan balances and marks its reimbursement claims PAID and its adjustments APPLIED. Returns 409 when adjustments were cancelled or added since the slip was calculated. Paid slips can only be corrected through void-and-reissue, which rolls these back.
+// @Tags Payroll
+// @Produce json
+// @Param id path int true "Payroll ID"
+// @Success 200 {object} domain.Payroll
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /payroll/slips/{id}/pay [post]
+func (h *PayrollHandler) MarkPayrollPaid(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	payroll, err := h.Service.MarkPayrollPaid(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(payrollErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, payroll)
+}
+
+// VoidPayrollRequest represents the payload to void and reissue a paid slip
+type VoidPayrollRequest struct {
+	Reason string `json:"reason" example:"Absensi tanggal 10 salah dicatat ABSENT"`
+}
+
+// VoidAndReissuePayroll handles POST /payroll/slips/:id/void
+// @Summary Void a paid payroll slip and issue a replacement
+// @Description The voided slip stays stored with its reason and a link to the replacement slip.
+// @Tags Payroll
+// @Accept json
+// @Produce json
+// @Param id path int true "Payroll ID"
+// @Param payload body VoidPayrollRequest true "Void reason"
+// @Success 201 {object} domain.Payroll
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /payroll/slips/{id}/void [post]
+func (h *PayrollHandler) VoidAndReissuePayroll(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req VoidPayrollRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.Reason == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A void reason is required"})
+		return
+	}
+
+	replacement, err := h.Service.VoidAndReissuePayroll(c.Request.Context(), uint(id), req.Reason)
+	if err != nil {
+		c.JSON(payrollErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, replacement)
+}
+
+// PreviewPayrollRequest represents the payload to simulate payroll without saving it
+type PreviewPayrollRequest struct {
+	Period     string                   `json:"period" example:"2025-11-01"`      // YYYY-MM-DD, normalized to the start of month
+	EmployeeID uint                     `json:"employee_id" example:"1"`          // Optional, one employee
+	Department string                   `json:"department" example:"Engineering"` // Optional, all employees in a department
+	Overrides  *domain.PayrollOverrides `json:"overrides"`                        // Optional what-if values
+}
+
+// PreviewPayroll handles POST /payroll/preview
+// @Summary Preview payroll without saving
+// @Description Runs the monthly payroll calculation for one employee, a department or everyone (when neither is given), applying optional what-if overrides. Nothing is persisted.
+// @Tags Payroll
+// @Accept json
+// @Produce json
+// @Param payload body PreviewPayrollRequest true "Preview request"
+// @Success 200 {array} domain.PayrollPreview
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /payroll/preview [post]
+func (h *PayrollHandler) PreviewPayroll(c *gin.Context) {
+	var req PreviewPayrollRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	period, err := time.Parse("2006-01-02", req.Period)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid period format. Use YYYY-MM-DD"})
+		return
+	}
+
+	previews, err := h.Service.PreviewPayroll(c.Request.Context(), domain.PayrollPreviewRequest{
+		Period:     period,
+		EmployeeID: req.EmployeeID,
+		Department: req.Department,
+		Overrides:  req.Overrides,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, previews)
+}
+
+// GetVarianceReport handles GET /payroll/reports/variance
+// @Summary Period-over-period payroll variance report
+// @Description Compares stored (non-void) slips of two periods per employee and in aggregate. New and departed employees are always listed; others only when base salary, allowance or absence deduction changed, or the take-home pay delta exceeds the threshold.
+// @Tags Payroll
+// @Produce json
+// @Produce text/csv
+// @Param from query string true "Base period (YYYY-MM-DD)"
+// @Param to query string true "Compared period (YYYY-MM-DD)"
+// @Param threshold query number false "Minimum absolute take-home pay delta to report (default 0)"
+// @Param format query string false "json (default) or csv"
+// @Success 200 {object} domain.PayrollVarianceReport
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /payroll/reports/variance [get]
+func (h *PayrollHandler) GetVarianceReport(c *gin.Context) {
+	from, err := time.Parse("2006-01-02", c.Query("from"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'from' date format, use YYYY-MM-DD"})
+		return
+	}
+
+	to, err := time.Parse("2006-01-02", c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'to' date format, use YYYY-MM-DD"})
+		return
+	}
+
+	threshold := 0.0
+	if thresholdStr := c.Query("threshold"); thresholdStr != "" {
+		threshold, err = strconv.ParseFloat(thresholdStr, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid threshold format"})
+			return
+		}
+	}
+
+	report, err := h.Service.GetVarianceReport(c.Request.Context(), from, to, threshold)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if c.Query("format") == "csv" {
+		filename := fmt.Sprintf("payroll-variance-%s-%s.csv", report.PeriodFrom.Format("2006-01"), report.PeriodTo.Format("2006-01"))
+		c.Header("Content-Disposition", "attachment; filename="+filename)
+		c.Header("Content-Type", "text/csv")
+		c.Status(http.StatusOK)
+		if err := writeVarianceCSV(c.Writer, report); err != nil {
+			c.Error(err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// writeVarianceCSV menulis baris laporan variance dalam format CSV
+func writeVarianceCSV(w io.Writer, report *domain.PayrollVarianceReport) error {
+	writer := csv.NewWriter(w)
+	header := []string{
+		"employee_id", "employee_name", "change", "reasons",
+		"base_salary_from", "base_salary_to", "base_salary_delta",
+		"allowance_from", "allowance_to", "allowance_delta",
+		"absence_deduction_from", "absence_deduction_to", "absence_deduction_delta",
+		"take_home_pay_from", "take_home_pay_to", "take_home_pay_delta",
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	money := func(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) }
+	for _, row := range report.Rows {
+		record := []string{
+			strconv.FormatUint(uint64(row.EmployeeID), 10), row.EmployeeName, row.Change, strings.Join(row.Reasons, ";"),
+			money(row.BaseSalaryFrom), money(row.BaseSalaryTo), money(row.BaseSalaryDelta),
+			money(row.AllowanceFrom), money(row.AllowanceTo), money(row.AllowanceDelta),
+			money(row.AbsenceDeductionFrom), money(row.AbsenceDeductionTo), money(row.AbsenceDeductionDelta),
+			money(row.TakeHomePayFrom), money(row.TakeHomePayTo), money(row.TakeHomePayDelta),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	// Baris total agregat
+	sum := report.Summary
+	total := []string{
+		"", "TOTAL", "", "",
+		money(sum.TotalBaseSalaryFrom), money(sum.TotalBaseSalaryTo), money(sum.TotalBaseSalaryTo - sum.TotalBaseSalaryFrom),
+		money(sum.TotalAllowanceFrom), money(sum.TotalAllowanceTo), money(sum.TotalAllowanceTo - sum.TotalAllowanceFrom),
+		money(sum.TotalAbsenceDeductionFrom), money(sum.TotalAbsenceDeductionTo), money(sum.TotalAbsenceDeductionTo - sum.TotalAbsenceDeductionFrom),
+		money(sum.TotalTakeHomePayFrom), money(sum.TotalTakeHomePayTo), money(sum.TotalTakeHomePayDelta),
+	}
+	if err := writer.Write(total); err != nil {
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// payrollErrorStatus memetakan error service payroll ke HTTP status
+func payrollErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrPayrollNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrPayrollNotEditable), errors.Is(err, domain.ErrPayrollNotPaid), errors.Is(err, domain.ErrLoanBalanceChange),
+		errors.Is(err, domain.ErrAdjustmentChanged), errors.Is(err, domain.ErrPayrollAlreadyGenerated):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
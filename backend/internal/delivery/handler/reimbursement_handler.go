@@ -0,0 +1,331 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"hr-payroll/internal/domain"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxReceiptSize adalah batas ukuran file bukti pembayaran (10 MB)
+const maxReceiptSize = 10 << 20
+
+// ReimbursementHandler mengurus endpoint HTTP untuk klaim reimbursement
+type ReimbursementHandler struct {
+	Service domain.ReimbursementService
+}
+
+func NewReimbursementHandler(s domain.ReimbursementService) *ReimbursementHandler {
+	return &ReimbursementHandler{Service: s}
+}
+
+// ReviewReimbursementRequest represents the payload to approve or reject a reimbursement claim
+type ReviewReimbursementRequest struct {
+	ReviewedBy string `json:"reviewed_by" example:"manager@example.com"`
+	Note       string `json:"note" example:"Sesuai kuitansi"` // Wajib diisi untuk penolakan
+}
+
+// GetCategories handles GET /reimbursements/categories
+// @Summary List reimbursement categories and their yearly limits
+// @Tags Reimbursements
+// @Accept json
+// @Produce json
+// @Success 200 {array} domain.ReimbursementCategory
+// @Failure 500 {object} map[string]string
+// @Router /reimbursements/categories [get]
+func (h *ReimbursementHandler) GetCategories(c *gin.Context) {
+	categories, err := h.Service.GetCategories(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve reimbursement categories"})
+		return
+	}
+
+	c.JSON(http.StatusOK, categories)
+}
+
+// CreateCategory handles POST /reimbursements/categories
+// @Summary Add a reimbursement category
+// @Description yearly_limit is the maximum an employee may claim per calendar year in this category (0 = unlimited).
+// @Tags Reimbursements
+// @Accept json
+// @Produce json
+// @Param category body domain.ReimbursementCategory true "Reimbursement category"
+// @Success 201 {object} domain.ReimbursementCategory
+// @Failure 400 {object} map[string]string
+// @Router /reimbursements/categories [post]
+func (h *ReimbursementHandler) CreateCategory(c *gin.Context) {
+	var req domain.ReimbursementCategory
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	category, err := h.Service.CreateCategory(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, category)
+}
+
+// UpdateCategory handles PUT /reimbursements/categories/:code
+// @Summary Update the name, yearly limit or active flag of a reimbursement category
+// @Description The code cannot be changed. Inactive categories cannot receive new claims.
+// @Tags Reimbursements
+// @Accept json
+// @Produce json
+// @Param code path string true "Category code"
+// @Param category body domain.ReimbursementCategory true "Reimbursement category"
+// @Success 200 {object} domain.ReimbursementCategory
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /reimbursements/categories/{code} [put]
+func (h *ReimbursementHandler) UpdateCategory(c *gin.Context) {
+	req := domain.ReimbursementCategory{Active: true} // active tidak dikirim = tetap aktif
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	category, err := h.Service.UpdateCategory(c.Request.Context(), c.Param("code"), &req)
+	if err != nil {
+		c.JSON(reimbursementErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, category)
+}
+
+// SubmitClaim handles POST /reimbursements
+// @Summary Submit a reimbursement claim with its receipt
+// @Description The claim must fit in the category's yearly limit for the year of expense_date (SUBMITTED and approved claims count, rejected ones do not). It then needs manager approval followed by finance approval.
+// @Tags Reimbursements
+// @Accept multipart/form-data
+// @Produce json
+// @Param employee_id formData int true "Employee ID"
+// @Param category_code formData string true "Category code, e.g. MEDICAL"
+// @Param expense_date formData string true "Expense date (YYYY-MM-DD)"
+// @Param amount formData number true "Claimed amount"
+// @Param description formData string false "Description"
+// @Param receipt formData file true "Receipt (JPG/PNG/PDF, max 10 MB)"
+// @Success 201 {object} domain.ReimbursementClaim
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /reimbursements [post]
+func (h *ReimbursementHandler) SubmitClaim(c *gin.Context) {
+	employeeID, err := strconv.ParseUint(c.PostForm("employee_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid employee_id format"})
+		return
+	}
+	expenseDate, err := time.Parse("2006-01-02", c.PostForm("expense_date"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid expense_date format, use YYYY-MM-DD"})
+		return
+	}
+	amount, err := strconv.ParseFloat(c.PostForm("amount"), 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid amount value"})
+		return
+	}
+
+	fileHeader, err := c.FormFile("receipt")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A receipt file is required"})
+		return
+	}
+	if fileHeader.Size > maxReceiptSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Receipt is larger than 10 MB"})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read receipt"})
+		return
+	}
+	defer file.Close()
+
+	claim, err := h.Service.SubmitClaim(c.Request.Context(), domain.ReimbursementSubmission{
+		EmployeeID:   uint(employeeID),
+		CategoryCode: c.PostForm("category_code"),
+		ExpenseDate:  expenseDate,
+		Amount:       amount,
+		Description:  c.PostForm("description"),
+		Receipt:      file,
+		ReceiptName:  fileHeader.Filename,
+	})
+	if err != nil {
+		c.JSON(reimbursementErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, claim)
+}
+
+// GetClaims handles GET /reimbursements
+// @Summary List reimbursement claims
+// @Tags Reimbursements
+// @Accept json
+// @Produce json
+// @Param employee_id query int false "Employee ID"
+// @Param status query string false "SUBMITTED, MANAGER_APPROVED, APPROVED, PAID or REJECTED"
+// @Success 200 {array} domain.ReimbursementClaim
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /reimbursements [get]
+func (h *ReimbursementHandler) GetClaims(c *gin.Context) {
+	var employeeID uint64
+	if employeeIDStr := c.Query("employee_id"); employeeIDStr != "" {
+		var err error
+		employeeID, err = strconv.ParseUint(employeeIDStr, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid employee_id format"})
+			return
+		}
+	}
+
+	claims, err := h.Service.GetClaims(c.Request.Context(), uint(employeeID), c.Query("status"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve reimbursement claims"})
+		return
+	}
+
+	c.JSON(http.StatusOK, claims)
+}
+
+// GetClaim handles GET /reimbursements/:id
+// @Summary Get a reimbursement claim with its approval trail
+// @Tags Reimbursements
+// @Accept json
+// @Produce json
+// @Param id path int true "Claim ID"
+// @Success 200 {object} domain.ReimbursementClaim
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /reimbursements/{id} [get]
+func (h *ReimbursementHandler) GetClaim(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	claim, err := h.Service.GetClaim(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(reimbursementErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, claim)
+}
+
+// GetReceipt handles GET /reimbursements/:id/receipt
+// @Summary Download the receipt attached to a claim
+// @Tags Reimbursements
+// @Produce image/jpeg
+// @Produce image/png
+// @Produce application/pdf
+// @Param id path int true "Claim ID"
+// @Success 200 {file} file
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /reimbursements/{id}/receipt [get]
+func (h *ReimbursementHandler) GetReceipt(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	receipt, err := h.Service.OpenReceipt(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Receipt not found"})
+		return
+	}
+	defer receipt.Close()
+
+	contentType := "application/octet-stream"
+	if name, ok := receipt.(interface{ Name() string }); ok {
+		if byExt := mime.TypeByExtension(filepath.Ext(name.Name())); byExt != "" {
+			contentType = byExt
+		}
+	}
+	c.Header("Content-Type", contentType)
+	c.Status(http.StatusOK)
+	_, _ = io.Copy(c.Writer, receipt)
+}
+
+// ApproveClaim handles POST /reimbursements/:id/approve
+// @Summary Approve the next stage of a reimbursement claim
+// @Description A SUBMITTED claim becomes MANAGER_APPROVED; a MANAGER_APPROVED claim becomes APPROVED by finance (a different reviewer, yearly limit re-checked). APPROVED claims are paid as a non-taxable earning on the employee's next generated payroll.
+// @Tags Reimbursements
+// @Accept json
+// @Produce json
+// @Param id path int true "Claim ID"
+// @Param payload body ReviewReimbursementRequest true "Reviewer and note"
+// @Success 200 {object} domain.ReimbursementClaim
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /reimbursements/{id}/approve [post]
+func (h *ReimbursementHandler) ApproveClaim(c *gin.Context) {
+	h.review(c, h.Service.ApproveClaim)
+}
+
+// RejectClaim handles POST /reimbursements/:id/reject
+// @Summary Reject a reimbursement claim awaiting manager or finance approval
+// @Tags Reimbursements
+// @Accept json
+// @Produce json
+// @Param id path int true "Claim ID"
+// @Param payload body ReviewReimbursementRequest true "Reviewer and reason"
+// @Success 200 {object} domain.ReimbursementClaim
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /reimbursements/{id}/reject [post]
+func (h *ReimbursementHandler) RejectClaim(c *gin.Context) {
+	h.review(c, h.Service.RejectClaim)
+}
+
+func (h *ReimbursementHandler) review(c *gin.Context, action func(ctx context.Context, id uint, reviewer string, note string) (*domain.ReimbursementClaim, error)) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req ReviewReimbursementRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.ReviewedBy == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "reviewed_by is required"})
+		return
+	}
+
+	claim, err := action(c.Request.Context(), uint(id), req.ReviewedBy, req.Note)
+	if err != nil {
+		c.JSON(reimbursementErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, claim)
+}
+
+func reimbursementErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrReimbursementNotFound), errors.Is(err, domain.ErrReimbursementCategoryNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrReimbursementNotReviewable), errors.Is(err, domain.ErrReimbursementLimitExceeded):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
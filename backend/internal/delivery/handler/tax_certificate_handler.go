@@ -0,0 +1,301 @@
+package handler
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"hr-payroll/internal/domain"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jung-kurt/gofpdf"
+)
+
+// TaxCertificateHandler mengurus endpoint HTTP untuk bukti potong 1721-A1
+type TaxCertificateHandler struct {
+	Service domain.TaxCertificateService
+}
+
+func NewTaxCertificateHandler(s domain.TaxCertificateService) *TaxCertificateHandler {
+	return &TaxCertificateHandler{Service: s}
+}
+
+// GetCertificates handles GET /payroll/tax-certificates
+// @Summary Form 1721-A1 for every employee with paid slips in a tax year
+// @Description Aggregates the year's PAID slips per employee (regular and THR). format=pdf renders one page per employee; format=ebupot returns the semicolon-separated 1721-A1 import file for the e-SPT / e-Bupot PPh 21 application.
+// @Tags Tax Certificates
+// @Produce json
+// @Produce application/pdf
+// @Produce text/csv
+// @Param year query int true "Tax year"
+// @Param format query string false "json (default), pdf or ebupot"
+// @Success 200 {array} domain.TaxCertificate
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /payroll/tax-certificates [get]
+func (h *TaxCertificateHandler) GetCertificates(c *gin.Context) {
+	year, err := strconv.Atoi(c.Query("year"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid year"})
+		return
+	}
+	format := strings.ToLower(c.DefaultQuery("format", "json"))
+	if format != "json" && format != "pdf" && format != "ebupot" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid format, use json, pdf or ebupot"})
+		return
+	}
+
+	certificates, err := h.Service.GetCertificates(c.Request.Context(), year)
+	if err != nil {
+		c.JSON(taxCertificateErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	filename := fmt.Sprintf("1721-A1-%d", year)
+	switch format {
+	case "pdf":
+		c.Header("Content-Disposition", "attachment; filename="+filename+".pdf")
+		c.Header("Content-Type", "application/pdf")
+		c.Status(http.StatusOK)
+		if err := writeTaxCertificatePDF(c.Writer, certificates); err != nil {
+			c.Error(err)
+		}
+	case "ebupot":
+		c.Header("Content-Disposition", "attachment; filename="+filename+"-ebupot.csv")
+		c.Header("Content-Type", "text/csv")
+		c.Status(http.StatusOK)
+		if err := writeTaxCertificateEBupot(c.Writer, certificates); err != nil {
+			c.Error(err)
+		}
+	default:
+		c.JSON(http.StatusOK, certificates)
+	}
+}
+
+// GetCertificate handles GET /payroll/tax-certificates/:employee_id
+// @Summary Form 1721-A1 for one employee
+// @Tags Tax Certificates
+// @Produce json
+// @Produce application/pdf
+// @Param employee_id path int true "Employee ID"
+// @Param year query int true "Tax year"
+// @Param format query string false "json (default) or pdf"
+// @Success 200 {object} domain.TaxCertificate
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /payroll/tax-certificates/{employee_id} [get]
+func (h *TaxCertificateHandler) GetCertificate(c *gin.Context) {
+	employeeID, err := strconv.ParseUint(c.Param("employee_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid employee_id format"})
+		return
+	}
+	year, err := strconv.Atoi(c.Query("year"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid year"})
+		return
+	}
+	format := strings.ToLower(c.DefaultQuery("format", "json"))
+	if format != "json" && format != "pdf" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid format, use json or pdf"})
+		return
+	}
+
+	certificate, err := h.Service.GetCertificate(c.Request.Context(), year, uint(employeeID))
+	if err != nil {
+		c.JSON(taxCertificateErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	if format == "pdf" {
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=1721-A1-%d-%d.pdf", year, employeeID))
+		c.Header("Content-Type", "application/pdf")
+		c.Status(http.StatusOK)
+		if err := writeTaxCertificatePDF(c.Writer, []domain.TaxCertificate{*certificate}); err != nil {
+			c.Error(err)
+		}
+		return
+	}
+	c.JSON(http.StatusOK, certificate)
+}
+
+// taxCertificateLines adalah rincian penghasilan dan penghitungan PPh 21 sesuai urutan formulir
+func taxCertificateLines(cert *domain.TaxCertificate) []struct {
+	Label  string
+	Amount float64
+} {
+	return []struct {
+		Label  string
+		Amount float64
+	}{
+		{"1. Gaji/Pensiun atau THT/JHT", cert.Salary},
+		{"2. Tunjangan PPh", cert.TaxAllowance},
+		{"3. Tunjangan Lainnya, Uang Lembur dan sebagainya", cert.OtherAllowances},
+		{"4. Honorarium dan Imbalan Lain Sejenisnya", cert.Honorarium},
+		{"5. Premi Asuransi yang Dibayar Pemberi Kerja", cert.InsurancePremium},
+		{"6. Penerimaan dalam Bentuk Natura dan Kenikmatan Lainnya", cert.BenefitsInKind},
+		{"7. Tantiem, Bonus, Gratifikasi, Jasa Produksi dan THR", cert.BonusAndTHR},
+		{"8. Jumlah Penghasilan Bruto (1 s.d. 7)", cert.GrossIncome},
+		{"9. Biaya Jabatan/Biaya Pensiun", cert.JobExpense},
+		{"10. Iuran Pensiun atau Iuran THT/JHT", cert.PensionContribution},
+		{"11. Jumlah Pengurangan (9 s.d. 10)", cert.TotalDeductions},
+		{"12. Jumlah Penghasilan Neto (8 - 11)", cert.NetIncome},
+		{"13. Penghasilan Neto Masa Sebelumnya", cert.PreviousNetIncome},
+		{"14. Jumlah Penghasilan Neto untuk Penghitungan PPh Pasal 21", cert.AnnualNetIncome},
+		{"15. Penghasilan Tidak Kena Pajak (PTKP)", cert.PTKP},
+		{"16. Penghasilan Kena Pajak Setahun (14 - 15)", cert.TaxableIncome},
+		{"17. PPh Pasal 21 atas Penghasilan Kena Pajak Setahun", cert.AnnualTax},
+		{"18. PPh Pasal 21 yang Telah Dipotong Masa Sebelumnya", cert.PreviousWithheldTax},
+		{"19. PPh Pasal 21 Terutang", cert.TaxDue},
+		{"20. PPh Pasal 21 yang Telah Dipotong dan Dilunasi", cert.WithheldTax},
+	}
+}
+
+// writeTaxCertificatePDF menulis formulir 1721-A1, satu halaman per karyawan
+func writeTaxCertificatePDF(w io.Writer, certificates []domain.TaxCertificate) error {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(15, 15, 15)
+	pdf.SetAutoPageBreak(false, 15)
+
+	row := func(label, value string) {
+		pdf.SetFont("Helvetica", "", 9)
+		pdf.CellFormat(55, 5, label, "", 0, "L", false, 0, "")
+		pdf.CellFormat(125, 5, ": "+value, "", 1, "L", false, 0, "")
+	}
+	section := func(title string) {
+		pdf.Ln(2)
+		pdf.SetFont("Helvetica", "B", 9)
+		pdf.SetFillColor(230, 230, 230)
+		pdf.CellFormat(180, 6, title, "1", 1, "L", true, 0, "")
+		pdf.Ln(1)
+	}
+
+	for i := range certificates {
+		cert := &certificates[i]
+		pdf.AddPage()
+
+		// Judul
+		pdf.SetFont("Helvetica", "B", 11)
+		pdf.CellFormat(180, 6, "BUKTI PEMOTONGAN PAJAK PENGHASILAN PASAL 21", "", 1, "C", false, 0, "")
+		pdf.CellFormat(180, 6, "BAGI PEGAWAI TETAP ATAU PENERIMA PENSIUN ATAU THT/JHT BERKALA", "", 1, "C", false, 0, "")
+		pdf.SetFont("Helvetica", "", 9)
+		pdf.CellFormat(180, 5, "FORMULIR 1721-A1", "", 1, "C", false, 0, "")
+		pdf.Ln(3)
+		row("Nomor", cert.Number)
+		row("Masa Perolehan Penghasilan", fmt.Sprintf("%02d - %02d / %d", cert.PeriodFrom, cert.PeriodTo, cert.Year))
+		row("NPWP Pemotong", formatNPWP(cert.Withholder.NPWP))
+		row("Nama Pemotong", cert.Withholder.Name)
+
+		section("A. IDENTITAS PENERIMA PENGHASILAN YANG DIPOTONG")
+		row("NPWP", formatNPWP(cert.NPWP))
+		row("NIK", cert.NIK)
+		row("Nama", cert.EmployeeName)
+		row("Jabatan", cert.Position)
+		row("Status PTKP / Tanggungan", fmt.Sprintf("%s / %d", cert.TaxStatus, cert.Dependents))
+
+		section("B. RINCIAN PENGHASILAN DAN PENGHITUNGAN PPh PASAL 21")
+		row("Kode Objek Pajak", cert.TaxObjectCode)
+		for _, line := range taxCertificateLines(cert) {
+			pdf.SetFont("Helvetica", "", 9)
+			pdf.CellFormat(140, 5, line.Label, "1", 0, "L", false, 0, "")
+			pdf.CellFormat(40, 5, formatRupiah(line.Amount), "1", 1, "R", false, 0, "")
+		}
+		pdf.SetFont("Helvetica", "I", 8)
+		pdf.CellFormat(180, 5, "Selisih PPh Pasal 21 terutang dan yang telah dipotong: "+formatRupiah(cert.TaxUnderpaid), "", 1, "L", false, 0, "")
+
+		section("C. IDENTITAS PEMOTONG")
+		row("NPWP", formatNPWP(cert.Withholder.SignerNPWP))
+		row("Nama", cert.Withholder.SignerName)
+		row("Tanggal", cert.IssuedAt.Format("02-01-2006"))
+		pdf.Ln(14)
+		pdf.CellFormat(120, 5, "", "", 0, "L", false, 0, "")
+		pdf.CellFormat(60, 5, "( "+cert.Withholder.SignerName+" )", "T", 1, "C", false, 0, "")
+	}
+	if len(certificates) == 0 {
+		pdf.AddPage()
+		pdf.SetFont("Helvetica", "", 10)
+		pdf.CellFormat(180, 6, "Tidak ada slip gaji PAID pada tahun pajak ini.", "", 1, "L", false, 0, "")
+	}
+	return pdf.Output(w)
+}
+
+// writeTaxCertificateEBupot menulis file impor 1721-A1 aplikasi e-SPT / e-Bupot PPh 21 (dipisah titik koma)
+func writeTaxCertificateEBupot(w io.Writer, certificates []domain.TaxCertificate) error {
+	writer := csv.NewWriter(w)
+	writer.Comma = ';'
+	header := []string{
+		"Masa Pajak", "Tahun Pajak", "Pembetulan", "Nomor Bukti Potong", "Masa Perolehan Awal", "Masa Perolehan Akhir",
+		"NPWP", "NIK", "Nama", "Alamat", "Jenis Kelamin", "Status PTKP", "Jumlah Tanggungan", "Nama Jabatan",
+		"WP Luar Negeri", "Kode Negara", "Kode Pajak",
+	}
+	for i := 1; i <= 20; i++ {
+		header = append(header, fmt.Sprintf("Jumlah %d", i))
+	}
+	header = append(header, "Status Pindah", "NPWP Pemotong", "Nama Pemotong", "Tanggal Bukti Potong")
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for i := range certificates {
+		cert := &certificates[i]
+		npwp := cert.NPWP
+		if npwp == "" {
+			npwp = "000000000000000" // Karyawan tanpa NPWP dilaporkan dengan NIK
+		}
+		status, _, _ := strings.Cut(cert.TaxStatus, "/")
+		record := []string{
+			strconv.Itoa(cert.PeriodTo), strconv.Itoa(cert.Year), "0", cert.Number,
+			strconv.Itoa(cert.PeriodFrom), strconv.Itoa(cert.PeriodTo),
+			npwp, cert.NIK, cert.EmployeeName, "", "", status, strconv.Itoa(cert.Dependents), cert.Position,
+			"N", "", cert.TaxObjectCode,
+		}
+		for _, line := range taxCertificateLines(cert) {
+			record = append(record, strconv.FormatFloat(math.Round(line.Amount), 'f', 0, 64))
+		}
+		record = append(record, "", cert.Withholder.SignerNPWP, cert.Withholder.SignerName, cert.IssuedAt.Format("02/01/2006"))
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// formatRupiah menulis angka dengan pemisah ribuan titik, mis. 1.234.567
+func formatRupiah(value float64) string {
+	digits := strconv.FormatFloat(math.Abs(math.Round(value)), 'f', 0, 64)
+	var b strings.Builder
+	if value <= -0.5 {
+		b.WriteByte('-')
+	}
+	for i, r := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// formatNPWP menulis NPWP 15 digit sebagai 01.234.567.8-901.234 (16 digit ditulis apa adanya)
+func formatNPWP(npwp string) string {
+	if len(npwp) != 15 {
+		return npwp
+	}
+	return fmt.Sprintf("%s.%s.%s.%s-%s.%s", npwp[0:2], npwp[2:5], npwp[5:8], npwp[8:9], npwp[9:12], npwp[12:15])
+}
+
+func taxCertificateErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrTaxCertificateNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrInvalidTaxYear):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
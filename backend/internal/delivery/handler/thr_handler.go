@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"errors"
+	"hr-payroll/internal/domain"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// THRHandler mengurus endpoint HTTP untuk Tunjangan Hari Raya
+type THRHandler struct {
+	Service domain.THRService
+}
+
+func NewTHRHandler(s domain.THRService) *THRHandler {
+	return &THRHandler{Service: s}
+}
+
+// SaveSchedule handles POST /payroll/thr/schedules
+// @Summary Create or replace the THR schedule of a religion for a year
+// @Description The year is taken from holiday_date. The payout date must be at least 7 days before the holiday and determines the period of the THR slips.
+// @Tags THR
+// @Accept json
+// @Produce json
+// @Param schedule body domain.THRSchedule true "THR schedule"
+// @Success 200 {object} domain.THRSchedule
+// @Failure 400 {object} map[string]string
+// @Router /payroll/thr/schedules [post]
+func (h *THRHandler) SaveSchedule(c *gin.Context) {
+	var req domain.THRSchedule
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	schedule, err := h.Service.SaveSchedule(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
+
+// GetSchedules handles GET /payroll/thr/schedules
+// @Summary List THR schedules of a year
+// @Tags THR
+// @Accept json
+// @Produce json
+// @Param year query int true "Year"
+// @Success 200 {array} domain.THRSchedule
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /payroll/thr/schedules [get]
+func (h *THRHandler) GetSchedules(c *gin.Context) {
+	year, err := strconv.Atoi(c.Query("year"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid year format"})
+		return
+	}
+
+	schedules, err := h.Service.GetSchedules(c.Request.Context(), year)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve THR schedules"})
+		return
+	}
+
+	c.JSON(http.StatusOK, schedules)
+}
+
+// RunTHR handles POST /payroll/thr/run
+// @Summary Calculate THR slips for all eligible employees
+// @Description One month's wage for 12+ months of service, service months / 12 below that, nothing under one month (Permenaker 6/2016). Slips have type THR; PPh 21 is withheld as irregular income, or as the TER difference with and without THR when PAYROLL_TAX_WITHHOLDING=TER. Employees that already have a THR slip for the period are skipped.
+// @Tags THR
+// @Accept json
+// @Produce json
+// @Param payload body domain.THRRunRequest true "Year, optional religion and dry run flag"
+// @Success 200 {object} domain.THRRunResult
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /payroll/thr/run [post]
+func (h *THRHandler) RunTHR(c *gin.Context) {
+	var req domain.THRRunRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	result, err := h.Service.RunTHR(c.Request.Context(), req)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, domain.ErrTHRScheduleNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"encoding/csv"
+	"fmt"
+	"hr-payroll/internal/domain"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xuri/excelize/v2"
+)
+
+// TimesheetHandler mengurus endpoint HTTP untuk rekap absensi bulanan
+type TimesheetHandler struct {
+	Service domain.TimesheetService
+}
+
+func NewTimesheetHandler(s domain.TimesheetService) *TimesheetHandler {
+	return &TimesheetHandler{Service: s}
+}
+
+// GetMonthlyTimesheet handles GET /attendances/timesheet
+// @Summary Monthly attendance timesheet for all employees
+// @Description Employees as rows, days of the month as columns with status codes, plus totals of present, absent, leave, late minutes, overtime hours and worked hours.
+// @Tags Attendances
+// @Accept json
+// @Produce json
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param period query string true "Month (YYYY-MM or YYYY-MM-DD)"
+// @Param department query string false "Department filter"
+// @Param format query string false "json (default), csv or xlsx"
+// @Success 200 {object} domain.Timesheet
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /attendances/timesheet [get]
+func (h *TimesheetHandler) GetMonthlyTimesheet(c *gin.Context) {
+	periodStr := c.Query("period")
+	period, err := time.Parse("2006-01", periodStr)
+	if err != nil {
+		period, err = time.Parse("2006-01-02", periodStr)
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'period' format, use YYYY-MM"})
+		return
+	}
+
+	format := strings.ToLower(c.DefaultQuery("format", "json"))
+	if format != "json" && format != "csv" && format != "xlsx" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid format, use json, csv or xlsx"})
+		return
+	}
+
+	timesheet, err := h.Service.GetMonthlyTimesheet(c.Request.Context(), period, c.Query("department"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	filename := "timesheet-" + timesheet.Period.Format("2006-01")
+	if timesheet.Department != "" {
+		filename += "-" + strings.ReplaceAll(timesheet.Department, " ", "_")
+	}
+	switch format {
+	case "csv":
+		c.Header("Content-Disposition", "attachment; filename="+filename+".csv")
+		c.Header("Content-Type", "text/csv")
+		c.Status(http.StatusOK)
+		if err := writeTimesheetCSV(c.Writer, timesheet); err != nil {
+			c.Error(err)
+		}
+	case "xlsx":
+		c.Header("Content-Disposition", "attachment; filename="+filename+".xlsx")
+		c.Header("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+		c.Status(http.StatusOK)
+		if err := writeTimesheetXLSX(c.Writer, timesheet); err != nil {
+			c.Error(err)
+		}
+	default:
+		c.JSON(http.StatusOK, timesheet)
+	}
+}
+
+// timesheetTable menyusun header dan baris rekap untuk CSV/XLSX
+func timesheetTable(timesheet *domain.Timesheet) [][]string {
+	header := []string{"employee_id", "employee_name", "department"}
+	for day := 1; day <= timesheet.DaysInMonth; day++ {
+		header = append(header, strconv.Itoa(day))
+	}
+	header = append(header, "present", "absent", "leave", "late_minutes", "overtime_hours", "worked_hours")
+
+	table := [][]string{header}
+	hours := func(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) }
+	for _, row := range timesheet.Rows {
+		record := []string{strconv.FormatUint(uint64(row.EmployeeID), 10), row.EmployeeName, row.Department}
+		record = append(record, row.Days...)
+		record = append(record,
+			strconv.Itoa(row.Present), strconv.Itoa(row.Absent), strconv.Itoa(row.Leave),
+			strconv.Itoa(row.LateMinutes), hours(row.OvertimeHours), hours(row.WorkedHours),
+		)
+		table = append(table, record)
+	}
+	return table
+}
+
+// writeTimesheetCSV menulis rekap absensi dalam format CSV
+func writeTimesheetCSV(w io.Writer, timesheet *domain.Timesheet) error {
+	writer := csv.NewWriter(w)
+	if err := writer.WriteAll(timesheetTable(timesheet)); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+// writeTimesheetXLSX menulis rekap absensi dalam satu sheet XLSX
+func writeTimesheetXLSX(w io.Writer, timesheet *domain.Timesheet) error {
+	file := excelize.NewFile()
+	defer file.Close()
+
+	sheet := timesheet.Period.Format("2006-01")
+	if err := file.SetSheetName(file.GetSheetName(0), sheet); err != nil {
+		return err
+	}
+	for i, record := range timesheetTable(timesheet) {
+		values := make([]interface{}, len(record))
+		for j, value := range record {
+			// Kolom angka ditulis sebagai angka agar bisa dijumlahkan di Excel
+			if number, err := strconv.ParseFloat(value, 64); err == nil && i > 0 && j != 1 && j != 2 {
+				values[j] = number
+			} else {
+				values[j] = value
+			}
+		}
+		cell, err := excelize.CoordinatesToCellName(1, i+1)
+		if err != nil {
+			return err
+		}
+		if err := file.SetSheetRow(sheet, cell, &values); err != nil {
+			return err
+		}
+	}
+	if err := file.SetPanes(sheet, &excelize.Panes{Freeze: true, XSplit: 3, YSplit: 1, TopLeftCell: "D2", ActivePane: "bottomRight"}); err != nil {
+		return fmt.Errorf("failed to freeze timesheet header: %w", err)
+	}
+	_, err := file.WriteTo(w)
+	return err
+}
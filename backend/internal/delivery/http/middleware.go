@@ -0,0 +1,216 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"hr-payroll/internal/domain"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Header identitas request untuk audit log
+const (
+	HeaderActor     = "X-Actor"
+	HeaderRequestID = "X-Request-ID"
+)
+
+// AuditMetadata menyisipkan actor (header X-Actor), request ID dan IP klien ke context request
+// agar repository dapat mencatatnya di audit log. Request ID dari klien dipakai ulang, jika kosong dibuat baru.
+//
+// X-Actor dikirim klien apa adanya dan TIDAK diverifikasi: API ini belum punya autentikasi, sehingga siapa pun
+// dapat mengisi nama orang lain. Actor di audit log hanya dapat dipercaya jika header ini diisi oleh gateway/proxy
+// yang sudah mengautentikasi pengguna dan menimpa nilai dari klien. Request tanpa header dicatat sebagai "unknown",
+// bukan "system", agar tidak tertukar dengan perubahan dari job terjadwal.
+func AuditMetadata() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actor := c.GetHeader(HeaderActor)
+		if actor == "" {
+			actor = domain.AuditActorUnknown
+		}
+
+		requestID := c.GetHeader(HeaderRequestID)
+		if requestID == "" {
+			requestID = newRequestID()
+		}
+		c.Header(HeaderRequestID, requestID)
+
+		ctx := domain.WithAuditMeta(c.Request.Context(), domain.AuditMeta{
+			Actor:     actor,
+			RequestID: requestID,
+			IP:        c.ClientIP(),
+		})
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+func newRequestID() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(buf)
+}
+
+// HeaderAdminKey membawa kunci admin (ADMIN_API_KEY) untuk endpoint admin
+const HeaderAdminKey = "X-Admin-Key"
+
+// RequireAdminKey membatasi route untuk pemegang ADMIN_API_KEY. API ini belum punya autentikasi pengguna,
+// sehingga endpoint yang menerbitkan kredensial (kunci kiosk) dilindungi kunci bersama ini.
+// Jika kunci tidak dikonfigurasi, route selalu ditolak alih-alih terbuka untuk siapa pun.
+func RequireAdminKey(adminKey string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if adminKey == "" {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Admin endpoints are disabled; set ADMIN_API_KEY"})
+			return
+		}
+		if subtle.ConstantTimeCompare([]byte(c.GetHeader(HeaderAdminKey)), []byte(adminKey)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or missing " + HeaderAdminKey})
+			return
+		}
+		c.Next()
+	}
+}
+
+// TimeoutConfig mengatur batas waktu request. Routes dikunci dengan "METHOD /path" sesuai pola route gin
+// (mis. "POST /api/v1/attendances/import"); route lain memakai Default. Nilai 0 berarti tanpa batas waktu.
+type TimeoutConfig struct {
+	Default time.Duration
+	Routes  map[string]time.Duration
+}
+
+// RequestTimeout memasang deadline pada context request sehingga query database ikut dibatalkan
+// saat batas waktu habis atau klien memutus koneksi.
+func RequestTimeout(cfg TimeoutConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		timeout, ok := cfg.Routes[c.Request.Method+" "+c.FullPath()]
+		if !ok {
+			timeout = cfg.Default
+		}
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && !c.Writer.Written() {
+			c.AbortWithStatusJSON(http.StatusGatewayTimeout, gin.H{"error": "Request timed out"})
+		}
+	}
+}
+
+// Header idempotency key
+const (
+	HeaderIdempotencyKey      = "Idempotency-Key"
+	HeaderIdempotencyReplayed = "Idempotent-Replayed"
+)
+
+// maxIdempotencyKeyLength mengikuti panjang kolom idempotency_key
+const maxIdempotencyKeyLength = 255
+
+// Idempotency memutar ulang respons pertama untuk request ber-header Idempotency-Key yang identik
+// (method, path dan body sama) dalam masa berlaku. Key yang dipakai untuk request berbeda ditolak 422,
+// key yang request pertamanya masih berjalan ditolak 409. Respons 5xx dan handler yang panik tidak disimpan agar bisa dicoba ulang.
+func Idempotency(service domain.IdempotencyService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(HeaderIdempotencyKey)
+		if service == nil || key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Idempotency-Key must be at most 255 characters"})
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Failed to read request body"})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		record, err := service.Begin(c.Request.Context(), key, requestFingerprint(c.Request.Method, c.Request.URL.RequestURI(), body))
+		switch {
+		case errors.Is(err, domain.ErrIdempotencyKeyReused):
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		case errors.Is(err, domain.ErrIdempotencyKeyInFlight):
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		case err != nil:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to check Idempotency-Key"})
+			return
+		case record != nil:
+			c.Header(HeaderIdempotencyReplayed, "true")
+			c.Data(record.StatusCode, record.ContentType, []byte(record.Body))
+			c.Abort()
+			return
+		}
+
+		// Simpan tetap dijalankan walau request sudah timeout/dibatalkan
+		ctx := context.WithoutCancel(c.Request.Context())
+
+		// Handler yang panik melepas reservasi lebih dulu, lalu panic diteruskan ke middleware Recovery
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				if err := service.Abandon(ctx, key); err != nil {
+					log.Printf("Failed to release Idempotency-Key %q: %v", key, err)
+				}
+				panic(recovered)
+			}
+		}()
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		status := c.Writer.Status()
+		if !c.Writer.Written() || status >= http.StatusInternalServerError {
+			if err := service.Abandon(ctx, key); err != nil {
+				log.Printf("Failed to release Idempotency-Key %q: %v", key, err)
+			}
+			return
+		}
+		if err := service.Complete(ctx, key, status, c.Writer.Header().Get("Content-Type"), recorder.body.Bytes()); err != nil {
+			log.Printf("Failed to store response for Idempotency-Key %q: %v", key, err)
+		}
+	}
+}
+
+// requestFingerprint adalah SHA-256 dari method, path+query dan body request
+func requestFingerprint(method string, uri string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method + " " + uri + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// responseRecorder menyalin body respons agar bisa disimpan untuk diputar ulang
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -2,6 +2,7 @@ package http
 
 import (
 	"hr-payroll/internal/delivery/handler"
+	"hr-payroll/internal/domain"
 	"time"
 
 	"github.com/gin-contrib/cors"
@@ -14,7 +15,28 @@ import (
 type RouterConfig struct {
 	EmployeeHandler   *handler.EmployeeHandler
 	AttendanceHandler *handler.AttendanceHandler
+	CorrectionHandler *handler.AttendanceCorrectionHandler
+	MobileHandler     *handler.MobileAttendanceHandler
+	OfficeHandler     *handler.OfficeHandler
+	KioskHandler      *handler.KioskHandler
+	StatusHandler     *handler.AttendanceStatusHandler
+	TimesheetHandler  *handler.TimesheetHandler
+	PeriodHandler     *handler.AttendancePeriodHandler
 	PayrollHandler    *handler.PayrollHandler
+	THRHandler        *handler.THRHandler
+	AdjustmentHandler *handler.PayrollAdjustmentHandler
+	TaxHandler        *handler.TaxCertificateHandler
+	LoanHandler       *handler.LoanHandler
+	ReimbHandler      *handler.ReimbursementHandler
+	HolidayHandler    *handler.HolidayHandler
+	AuditHandler      *handler.AuditLogHandler
+
+	// Timeouts: batas waktu per request (default dan per route)
+	Timeouts TimeoutConfig
+	// Idempotency: penyimpanan respons untuk header Idempotency-Key (nil = header diabaikan)
+	Idempotency domain.IdempotencyService
+	// AdminKey: ADMIN_API_KEY untuk endpoint admin (kosong = endpoint admin ditolak)
+	AdminKey string
 }
 
 // SetupRouter mengkonfigurasi dan mengembalikan router Gin
@@ -23,15 +45,22 @@ func SetupRouter(router *gin.Engine, cfg RouterConfig) {
 	router.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"http://localhost:3000"},
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Kiosk-Key", HeaderAdminKey, HeaderActor, HeaderRequestID, HeaderIdempotencyKey},
+		ExposeHeaders:    []string{"Content-Length", HeaderRequestID, HeaderIdempotencyReplayed},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}))
+	router.Use(AuditMetadata())
+	router.Use(RequestTimeout(cfg.Timeouts))
 
 	// Endpoint Swagger
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	// Middleware Idempotency-Key untuk endpoint tulis yang sering di-retry klien
+	idempotent := Idempotency(cfg.Idempotency)
+	// Endpoint yang menerbitkan kredensial hanya untuk admin
+	adminOnly := RequireAdminKey(cfg.AdminKey)
+
 	// Grouping API Version 1
 	v1 := router.Group("/api/v1")
 	{
@@ -40,16 +69,90 @@ func SetupRouter(router *gin.Engine, cfg RouterConfig) {
 		v1.GET("/employees", cfg.EmployeeHandler.GetAllEmployees)
 		v1.GET("/employees/:id", cfg.EmployeeHandler.GetEmployeeByID)
 		v1.PUT("/employees/:id", cfg.EmployeeHandler.UpdateEmployee)
+		v1.POST("/employees/:id/salaries", cfg.EmployeeHandler.AddSalaryChange)
+		v1.GET("/employees/:id/salaries", cfg.EmployeeHandler.GetSalaryHistory)
 
 		// 2. Attendance Management Routes
-		v1.POST("/attendances", cfg.AttendanceHandler.RecordAttendance)
-		v1.PUT("/attendances/checkout", cfg.AttendanceHandler.RecordCheckout)
+		v1.POST("/attendances", idempotent, cfg.AttendanceHandler.RecordAttendance)
+		v1.PUT("/attendances/checkout", idempotent, cfg.AttendanceHandler.RecordCheckout)
 		v1.GET("/attendances", cfg.AttendanceHandler.GetAttendanceByPeriod)
+		v1.GET("/attendances/timesheet", cfg.TimesheetHandler.GetMonthlyTimesheet)
+		v1.POST("/attendances/import", cfg.AttendanceHandler.ImportAttendance)
+		v1.POST("/attendances/punches", idempotent, cfg.AttendanceHandler.RecordPunch)
+		v1.GET("/attendances/punches", cfg.AttendanceHandler.GetPunchesByPeriod)
+		v1.POST("/attendances/recompute", cfg.AttendanceHandler.RecomputeAttendance)
+		v1.POST("/attendances/absences/mark", cfg.AttendanceHandler.MarkAbsences)
+		v1.POST("/attendances/mobile/punches", idempotent, cfg.MobileHandler.RecordMobilePunch)
+		v1.GET("/attendances/punches/flagged", cfg.MobileHandler.GetFlaggedPunches)
+		v1.GET("/attendances/punches/:id/selfie", cfg.MobileHandler.GetPunchSelfie)
+		v1.POST("/attendances/punches/:id/approve", cfg.MobileHandler.ApproveFlaggedPunch)
+		v1.POST("/attendances/punches/:id/reject", cfg.MobileHandler.RejectFlaggedPunch)
+		v1.POST("/attendances/kiosk/scan", idempotent, cfg.KioskHandler.ScanKioskToken)
+		v1.GET("/attendance-statuses", cfg.StatusHandler.GetStatuses)
+		v1.POST("/attendance-statuses", cfg.StatusHandler.CreateStatus)
+		v1.PUT("/attendance-statuses/:code", cfg.StatusHandler.UpdateStatus)
+		v1.POST("/attendances/corrections", cfg.CorrectionHandler.SubmitCorrection)
+		v1.GET("/attendances/corrections", cfg.CorrectionHandler.GetCorrections)
+		v1.GET("/attendances/corrections/:id", cfg.CorrectionHandler.GetCorrection)
+		v1.POST("/attendances/corrections/:id/approve", cfg.CorrectionHandler.ApproveCorrection)
+		v1.POST("/attendances/corrections/:id/reject", cfg.CorrectionHandler.RejectCorrection)
+		v1.GET("/attendances/periods", cfg.PeriodHandler.GetPeriods)
+		v1.POST("/attendances/periods/:period/lock", cfg.PeriodHandler.LockPeriod)
+		v1.POST("/attendances/periods/:period/unlock", cfg.PeriodHandler.UnlockPeriod)
+		v1.GET("/attendances/periods/:period/unlocks", cfg.PeriodHandler.GetUnlocks)
 
 		// 3. Payroll Generation Routes
-		v1.POST("/payroll/generate", cfg.PayrollHandler.GeneratePayroll)
+		v1.POST("/payroll/generate", idempotent, cfg.PayrollHandler.GeneratePayroll)
+		v1.POST("/payroll/preview", cfg.PayrollHandler.PreviewPayroll)
 		v1.GET("/payroll/slips", cfg.PayrollHandler.GetPayrollSlips)
 		v1.GET("/payroll/slips/:id", cfg.PayrollHandler.GetPayrollDetail)
+		v1.POST("/payroll/slips/:id/recalculate", cfg.PayrollHandler.RecalculatePayroll)
+		v1.POST("/payroll/slips/:id/pay", cfg.PayrollHandler.MarkPayrollPaid)
+		v1.POST("/payroll/slips/:id/void", cfg.PayrollHandler.VoidAndReissuePayroll)
+		v1.GET("/payroll/reports/variance", cfg.PayrollHandler.GetVarianceReport)
+		v1.POST("/payroll/thr/schedules", cfg.THRHandler.SaveSchedule)
+		v1.GET("/payroll/thr/schedules", cfg.THRHandler.GetSchedules)
+		v1.POST("/payroll/thr/run", cfg.THRHandler.RunTHR)
+		v1.GET("/payroll/adjustments/components", cfg.AdjustmentHandler.GetComponents)
+		v1.POST("/payroll/adjustments", cfg.AdjustmentHandler.CreateAdjustment)
+		v1.POST("/payroll/adjustments/import", cfg.AdjustmentHandler.ImportAdjustments)
+		v1.GET("/payroll/adjustments", cfg.AdjustmentHandler.GetAdjustments)
+		v1.GET("/payroll/adjustments/:id", cfg.AdjustmentHandler.GetAdjustment)
+		v1.POST("/payroll/adjustments/:id/cancel", cfg.AdjustmentHandler.CancelAdjustment)
+		v1.GET("/payroll/tax-certificates", cfg.TaxHandler.GetCertificates)
+		v1.GET("/payroll/tax-certificates/:employee_id", cfg.TaxHandler.GetCertificate)
+
+		// 4. Holiday Calendar Routes
+		v1.POST("/holidays", cfg.HolidayHandler.CreateHoliday)
+		v1.GET("/holidays", cfg.HolidayHandler.GetHolidaysByPeriod)
+		v1.DELETE("/holidays/:id", cfg.HolidayHandler.DeleteHoliday)
+
+		// 5. Office & Geofence Routes
+		v1.POST("/offices", cfg.OfficeHandler.CreateOffice)
+		v1.GET("/offices", cfg.OfficeHandler.GetAllOffices)
+		v1.PUT("/offices/:id", cfg.OfficeHandler.UpdateOffice)
+		v1.POST("/offices/:id/kiosk-key", adminOnly, cfg.KioskHandler.IssueKioskKey)
+		v1.GET("/offices/:id/kiosk-token", cfg.KioskHandler.GetKioskToken)
+
+		// 6. Loan Routes
+		v1.POST("/loans", cfg.LoanHandler.CreateLoan)
+		v1.GET("/loans", cfg.LoanHandler.GetLoans)
+		v1.GET("/loans/:id", cfg.LoanHandler.GetLoan)
+		v1.POST("/loans/:id/settle", cfg.LoanHandler.SettleLoan)
+
+		// 7. Reimbursement Routes
+		v1.GET("/reimbursements/categories", cfg.ReimbHandler.GetCategories)
+		v1.POST("/reimbursements/categories", cfg.ReimbHandler.CreateCategory)
+		v1.PUT("/reimbursements/categories/:code", cfg.ReimbHandler.UpdateCategory)
+		v1.POST("/reimbursements", cfg.ReimbHandler.SubmitClaim)
+		v1.GET("/reimbursements", cfg.ReimbHandler.GetClaims)
+		v1.GET("/reimbursements/:id", cfg.ReimbHandler.GetClaim)
+		v1.GET("/reimbursements/:id/receipt", cfg.ReimbHandler.GetReceipt)
+		v1.POST("/reimbursements/:id/approve", cfg.ReimbHandler.ApproveClaim)
+		v1.POST("/reimbursements/:id/reject", cfg.ReimbHandler.RejectClaim)
+
+		// 8. Audit Log Routes
+		v1.GET("/audit-logs", cfg.AuditHandler.GetAuditLogs)
 	}
 
 }
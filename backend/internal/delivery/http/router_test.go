@@ -0,0 +1,769 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"hr-payroll/internal/delivery/handler"
+	"hr-payroll/internal/domain"
+	"hr-payroll/internal/repository/memory"
+	"hr-payroll/internal/service"
+	"hr-payroll/internal/storage"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// testAdminKey adalah ADMIN_API_KEY router test
+const testAdminKey = "test-admin-key"
+
+// testServer adalah router lengkap (seperti cmd/main.go) di atas repository memori
+type testServer struct {
+	router     *gin.Engine
+	employees  domain.EmployeeRepository
+	attendance domain.AttendanceRepository
+	payrolls   domain.PayrollRepository
+	auditLogs  *memory.AuditLogRepository
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	employeeRepo := memory.NewEmployeeRepository()
+	attendanceRepo := memory.NewAttendanceRepository()
+	punchRepo := memory.NewPunchRepository()
+	attendanceStatusRepo := memory.NewAttendanceStatusRepository()
+	attendancePeriodRepo := memory.NewAttendancePeriodRepository()
+	payrollRepo := memory.NewPayrollRepository()
+	holidayRepo := memory.NewHolidayRepository()
+	salaryHistoryRepo := memory.NewSalaryHistoryRepository()
+	thrScheduleRepo := memory.NewTHRScheduleRepository()
+	loanRepo := memory.NewLoanRepository()
+	reimbursementRepo := memory.NewReimbursementRepository()
+	adjustmentRepo := memory.NewPayrollAdjustmentRepository()
+	correctionRepo := memory.NewAttendanceCorrectionRepository()
+	officeRepo := memory.NewOfficeRepository()
+	kioskTokenUseRepo := memory.NewKioskTokenUseRepository()
+	auditLogRepo := memory.NewAuditLogRepository()
+	idempotencyRepo := memory.NewIdempotencyRepository()
+	fileStorage := storage.NewLocalFileStorage(t.TempDir())
+	txManager := memory.NewTxManager(employeeRepo, attendanceRepo, punchRepo, attendanceStatusRepo, attendancePeriodRepo, payrollRepo,
+		holidayRepo, salaryHistoryRepo, thrScheduleRepo, loanRepo, reimbursementRepo, adjustmentRepo, correctionRepo, officeRepo,
+		kioskTokenUseRepo, auditLogRepo)
+
+	// Data awal yang dibuat migrasi database
+	for _, status := range domain.DefaultAttendanceStatuses {
+		if err := attendanceStatusRepo.Save(ctx, &status); err != nil {
+			t.Fatalf("seed attendance status: %v", err)
+		}
+	}
+	for _, category := range domain.DefaultReimbursementCategories {
+		if err := reimbursementRepo.SaveCategory(ctx, &category); err != nil {
+			t.Fatalf("seed reimbursement category: %v", err)
+		}
+	}
+
+	employeeService := service.NewEmployeeServiceImpl(employeeRepo, salaryHistoryRepo)
+	attendanceService := service.NewAttendanceServiceImpl(attendanceRepo, punchRepo, attendanceStatusRepo, attendancePeriodRepo, payrollRepo, txManager, service.AttendanceConfig{})
+	attendanceImportService := service.NewAttendanceImportServiceImpl(attendanceService, employeeRepo)
+	absenceMarkingService := service.NewAbsenceMarkingServiceImpl(attendanceService, attendanceRepo, employeeRepo, holidayRepo)
+	payrollService := service.NewPayrollServiceImpl(employeeRepo, attendanceRepo, payrollRepo, holidayRepo, salaryHistoryRepo, attendanceStatusRepo, loanRepo, reimbursementRepo, adjustmentRepo, txManager, service.PayrollConfig{})
+	thrService := service.NewTHRServiceImpl(thrScheduleRepo, employeeRepo, payrollRepo, salaryHistoryRepo, service.THRConfig{})
+	adjustmentService := service.NewPayrollAdjustmentServiceImpl(adjustmentRepo, employeeRepo, payrollRepo)
+	taxCertificateService := service.NewTaxCertificateServiceImpl(payrollRepo, employeeRepo, service.TaxCertificateConfig{
+		Withholder: domain.TaxWithholder{Name: "PT Contoh", NPWP: "0123456789012345"},
+	})
+	holidayService := service.NewHolidayServiceImpl(holidayRepo)
+	correctionService := service.NewAttendanceCorrectionServiceImpl(correctionRepo, attendanceRepo, employeeRepo, payrollRepo, attendanceStatusRepo, attendancePeriodRepo, attendanceService)
+	attendancePeriodService := service.NewAttendancePeriodServiceImpl(attendancePeriodRepo, payrollRepo)
+	officeService := service.NewOfficeServiceImpl(officeRepo)
+	loanService := service.NewLoanServiceImpl(loanRepo, employeeRepo, memory.NewTxManager())
+	reimbursementService := service.NewReimbursementServiceImpl(reimbursementRepo, employeeRepo, fileStorage)
+	attendanceStatusService := service.NewAttendanceStatusServiceImpl(attendanceStatusRepo)
+	auditLogService := service.NewAuditLogServiceImpl(auditLogRepo)
+	idempotencyService := service.NewIdempotencyServiceImpl(idempotencyRepo, service.IdempotencyConfig{})
+	timesheetService := service.NewTimesheetServiceImpl(employeeRepo, attendanceRepo, attendanceStatusRepo, service.TimesheetConfig{})
+	mobileAttendanceService := service.NewMobileAttendanceServiceImpl(attendanceService, punchRepo, employeeRepo, officeRepo, fileStorage, attendancePeriodRepo, payrollRepo)
+	kioskService := service.NewKioskServiceImpl(attendanceService, officeRepo, employeeRepo, kioskTokenUseRepo, txManager, service.KioskConfig{
+		Secret: []byte("test-kiosk-secret"),
+	})
+
+	router := gin.New()
+	SetupRouter(router, RouterConfig{
+		EmployeeHandler:   handler.NewEmployeeHandler(employeeService),
+		AttendanceHandler: handler.NewAttendanceHandler(attendanceService, attendanceImportService, absenceMarkingService),
+		CorrectionHandler: handler.NewAttendanceCorrectionHandler(correctionService),
+		MobileHandler:     handler.NewMobileAttendanceHandler(mobileAttendanceService),
+		OfficeHandler:     handler.NewOfficeHandler(officeService),
+		KioskHandler:      handler.NewKioskHandler(kioskService),
+		StatusHandler:     handler.NewAttendanceStatusHandler(attendanceStatusService),
+		TimesheetHandler:  handler.NewTimesheetHandler(timesheetService),
+		PeriodHandler:     handler.NewAttendancePeriodHandler(attendancePeriodService),
+		PayrollHandler:    handler.NewPayrollHandler(payrollService),
+		THRHandler:        handler.NewTHRHandler(thrService),
+		AdjustmentHandler: handler.NewPayrollAdjustmentHandler(adjustmentService),
+		TaxHandler:        handler.NewTaxCertificateHandler(taxCertificateService),
+		LoanHandler:       handler.NewLoanHandler(loanService),
+		ReimbHandler:      handler.NewReimbursementHandler(reimbursementService),
+		HolidayHandler:    handler.NewHolidayHandler(holidayService),
+		AuditHandler:      handler.NewAuditLogHandler(auditLogService),
+		Timeouts:          TimeoutConfig{Default: 5 * time.Second},
+		Idempotency:       idempotencyService,
+		AdminKey:          testAdminKey,
+	})
+
+	return &testServer{
+		router:     router,
+		employees:  employeeRepo,
+		attendance: attendanceRepo,
+		payrolls:   payrollRepo,
+		auditLogs:  auditLogRepo,
+	}
+}
+
+// seedEmployee menyimpan karyawan langsung ke repository dan mengembalikan ID-nya
+func (s *testServer) seedEmployee(t *testing.T, emp domain.Employee) uint {
+	t.Helper()
+	if err := s.employees.Save(context.Background(), &emp); err != nil {
+		t.Fatalf("seed employee: %v", err)
+	}
+	return emp.ID
+}
+
+// routeCase adalah satu request dalam skenario; case dijalankan berurutan dan berbagi data
+type routeCase struct {
+	name        string
+	method      string
+	path        string
+	body        any // string (JSON mentah), *multipartForm, atau nil
+	header      map[string]string
+	wantStatus  int
+	wantContain string // potongan body respons yang wajib ada
+}
+
+func (s *testServer) run(t *testing.T, cases []routeCase) {
+	t.Helper()
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := s.do(t, tc.method, tc.path, tc.body, tc.header)
+			if w.Code != tc.wantStatus {
+				t.Fatalf("%s %s status = %d, want %d; body: %s", tc.method, tc.path, w.Code, tc.wantStatus, w.Body.String())
+			}
+			if tc.wantContain != "" && !strings.Contains(w.Body.String(), tc.wantContain) {
+				t.Errorf("%s %s body = %s, want it to contain %q", tc.method, tc.path, w.Body.String(), tc.wantContain)
+			}
+		})
+	}
+}
+
+func (s *testServer) do(t *testing.T, method string, path string, body any, header map[string]string) *httptest.ResponseRecorder {
+	t.Helper()
+	var reader io.Reader
+	contentType := ""
+	switch b := body.(type) {
+	case string:
+		reader, contentType = strings.NewReader(b), "application/json"
+	case *multipartForm:
+		reader, contentType = b.encode(t)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	for key, value := range header {
+		req.Header.Set(key, value)
+	}
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+// decode membaca respons JSON dari request yang diharapkan berhasil
+func (s *testServer) decode(t *testing.T, method string, path string, body any, header map[string]string, out any) {
+	t.Helper()
+	w := s.do(t, method, path, body, header)
+	if w.Code >= 300 {
+		t.Fatalf("%s %s status = %d; body: %s", method, path, w.Code, w.Body.String())
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), out); err != nil {
+		t.Fatalf("decode %s %s: %v", method, path, err)
+	}
+}
+
+// multipartForm adalah body multipart/form-data dengan satu file opsional
+type multipartForm struct {
+	fields    map[string]string
+	fileField string
+	fileName  string
+	content   string
+}
+
+func (f *multipartForm) encode(t *testing.T) (io.Reader, string) {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	for key, value := range f.fields {
+		if err := writer.WriteField(key, value); err != nil {
+			t.Fatalf("write field %s: %v", key, err)
+		}
+	}
+	if f.fileField != "" {
+		part, err := writer.CreateFormFile(f.fileField, f.fileName)
+		if err != nil {
+			t.Fatalf("create form file: %v", err)
+		}
+		if _, err := io.WriteString(part, f.content); err != nil {
+			t.Fatalf("write form file: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("close multipart writer: %v", err)
+	}
+	return &buf, writer.FormDataContentType()
+}
+
+func TestEmployeeRoutes(t *testing.T) {
+	srv := newTestServer(t)
+	srv.run(t, []routeCase{
+		{name: "create", method: http.MethodPost, path: "/api/v1/employees",
+			body:       `{"name":"Budi","base_salary":4400000,"allowance":500000,"religion":"islam","npwp":"01.234.567.8-901.234"}`,
+			wantStatus: http.StatusCreated, wantContain: `"religion":"ISLAM"`},
+		{name: "create with invalid body", method: http.MethodPost, path: "/api/v1/employees", body: `{"name":`, wantStatus: http.StatusBadRequest},
+		{name: "create with invalid NIK", method: http.MethodPost, path: "/api/v1/employees", body: `{"name":"Sari","nik":"123"}`, wantStatus: http.StatusBadRequest},
+		{name: "list", method: http.MethodGet, path: "/api/v1/employees", wantStatus: http.StatusOK, wantContain: `"name":"Budi"`},
+		{name: "get", method: http.MethodGet, path: "/api/v1/employees/1", wantStatus: http.StatusOK, wantContain: `"npwp":"012345678901234"`},
+		{name: "get unknown", method: http.MethodGet, path: "/api/v1/employees/99", wantStatus: http.StatusNotFound},
+		{name: "get invalid id", method: http.MethodGet, path: "/api/v1/employees/abc", wantStatus: http.StatusBadRequest},
+		{name: "update", method: http.MethodPut, path: "/api/v1/employees/1",
+			body: `{"name":"Budi Santoso","base_salary":4400000,"allowance":500000}`, wantStatus: http.StatusOK, wantContain: `"name":"Budi Santoso"`},
+		{name: "partial update keeps omitted fields", method: http.MethodPut, path: "/api/v1/employees/1",
+			body: `{"position":"Lead"}`, wantStatus: http.StatusOK, wantContain: `"religion":"ISLAM","tax_status":"","npwp":"012345678901234"`},
+		{name: "update unknown", method: http.MethodPut, path: "/api/v1/employees/99", body: `{"name":"X"}`, wantStatus: http.StatusNotFound},
+		{name: "add salary change", method: http.MethodPost, path: "/api/v1/employees/1/salaries",
+			body: `{"base_salary":5000000,"allowance":500000,"effective_from":"2025-11-01","note":"Kenaikan"}`, wantStatus: http.StatusCreated},
+		{name: "add salary change with invalid date", method: http.MethodPost, path: "/api/v1/employees/1/salaries",
+			body: `{"base_salary":5000000,"effective_from":"01-11-2025"}`, wantStatus: http.StatusBadRequest},
+		{name: "salary history", method: http.MethodGet, path: "/api/v1/employees/1/salaries", wantStatus: http.StatusOK, wantContain: `"base_salary":5000000`},
+		{name: "salary history unknown", method: http.MethodGet, path: "/api/v1/employees/99/salaries", wantStatus: http.StatusNotFound},
+	})
+}
+
+func TestAttendanceRoutes(t *testing.T) {
+	srv := newTestServer(t)
+	joinDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	empID := srv.seedEmployee(t, domain.Employee{Name: "Budi", BaseSalary: 4400000, DeviceUserID: "1001", JoinDate: &joinDate})
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	srv.run(t, []routeCase{
+		{name: "record", method: http.MethodPost, path: "/api/v1/attendances",
+			body:       fmt.Sprintf(`{"employee_id":%d,"date":"2025-11-10T00:00:00Z","status":"PRESENT","check_in":"2025-11-10T08:00:00Z"}`, empID),
+			wantStatus: http.StatusCreated},
+		{name: "record duplicate", method: http.MethodPost, path: "/api/v1/attendances",
+			body:       fmt.Sprintf(`{"employee_id":%d,"date":"2025-11-10T00:00:00Z","status":"PRESENT","check_in":"2025-11-10T08:00:00Z"}`, empID),
+			wantStatus: http.StatusConflict, wantContain: "already recorded"},
+		{name: "record without required check-in", method: http.MethodPost, path: "/api/v1/attendances",
+			body: fmt.Sprintf(`{"employee_id":%d,"date":"2025-11-11T00:00:00Z","status":"PRESENT"}`, empID), wantStatus: http.StatusConflict},
+		{name: "record with invalid body", method: http.MethodPost, path: "/api/v1/attendances", body: `[]`, wantStatus: http.StatusBadRequest},
+		{name: "checkout without check-in", method: http.MethodPut, path: "/api/v1/attendances/checkout",
+			body: fmt.Sprintf(`{"employee_id":%d}`, empID), wantStatus: http.StatusConflict, wantContain: "no check-in record"},
+		{name: "record today", method: http.MethodPost, path: "/api/v1/attendances",
+			body:       fmt.Sprintf(`{"employee_id":%d,"date":%q,"status":"PRESENT","check_in":%q}`, empID, today.Format(time.RFC3339), today.Format(time.RFC3339)),
+			wantStatus: http.StatusCreated},
+		{name: "checkout", method: http.MethodPut, path: "/api/v1/attendances/checkout", body: fmt.Sprintf(`{"employee_id":%d}`, empID), wantStatus: http.StatusOK},
+		{name: "checkout twice", method: http.MethodPut, path: "/api/v1/attendances/checkout",
+			body: fmt.Sprintf(`{"employee_id":%d}`, empID), wantStatus: http.StatusConflict, wantContain: "already checked out"},
+		{name: "list by period", method: http.MethodGet, path: fmt.Sprintf("/api/v1/attendances?employee_id=%d&from=2025-11-01&to=2025-11-30", empID),
+			wantStatus: http.StatusOK, wantContain: `"status":"PRESENT"`},
+		{name: "list with invalid date", method: http.MethodGet, path: "/api/v1/attendances?employee_id=1&from=2025/11/01&to=2025-11-30", wantStatus: http.StatusBadRequest},
+		{name: "punch", method: http.MethodPost, path: "/api/v1/attendances/punches",
+			body: fmt.Sprintf(`{"employee_id":%d,"timestamp":"2025-11-12T08:01:00Z","direction":"IN"}`, empID), wantStatus: http.StatusCreated},
+		{name: "punch with invalid direction", method: http.MethodPost, path: "/api/v1/attendances/punches",
+			body: fmt.Sprintf(`{"employee_id":%d,"timestamp":"2025-11-12T17:01:00Z","direction":"SIDEWAYS"}`, empID), wantStatus: http.StatusBadRequest},
+		{name: "punch without employee", method: http.MethodPost, path: "/api/v1/attendances/punches", body: `{"direction":"IN"}`, wantStatus: http.StatusBadRequest},
+		{name: "list punches", method: http.MethodGet, path: fmt.Sprintf("/api/v1/attendances/punches?employee_id=%d&from=2025-11-12&to=2025-11-12", empID),
+			wantStatus: http.StatusOK, wantContain: `"direction":"IN"`},
+		{name: "recompute", method: http.MethodPost, path: "/api/v1/attendances/recompute",
+			body: fmt.Sprintf(`{"employee_id":%d,"date":"2025-11-12"}`, empID), wantStatus: http.StatusOK},
+		{name: "recompute day without data", method: http.MethodPost, path: "/api/v1/attendances/recompute",
+			body: fmt.Sprintf(`{"employee_id":%d,"date":"2025-11-13"}`, empID), wantStatus: http.StatusBadRequest},
+		{name: "import csv", method: http.MethodPost, path: "/api/v1/attendances/import",
+			body: &multipartForm{fileField: "file", fileName: "attlog.csv",
+				content: "device_user_id,timestamp\n1001,2025-11-14 08:00:00\n1001,2025-11-14 17:00:00\n"},
+			wantStatus: http.StatusOK, wantContain: `"imported":1`},
+		{name: "import without file", method: http.MethodPost, path: "/api/v1/attendances/import", body: &multipartForm{}, wantStatus: http.StatusBadRequest},
+		{name: "mark absences", method: http.MethodPost, path: "/api/v1/attendances/absences/mark",
+			body: `{"from":"2025-11-17","to":"2025-11-17"}`, wantStatus: http.StatusOK},
+		{name: "mark absences with reversed range", method: http.MethodPost, path: "/api/v1/attendances/absences/mark",
+			body: `{"from":"2025-11-30","to":"2025-11-01"}`, wantStatus: http.StatusBadRequest},
+	})
+
+	marked, err := srv.attendance.FindByEmployeeAndDate(context.Background(), empID, time.Date(2025, 11, 17, 0, 0, 0, 0, time.UTC))
+	if err != nil || marked == nil || marked.Status != domain.AttendanceStatusAbsent {
+		t.Errorf("marked attendance = %+v (err %v), want ABSENT", marked, err)
+	}
+}
+
+func TestAttendanceIdempotencyKey(t *testing.T) {
+	srv := newTestServer(t)
+	empID := srv.seedEmployee(t, domain.Employee{Name: "Budi", BaseSalary: 4400000})
+	body := fmt.Sprintf(`{"employee_id":%d,"date":"2025-11-10T00:00:00Z","status":"LEAVE"}`, empID)
+	key := map[string]string{HeaderIdempotencyKey: "attendance-1"}
+
+	srv.run(t, []routeCase{
+		{name: "first request", method: http.MethodPost, path: "/api/v1/attendances", body: body, header: key, wantStatus: http.StatusCreated},
+		{name: "retry is replayed", method: http.MethodPost, path: "/api/v1/attendances", body: body, header: key, wantStatus: http.StatusCreated},
+		{name: "same key with another payload", method: http.MethodPost, path: "/api/v1/attendances",
+			body: strings.Replace(body, "LEAVE", "WFH", 1), header: key, wantStatus: http.StatusUnprocessableEntity},
+		{name: "without key the duplicate is rejected", method: http.MethodPost, path: "/api/v1/attendances", body: body, wantStatus: http.StatusConflict},
+	})
+}
+
+func TestAttendanceStatusRoutes(t *testing.T) {
+	srv := newTestServer(t)
+	srv.run(t, []routeCase{
+		{name: "list", method: http.MethodGet, path: "/api/v1/attendance-statuses", wantStatus: http.StatusOK, wantContain: `"code":"HALF_DAY"`},
+		{name: "create", method: http.MethodPost, path: "/api/v1/attendance-statuses",
+			body: `{"code":"TRAINING","name":"Pelatihan","counts_as_present":true}`, wantStatus: http.StatusCreated, wantContain: `"active":true`},
+		{name: "create duplicate", method: http.MethodPost, path: "/api/v1/attendance-statuses", body: `{"code":"TRAINING","name":"Pelatihan"}`, wantStatus: http.StatusBadRequest},
+		{name: "update", method: http.MethodPut, path: "/api/v1/attendance-statuses/TRAINING",
+			body: `{"name":"Pelatihan eksternal","counts_as_present":true,"deduction_weight":0}`, wantStatus: http.StatusOK, wantContain: "Pelatihan eksternal"},
+		{name: "update unknown", method: http.MethodPut, path: "/api/v1/attendance-statuses/NOPE", body: `{"name":"X"}`, wantStatus: http.StatusNotFound},
+	})
+}
+
+func TestAttendanceCorrectionRoutes(t *testing.T) {
+	srv := newTestServer(t)
+	empID := srv.seedEmployee(t, domain.Employee{Name: "Budi", BaseSalary: 4400000})
+	if err := srv.attendance.Save(context.Background(), &domain.Attendance{EmployeeID: empID, Date: time.Date(2025, 11, 10, 0, 0, 0, 0, time.UTC), Status: domain.AttendanceStatusAbsent}); err != nil {
+		t.Fatalf("seed attendance: %v", err)
+	}
+
+	correction := fmt.Sprintf(`{"employee_id":%d,"date":"2025-11-10T00:00:00Z","requested_status":"PRESENT","requested_check_in":"2025-11-10T08:55:00Z","reason":"Lupa check-in"}`, empID)
+	srv.run(t, []routeCase{
+		{name: "submit", method: http.MethodPost, path: "/api/v1/attendances/corrections", body: correction, wantStatus: http.StatusCreated, wantContain: `"status":"PENDING"`},
+		{name: "submit with invalid body", method: http.MethodPost, path: "/api/v1/attendances/corrections", body: `{`, wantStatus: http.StatusBadRequest},
+		{name: "submit second", method: http.MethodPost, path: "/api/v1/attendances/corrections", body: correction, wantStatus: http.StatusCreated},
+		{name: "list pending", method: http.MethodGet, path: "/api/v1/attendances/corrections?status=PENDING", wantStatus: http.StatusOK, wantContain: `"reason":"Lupa check-in"`},
+		{name: "get", method: http.MethodGet, path: "/api/v1/attendances/corrections/1", wantStatus: http.StatusOK},
+		{name: "get unknown", method: http.MethodGet, path: "/api/v1/attendances/corrections/99", wantStatus: http.StatusNotFound},
+		{name: "approve", method: http.MethodPost, path: "/api/v1/attendances/corrections/1/approve",
+			body: `{"reviewer":"manager@example.com","note":"OK"}`, wantStatus: http.StatusOK, wantContain: `"after_status":"PRESENT"`},
+		{name: "approve twice", method: http.MethodPost, path: "/api/v1/attendances/corrections/1/approve",
+			body: `{"reviewer":"manager@example.com"}`, wantStatus: http.StatusConflict},
+		{name: "reject", method: http.MethodPost, path: "/api/v1/attendances/corrections/2/reject",
+			body: `{"reviewer":"manager@example.com","note":"Sudah dikoreksi"}`, wantStatus: http.StatusOK, wantContain: `"status":"REJECTED"`},
+	})
+}
+
+func TestAttendancePeriodRoutes(t *testing.T) {
+	srv := newTestServer(t)
+	empID := srv.seedEmployee(t, domain.Employee{Name: "Budi", BaseSalary: 4400000})
+
+	srv.run(t, []routeCase{
+		{name: "lock", method: http.MethodPost, path: "/api/v1/attendances/periods/2025-11/lock",
+			body: `{"locked_by":"payroll@example.com"}`, wantStatus: http.StatusOK, wantContain: `"status":"LOCKED"`},
+		{name: "lock again is a no-op", method: http.MethodPost, path: "/api/v1/attendances/periods/2025-11/lock", body: `{"locked_by":"other@example.com"}`,
+			wantStatus: http.StatusOK, wantContain: `"locked_by":"payroll@example.com"`},
+		{name: "lock with invalid period", method: http.MethodPost, path: "/api/v1/attendances/periods/november/lock", body: `{}`, wantStatus: http.StatusBadRequest},
+		{name: "attendance in locked period", method: http.MethodPost, path: "/api/v1/attendances/punches",
+			body: fmt.Sprintf(`{"employee_id":%d,"timestamp":"2025-11-12T08:01:00Z"}`, empID), wantStatus: http.StatusConflict},
+		{name: "list", method: http.MethodGet, path: "/api/v1/attendances/periods", wantStatus: http.StatusOK, wantContain: `"locked_by":"payroll@example.com"`},
+		{name: "unlock", method: http.MethodPost, path: "/api/v1/attendances/periods/2025-11/unlock",
+			body: `{"unlocked_by":"hr.admin@example.com","reason":"Koreksi cuti"}`, wantStatus: http.StatusOK, wantContain: `"status":"OPEN"`},
+		{name: "unlock twice", method: http.MethodPost, path: "/api/v1/attendances/periods/2025-11/unlock",
+			body: `{"unlocked_by":"hr.admin@example.com","reason":"Koreksi cuti"}`, wantStatus: http.StatusConflict},
+		{name: "unlocks", method: http.MethodGet, path: "/api/v1/attendances/periods/2025-11/unlocks", wantStatus: http.StatusOK, wantContain: `"reason":"Koreksi cuti"`},
+	})
+}
+
+func TestTimesheetRoutes(t *testing.T) {
+	srv := newTestServer(t)
+	srv.seedEmployee(t, domain.Employee{Name: "Budi", BaseSalary: 4400000, Department: "Engineering"})
+	srv.run(t, []routeCase{
+		{name: "monthly timesheet", method: http.MethodGet, path: "/api/v1/attendances/timesheet?period=2025-11", wantStatus: http.StatusOK, wantContain: `"employee_name":"Budi"`},
+		{name: "timesheet csv", method: http.MethodGet, path: "/api/v1/attendances/timesheet?period=2025-11&format=csv", wantStatus: http.StatusOK},
+		{name: "invalid period", method: http.MethodGet, path: "/api/v1/attendances/timesheet?period=11-2025", wantStatus: http.StatusBadRequest},
+	})
+}
+
+func TestOfficeRoutes(t *testing.T) {
+	srv := newTestServer(t)
+	srv.run(t, []routeCase{
+		{name: "create", method: http.MethodPost, path: "/api/v1/offices",
+			body:       `{"name":"Kantor Pusat","geofence_type":"RADIUS","latitude":-6.2088,"longitude":106.8456,"radius_meters":150}`,
+			wantStatus: http.StatusCreated, wantContain: `"policy":"REJECT"`},
+		{name: "create polygon with too few points", method: http.MethodPost, path: "/api/v1/offices",
+			body: `{"name":"Gudang","geofence_type":"POLYGON","polygon":[{"latitude":-6.2,"longitude":106.8}]}`, wantStatus: http.StatusBadRequest},
+		{name: "list", method: http.MethodGet, path: "/api/v1/offices", wantStatus: http.StatusOK, wantContain: `"name":"Kantor Pusat"`},
+		{name: "update", method: http.MethodPut, path: "/api/v1/offices/1",
+			body:       `{"name":"Kantor Pusat","geofence_type":"RADIUS","latitude":-6.2088,"longitude":106.8456,"radius_meters":200,"policy":"FLAG"}`,
+			wantStatus: http.StatusOK, wantContain: `"policy":"FLAG"`},
+		{name: "update unknown", method: http.MethodPut, path: "/api/v1/offices/99",
+			body: `{"name":"X","geofence_type":"RADIUS","latitude":-6.2,"longitude":106.8,"radius_meters":100}`, wantStatus: http.StatusNotFound},
+	})
+}
+
+func TestMobileAttendanceRoutes(t *testing.T) {
+	srv := newTestServer(t)
+	empID := srv.seedEmployee(t, domain.Employee{Name: "Budi", BaseSalary: 4400000})
+	srv.do(t, http.MethodPost, "/api/v1/offices", `{"name":"Kantor Pusat","geofence_type":"RADIUS","latitude":-6.2088,"longitude":106.8456,"radius_meters":150,"policy":"FLAG"}`, nil)
+	today := time.Now().Format("2006-01-02")
+
+	punch := func(lat string, lng string) *multipartForm {
+		return &multipartForm{
+			fields:    map[string]string{"employee_id": fmt.Sprint(empID), "direction": "IN", "latitude": lat, "longitude": lng, "accuracy": "10"},
+			fileField: "selfie", fileName: "selfie.jpg", content: "\xff\xd8\xff\xe0 selfie",
+		}
+	}
+	srv.run(t, []routeCase{
+		{name: "punch inside geofence", method: http.MethodPost, path: "/api/v1/attendances/mobile/punches",
+			body: punch("-6.2088", "106.8456"), wantStatus: http.StatusCreated, wantContain: `"geofence_status":"INSIDE"`},
+		{name: "punch outside geofence is flagged", method: http.MethodPost, path: "/api/v1/attendances/mobile/punches",
+			body: punch("-6.3000", "106.9000"), wantStatus: http.StatusCreated, wantContain: `"flagged":true`},
+		{name: "punch without location", method: http.MethodPost, path: "/api/v1/attendances/mobile/punches",
+			body: &multipartForm{fields: map[string]string{"employee_id": fmt.Sprint(empID)}}, wantStatus: http.StatusBadRequest},
+		{name: "flagged punches", method: http.MethodGet, path: fmt.Sprintf("/api/v1/attendances/punches/flagged?from=%s&to=%s", today, today),
+			wantStatus: http.StatusOK, wantContain: `"flagged":true`},
+		{name: "selfie", method: http.MethodGet, path: "/api/v1/attendances/punches/1/selfie", wantStatus: http.StatusOK, wantContain: "selfie"},
+		{name: "selfie of unknown punch", method: http.MethodGet, path: "/api/v1/attendances/punches/99/selfie", wantStatus: http.StatusNotFound},
+	})
+
+	// Punch yang ditandai tidak dihitung sampai disetujui
+	otherID := srv.seedEmployee(t, domain.Employee{Name: "Sari", BaseSalary: 4400000})
+	flagged := punch("-6.3000", "106.9000")
+	flagged.fields["employee_id"] = fmt.Sprint(otherID)
+	attendances := fmt.Sprintf("/api/v1/attendances?employee_id=%d&from=%s&to=%s", otherID, today, today)
+	srv.run(t, []routeCase{
+		{name: "flagged punch alone does not create attendance", method: http.MethodPost, path: "/api/v1/attendances/mobile/punches",
+			body: flagged, wantStatus: http.StatusCreated, wantContain: `"attendance":null`},
+		{name: "no attendance before review", method: http.MethodGet, path: attendances, wantStatus: http.StatusOK, wantContain: "[]"},
+		{name: "approve without reviewer", method: http.MethodPost, path: "/api/v1/attendances/punches/3/approve", body: `{}`, wantStatus: http.StatusBadRequest},
+		{name: "approve unflagged punch", method: http.MethodPost, path: "/api/v1/attendances/punches/1/approve",
+			body: `{"reviewer":"manager@example.com"}`, wantStatus: http.StatusConflict},
+		{name: "approve unknown punch", method: http.MethodPost, path: "/api/v1/attendances/punches/99/approve",
+			body: `{"reviewer":"manager@example.com"}`, wantStatus: http.StatusNotFound},
+		{name: "approve", method: http.MethodPost, path: "/api/v1/attendances/punches/3/approve",
+			body: `{"reviewer":"manager@example.com","note":"Meeting di kantor klien"}`, wantStatus: http.StatusOK, wantContain: `"review_status":"APPROVED"`},
+		{name: "attendance after approval", method: http.MethodGet, path: attendances, wantStatus: http.StatusOK, wantContain: `"status":"PRESENT"`},
+		{name: "reject after approval", method: http.MethodPost, path: "/api/v1/attendances/punches/3/reject",
+			body: `{"reviewer":"manager@example.com"}`, wantStatus: http.StatusConflict},
+		{name: "reject", method: http.MethodPost, path: "/api/v1/attendances/punches/2/reject",
+			body: `{"reviewer":"manager@example.com"}`, wantStatus: http.StatusOK, wantContain: `"review_status":"REJECTED"`},
+	})
+}
+
+func TestKioskRoutes(t *testing.T) {
+	srv := newTestServer(t)
+	empID := srv.seedEmployee(t, domain.Employee{Name: "Budi", BaseSalary: 4400000})
+	srv.do(t, http.MethodPost, "/api/v1/offices", `{"name":"Kantor Pusat","geofence_type":"RADIUS","latitude":-6.2088,"longitude":106.8456,"radius_meters":150}`, nil)
+
+	var key domain.KioskKey
+	admin := map[string]string{HeaderAdminKey: testAdminKey}
+	srv.decode(t, http.MethodPost, "/api/v1/offices/1/kiosk-key", nil, admin, &key)
+	var token domain.KioskToken
+	srv.decode(t, http.MethodGet, "/api/v1/offices/1/kiosk-token", nil, map[string]string{"X-Kiosk-Key": key.Key}, &token)
+
+	scan := fmt.Sprintf(`{"employee_id":%d,"token":%q,"action":"CHECK_IN"}`, empID, token.Token)
+	srv.run(t, []routeCase{
+		{name: "issue key without admin key", method: http.MethodPost, path: "/api/v1/offices/1/kiosk-key", wantStatus: http.StatusUnauthorized},
+		{name: "issue key with wrong admin key", method: http.MethodPost, path: "/api/v1/offices/1/kiosk-key", header: map[string]string{HeaderAdminKey: "guess"}, wantStatus: http.StatusUnauthorized},
+		{name: "issue key for unknown office", method: http.MethodPost, path: "/api/v1/offices/99/kiosk-key", header: admin, wantStatus: http.StatusNotFound},
+		// Kunci tablet tetap berlaku karena percobaan rotasi tanpa kunci admin ditolak
+		{name: "token with issued key", method: http.MethodGet, path: "/api/v1/offices/1/kiosk-token", header: map[string]string{"X-Kiosk-Key": key.Key}, wantStatus: http.StatusOK},
+		{name: "token with wrong key", method: http.MethodGet, path: "/api/v1/offices/1/kiosk-token", header: map[string]string{"X-Kiosk-Key": "wrong"}, wantStatus: http.StatusUnauthorized},
+		{name: "scan", method: http.MethodPost, path: "/api/v1/attendances/kiosk/scan", body: scan, wantStatus: http.StatusOK},
+		{name: "scan same token again", method: http.MethodPost, path: "/api/v1/attendances/kiosk/scan", body: scan, wantStatus: http.StatusConflict},
+		{name: "scan forged token", method: http.MethodPost, path: "/api/v1/attendances/kiosk/scan",
+			body: fmt.Sprintf(`{"employee_id":%d,"token":"1.1.forged","action":"CHECK_IN"}`, empID), wantStatus: http.StatusUnauthorized},
+	})
+}
+
+func TestPayrollRoutes(t *testing.T) {
+	srv := newTestServer(t)
+	empID := srv.seedEmployee(t, domain.Employee{Name: "Budi", BaseSalary: 4400000, Allowance: 600000, Department: "Engineering"})
+	for _, d := range []int{3, 4} {
+		if err := srv.attendance.Save(context.Background(), &domain.Attendance{EmployeeID: empID, Date: time.Date(2025, 11, d, 0, 0, 0, 0, time.UTC), Status: domain.AttendanceStatusAbsent}); err != nil {
+			t.Fatalf("seed attendance: %v", err)
+		}
+	}
+
+	generate := fmt.Sprintf(`{"employee_id":%d,"period":"2025-11-01"}`, empID)
+	srv.run(t, []routeCase{
+		{name: "generate", method: http.MethodPost, path: "/api/v1/payroll/generate", body: generate,
+			wantStatus: http.StatusCreated, wantContain: `"absence_deduction":400000`},
+		{name: "generate duplicate", method: http.MethodPost, path: "/api/v1/payroll/generate", body: generate, wantStatus: http.StatusConflict},
+		{name: "generate with invalid period", method: http.MethodPost, path: "/api/v1/payroll/generate",
+			body: fmt.Sprintf(`{"employee_id":%d,"period":"11/2025"}`, empID), wantStatus: http.StatusBadRequest},
+		{name: "preview", method: http.MethodPost, path: "/api/v1/payroll/preview",
+			body: `{"period":"2025-11-01","department":"Engineering","overrides":{"extra_absences":1}}`, wantStatus: http.StatusOK, wantContain: `"employee_id":1`},
+		{name: "list slips", method: http.MethodGet, path: "/api/v1/payroll/slips", wantStatus: http.StatusOK, wantContain: `"take_home_pay":4600000`},
+		{name: "slip detail", method: http.MethodGet, path: "/api/v1/payroll/slips/1", wantStatus: http.StatusOK},
+		{name: "recalculate", method: http.MethodPost, path: "/api/v1/payroll/slips/1/recalculate", wantStatus: http.StatusOK},
+		{name: "void unpaid slip", method: http.MethodPost, path: "/api/v1/payroll/slips/1/void", body: `{"reason":"Salah hitung"}`, wantStatus: http.StatusConflict},
+		{name: "pay", method: http.MethodPost, path: "/api/v1/payroll/slips/1/pay", wantStatus: http.StatusOK, wantContain: `"status":"PAID"`},
+		{name: "recalculate paid slip", method: http.MethodPost, path: "/api/v1/payroll/slips/1/recalculate", wantStatus: http.StatusConflict},
+		{name: "void and reissue", method: http.MethodPost, path: "/api/v1/payroll/slips/1/void",
+			body: `{"reason":"Absensi salah dicatat"}`, wantStatus: http.StatusCreated, wantContain: `"replaces_id":1`},
+		{name: "pay voided slip", method: http.MethodPost, path: "/api/v1/payroll/slips/1/pay", wantStatus: http.StatusConflict},
+		{name: "variance report", method: http.MethodGet, path: "/api/v1/payroll/reports/variance?from=2025-10-01&to=2025-11-01", wantStatus: http.StatusOK},
+		{name: "variance report with invalid range", method: http.MethodGet, path: "/api/v1/payroll/reports/variance?from=2025-11", wantStatus: http.StatusBadRequest},
+	})
+}
+
+func TestPayrollGenerateIdempotencyKey(t *testing.T) {
+	srv := newTestServer(t)
+	empID := srv.seedEmployee(t, domain.Employee{Name: "Budi", BaseSalary: 4400000})
+	body := fmt.Sprintf(`{"employee_id":%d,"period":"2025-11-01"}`, empID)
+	key := map[string]string{HeaderIdempotencyKey: "payroll-2025-11"}
+
+	first := srv.do(t, http.MethodPost, "/api/v1/payroll/generate", body, key)
+	retry := srv.do(t, http.MethodPost, "/api/v1/payroll/generate", body, key)
+	if first.Code != http.StatusCreated || retry.Code != http.StatusCreated {
+		t.Fatalf("status = %d then %d, want 201 twice", first.Code, retry.Code)
+	}
+	if retry.Header().Get(HeaderIdempotencyReplayed) != "true" || retry.Body.String() != first.Body.String() {
+		t.Errorf("retry was not replayed: header %q, body %s", retry.Header().Get(HeaderIdempotencyReplayed), retry.Body.String())
+	}
+	if slips, _ := srv.payrolls.FindAll(context.Background()); len(slips) != 1 {
+		t.Errorf("stored slips = %d, want 1", len(slips))
+	}
+}
+
+func TestTHRRoutes(t *testing.T) {
+	srv := newTestServer(t)
+	joinDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	srv.seedEmployee(t, domain.Employee{Name: "Budi", BaseSalary: 4400000, Allowance: 600000, Religion: "ISLAM", JoinDate: &joinDate})
+
+	srv.run(t, []routeCase{
+		{name: "save schedule", method: http.MethodPost, path: "/api/v1/payroll/thr/schedules",
+			body:       `{"year":2026,"religion":"ISLAM","holiday_name":"Idul Fitri","holiday_date":"2026-03-20T00:00:00Z","payout_date":"2026-03-10T00:00:00Z"}`,
+			wantStatus: http.StatusOK},
+		{name: "save schedule with unknown religion", method: http.MethodPost, path: "/api/v1/payroll/thr/schedules",
+			body: `{"year":2026,"religion":"JEDI","holiday_date":"2026-03-20T00:00:00Z","payout_date":"2026-03-10T00:00:00Z"}`, wantStatus: http.StatusBadRequest},
+		{name: "list schedules", method: http.MethodGet, path: "/api/v1/payroll/thr/schedules?year=2026", wantStatus: http.StatusOK, wantContain: `"religion":"ISLAM"`},
+		{name: "list schedules without year", method: http.MethodGet, path: "/api/v1/payroll/thr/schedules", wantStatus: http.StatusBadRequest},
+		{name: "dry run", method: http.MethodPost, path: "/api/v1/payroll/thr/run", body: `{"year":2026,"religion":"ISLAM","dry_run":true}`,
+			wantStatus: http.StatusOK, wantContain: `"type":"THR"`},
+		{name: "run without schedule", method: http.MethodPost, path: "/api/v1/payroll/thr/run", body: `{"year":2027,"religion":"ISLAM"}`, wantStatus: http.StatusNotFound},
+	})
+}
+
+func TestPayrollAdjustmentRoutes(t *testing.T) {
+	srv := newTestServer(t)
+	empID := srv.seedEmployee(t, domain.Employee{Name: "Budi", BaseSalary: 4400000})
+
+	srv.run(t, []routeCase{
+		{name: "components", method: http.MethodGet, path: "/api/v1/payroll/adjustments/components", wantStatus: http.StatusOK, wantContain: `"code":"BONUS"`},
+		{name: "create", method: http.MethodPost, path: "/api/v1/payroll/adjustments",
+			body:       fmt.Sprintf(`{"employee_id":%d,"period":"2025-11","component":"BONUS","amount":1500000,"entered_by":"hr.admin@example.com"}`, empID),
+			wantStatus: http.StatusCreated, wantContain: `"status":"PENDING"`},
+		{name: "create with unknown component", method: http.MethodPost, path: "/api/v1/payroll/adjustments",
+			body:       fmt.Sprintf(`{"employee_id":%d,"period":"2025-11","component":"LOTTERY","amount":1,"entered_by":"hr.admin@example.com"}`, empID),
+			wantStatus: http.StatusBadRequest},
+		{name: "import dry run", method: http.MethodPost, path: "/api/v1/payroll/adjustments/import",
+			body: &multipartForm{fields: map[string]string{"entered_by": "hr.admin@example.com", "dry_run": "true"}, fileField: "file", fileName: "adjustments.csv",
+				content: fmt.Sprintf("employee_id,period,component,amount,note\n%d,2025-11,COMMISSION,250000,Komisi\n", empID)},
+			wantStatus: http.StatusOK},
+		{name: "list", method: http.MethodGet, path: fmt.Sprintf("/api/v1/payroll/adjustments?employee_id=%d&period=2025-11", empID), wantStatus: http.StatusOK, wantContain: `"component":"BONUS"`},
+		{name: "get", method: http.MethodGet, path: "/api/v1/payroll/adjustments/1", wantStatus: http.StatusOK},
+		{name: "get unknown", method: http.MethodGet, path: "/api/v1/payroll/adjustments/99", wantStatus: http.StatusNotFound},
+		{name: "cancel", method: http.MethodPost, path: "/api/v1/payroll/adjustments/1/cancel",
+			body: `{"cancelled_by":"hr.admin@example.com"}`, wantStatus: http.StatusOK, wantContain: `"status":"CANCELLED"`},
+		{name: "cancel twice", method: http.MethodPost, path: "/api/v1/payroll/adjustments/1/cancel", body: `{"cancelled_by":"hr.admin@example.com"}`, wantStatus: http.StatusConflict},
+	})
+}
+
+func TestTaxCertificateRoutes(t *testing.T) {
+	srv := newTestServer(t)
+	empID := srv.seedEmployee(t, domain.Employee{Name: "Budi", BaseSalary: 4400000, NPWP: "0123456789012345", NIK: "3171012345670001"})
+	paidAt := time.Date(2025, 11, 28, 0, 0, 0, 0, time.UTC)
+	if err := srv.payrolls.Save(context.Background(), &domain.Payroll{EmployeeID: empID, Period: time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC),
+		BaseSalary: 4400000, TakeHomePay: 4400000, Status: domain.PayrollStatusPaid, PaidAt: &paidAt}); err != nil {
+		t.Fatalf("seed payroll: %v", err)
+	}
+
+	srv.run(t, []routeCase{
+		{name: "list", method: http.MethodGet, path: "/api/v1/payroll/tax-certificates?year=2025", wantStatus: http.StatusOK, wantContain: `"employee_id":1`},
+		{name: "list without year", method: http.MethodGet, path: "/api/v1/payroll/tax-certificates", wantStatus: http.StatusBadRequest},
+		{name: "employee certificate", method: http.MethodGet, path: fmt.Sprintf("/api/v1/payroll/tax-certificates/%d?year=2025", empID), wantStatus: http.StatusOK},
+		{name: "employee without paid slips", method: http.MethodGet, path: "/api/v1/payroll/tax-certificates/99?year=2025", wantStatus: http.StatusNotFound},
+	})
+}
+
+func TestLoanRoutes(t *testing.T) {
+	srv := newTestServer(t)
+	empID := srv.seedEmployee(t, domain.Employee{Name: "Budi", BaseSalary: 4400000})
+
+	srv.run(t, []routeCase{
+		{name: "create", method: http.MethodPost, path: "/api/v1/loans",
+			body:       fmt.Sprintf(`{"employee_id":%d,"principal":3000000,"installment_count":6,"start_period":"2025-11-01T00:00:00Z"}`, empID),
+			wantStatus: http.StatusCreated, wantContain: `"installment_amount":500000`},
+		{name: "create without installments", method: http.MethodPost, path: "/api/v1/loans",
+			body: fmt.Sprintf(`{"employee_id":%d,"principal":3000000,"start_period":"2025-11-01T00:00:00Z"}`, empID), wantStatus: http.StatusBadRequest},
+		{name: "list", method: http.MethodGet, path: fmt.Sprintf("/api/v1/loans?employee_id=%d", empID), wantStatus: http.StatusOK, wantContain: `"status":"ACTIVE"`},
+		{name: "get", method: http.MethodGet, path: "/api/v1/loans/1", wantStatus: http.StatusOK},
+		{name: "get unknown", method: http.MethodGet, path: "/api/v1/loans/99", wantStatus: http.StatusNotFound},
+		{name: "settle", method: http.MethodPost, path: "/api/v1/loans/1/settle", body: `{"note":"Dilunasi tunai"}`, wantStatus: http.StatusOK, wantContain: `"status":"SETTLED"`},
+		{name: "settle twice", method: http.MethodPost, path: "/api/v1/loans/1/settle", wantStatus: http.StatusConflict},
+	})
+}
+
+func TestReimbursementRoutes(t *testing.T) {
+	srv := newTestServer(t)
+	empID := srv.seedEmployee(t, domain.Employee{Name: "Budi", BaseSalary: 4400000})
+	claim := func(amount string, fileName string) *multipartForm {
+		return &multipartForm{
+			fields:    map[string]string{"employee_id": fmt.Sprint(empID), "category_code": "MEDICAL", "expense_date": "2025-11-03", "amount": amount, "description": "Dokter"},
+			fileField: "receipt", fileName: fileName, content: "%PDF-1.4 kuitansi",
+		}
+	}
+
+	srv.run(t, []routeCase{
+		{name: "categories", method: http.MethodGet, path: "/api/v1/reimbursements/categories", wantStatus: http.StatusOK, wantContain: `"code":"MEDICAL"`},
+		{name: "create category", method: http.MethodPost, path: "/api/v1/reimbursements/categories",
+			body: `{"code":"OPTIC","name":"Kacamata","yearly_limit":1000000}`, wantStatus: http.StatusCreated},
+		{name: "update category", method: http.MethodPut, path: "/api/v1/reimbursements/categories/MEDICAL",
+			body: `{"name":"Pengobatan","yearly_limit":500000,"active":true}`, wantStatus: http.StatusOK, wantContain: `"yearly_limit":500000`},
+		{name: "update unknown category", method: http.MethodPut, path: "/api/v1/reimbursements/categories/NOPE", body: `{"name":"X"}`, wantStatus: http.StatusNotFound},
+		{name: "submit", method: http.MethodPost, path: "/api/v1/reimbursements", body: claim("350000", "kuitansi.pdf"), wantStatus: http.StatusCreated, wantContain: `"status":"SUBMITTED"`},
+		{name: "submit over yearly limit", method: http.MethodPost, path: "/api/v1/reimbursements", body: claim("200000", "kuitansi.pdf"), wantStatus: http.StatusConflict},
+		{name: "submit with unsupported receipt", method: http.MethodPost, path: "/api/v1/reimbursements", body: claim("10000", "kuitansi.exe"), wantStatus: http.StatusBadRequest},
+		{name: "list", method: http.MethodGet, path: fmt.Sprintf("/api/v1/reimbursements?employee_id=%d", empID), wantStatus: http.StatusOK, wantContain: `"category_code":"MEDICAL"`},
+		{name: "get", method: http.MethodGet, path: "/api/v1/reimbursements/1", wantStatus: http.StatusOK},
+		{name: "get unknown", method: http.MethodGet, path: "/api/v1/reimbursements/99", wantStatus: http.StatusNotFound},
+		{name: "receipt", method: http.MethodGet, path: "/api/v1/reimbursements/1/receipt", wantStatus: http.StatusOK, wantContain: "kuitansi"},
+		{name: "manager approval", method: http.MethodPost, path: "/api/v1/reimbursements/1/approve",
+			body: `{"reviewed_by":"manager@example.com"}`, wantStatus: http.StatusOK, wantContain: `"status":"MANAGER_APPROVED"`},
+		{name: "finance approval", method: http.MethodPost, path: "/api/v1/reimbursements/1/approve",
+			body: `{"reviewed_by":"finance@example.com"}`, wantStatus: http.StatusOK, wantContain: `"status":"APPROVED"`},
+		{name: "reject approved claim", method: http.MethodPost, path: "/api/v1/reimbursements/1/reject",
+			body: `{"reviewed_by":"finance@example.com","note":"Salah"}`, wantStatus: http.StatusConflict},
+	})
+}
+
+func TestHolidayRoutes(t *testing.T) {
+	srv := newTestServer(t)
+	srv.run(t, []routeCase{
+		{name: "create", method: http.MethodPost, path: "/api/v1/holidays", body: `{"date":"2025-12-25T00:00:00Z","name":"Hari Raya Natal"}`, wantStatus: http.StatusCreated},
+		{name: "create duplicate date", method: http.MethodPost, path: "/api/v1/holidays", body: `{"date":"2025-12-25T00:00:00Z","name":"Natal"}`, wantStatus: http.StatusConflict},
+		{name: "list", method: http.MethodGet, path: "/api/v1/holidays?from=2025-12-01&to=2025-12-31", wantStatus: http.StatusOK, wantContain: "Hari Raya Natal"},
+		{name: "list without range", method: http.MethodGet, path: "/api/v1/holidays", wantStatus: http.StatusBadRequest},
+		{name: "delete", method: http.MethodDelete, path: "/api/v1/holidays/1", wantStatus: http.StatusNoContent},
+		{name: "delete unknown", method: http.MethodDelete, path: "/api/v1/holidays/1", wantStatus: http.StatusNotFound},
+	})
+}
+
+func TestAuditLogRoutes(t *testing.T) {
+	srv := newTestServer(t)
+	srv.auditLogs.Append(domain.AuditLog{Actor: "hr.admin@example.com", Action: domain.AuditActionUpdate, Entity: domain.AuditEntityEmployee, EntityID: 1})
+	srv.auditLogs.Append(domain.AuditLog{Actor: "system", Action: domain.AuditActionCreate, Entity: domain.AuditEntityPayroll, EntityID: 1})
+
+	srv.run(t, []routeCase{
+		{name: "list", method: http.MethodGet, path: "/api/v1/audit-logs", wantStatus: http.StatusOK, wantContain: `"entity":"payroll"`},
+		{name: "filter by entity", method: http.MethodGet, path: "/api/v1/audit-logs?entity=employee&entity_id=1", wantStatus: http.StatusOK, wantContain: `"actor":"hr.admin@example.com"`},
+		{name: "unknown entity", method: http.MethodGet, path: "/api/v1/audit-logs?entity=office", wantStatus: http.StatusBadRequest},
+		{name: "invalid date", method: http.MethodGet, path: "/api/v1/audit-logs?from=yesterday", wantStatus: http.StatusBadRequest},
+	})
+}
+
+func TestAuditMetadataActor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(AuditMetadata())
+	router.GET("/actor", func(c *gin.Context) {
+		c.String(http.StatusOK, domain.AuditMetaFromContext(c.Request.Context()).Actor)
+	})
+
+	tests := []struct {
+		name  string
+		actor string
+		want  string
+	}{
+		{name: "header sent", actor: "hr.admin@example.com", want: "hr.admin@example.com"},
+		{name: "header missing", want: domain.AuditActorUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/actor", nil)
+			if tt.actor != "" {
+				req.Header.Set(HeaderActor, tt.actor)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			if got := rec.Body.String(); got != tt.want {
+				t.Errorf("actor = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequireAdminKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name       string
+		adminKey   string
+		header     string
+		wantStatus int
+	}{
+		{name: "valid key", adminKey: testAdminKey, header: testAdminKey, wantStatus: http.StatusOK},
+		{name: "wrong key", adminKey: testAdminKey, header: "guess", wantStatus: http.StatusUnauthorized},
+		{name: "admin key not configured", header: "", wantStatus: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.POST("/admin", RequireAdminKey(tt.adminKey), func(c *gin.Context) { c.Status(http.StatusOK) })
+			req := httptest.NewRequest(http.MethodPost, "/admin", nil)
+			req.Header.Set(HeaderAdminKey, tt.header)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestIdempotencyPanicReleasesKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	calls := 0
+	router := gin.New()
+	router.Use(gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, _ any) { c.AbortWithStatus(http.StatusInternalServerError) }))
+	idempotency := service.NewIdempotencyServiceImpl(memory.NewIdempotencyRepository(), service.IdempotencyConfig{})
+	router.POST("/payroll", Idempotency(idempotency), func(c *gin.Context) {
+		calls++
+		if calls == 1 {
+			panic("handler crashed")
+		}
+		c.JSON(http.StatusCreated, gin.H{"call": calls})
+	})
+
+	send := func() int {
+		req := httptest.NewRequest(http.MethodPost, "/payroll", strings.NewReader(`{}`))
+		req.Header.Set(HeaderIdempotencyKey, "payroll-1")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	if status := send(); status != http.StatusInternalServerError {
+		t.Fatalf("first request status = %d, want %d", status, http.StatusInternalServerError)
+	}
+	// Reservasi dilepas saat panik, sehingga retry diproses ulang alih-alih 409 sampai kedaluwarsa
+	if status := send(); status != http.StatusCreated {
+		t.Fatalf("retry status = %d, want %d", status, http.StatusCreated)
+	}
+	if status := send(); status != http.StatusCreated || calls != 2 {
+		t.Errorf("replay status = %d after %d handler calls, want %d after 2", status, calls, http.StatusCreated)
+	}
+}
@@ -1,29 +1,41 @@
 package domain
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 // Attendance adalah entitas bisnis inti untuk kehadiran harian
 type Attendance struct {
-	ID         uint       `json:"id" gorm:"primaryKey" example:"1"`
-	EmployeeID uint       `json:"employee_id" example:"1"`
-	Date       time.Time  `json:"date" gorm:"uniqueIndex:idx_employee_date" example:"2025-11-10T00:00:00Z"` // Memastikan unik per employee per hari
-	Status     string     `json:"status" example:"PRESENT"`                                    // PRESENT, ABSENT, LEAVE
-	CheckIn    *time.Time `json:"check_in" example:"2025-11-10T09:00:00Z"`
-	CheckOut   *time.Time `json:"check_out" example:"2025-11-10T17:00:00Z"`
-	CreatedAt  time.Time  `json:"created_at"`
+	ID            uint       `json:"id" gorm:"primaryKey" example:"1"`
+	EmployeeID    uint       `json:"employee_id" gorm:"uniqueIndex:idx_employee_date" example:"1"`
+	Date          time.Time  `json:"date" gorm:"uniqueIndex:idx_employee_date" example:"2025-11-10T00:00:00Z"` // Memastikan unik per employee per hari
+	Status        string     `json:"status" example:"PRESENT"`                                                 // Kode dari katalog attendance_statuses
+	CheckIn       *time.Time `json:"check_in" example:"2025-11-10T09:00:00Z"`
+	CheckOut      *time.Time `json:"check_out" example:"2025-11-10T17:00:00Z"`
+	WorkedMinutes int        `json:"worked_minutes" example:"480"` // Menit kerja hasil pairing punch
+	DocumentURL   string     `json:"document_url" example:""`      // Dokumen pendukung untuk status yang mewajibkannya
+	CreatedAt     time.Time  `json:"created_at"`
 }
 
 // AttendanceRepository mendefinisikan kontrak operasi data (Port)
 type AttendanceRepository interface {
-	Save(att *Attendance) error
-	Update(att *Attendance) error
-	FindByEmployeeAndDate(employeeID uint, date time.Time) (*Attendance, error)
-	FindByPeriod(employeeID uint, dateFrom time.Time, dateTo time.Time) ([]Attendance, error)
+	Save(ctx context.Context, att *Attendance) error
+	Update(ctx context.Context, att *Attendance) error
+	FindByEmployeeAndDate(ctx context.Context, employeeID uint, date time.Time) (*Attendance, error)
+	FindByPeriod(ctx context.Context, employeeID uint, dateFrom time.Time, dateTo time.Time) ([]Attendance, error)
+	FindAllByPeriod(ctx context.Context, dateFrom time.Time, dateTo time.Time) ([]Attendance, error) // Semua karyawan dalam satu query
 }
 
 // AttendanceService mendefinisikan kontrak Use Case
 type AttendanceService interface {
-	RecordAttendance(att *Attendance) (*Attendance, error)
-	RecordCheckout(employeeID uint, checkOutTime time.Time) (*Attendance, error)
-	GetAttendanceByPeriod(employeeID uint, dateFrom time.Time, dateTo time.Time) ([]Attendance, error)
+	ValidateAttendance(ctx context.Context, att *Attendance) error
+	RecordAttendance(ctx context.Context, att *Attendance) (*Attendance, error)
+	RecordCheckout(ctx context.Context, employeeID uint, checkOutTime time.Time) (*Attendance, error)
+	GetAttendanceByPeriod(ctx context.Context, employeeID uint, dateFrom time.Time, dateTo time.Time) ([]Attendance, error)
+	RecordPunch(ctx context.Context, punch *Punch) (*Attendance, error)
+	RecordPunches(ctx context.Context, employeeID uint, date time.Time, punches []Punch) (*Attendance, error)
+	SummarizePunches(ctx context.Context, employeeID uint, date time.Time, punches []Punch) (*Attendance, error)
+	RecomputeAttendance(ctx context.Context, employeeID uint, date time.Time) (*Attendance, error)
+	GetPunchesByPeriod(ctx context.Context, employeeID uint, dateFrom time.Time, dateTo time.Time) ([]Punch, error)
 }
@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// AbsenceMarkingResult adalah ringkasan satu kali proses penandaan ABSENT otomatis
+type AbsenceMarkingResult struct {
+	From        time.Time    `json:"from" example:"2025-11-01T00:00:00Z"`
+	To          time.Time    `json:"to" example:"2025-11-30T00:00:00Z"`
+	WorkingDays int          `json:"working_days" example:"20"` // Hari kerja (Senin-Jumat, bukan libur) yang diperiksa
+	Checked     int          `json:"checked" example:"200"`     // Kombinasi karyawan aktif x hari kerja
+	Created     int          `json:"created" example:"3"`       // Record ABSENT baru
+	Records     []Attendance `json:"records"`
+}
+
+// AbsenceMarkingService menandai ABSENT untuk karyawan aktif yang tidak punya absensi, cuti,
+// atau libur pada hari kerja yang sudah lewat. Aman dijalankan berulang kali (idempotent).
+type AbsenceMarkingService interface {
+	MarkAbsences(ctx context.Context, dateFrom time.Time, dateTo time.Time) (*AbsenceMarkingResult, error)
+}
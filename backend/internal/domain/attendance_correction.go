@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Status pengajuan koreksi absensi
+const (
+	CorrectionStatusPending  = "PENDING"
+	CorrectionStatusApproved = "APPROVED"
+	CorrectionStatusRejected = "REJECTED"
+)
+
+var (
+	ErrCorrectionNotFound   = errors.New("attendance correction request not found")
+	ErrCorrectionNotPending = errors.New("attendance correction request is not PENDING")
+)
+
+// AttendanceCorrection adalah pengajuan koreksi absensi oleh karyawan.
+// Nilai Before* dan After* diisi saat koreksi disetujui dan diterapkan, sebagai jejak audit.
+type AttendanceCorrection struct {
+	ID                uint       `json:"id" gorm:"primaryKey" example:"1"`
+	EmployeeID        uint       `json:"employee_id" gorm:"index" example:"1"`
+	Date              time.Time  `json:"date" gorm:"index" example:"2025-11-10T00:00:00Z"`
+	RequestedStatus   string     `json:"requested_status" example:"PRESENT"` // Kosong = status tidak diubah
+	RequestedCheckIn  *time.Time `json:"requested_check_in" example:"2025-11-10T08:55:00Z"`
+	RequestedCheckOut *time.Time `json:"requested_check_out" example:"2025-11-10T17:05:00Z"`
+	Reason            string     `json:"reason" example:"Lupa check-in, mesin fingerprint error"`
+	DocumentURL       string     `json:"document_url" example:"https://files.example.com/surat-dokter.pdf"`
+
+	Status     string     `json:"status" gorm:"index;default:PENDING" example:"PENDING"` // PENDING, APPROVED, REJECTED
+	ReviewedBy string     `json:"reviewed_by" example:"manager@example.com"`
+	ReviewNote string     `json:"review_note" example:""`
+	ReviewedAt *time.Time `json:"reviewed_at"`
+
+	AttendanceID   *uint      `json:"attendance_id" example:"1"` // Record absensi yang dikoreksi
+	BeforeStatus   string     `json:"before_status" example:"ABSENT"`
+	BeforeCheckIn  *time.Time `json:"before_check_in"`
+	BeforeCheckOut *time.Time `json:"before_check_out"`
+	AfterStatus    string     `json:"after_status" example:"PRESENT"`
+	AfterCheckIn   *time.Time `json:"after_check_in"`
+	AfterCheckOut  *time.Time `json:"after_check_out"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// AttendanceCorrectionRepository mendefinisikan kontrak operasi data (Port)
+type AttendanceCorrectionRepository interface {
+	Save(ctx context.Context, correction *AttendanceCorrection) error
+	Update(ctx context.Context, correction *AttendanceCorrection) error
+	FindByID(ctx context.Context, id uint) (*AttendanceCorrection, error)
+	// FindAll memfilter berdasarkan karyawan dan status; nilai kosong berarti tanpa filter
+	FindAll(ctx context.Context, employeeID uint, status string) ([]AttendanceCorrection, error)
+}
+
+// AttendanceCorrectionService mendefinisikan kontrak Use Case
+type AttendanceCorrectionService interface {
+	SubmitCorrection(ctx context.Context, correction *AttendanceCorrection) (*AttendanceCorrection, error)
+	ApproveCorrection(ctx context.Context, id uint, reviewer string, note string) (*AttendanceCorrection, error)
+	RejectCorrection(ctx context.Context, id uint, reviewer string, note string) (*AttendanceCorrection, error)
+	GetCorrections(ctx context.Context, employeeID uint, status string) ([]AttendanceCorrection, error)
+	GetCorrection(ctx context.Context, id uint) (*AttendanceCorrection, error)
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"context"
+	"io"
+	"strings"
+)
+
+// Format file ekspor mesin absensi yang didukung
+const (
+	ImportFormatCSV    = "CSV"    // Header: device_user_id,timestamp (atau kolom date + time terpisah)
+	ImportFormatATTLOG = "ATTLOG" // File teks ATTLOG .dat ZKTeco/Solution: <user id>\t<yyyy-mm-dd hh:mm:ss>\t...
+	ImportFormatXLSX   = "XLSX"   // Template Excel, sheet pertama dengan header yang sama seperti CSV
+)
+
+// AttendanceImportRowError adalah error untuk satu baris file sumber
+type AttendanceImportRowError struct {
+	Row          int    `json:"row" example:"12"` // Nomor baris di file sumber (1 = baris pertama)
+	DeviceUserID string `json:"device_user_id" example:"1001"`
+	Date         string `json:"date,omitempty" example:"2025-11-10"`
+	Error        string `json:"error" example:"device user ID is not mapped to any employee"`
+}
+
+// AttendanceImportResult adalah ringkasan hasil impor (atau simulasi jika DryRun)
+type AttendanceImportResult struct {
+	Format    string                     `json:"format" example:"ATTLOG"`
+	DryRun    bool                       `json:"dry_run" example:"true"`
+	Punches   int                        `json:"punches" example:"120"` // Jumlah punch mentah yang terbaca
+	Days      int                        `json:"days" example:"60"`     // Jumlah pasangan karyawan-hari
+	Imported  int                        `json:"imported" example:"58"` // Attendance yang tersimpan (atau akan tersimpan saat dry run)
+	Failed    int                        `json:"failed" example:"2"`    // Karyawan-hari yang gagal
+	Records   []Attendance               `json:"records"`
+	RowErrors []AttendanceImportRowError `json:"row_errors"`
+}
+
+// AttendanceImportService mendefinisikan kontrak Use Case impor absensi dari mesin fingerprint
+type AttendanceImportService interface {
+	Import(ctx context.Context, r io.Reader, format string, dryRun bool) (*AttendanceImportResult, error)
+}
+
+// ImportFormatFromFilename menebak format impor dari ekstensi file, "" jika tidak dikenali
+func ImportFormatFromFilename(name string) string {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, ".csv"):
+		return ImportFormatCSV
+	case strings.HasSuffix(lower, ".xlsx"):
+		return ImportFormatXLSX
+	case strings.HasSuffix(lower, ".dat"), strings.HasSuffix(lower, ".txt"):
+		return ImportFormatATTLOG
+	}
+	return ""
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Status periode absensi
+const (
+	AttendancePeriodOpen   = "OPEN"
+	AttendancePeriodLocked = "LOCKED"
+)
+
+var (
+	ErrAttendancePeriodLocked    = errors.New("attendance period is locked; unlock it before changing attendance")
+	ErrAttendancePeriodNotLocked = errors.New("attendance period is not locked")
+	ErrPayrollPeriodLocked       = errors.New("payroll for this attendance period is already PAID; unlock the period before changing attendance")
+)
+
+// AttendancePeriod menyimpan status kunci absensi per bulan.
+// Selain kunci eksplisit (LOCKED), absensi seorang karyawan juga terkunci jika slip gajinya
+// untuk bulan itu sudah PAID setelah pembukaan (unlock) terakhir.
+type AttendancePeriod struct {
+	ID         uint       `json:"id" gorm:"primaryKey" example:"1"`
+	Period     time.Time  `json:"period" gorm:"uniqueIndex" example:"2025-11-01T00:00:00Z"` // Tanggal 1 bulan tersebut
+	Status     string     `json:"status" gorm:"default:OPEN" example:"LOCKED"`              // OPEN, LOCKED
+	LockedBy   string     `json:"locked_by" example:"payroll@example.com"`
+	LockedAt   *time.Time `json:"locked_at"`
+	UnlockedAt *time.Time `json:"unlocked_at"` // Slip PAID sebelum waktu ini tidak lagi mengunci absensi
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// AttendancePeriodUnlock adalah catatan setiap kali periode yang terkunci dibuka kembali
+type AttendancePeriodUnlock struct {
+	ID         uint      `json:"id" gorm:"primaryKey" example:"1"`
+	Period     time.Time `json:"period" gorm:"index" example:"2025-11-01T00:00:00Z"`
+	UnlockedBy string    `json:"unlocked_by" example:"hr.admin@example.com"`
+	Reason     string    `json:"reason" example:"Koreksi cuti sakit yang terlambat diinput"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// AttendancePeriodRepository mendefinisikan kontrak operasi data (Port)
+type AttendancePeriodRepository interface {
+	Save(ctx context.Context, period *AttendancePeriod) error
+	Update(ctx context.Context, period *AttendancePeriod) error
+	// FindByPeriod mengembalikan nil jika bulan tersebut belum pernah dikunci
+	FindByPeriod(ctx context.Context, period time.Time) (*AttendancePeriod, error)
+	FindAll(ctx context.Context) ([]AttendancePeriod, error)
+	SaveUnlock(ctx context.Context, unlock *AttendancePeriodUnlock) error
+	// FindUnlocks memfilter berdasarkan bulan; zero time berarti semua bulan
+	FindUnlocks(ctx context.Context, period time.Time) ([]AttendancePeriodUnlock, error)
+}
+
+// AttendancePeriodService mendefinisikan kontrak Use Case
+type AttendancePeriodService interface {
+	LockPeriod(ctx context.Context, period time.Time, actor string) (*AttendancePeriod, error)
+	UnlockPeriod(ctx context.Context, period time.Time, actor string, reason string) (*AttendancePeriod, error)
+	GetPeriods(ctx context.Context) ([]AttendancePeriod, error)
+	GetUnlocks(ctx context.Context, period time.Time) ([]AttendancePeriodUnlock, error)
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Kode status bawaan. Status lain dapat ditambahkan lewat katalog.
+const (
+	AttendanceStatusPresent = "PRESENT"
+	AttendanceStatusAbsent  = "ABSENT"
+	AttendanceStatusLeave   = "LEAVE"
+)
+
+var ErrAttendanceStatusNotFound = errors.New("attendance status not found")
+
+// AttendanceStatus adalah satu entri katalog status absensi dan aturannya
+type AttendanceStatus struct {
+	ID               uint      `json:"id" gorm:"primaryKey" example:"1"`
+	Code             string    `json:"code" gorm:"uniqueIndex" example:"HALF_DAY"`
+	Name             string    `json:"name" example:"Setengah hari"`
+	CountsAsPresent  bool      `json:"counts_as_present" example:"true"`
+	DeductionWeight  float64   `json:"deduction_weight" example:"0.5"` // Porsi potongan gaji harian: 0, 0.5, atau 1
+	CheckInRequired  bool      `json:"check_in_required" example:"true"`
+	DocumentRequired bool      `json:"document_required" example:"false"` // Mis. surat dokter untuk SICK
+	Active           bool      `json:"active" gorm:"default:true" example:"true"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// DefaultAttendanceStatuses adalah isi awal katalog (dibuat saat migrasi jika belum ada)
+var DefaultAttendanceStatuses = []AttendanceStatus{
+	{Code: AttendanceStatusPresent, Name: "Hadir", CountsAsPresent: true, DeductionWeight: 0, CheckInRequired: true, Active: true},
+	{Code: AttendanceStatusAbsent, Name: "Tidak hadir", CountsAsPresent: false, DeductionWeight: 1, Active: true},
+	{Code: AttendanceStatusLeave, Name: "Cuti", CountsAsPresent: false, DeductionWeight: 0, Active: true},
+	{Code: "HALF_DAY", Name: "Setengah hari", CountsAsPresent: true, DeductionWeight: 0.5, CheckInRequired: true, Active: true},
+	{Code: "WFH", Name: "Kerja dari rumah", CountsAsPresent: true, DeductionWeight: 0, Active: true},
+	{Code: "SICK", Name: "Sakit dengan surat dokter", CountsAsPresent: false, DeductionWeight: 0, DocumentRequired: true, Active: true},
+	{Code: "BUSINESS_TRIP", Name: "Dinas luar", CountsAsPresent: true, DeductionWeight: 0, Active: true},
+}
+
+// AttendanceStatusRepository mendefinisikan kontrak operasi data (Port)
+type AttendanceStatusRepository interface {
+	Save(ctx context.Context, status *AttendanceStatus) error
+	Update(ctx context.Context, status *AttendanceStatus) error
+	FindByCode(ctx context.Context, code string) (*AttendanceStatus, error)
+	FindAll(ctx context.Context) ([]AttendanceStatus, error)
+}
+
+// AttendanceStatusService mendefinisikan kontrak Use Case
+type AttendanceStatusService interface {
+	CreateStatus(ctx context.Context, status *AttendanceStatus) (*AttendanceStatus, error)
+	UpdateStatus(ctx context.Context, code string, status *AttendanceStatus) (*AttendanceStatus, error)
+	GetStatuses(ctx context.Context) ([]AttendanceStatus, error)
+}
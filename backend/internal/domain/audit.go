@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Aksi yang dicatat di audit log
+const (
+	AuditActionCreate = "CREATE"
+	AuditActionUpdate = "UPDATE"
+	AuditActionDelete = "DELETE"
+)
+
+// Entitas yang perubahannya dicatat di audit log
+const (
+	AuditEntityEmployee   = "employee"
+	AuditEntityAttendance = "attendance"
+	AuditEntityPayroll    = "payroll"
+)
+
+// Actor bawaan audit log
+const (
+	AuditActorSystem  = "system"  // Perubahan dari job terjadwal (context tanpa identitas request)
+	AuditActorUnknown = "unknown" // Request HTTP tanpa header X-Actor
+)
+
+var ErrInvalidAuditEntity = errors.New("invalid audit entity; use employee, attendance or payroll")
+
+// AuditChange adalah nilai satu field sebelum dan sesudah perubahan
+type AuditChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// AuditChanges adalah diff per field (nama field JSON -> nilai lama/baru), disimpan sebagai teks JSON
+type AuditChanges map[string]AuditChange
+
+// Value implements driver.Valuer.
+func (c AuditChanges) Value() (driver.Value, error) {
+	if c == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner.
+func (c *AuditChanges) Scan(value any) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*c = AuditChanges{}
+		return nil
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		return fmt.Errorf("unsupported audit changes type %T", value)
+	}
+	return json.Unmarshal(data, c)
+}
+
+// AuditLog adalah catatan append-only untuk setiap perubahan data karyawan, absensi dan slip gaji
+type AuditLog struct {
+	ID        uint         `json:"id" gorm:"primaryKey" example:"1"`
+	Actor     string       `json:"actor" gorm:"index" example:"hr.admin@example.com"` // Header X-Actor (tidak diverifikasi), "unknown" jika kosong, "system" untuk job
+	Action    string       `json:"action" example:"UPDATE"`                           // CREATE, UPDATE, DELETE
+	Entity    string       `json:"entity" gorm:"index:idx_audit_entity" example:"employee"`
+	EntityID  uint         `json:"entity_id" gorm:"index:idx_audit_entity" example:"1"`
+	Changes   AuditChanges `json:"changes" gorm:"type:text" swaggertype:"object"`
+	RequestID string       `json:"request_id" gorm:"index" example:"5f2c9a7e1b3d4c60"`
+	IP        string       `json:"ip" example:"10.0.0.12"`
+	CreatedAt time.Time    `json:"created_at" gorm:"index"`
+}
+
+// AuditMeta adalah identitas request yang ikut dicatat pada setiap baris audit
+type AuditMeta struct {
+	Actor     string
+	RequestID string
+	IP        string
+}
+
+type auditMetaKey struct{}
+
+// WithAuditMeta menyisipkan identitas request ke context
+func WithAuditMeta(ctx context.Context, meta AuditMeta) context.Context {
+	return context.WithValue(ctx, auditMetaKey{}, meta)
+}
+
+// AuditMetaFromContext mengambil identitas request; actor kosong dianggap "system"
+func AuditMetaFromContext(ctx context.Context) AuditMeta {
+	meta, _ := ctx.Value(auditMetaKey{}).(AuditMeta)
+	if meta.Actor == "" {
+		meta.Actor = AuditActorSystem
+	}
+	return meta
+}
+
+// AuditLogFilter memfilter audit log; nilai kosong/zero berarti tanpa filter
+type AuditLogFilter struct {
+	Entity   string
+	EntityID uint
+	Actor    string
+	From     time.Time // Inklusif
+	To       time.Time // Inklusif sampai akhir hari
+}
+
+// AuditLogRepository hanya membaca; baris audit ditulis oleh repository entitas dalam transaksi yang sama
+type AuditLogRepository interface {
+	FindAll(ctx context.Context, filter AuditLogFilter) ([]AuditLog, error)
+}
+
+// AuditLogService mendefinisikan kontrak Use Case
+type AuditLogService interface {
+	GetAuditLogs(ctx context.Context, filter AuditLogFilter) ([]AuditLog, error)
+}
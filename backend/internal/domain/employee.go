@@ -1,30 +1,48 @@
 package domain
 
-import "time"
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrInvalidEmployee = errors.New("invalid employee data")
 
 // Employee adalah entitas bisnis inti
 type Employee struct {
-	ID         uint      `json:"id" gorm:"primaryKey" example:"1"`
-	Name       string    `json:"name" example:"John Doe"`
-	BaseSalary float64   `json:"base_salary" example:"50000"`
-	Allowance  float64   `json:"allowance" example:"5000"`
-	Position   string    `json:"position" example:"Software Engineer"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	ID           uint       `json:"id" gorm:"primaryKey" example:"1"`
+	Name         string     `json:"name" example:"John Doe"`
+	BaseSalary   float64    `json:"base_salary" example:"50000"`
+	Allowance    float64    `json:"allowance" example:"5000"`
+	Position     string     `json:"position" example:"Software Engineer"`
+	Department   string     `json:"department" gorm:"index" example:"Engineering"`
+	DeviceUserID string     `json:"device_user_id" gorm:"index" example:"1001"` // User ID di mesin fingerprint
+	OfficeID     *uint      `json:"office_id" example:"1"`                      // Kantor untuk check-in mobile, nil = kantor mana pun
+	JoinDate     *time.Time `json:"join_date" example:"2025-01-06T00:00:00Z"`   // Tanggal mulai bekerja, nil = dianggap aktif sejak awal
+	ResignDate   *time.Time `json:"resign_date" example:"2025-12-31T00:00:00Z"` // Hari kerja terakhir, nil = masih aktif
+	Religion     string     `json:"religion" example:"ISLAM"`                   // Menentukan jadwal pembayaran THR
+	TaxStatus    string     `json:"tax_status" example:"K/1"`                   // Status PTKP (TK/0 s.d. K/3), kosong = TK/0
+	NPWP         string     `json:"npwp" example:"0123456789012345"`            // NPWP (15 atau 16 digit) untuk bukti potong 1721-A1
+	NIK          string     `json:"nik" example:"3171012345670001"`             // NIK KTP (16 digit)
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
 }
 
 // EmployeeRepository mendefinisikan kontrak operasi data (Port)
 type EmployeeRepository interface {
-	Save(emp *Employee) error
-	FindByID(id uint) (*Employee, error)
-	FindAll() ([]Employee, error)
-	Update(emp *Employee) error
+	Save(ctx context.Context, emp *Employee) error
+	FindByID(ctx context.Context, id uint) (*Employee, error)
+	FindAll(ctx context.Context) ([]Employee, error)
+	FindByDepartment(ctx context.Context, department string) ([]Employee, error)
+	Update(ctx context.Context, emp *Employee) error
 }
 
 // EmployeeService mendefinisikan kontrak Use Case
 type EmployeeService interface {
-	CreateEmployee(emp *Employee) (*Employee, error)
-	GetEmployeeByID(id uint) (*Employee, error)
-	GetAllEmployees() ([]Employee, error)
-	UpdateEmployee(id uint, emp *Employee) (*Employee, error)
+	CreateEmployee(ctx context.Context, emp *Employee) (*Employee, error)
+	GetEmployeeByID(ctx context.Context, id uint) (*Employee, error)
+	GetAllEmployees(ctx context.Context) ([]Employee, error)
+	UpdateEmployee(ctx context.Context, id uint, emp *Employee) (*Employee, error)
+	AddSalaryChange(ctx context.Context, employeeID uint, change *SalaryHistory) (*SalaryHistory, error)
+	GetSalaryHistory(ctx context.Context, employeeID uint) ([]SalaryHistory, error)
 }
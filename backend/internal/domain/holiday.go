@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Holiday adalah hari libur (nasional / cuti bersama) pada kalender kerja perusahaan
+type Holiday struct {
+	ID        uint      `json:"id" gorm:"primaryKey" example:"1"`
+	Date      time.Time `json:"date" gorm:"uniqueIndex" example:"2025-12-25T00:00:00Z"`
+	Name      string    `json:"name" example:"Hari Raya Natal"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// HolidayRepository mendefinisikan kontrak operasi data (Port)
+type HolidayRepository interface {
+	Save(ctx context.Context, holiday *Holiday) error
+	Delete(ctx context.Context, id uint) error
+	FindByPeriod(ctx context.Context, dateFrom time.Time, dateTo time.Time) ([]Holiday, error)
+}
+
+// HolidayService mendefinisikan kontrak Use Case
+type HolidayService interface {
+	CreateHoliday(ctx context.Context, holiday *Holiday) (*Holiday, error)
+	DeleteHoliday(ctx context.Context, id uint) error
+	GetHolidaysByPeriod(ctx context.Context, dateFrom time.Time, dateTo time.Time) ([]Holiday, error)
+}
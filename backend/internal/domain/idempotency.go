@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrIdempotencyKeyReused   = errors.New("Idempotency-Key was already used for a different request")
+	ErrIdempotencyKeyInFlight = errors.New("a request with this Idempotency-Key is still being processed")
+)
+
+// IdempotencyRecord menyimpan respons pertama untuk sebuah Idempotency-Key agar retry dengan request
+// yang sama (fingerprint sama) dalam masa berlaku bisa diputar ulang tanpa menjalankan ulang handler
+type IdempotencyRecord struct {
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	Key         string     `json:"key" gorm:"column:idempotency_key;uniqueIndex;size:255"`
+	Fingerprint string     `json:"fingerprint"` // SHA-256 dari method, path dan body request
+	StatusCode  int        `json:"status_code"` // 0 = request pertama masih diproses
+	ContentType string     `json:"content_type"`
+	Body        string     `json:"body" gorm:"type:text"`
+	CompletedAt *time.Time `json:"completed_at"`
+	ExpiresAt   time.Time  `json:"expires_at" gorm:"index"` // Lease selama diproses, TTL setelah Complete
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// IdempotencyRepository mendefinisikan kontrak operasi data (Port)
+type IdempotencyRepository interface {
+	// Reserve menyimpan record baru; jika key sudah dipakai (dan belum kedaluwarsa) record lama
+	// dikembalikan dan record baru tidak disimpan. Record yang sudah kedaluwarsa dibersihkan lebih dulu.
+	Reserve(ctx context.Context, record *IdempotencyRecord) (*IdempotencyRecord, error)
+	// Complete menyimpan respons dan memperpanjang ExpiresAt dari lease reservasi ke masa berlaku respons
+	Complete(ctx context.Context, record *IdempotencyRecord) error
+	Delete(ctx context.Context, key string) error
+}
+
+// IdempotencyService mendefinisikan kontrak Use Case
+type IdempotencyService interface {
+	// Begin mengembalikan record tersimpan untuk diputar ulang, atau nil jika request ini yang pertama
+	// (handler harus dijalankan lalu hasilnya disimpan dengan Complete atau dibatalkan dengan Abandon)
+	Begin(ctx context.Context, key string, fingerprint string) (*IdempotencyRecord, error)
+	Complete(ctx context.Context, key string, statusCode int, contentType string, body []byte) error
+	// Abandon menghapus reservasi sehingga retry berikutnya diproses ulang (dipakai untuk respons 5xx dan handler yang panik)
+	Abandon(ctx context.Context, key string) error
+}
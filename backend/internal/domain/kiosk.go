@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// KioskTokenPeriod adalah masa berlaku (dan interval rotasi) token QR kiosk
+const KioskTokenPeriod = 30 * time.Second
+
+// Aksi saat karyawan memindai QR kiosk
+const (
+	KioskActionCheckIn  = "CHECK_IN"
+	KioskActionCheckOut = "CHECK_OUT"
+)
+
+var (
+	ErrKioskKeyInvalid    = errors.New("invalid kiosk key")
+	ErrKioskTokenInvalid  = errors.New("invalid kiosk token")
+	ErrKioskTokenExpired  = errors.New("kiosk token has expired")
+	ErrKioskTokenReplayed = errors.New("kiosk token has already been used by this employee")
+)
+
+// KioskToken adalah token QR yang sedang ditampilkan tablet kiosk
+type KioskToken struct {
+	OfficeID  uint      `json:"office_id" example:"1"`
+	Token     string    `json:"token" example:"1.58871234.Hq3v..."`
+	IssuedAt  time.Time `json:"issued_at"`
+	ExpiresAt time.Time `json:"expires_at"` // Kiosk mengambil token baru setelah waktu ini
+}
+
+// KioskKey adalah kunci rahasia tablet kiosk; hanya ditampilkan sekali saat dibuat
+type KioskKey struct {
+	OfficeID uint   `json:"office_id" example:"1"`
+	Key      string `json:"key" example:"3f1c9a..."`
+}
+
+// KioskScanRequest adalah hasil pindai QR kiosk dari aplikasi karyawan
+type KioskScanRequest struct {
+	EmployeeID uint   `json:"employee_id" example:"1"`
+	Token      string `json:"token" example:"1.58871234.Hq3v..."`
+	Action     string `json:"action" example:"CHECK_IN"` // CHECK_IN, CHECK_OUT
+}
+
+// KioskTokenUse mencatat token yang sudah dipakai, agar satu token tidak bisa dipakai ulang oleh karyawan yang sama
+type KioskTokenUse struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	OfficeID    uint      `json:"office_id" gorm:"uniqueIndex:idx_kiosk_token_use"`
+	TokenWindow int64     `json:"token_window" gorm:"uniqueIndex:idx_kiosk_token_use"` // Nomor jendela 30 detik token
+	EmployeeID  uint      `json:"employee_id" gorm:"uniqueIndex:idx_kiosk_token_use"`
+	Action      string    `json:"action"`
+	UsedAt      time.Time `json:"used_at"`
+}
+
+// KioskTokenUseRepository mendefinisikan kontrak operasi data (Port)
+type KioskTokenUseRepository interface {
+	Save(ctx context.Context, use *KioskTokenUse) error
+	Exists(ctx context.Context, officeID uint, tokenWindow int64, employeeID uint) (bool, error)
+}
+
+// KioskService mendefinisikan kontrak Use Case untuk check-in lewat QR kiosk
+type KioskService interface {
+	IssueKioskKey(ctx context.Context, officeID uint) (*KioskKey, error)
+	CurrentToken(ctx context.Context, officeID uint, kioskKey string) (*KioskToken, error)
+	Scan(ctx context.Context, req KioskScanRequest) (*Attendance, error)
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Status pinjaman karyawan
+const (
+	LoanStatusActive  = "ACTIVE"
+	LoanStatusSettled = "SETTLED"
+)
+
+// Jenis mutasi saldo pinjaman
+const (
+	LoanTxInstallment = "INSTALLMENT" // Cicilan dari slip gaji yang dibayar
+	LoanTxSettlement  = "SETTLEMENT"  // Pelunasan dipercepat
+	LoanTxReversal    = "REVERSAL"    // Pembatalan cicilan karena slip di-void
+)
+
+const PayrollItemCodeLoan = "LOAN" // Potongan cicilan pinjaman / kasbon
+
+var (
+	ErrLoanNotFound      = errors.New("loan not found")
+	ErrLoanNotActive     = errors.New("loan is not ACTIVE")
+	ErrLoanBalanceChange = errors.New("loan balance changed since the slip was generated; recalculate the slip first")
+)
+
+// Loan adalah pinjaman / kasbon karyawan yang dicicil lewat potongan gaji
+type Loan struct {
+	ID                 uint       `json:"id" gorm:"primaryKey" example:"1"`
+	EmployeeID         uint       `json:"employee_id" gorm:"index" example:"1"`
+	Principal          float64    `json:"principal" example:"3000000"`
+	InstallmentAmount  float64    `json:"installment_amount" example:"500000"` // Diisi langsung, atau dihitung dari installment_count
+	InstallmentCount   int        `json:"installment_count" example:"6"`
+	StartPeriod        time.Time  `json:"start_period" example:"2025-11-01T00:00:00Z"` // Bulan cicilan pertama
+	OutstandingBalance float64    `json:"outstanding_balance" example:"3000000"`
+	Status             string     `json:"status" gorm:"index;default:ACTIVE" example:"ACTIVE"` // ACTIVE, SETTLED
+	Note               string     `json:"note" example:"Kasbon biaya sekolah"`
+	SettledAt          *time.Time `json:"settled_at"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+
+	Transactions []LoanTransaction `json:"transactions,omitempty" gorm:"foreignKey:LoanID"`
+}
+
+// LoanTransaction adalah mutasi saldo pinjaman
+type LoanTransaction struct {
+	ID           uint      `json:"id" gorm:"primaryKey" example:"1"`
+	LoanID       uint      `json:"loan_id" gorm:"index" example:"1"`
+	PayrollID    *uint     `json:"payroll_id" example:"10"` // Slip sumber cicilan / reversal
+	Type         string    `json:"type" example:"INSTALLMENT"`
+	Amount       float64   `json:"amount" example:"500000"` // Positif mengurangi saldo, negatif (reversal) menambah
+	BalanceAfter float64   `json:"balance_after" example:"2500000"`
+	Note         string    `json:"note" example:""`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// LoanSettlementRequest adalah pelunasan dipercepat
+type LoanSettlementRequest struct {
+	Note string `json:"note" example:"Dilunasi tunai"`
+}
+
+// LoanRepository mendefinisikan kontrak operasi data (Port)
+type LoanRepository interface {
+	Save(ctx context.Context, loan *Loan) error
+	Update(ctx context.Context, loan *Loan) error
+	FindByID(ctx context.Context, id uint) (*Loan, error)
+	// FindByEmployee memfilter berdasarkan karyawan; 0 berarti semua karyawan
+	FindByEmployee(ctx context.Context, employeeID uint) ([]Loan, error)
+	// FindActiveByEmployee mengembalikan pinjaman ACTIVE urut start_period
+	FindActiveByEmployee(ctx context.Context, employeeID uint) ([]Loan, error)
+	SaveTransaction(ctx context.Context, tx *LoanTransaction) error
+}
+
+// LoanService mendefinisikan kontrak Use Case
+type LoanService interface {
+	CreateLoan(ctx context.Context, loan *Loan) (*Loan, error)
+	GetLoans(ctx context.Context, employeeID uint) ([]Loan, error)
+	GetLoan(ctx context.Context, id uint) (*Loan, error)
+	SettleLoan(ctx context.Context, id uint, req LoanSettlementRequest) (*Loan, error)
+}
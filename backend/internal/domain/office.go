@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// Bentuk geofence kantor
+const (
+	GeofenceRadius  = "RADIUS"  // Lingkaran: titik pusat + radius meter
+	GeofencePolygon = "POLYGON" // Poligon: daftar titik sudut
+)
+
+// Kebijakan untuk punch di luar geofence
+const (
+	GeofencePolicyReject = "REJECT" // Punch ditolak
+	GeofencePolicyFlag   = "FLAG"   // Punch disimpan dan ditandai untuk direview
+)
+
+// Hasil pemeriksaan geofence pada punch
+const (
+	GeofenceInside      = "INSIDE"
+	GeofenceOutside     = "OUTSIDE"
+	GeofenceLowAccuracy = "LOW_ACCURACY" // Akurasi GPS lebih buruk dari batas kantor
+)
+
+var (
+	ErrOfficeNotFound    = errors.New("office not found")
+	ErrOutsideGeofence   = errors.New("location is outside the office geofence")
+	ErrGPSAccuracyTooLow = errors.New("GPS accuracy is too low for this office")
+)
+
+// GeoPoint adalah satu koordinat GPS
+type GeoPoint struct {
+	Latitude  float64 `json:"latitude" example:"-6.2088"`
+	Longitude float64 `json:"longitude" example:"106.8456"`
+}
+
+// Office adalah lokasi kerja beserta geofence untuk check-in dari aplikasi
+type Office struct {
+	ID                uint       `json:"id" gorm:"primaryKey" example:"1"`
+	Name              string     `json:"name" example:"Kantor Pusat"`
+	GeofenceType      string     `json:"geofence_type" example:"RADIUS"` // RADIUS, POLYGON
+	Latitude          float64    `json:"latitude" example:"-6.2088"`     // Pusat lingkaran (RADIUS)
+	Longitude         float64    `json:"longitude" example:"106.8456"`
+	RadiusMeters      float64    `json:"radius_meters" example:"150"`
+	Polygon           []GeoPoint `json:"polygon" gorm:"serializer:json"`                // Titik sudut (POLYGON)
+	MaxAccuracyMeters float64    `json:"max_accuracy_meters" example:"100"`             // 0 = tidak dibatasi
+	Policy            string     `json:"policy" gorm:"default:REJECT" example:"REJECT"` // REJECT, FLAG
+	KioskKeyHash      string     `json:"-"`                                             // SHA-256 kunci tablet kiosk, lihat IssueKioskKey
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+// OfficeRepository mendefinisikan kontrak operasi data (Port)
+type OfficeRepository interface {
+	Save(ctx context.Context, office *Office) error
+	Update(ctx context.Context, office *Office) error
+	FindByID(ctx context.Context, id uint) (*Office, error)
+	FindAll(ctx context.Context) ([]Office, error)
+}
+
+// OfficeService mendefinisikan kontrak Use Case
+type OfficeService interface {
+	CreateOffice(ctx context.Context, office *Office) (*Office, error)
+	UpdateOffice(ctx context.Context, id uint, office *Office) (*Office, error)
+	GetAllOffices(ctx context.Context) ([]Office, error)
+}
+
+// FileStorage menyimpan file unggahan (mis. selfie) dan mengembalikan path untuk dibaca kembali
+type FileStorage interface {
+	Save(name string, r io.Reader) (string, error)
+	Open(path string) (io.ReadCloser, error)
+	Delete(path string) error
+}
+
+// MobilePunchRequest adalah punch dari aplikasi mobile dengan lokasi GPS dan selfie opsional
+type MobilePunchRequest struct {
+	EmployeeID     uint
+	Direction      string
+	Latitude       float64
+	Longitude      float64
+	AccuracyMeters float64
+	Selfie         io.Reader // nil jika tidak ada
+	SelfieName     string
+}
+
+// MobileAttendanceService mendefinisikan kontrak Use Case untuk check-in/check-out dari aplikasi
+type MobileAttendanceService interface {
+	RecordMobilePunch(ctx context.Context, req MobilePunchRequest) (*Attendance, *Punch, error)
+	GetFlaggedPunches(ctx context.Context, dateFrom time.Time, dateTo time.Time) ([]Punch, error)
+	OpenSelfie(ctx context.Context, punchID uint) (io.ReadCloser, error)
+	ApproveFlaggedPunch(ctx context.Context, punchID uint, reviewer string, note string) (*Punch, error)
+	RejectFlaggedPunch(ctx context.Context, punchID uint, reviewer string, note string) (*Punch, error)
+}
@@ -1,31 +1,139 @@
 package domain
 
-import "time"
+import (
+	"context"
+	"errors"
+	"time"
+)
 
-// Payroll adalah entitas bisnis inti untuk slip gaji bulanan
+// Metode pro-rata gaji untuk karyawan yang masuk/keluar di tengah periode
+const (
+	ProrationCalendarDays = "CALENDAR_DAYS" // hari aktif / jumlah hari kalender dalam bulan
+	ProrationWorkingDays  = "WORKING_DAYS"  // hari kerja aktif / jumlah hari kerja dalam bulan (Senin-Jumat dikurangi libur)
+	ProrationFixed30      = "FIXED_30"      // hari aktif / 30, bulan penuh selalu dihitung 30
+)
+
+// Jenis slip gaji
+const (
+	PayrollTypeRegular = "REGULAR" // Gaji bulanan
+	PayrollTypeTHR     = "THR"     // Tunjangan Hari Raya
+)
+
+// Metode pajak slip gaji
+const (
+	TaxMethodIrregularIncome = "PPH21_IRREGULAR" // PPh 21 atas penghasilan tidak teratur (selisih pajak setahun dengan dan tanpa THR)
+	TaxMethodTER             = "PPH21_TER"       // PPh 21 bulanan: tarif efektif rata-rata (PP 58/2023) x penghasilan bruto sebulan
+	TaxMethodAnnual          = "PPH21_ANNUAL"    // PPh 21 masa pajak terakhir (Desember / bulan resign): pajak setahun Pasal 17 dikurangi yang sudah dipotong
+)
+
+// Pemotongan PPh 21 atas gaji bulanan (PAYROLL_TAX_WITHHOLDING)
+const (
+	TaxWithholdingNone = "NONE" // Gaji teratur tidak dipotong; hanya THR, bonus, komisi, insentif (metode selisih)
+	TaxWithholdingTER  = "TER"  // Gaji teratur dan penerimaan kena pajak dipotong tarif efektif setiap bulan
+)
+
+// Status slip gaji
+const (
+	PayrollStatusGenerated = "GENERATED" // Draft, masih boleh dihitung ulang
+	PayrollStatusPaid      = "PAID"      // Sudah dibayar, koreksi hanya lewat void-and-reissue
+	PayrollStatusVoid      = "VOID"      // Dibatalkan, digantikan slip lain
+)
+
+var (
+	ErrPayrollNotFound         = errors.New("payroll slip not found")
+	ErrPayrollAlreadyGenerated = errors.New("payroll already generated for this employee and period")
+	ErrPayrollNotEditable      = errors.New("payroll slip is not in GENERATED status")
+	ErrPayrollNotPaid          = errors.New("only PAID payroll slips can be voided and reissued")
+)
+
+// Jenis dan kode komponen baris slip gaji
+const (
+	PayrollItemEarning   = "EARNING"
+	PayrollItemDeduction = "DEDUCTION"
+
+	PayrollItemCodeRapel = "RAPEL" // Selisih gaji retroaktif untuk periode yang sudah dibayar
+	PayrollItemCodeTHR   = "THR"   // Tunjangan Hari Raya
+	PayrollItemCodePPh21 = "PPH21" // Potongan PPh 21
+)
+
+// PayrollItem adalah baris tambahan (penerimaan/potongan) pada slip gaji
+type PayrollItem struct {
+	ID          uint       `json:"id" gorm:"primaryKey" example:"1"`
+	PayrollID   uint       `json:"payroll_id" gorm:"index" example:"1"`
+	Code        string     `json:"code" example:"RAPEL"`
+	Type        string     `json:"type" example:"EARNING"` // EARNING, DEDUCTION
+	Description string     `json:"description" example:"Rapel gaji periode 2025-10"`
+	Amount      float64    `json:"amount" example:"2500"`
+	RefPeriod   *time.Time `json:"ref_period" example:"2025-10-01T00:00:00Z"` // Periode yang dikoreksi (untuk RAPEL)
+	RefID       *uint      `json:"ref_id" example:"1"`                        // ID sumber baris (mis. pinjaman untuk LOAN)
+	Taxable     bool       `json:"taxable" example:"false"`                   // Penerimaan yang dikenai PPh 21 (THR, bonus, komisi)
+}
+
+// Payroll adalah entitas bisnis inti untuk slip gaji bulanan.
+// Slip THR memakai struktur yang sama: BaseSalary + Allowance adalah upah dasar THR,
+// ProrationFactor = masa kerja / 12, ActiveFrom-ActiveTo = masa kerja sampai hari raya.
 type Payroll struct {
-	ID               uint      `json:"id" gorm:"primaryKey" example:"1"`
-	EmployeeID       uint      `json:"employee_id" gorm:"uniqueIndex:idx_employee_period" example:"1"`
-	Period           time.Time `json:"period" gorm:"uniqueIndex:idx_employee_period" example:"2025-11-01T00:00:00Z"` // Biasanya awal bulan
-	BaseSalary       float64   `json:"base_salary" example:"50000"`
-	Allowance        float64   `json:"allowance" example:"5000"`
-	TotalAbsent      int       `json:"total_absent" example:"2"`
-	AbsenceDeduction float64   `json:"absence_deduction" example:"1000"`
-	TakeHomePay      float64   `json:"take_home_pay" example:"54000"`
-	GeneratedAt      time.Time `json:"generated_at"`
+	ID                uint      `json:"id" gorm:"primaryKey" example:"1"`
+	EmployeeID        uint      `json:"employee_id" gorm:"uniqueIndex:idx_employee_period,where:status <> 'VOID'" example:"1"`
+	Period            time.Time `json:"period" gorm:"uniqueIndex:idx_employee_period,where:status <> 'VOID'" example:"2025-11-01T00:00:00Z"`  // Biasanya awal bulan
+	Type              string    `json:"type" gorm:"uniqueIndex:idx_employee_period,where:status <> 'VOID';default:REGULAR" example:"REGULAR"` // REGULAR, THR
+	BaseSalary        float64   `json:"base_salary" example:"50000"`
+	Allowance         float64   `json:"allowance" example:"5000"`
+	ProrationMethod   string    `json:"proration_method" example:"CALENDAR_DAYS"`
+	ProrationFactor   float64   `json:"proration_factor" example:"1"`
+	DaysCounted       int       `json:"days_counted" example:"30"`                  // Hari aktif yang dihitung sesuai metode pro-rata
+	DaysInPeriod      int       `json:"days_in_period" example:"30"`                // Pembagi sesuai metode pro-rata
+	ActiveFrom        time.Time `json:"active_from" example:"2025-11-01T00:00:00Z"` // Hari pertama yang dihitung
+	ActiveTo          time.Time `json:"active_to" example:"2025-11-30T00:00:00Z"`   // Hari terakhir yang dihitung
+	ProratedBase      float64   `json:"prorated_base" example:"50000"`
+	ProratedAllowance float64   `json:"prorated_allowance" example:"5000"`
+	TotalAbsent       float64   `json:"total_absent" example:"2"` // Hari potongan (jumlah bobot status, mis. 1.5)
+	AbsenceDeduction  float64   `json:"absence_deduction" example:"1000"`
+	RetroAdjustment   float64   `json:"retro_adjustment" example:"0"` // Total rapel dari periode sebelumnya
+	Reimbursement     float64   `json:"reimbursement" example:"0"`    // Total klaim reimbursement yang dibayar (tidak kena pajak)
+	Adjustment        float64   `json:"adjustment" example:"0"`       // Total bersih bonus/komisi/insentif dikurangi denda
+	LoanDeduction     float64   `json:"loan_deduction" example:"0"`   // Total cicilan pinjaman yang dipotong
+	ServiceMonths     int       `json:"service_months" example:"0"`   // Masa kerja dalam bulan penuh (slip THR)
+	Tax               float64   `json:"tax" example:"0"`              // PPh 21 yang dipotong (negatif = kelebihan potong dikembalikan di masa pajak terakhir)
+	TaxMethod         string    `json:"tax_method" example:""`        // Kosong = slip tidak memotong pajak
+	TaxStatus         string    `json:"tax_status" example:"K/1"`     // Status PTKP awal tahun pajak yang dipakai slip ini
+	TakeHomePay       float64   `json:"take_home_pay" example:"54000"`
+	GeneratedAt       time.Time `json:"generated_at"`
+
+	Status       string     `json:"status" gorm:"default:GENERATED" example:"GENERATED"` // GENERATED, PAID, VOID
+	PaidAt       *time.Time `json:"paid_at"`
+	VoidedAt     *time.Time `json:"voided_at"`
+	VoidReason   string     `json:"void_reason" example:""`
+	ReplacesID   *uint      `json:"replaces_id" example:"1"`    // Slip VOID yang digantikan slip ini
+	ReplacedByID *uint      `json:"replaced_by_id" example:"2"` // Slip pengganti (untuk slip VOID)
+
+	Items []PayrollItem `json:"items" gorm:"foreignKey:PayrollID"`
 }
 
-// PayrollRepository mendefinisikan kontrak operasi data (Port)
+// PayrollRepository mendefinisikan kontrak operasi data (Port).
+// Slip VOID hanya dikembalikan oleh FindByID. FindByEmployeeAndPeriod, FindByEmployee dan
+// FindByPeriod hanya mengembalikan slip REGULAR; FindAll, FindPaidByYear dan FindByID semua jenis.
 type PayrollRepository interface {
-	Save(payroll *Payroll) error
-	FindByEmployeeAndPeriod(employeeID uint, period time.Time) (*Payroll, error)
-	FindAll() ([]Payroll, error)
-	FindByID(id uint) (*Payroll, error)
+	Save(ctx context.Context, payroll *Payroll) error
+	Update(ctx context.Context, payroll *Payroll) error
+	FindByEmployeeAndPeriod(ctx context.Context, employeeID uint, period time.Time) (*Payroll, error)
+	FindByEmployeePeriodAndType(ctx context.Context, employeeID uint, period time.Time, payrollType string) (*Payroll, error)
+	FindByEmployee(ctx context.Context, employeeID uint) ([]Payroll, error)
+	FindByPeriod(ctx context.Context, period time.Time) ([]Payroll, error)
+	FindAll(ctx context.Context) ([]Payroll, error)
+	// FindPaidByYear mengembalikan slip PAID (semua jenis) yang periodenya jatuh di tahun tersebut
+	FindPaidByYear(ctx context.Context, year int) ([]Payroll, error)
+	FindByID(ctx context.Context, id uint) (*Payroll, error)
 }
 
 // PayrollService mendefinisikan kontrak Use Case
 type PayrollService interface {
-	GenerateMonthlyPayroll(employeeID uint, period time.Time) (*Payroll, error)
-	GetPayrollSlips() ([]Payroll, error)
-	GetPayrollDetail(id uint) (*Payroll, error)
+	GenerateMonthlyPayroll(ctx context.Context, employeeID uint, period time.Time) (*Payroll, error)
+	GetPayrollSlips(ctx context.Context) ([]Payroll, error)
+	GetPayrollDetail(ctx context.Context, id uint) (*Payroll, error)
+	RecalculatePayroll(ctx context.Context, id uint) (*Payroll, error)
+	MarkPayrollPaid(ctx context.Context, id uint) (*Payroll, error)
+	VoidAndReissuePayroll(ctx context.Context, id uint, reason string) (*Payroll, error)
+	PreviewPayroll(ctx context.Context, req PayrollPreviewRequest) ([]PayrollPreview, error)
+	GetVarianceReport(ctx context.Context, periodFrom time.Time, periodTo time.Time, threshold float64) (*PayrollVarianceReport, error)
 }
@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// Status penyesuaian payroll: PENDING -> APPLIED saat slip yang memuatnya dibayar, atau CANCELLED
+const (
+	AdjustmentPending   = "PENDING"
+	AdjustmentApplied   = "APPLIED"
+	AdjustmentCancelled = "CANCELLED"
+)
+
+// Sumber input penyesuaian
+const (
+	AdjustmentSourceAPI = "API"
+	AdjustmentSourceCSV = "CSV"
+)
+
+var (
+	ErrAdjustmentNotFound         = errors.New("payroll adjustment not found")
+	ErrAdjustmentNotPending       = errors.New("payroll adjustment is not PENDING")
+	ErrAdjustmentPeriodClosed     = errors.New("payroll for this employee and period is already PAID; use a later period")
+	ErrAdjustmentChanged          = errors.New("payroll adjustments changed since the slip was generated; recalculate the slip first")
+	ErrUnknownAdjustmentComponent = errors.New("unknown adjustment component")
+)
+
+// AdjustmentComponent menentukan sisi slip dan perlakuan pajak suatu komponen penyesuaian
+type AdjustmentComponent struct {
+	Code    string `json:"code" example:"BONUS"`
+	Name    string `json:"name" example:"Bonus"`
+	Type    string `json:"type" example:"EARNING"` // EARNING atau DEDUCTION
+	Taxable bool   `json:"taxable" example:"true"` // Dikenai PPh 21 (metode selisih, atau masuk bruto TER)
+}
+
+// AdjustmentComponents adalah katalog komponen penyesuaian yang dikenali, dipakai juga sebagai kode baris slip
+var AdjustmentComponents = []AdjustmentComponent{
+	{Code: "BONUS", Name: "Bonus", Type: PayrollItemEarning, Taxable: true},
+	{Code: "COMMISSION", Name: "Komisi", Type: PayrollItemEarning, Taxable: true},
+	{Code: "INCENTIVE", Name: "Insentif", Type: PayrollItemEarning, Taxable: true},
+	{Code: "PENALTY", Name: "Denda", Type: PayrollItemDeduction, Taxable: false},
+}
+
+// FindAdjustmentComponent mengembalikan komponen berdasarkan kode, nil jika tidak dikenali
+func FindAdjustmentComponent(code string) *AdjustmentComponent {
+	for i := range AdjustmentComponents {
+		if AdjustmentComponents[i].Code == code {
+			return &AdjustmentComponents[i]
+		}
+	}
+	return nil
+}
+
+// PayrollAdjustment adalah bonus, komisi, insentif atau denda satu kali untuk satu slip bulanan
+type PayrollAdjustment struct {
+	ID          uint       `json:"id" gorm:"primaryKey" example:"1"`
+	EmployeeID  uint       `json:"employee_id" gorm:"index" example:"1"`
+	Period      time.Time  `json:"period" gorm:"index" example:"2025-11-01T00:00:00Z"` // Awal bulan slip yang memuatnya
+	Component   string     `json:"component" example:"BONUS"`
+	Type        string     `json:"type" example:"EARNING"` // Disalin dari komponen saat dicatat
+	Taxable     bool       `json:"taxable" example:"true"` // Disalin dari komponen saat dicatat
+	Amount      float64    `json:"amount" example:"1500000"`
+	Note        string     `json:"note" example:"Bonus target Q3"`
+	EnteredBy   string     `json:"entered_by" example:"hr.admin@example.com"`
+	Source      string     `json:"source" example:"API"` // API atau CSV
+	Status      string     `json:"status" gorm:"index;default:PENDING" example:"PENDING"`
+	PayrollID   *uint      `json:"payroll_id" gorm:"index" example:"10"` // Slip yang memuat penyesuaian ini
+	AppliedAt   *time.Time `json:"applied_at"`
+	CancelledBy string     `json:"cancelled_by" example:""`
+	CancelledAt *time.Time `json:"cancelled_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// AdjustmentImportRowError adalah error untuk satu baris file CSV
+type AdjustmentImportRowError struct {
+	Row        int    `json:"row" example:"3"` // Nomor baris di file (1 = header)
+	EmployeeID string `json:"employee_id" example:"1"`
+	Error      string `json:"error" example:"unknown adjustment component"`
+}
+
+// AdjustmentImportResult adalah ringkasan unggahan CSV (atau simulasi jika DryRun)
+type AdjustmentImportResult struct {
+	DryRun      bool                       `json:"dry_run" example:"false"`
+	Rows        int                        `json:"rows" example:"10"`
+	Imported    int                        `json:"imported" example:"9"`
+	Failed      int                        `json:"failed" example:"1"`
+	Adjustments []PayrollAdjustment        `json:"adjustments"`
+	RowErrors   []AdjustmentImportRowError `json:"row_errors"`
+}
+
+// PayrollAdjustmentRepository mendefinisikan kontrak operasi data (Port)
+type PayrollAdjustmentRepository interface {
+	Save(ctx context.Context, adjustment *PayrollAdjustment) error
+	Update(ctx context.Context, adjustment *PayrollAdjustment) error
+	FindByID(ctx context.Context, id uint) (*PayrollAdjustment, error)
+	// FindAll memfilter berdasarkan karyawan, periode dan status; nilai kosong berarti tanpa filter
+	FindAll(ctx context.Context, employeeID uint, period time.Time, status string) ([]PayrollAdjustment, error)
+	// FindPayable mengembalikan penyesuaian PENDING untuk periode tersebut yang belum terikat slip lain selain payrollID
+	FindPayable(ctx context.Context, employeeID uint, period time.Time, payrollID uint) ([]PayrollAdjustment, error)
+	FindByPayroll(ctx context.Context, payrollID uint) ([]PayrollAdjustment, error)
+}
+
+// PayrollAdjustmentService mendefinisikan kontrak Use Case
+type PayrollAdjustmentService interface {
+	CreateAdjustment(ctx context.Context, adjustment *PayrollAdjustment) (*PayrollAdjustment, error)
+	// ImportCSV membaca kolom employee_id, period (YYYY-MM), component, amount, note; baris valid disimpan kecuali dryRun
+	ImportCSV(ctx context.Context, r io.Reader, enteredBy string, dryRun bool) (*AdjustmentImportResult, error)
+	CancelAdjustment(ctx context.Context, id uint, cancelledBy string) (*PayrollAdjustment, error)
+	GetAdjustments(ctx context.Context, employeeID uint, period time.Time, status string) ([]PayrollAdjustment, error)
+	GetAdjustment(ctx context.Context, id uint) (*PayrollAdjustment, error)
+}
@@ -0,0 +1,47 @@
+package domain
+
+import "time"
+
+// PayrollOverrides adalah input what-if untuk simulasi payroll. Field nil berarti pakai data asli.
+type PayrollOverrides struct {
+	BaseSalary    *float64 `json:"base_salary" example:"60000"`
+	Allowance     *float64 `json:"allowance" example:"7500"`
+	ExtraAbsences int      `json:"extra_absences" example:"1"` // Ditambahkan ke hari potongan dari data absensi
+}
+
+// PayrollPreviewRequest menentukan cakupan simulasi: satu karyawan, satu departemen, atau semua
+type PayrollPreviewRequest struct {
+	Period     time.Time
+	EmployeeID uint
+	Department string
+	Overrides  *PayrollOverrides
+}
+
+// PayrollLine adalah satu baris rincian slip (penerimaan atau potongan)
+type PayrollLine struct {
+	Code        string  `json:"code" example:"BASE_SALARY"`
+	Type        string  `json:"type" example:"EARNING"` // EARNING, DEDUCTION
+	Description string  `json:"description" example:"Gaji pokok (pro-rata)"`
+	Amount      float64 `json:"amount" example:"50000"`
+}
+
+// PayrollDiff adalah selisih hasil simulasi terhadap slip terakhir yang sudah digenerate (simulasi - slip terakhir)
+type PayrollDiff struct {
+	BaseSalary       float64 `json:"base_salary" example:"10000"`
+	Allowance        float64 `json:"allowance" example:"0"`
+	TotalAbsent      float64 `json:"total_absent" example:"1"`
+	AbsenceDeduction float64 `json:"absence_deduction" example:"2727.27"`
+	RetroAdjustment  float64 `json:"retro_adjustment" example:"0"`
+	TakeHomePay      float64 `json:"take_home_pay" example:"7272.73"`
+}
+
+// PayrollPreview adalah hasil simulasi payroll untuk satu karyawan (tidak disimpan)
+type PayrollPreview struct {
+	EmployeeID   uint          `json:"employee_id" example:"1"`
+	EmployeeName string        `json:"employee_name" example:"John Doe"`
+	Payroll      *Payroll      `json:"payroll,omitempty"`
+	Lines        []PayrollLine `json:"lines,omitempty"`
+	LastSlip     *Payroll      `json:"last_slip,omitempty"` // Slip terakhir (tidak VOID) sampai periode simulasi
+	Diff         *PayrollDiff  `json:"diff,omitempty"`
+	Error        string        `json:"error,omitempty"` // Diisi jika karyawan ini tidak bisa dihitung
+}
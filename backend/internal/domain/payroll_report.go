@@ -0,0 +1,56 @@
+package domain
+
+import "time"
+
+// Jenis perubahan karyawan pada laporan variance payroll
+const (
+	VarianceNew      = "NEW"      // Hanya ada slip di periode pembanding (to)
+	VarianceDeparted = "DEPARTED" // Hanya ada slip di periode dasar (from)
+	VarianceChanged  = "CHANGED"  // Ada di kedua periode dengan perubahan yang dilaporkan
+)
+
+// PayrollVarianceRow membandingkan slip satu karyawan di dua periode (delta = to - from)
+type PayrollVarianceRow struct {
+	EmployeeID            uint     `json:"employee_id" example:"1"`
+	EmployeeName          string   `json:"employee_name" example:"John Doe"`
+	Change                string   `json:"change" example:"CHANGED"` // NEW, DEPARTED, CHANGED
+	Reasons               []string `json:"reasons" example:"BASE_SALARY,TAKE_HOME_PAY"`
+	BaseSalaryFrom        float64  `json:"base_salary_from" example:"50000"`
+	BaseSalaryTo          float64  `json:"base_salary_to" example:"55000"`
+	BaseSalaryDelta       float64  `json:"base_salary_delta" example:"5000"`
+	AllowanceFrom         float64  `json:"allowance_from" example:"5000"`
+	AllowanceTo           float64  `json:"allowance_to" example:"5000"`
+	AllowanceDelta        float64  `json:"allowance_delta" example:"0"`
+	AbsenceDeductionFrom  float64  `json:"absence_deduction_from" example:"0"`
+	AbsenceDeductionTo    float64  `json:"absence_deduction_to" example:"2500"`
+	AbsenceDeductionDelta float64  `json:"absence_deduction_delta" example:"2500"`
+	TakeHomePayFrom       float64  `json:"take_home_pay_from" example:"55000"`
+	TakeHomePayTo         float64  `json:"take_home_pay_to" example:"57500"`
+	TakeHomePayDelta      float64  `json:"take_home_pay_delta" example:"2500"`
+}
+
+// PayrollVarianceSummary adalah agregat kedua periode
+type PayrollVarianceSummary struct {
+	EmployeesFrom             int     `json:"employees_from" example:"10"`
+	EmployeesTo               int     `json:"employees_to" example:"11"`
+	NewEmployees              int     `json:"new_employees" example:"1"`
+	DepartedEmployees         int     `json:"departed_employees" example:"0"`
+	TotalBaseSalaryFrom       float64 `json:"total_base_salary_from" example:"500000"`
+	TotalBaseSalaryTo         float64 `json:"total_base_salary_to" example:"555000"`
+	TotalAllowanceFrom        float64 `json:"total_allowance_from" example:"50000"`
+	TotalAllowanceTo          float64 `json:"total_allowance_to" example:"55000"`
+	TotalAbsenceDeductionFrom float64 `json:"total_absence_deduction_from" example:"0"`
+	TotalAbsenceDeductionTo   float64 `json:"total_absence_deduction_to" example:"2500"`
+	TotalTakeHomePayFrom      float64 `json:"total_take_home_pay_from" example:"550000"`
+	TotalTakeHomePayTo        float64 `json:"total_take_home_pay_to" example:"607500"`
+	TotalTakeHomePayDelta     float64 `json:"total_take_home_pay_delta" example:"57500"`
+}
+
+// PayrollVarianceReport adalah laporan perbandingan payroll antar dua periode
+type PayrollVarianceReport struct {
+	PeriodFrom time.Time              `json:"period_from" example:"2025-10-01T00:00:00Z"`
+	PeriodTo   time.Time              `json:"period_to" example:"2025-11-01T00:00:00Z"`
+	Threshold  float64                `json:"threshold" example:"100000"` // Selisih take-home pay minimum agar dilaporkan
+	Summary    PayrollVarianceSummary `json:"summary"`
+	Rows       []PayrollVarianceRow   `json:"rows"`
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Arah punch
+const (
+	PunchIn      = "IN"
+	PunchOut     = "OUT"
+	PunchUnknown = "" // Mesin tidak mengirim arah, ditentukan oleh aturan pairing
+)
+
+// Sumber punch
+const (
+	PunchSourceManual     = "MANUAL"     // Lewat POST /attendances atau checkout
+	PunchSourceDevice     = "DEVICE"     // Langsung dari mesin / aplikasi
+	PunchSourceImport     = "IMPORT"     // Dari file ekspor mesin fingerprint
+	PunchSourceMobile     = "MOBILE"     // Dari aplikasi dengan GPS (dan selfie)
+	PunchSourceCorrection = "CORRECTION" // Jam masuk/pulang dari koreksi absensi yang disetujui
+)
+
+// Status review punch yang ditandai (kebijakan FLAG). Punch yang belum disetujui tidak dihitung di ringkasan harian.
+const (
+	PunchReviewPending  = "PENDING"
+	PunchReviewApproved = "APPROVED"
+	PunchReviewRejected = "REJECTED"
+)
+
+var (
+	ErrPunchNotFound         = errors.New("punch not found")
+	ErrPunchNotPendingReview = errors.New("punch is not flagged or was already reviewed")
+)
+
+// Aturan pairing punch menjadi ringkasan absensi harian
+const (
+	PairingFirstInLastOut = "FIRST_IN_LAST_OUT" // Check-in = punch pertama, check-out = punch terakhir
+	PairingSessions       = "PAIRED_SESSIONS"   // Pasangan IN/OUT dijumlahkan, istirahat tidak dihitung jam kerja
+)
+
+// Punch adalah satu catatan tap/clock mentah (append-only). Attendance harian dihitung ulang dari tabel ini.
+type Punch struct {
+	ID         uint      `json:"id" gorm:"primaryKey" example:"1"`
+	EmployeeID uint      `json:"employee_id" gorm:"uniqueIndex:idx_punch_employee_time" example:"1"`
+	Timestamp  time.Time `json:"timestamp" gorm:"uniqueIndex:idx_punch_employee_time" example:"2025-11-10T08:01:23Z"`
+	Direction  string    `json:"direction" example:"IN"`  // IN, OUT, atau kosong
+	Source     string    `json:"source" example:"DEVICE"` // MANUAL, DEVICE, IMPORT, MOBILE, CORRECTION
+	DeviceID   string    `json:"device_id" example:"ZK-LOBBY-01"`
+	Location   string    `json:"location" example:"Kantor Pusat"`
+
+	// Data check-in mobile (kosong untuk punch dari mesin/impor)
+	Latitude       *float64 `json:"latitude" example:"-6.2088"`
+	Longitude      *float64 `json:"longitude" example:"106.8456"`
+	AccuracyMeters *float64 `json:"accuracy_meters" example:"12.5"`
+	OfficeID       *uint    `json:"office_id" example:"1"`                   // Kantor yang geofence-nya cocok / terdekat
+	DistanceMeters *float64 `json:"distance_meters" example:"35.2"`          // Jarak ke pusat / tepi geofence kantor
+	GeofenceStatus string   `json:"geofence_status" example:"INSIDE"`        // INSIDE, OUTSIDE, LOW_ACCURACY
+	Flagged        bool     `json:"flagged" gorm:"index" example:"false"`    // Perlu direview (kebijakan FLAG)
+	SelfiePath     string   `json:"selfie_path" example:"selfies/1/abc.jpg"` // Path di FileStorage
+
+	// Review punch yang ditandai; kosong untuk punch yang tidak ditandai
+	ReviewStatus string     `json:"review_status" example:"PENDING"` // PENDING, APPROVED, REJECTED
+	ReviewedBy   string     `json:"reviewed_by" example:"manager@example.com"`
+	ReviewNote   string     `json:"review_note" example:""`
+	ReviewedAt   *time.Time `json:"reviewed_at"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PunchRepository mendefinisikan kontrak operasi data (Port). Tidak ada Update/Delete: punch bersifat append-only,
+// kecuali kolom review punch yang ditandai.
+type PunchRepository interface {
+	Save(ctx context.Context, punch *Punch) error
+	UpdateReview(ctx context.Context, punch *Punch) error // Hanya menyimpan review_status, reviewed_by, review_note, reviewed_at
+	FindByEmployeeAndDate(ctx context.Context, employeeID uint, date time.Time) ([]Punch, error)
+	FindByPeriod(ctx context.Context, employeeID uint, dateFrom time.Time, dateTo time.Time) ([]Punch, error)
+	FindByID(ctx context.Context, id uint) (*Punch, error)
+	FindFlagged(ctx context.Context, dateFrom time.Time, dateTo time.Time) ([]Punch, error)
+}
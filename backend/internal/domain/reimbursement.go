@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// Status klaim reimbursement: SUBMITTED -> MANAGER_APPROVED -> APPROVED -> PAID, atau REJECTED
+const (
+	ReimbursementSubmitted       = "SUBMITTED"
+	ReimbursementManagerApproved = "MANAGER_APPROVED"
+	ReimbursementApproved        = "APPROVED" // Disetujui finance, menunggu dibayar lewat payroll
+	ReimbursementPaid            = "PAID"
+	ReimbursementRejected        = "REJECTED"
+)
+
+const PayrollItemCodeReimbursement = "REIMBURSEMENT" // Penggantian biaya (tidak kena pajak)
+
+var (
+	ErrReimbursementNotFound         = errors.New("reimbursement claim not found")
+	ErrReimbursementNotReviewable    = errors.New("reimbursement claim is not awaiting approval")
+	ErrReimbursementCategoryNotFound = errors.New("reimbursement category not found")
+	ErrReimbursementLimitExceeded    = errors.New("claim exceeds the yearly limit of this category")
+)
+
+// ReimbursementCategory adalah jenis klaim beserta batas tahunannya per karyawan
+type ReimbursementCategory struct {
+	ID          uint      `json:"id" gorm:"primaryKey" example:"1"`
+	Code        string    `json:"code" gorm:"uniqueIndex" example:"MEDICAL"`
+	Name        string    `json:"name" example:"Pengobatan"`
+	YearlyLimit float64   `json:"yearly_limit" example:"5000000"` // 0 = tanpa batas
+	Active      bool      `json:"active" gorm:"default:true" example:"true"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// DefaultReimbursementCategories adalah kategori bawaan yang dibuat saat migrasi (tanpa batas sampai diatur admin)
+var DefaultReimbursementCategories = []ReimbursementCategory{
+	{Code: "MEDICAL", Name: "Pengobatan", Active: true},
+	{Code: "TRANSPORT", Name: "Transportasi", Active: true},
+	{Code: "INTERNET", Name: "Internet", Active: true},
+}
+
+// ReimbursementClaim adalah klaim penggantian biaya karyawan
+type ReimbursementClaim struct {
+	ID           uint      `json:"id" gorm:"primaryKey" example:"1"`
+	EmployeeID   uint      `json:"employee_id" gorm:"index" example:"1"`
+	CategoryCode string    `json:"category_code" gorm:"index" example:"MEDICAL"`
+	ExpenseDate  time.Time `json:"expense_date" example:"2025-11-03T00:00:00Z"` // Tanggal pengeluaran, menentukan tahun batas klaim
+	Amount       float64   `json:"amount" example:"350000"`
+	Description  string    `json:"description" example:"Konsultasi dokter umum"`
+	ReceiptPath  string    `json:"receipt_path" example:"receipts/1/20251103-101500-a1b2c3d4.jpg"`
+
+	Status          string     `json:"status" gorm:"index;default:SUBMITTED" example:"SUBMITTED"`
+	ManagerBy       string     `json:"manager_by" example:"manager@example.com"`
+	ManagerNote     string     `json:"manager_note" example:""`
+	ManagerAt       *time.Time `json:"manager_at"`
+	FinanceBy       string     `json:"finance_by" example:"finance@example.com"`
+	FinanceNote     string     `json:"finance_note" example:""`
+	FinanceAt       *time.Time `json:"finance_at"`
+	RejectedBy      string     `json:"rejected_by" example:""`
+	RejectionReason string     `json:"rejection_reason" example:""`
+	PayrollID       *uint      `json:"payroll_id" gorm:"index" example:"10"` // Slip yang membayar klaim ini
+	PaidAt          *time.Time `json:"paid_at"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ReimbursementSubmission adalah data pengajuan klaim beserta bukti pembayaran
+type ReimbursementSubmission struct {
+	EmployeeID   uint
+	CategoryCode string
+	ExpenseDate  time.Time
+	Amount       float64
+	Description  string
+	Receipt      io.Reader
+	ReceiptName  string
+}
+
+// ReimbursementRepository mendefinisikan kontrak operasi data (Port)
+type ReimbursementRepository interface {
+	SaveCategory(ctx context.Context, category *ReimbursementCategory) error
+	UpdateCategory(ctx context.Context, category *ReimbursementCategory) error
+	FindCategoryByCode(ctx context.Context, code string) (*ReimbursementCategory, error)
+	FindCategories(ctx context.Context) ([]ReimbursementCategory, error)
+
+	Save(ctx context.Context, claim *ReimbursementClaim) error
+	Update(ctx context.Context, claim *ReimbursementClaim) error
+	FindByID(ctx context.Context, id uint) (*ReimbursementClaim, error)
+	// FindAll memfilter berdasarkan karyawan dan status; nilai kosong berarti tanpa filter
+	FindAll(ctx context.Context, employeeID uint, status string) ([]ReimbursementClaim, error)
+	// SumClaimed menjumlahkan klaim (selain REJECTED) karyawan per kategori per tahun, kecuali klaim excludeID
+	SumClaimed(ctx context.Context, employeeID uint, categoryCode string, year int, excludeID uint) (float64, error)
+	// FindPayable mengembalikan klaim APPROVED yang belum terikat slip lain selain payrollID
+	FindPayable(ctx context.Context, employeeID uint, payrollID uint) ([]ReimbursementClaim, error)
+	FindByPayroll(ctx context.Context, payrollID uint) ([]ReimbursementClaim, error)
+}
+
+// ReimbursementService mendefinisikan kontrak Use Case
+type ReimbursementService interface {
+	CreateCategory(ctx context.Context, category *ReimbursementCategory) (*ReimbursementCategory, error)
+	UpdateCategory(ctx context.Context, code string, category *ReimbursementCategory) (*ReimbursementCategory, error)
+	GetCategories(ctx context.Context) ([]ReimbursementCategory, error)
+
+	SubmitClaim(ctx context.Context, req ReimbursementSubmission) (*ReimbursementClaim, error)
+	// ApproveClaim menyetujui tahap berikutnya: manajer untuk SUBMITTED, finance untuk MANAGER_APPROVED
+	ApproveClaim(ctx context.Context, id uint, reviewer string, note string) (*ReimbursementClaim, error)
+	RejectClaim(ctx context.Context, id uint, reviewer string, reason string) (*ReimbursementClaim, error)
+	GetClaims(ctx context.Context, employeeID uint, status string) ([]ReimbursementClaim, error)
+	GetClaim(ctx context.Context, id uint) (*ReimbursementClaim, error)
+	OpenReceipt(ctx context.Context, id uint) (io.ReadCloser, error)
+}
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// SalaryHistory mencatat gaji pokok & tunjangan karyawan yang berlaku mulai tanggal tertentu
+type SalaryHistory struct {
+	ID            uint      `json:"id" gorm:"primaryKey" example:"1"`
+	EmployeeID    uint      `json:"employee_id" gorm:"index" example:"1"`
+	BaseSalary    float64   `json:"base_salary" example:"55000"`
+	Allowance     float64   `json:"allowance" example:"5000"`
+	EffectiveFrom time.Time `json:"effective_from" gorm:"index" example:"2025-11-01T00:00:00Z"` // Berlaku mulai tanggal ini
+	Note          string    `json:"note" example:"Kenaikan gaji tahunan"`
+	Baseline      bool      `json:"baseline" example:"false"` // Gaji awal yang dicatat otomatis, tidak memicu rapel
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// SalaryHistoryRepository mendefinisikan kontrak operasi data (Port)
+type SalaryHistoryRepository interface {
+	Save(ctx context.Context, history *SalaryHistory) error
+	FindByEmployee(ctx context.Context, employeeID uint) ([]SalaryHistory, error)
+}
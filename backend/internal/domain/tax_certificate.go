@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Kode objek pajak PPh 21 pegawai tetap pada bukti potong 1721-A1
+const TaxObjectCodePermanentEmployee = "21-100-01"
+
+var (
+	ErrTaxCertificateNotFound = errors.New("no paid payroll slips for this employee in the tax year")
+	ErrInvalidTaxYear         = errors.New("invalid tax year")
+)
+
+// TaxWithholder adalah identitas pemberi kerja (pemotong pajak) pada bukti potong
+type TaxWithholder struct {
+	Name       string `json:"name" example:"PT Contoh Sejahtera"`
+	NPWP       string `json:"npwp" example:"0123456789012000"`
+	SignerName string `json:"signer_name" example:"Budi Santoso"`
+	SignerNPWP string `json:"signer_npwp" example:"0987654321098000"`
+}
+
+// TaxCertificate adalah data formulir 1721-A1 (bukti potong PPh 21 pegawai tetap) untuk satu tahun pajak.
+// Angka baris 1-20 mengikuti urutan rincian pada formulir.
+type TaxCertificate struct {
+	Number      string    `json:"number" example:"1.1-12.25-0000001"` // Nomor bukti potong: 1.1-<bulan akhir>.<tahun>-<urut>
+	Year        int       `json:"year" example:"2025"`
+	PeriodFrom  int       `json:"period_from" example:"1"`   // Masa perolehan awal (bulan)
+	PeriodTo    int       `json:"period_to" example:"12"`    // Masa perolehan akhir (bulan)
+	MonthsCount int       `json:"months_count" example:"12"` // Jumlah bulan dengan slip gaji
+	IssuedAt    time.Time `json:"issued_at"`
+
+	Withholder TaxWithholder `json:"withholder"`
+
+	EmployeeID    uint   `json:"employee_id" example:"1"`
+	EmployeeName  string `json:"employee_name" example:"John Doe"`
+	NPWP          string `json:"npwp" example:"0123456789012345"`
+	NIK           string `json:"nik" example:"3171012345670001"`
+	Position      string `json:"position" example:"Software Engineer"`
+	TaxStatus     string `json:"tax_status" example:"K/1"`
+	Dependents    int    `json:"dependents" example:"1"`
+	TaxObjectCode string `json:"tax_object_code" example:"21-100-01"`
+
+	Salary              float64 `json:"salary" example:"120000000"`            // 1. Gaji (pro-rata, setelah potongan absen, termasuk rapel)
+	TaxAllowance        float64 `json:"tax_allowance" example:"0"`             // 2. Tunjangan PPh
+	OtherAllowances     float64 `json:"other_allowances" example:"12000000"`   // 3. Tunjangan lainnya, uang lembur
+	Honorarium          float64 `json:"honorarium" example:"0"`                // 4. Honorarium dan imbalan lain sejenis
+	InsurancePremium    float64 `json:"insurance_premium" example:"0"`         // 5. Premi asuransi yang dibayar pemberi kerja
+	BenefitsInKind      float64 `json:"benefits_in_kind" example:"0"`          // 6. Natura dan kenikmatan lainnya
+	BonusAndTHR         float64 `json:"bonus_and_thr" example:"11000000"`      // 7. Tantiem, bonus, gratifikasi, jasa produksi dan THR
+	GrossIncome         float64 `json:"gross_income" example:"143000000"`      // 8. Jumlah penghasilan bruto (1 s.d. 7)
+	JobExpense          float64 `json:"job_expense" example:"6000000"`         // 9. Biaya jabatan
+	PensionContribution float64 `json:"pension_contribution" example:"0"`      // 10. Iuran pensiun atau JHT
+	TotalDeductions     float64 `json:"total_deductions" example:"6000000"`    // 11. Jumlah pengurangan (9 + 10)
+	NetIncome           float64 `json:"net_income" example:"137000000"`        // 12. Penghasilan neto (8 - 11)
+	PreviousNetIncome   float64 `json:"previous_net_income" example:"0"`       // 13. Penghasilan neto masa sebelumnya
+	AnnualNetIncome     float64 `json:"annual_net_income" example:"137000000"` // 14. Penghasilan neto setahun
+	PTKP                float64 `json:"ptkp" example:"63000000"`               // 15. PTKP
+	TaxableIncome       float64 `json:"taxable_income" example:"74000000"`     // 16. PKP setahun (dibulatkan ke bawah ribuan)
+	AnnualTax           float64 `json:"annual_tax" example:"5100000"`          // 17. PPh 21 atas PKP setahun
+	PreviousWithheldTax float64 `json:"previous_withheld_tax" example:"0"`     // 18. PPh 21 yang telah dipotong masa sebelumnya
+	TaxDue              float64 `json:"tax_due" example:"5100000"`             // 19. PPh 21 terutang
+	WithheldTax         float64 `json:"withheld_tax" example:"1100000"`        // 20. PPh 21 yang telah dipotong dan dilunasi lewat slip
+	TaxUnderpaid        float64 `json:"tax_underpaid" example:"4000000"`       // Selisih 19 - 20 (negatif = lebih potong)
+	PayrollIDs          []uint  `json:"payroll_ids"`                           // Slip PAID yang dijumlahkan
+}
+
+// TaxCertificateService mendefinisikan kontrak Use Case bukti potong 1721-A1
+type TaxCertificateService interface {
+	// GetCertificates menyusun 1721-A1 untuk semua karyawan yang punya slip PAID di tahun tersebut
+	GetCertificates(ctx context.Context, year int) ([]TaxCertificate, error)
+	GetCertificate(ctx context.Context, year int, employeeID uint) (*TaxCertificate, error)
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Dasar upah THR (Permenaker 6/2016: upah = gaji pokok + tunjangan tetap, atau upah bersih tanpa tunjangan)
+const (
+	THRWageBaseOnly          = "BASE"                // Gaji pokok saja
+	THRWageBasePlusAllowance = "BASE_PLUS_ALLOWANCE" // Gaji pokok + tunjangan tetap
+)
+
+// Agama karyawan untuk jadwal THR
+const (
+	ReligionIslam    = "ISLAM"
+	ReligionKristen  = "KRISTEN"
+	ReligionKatolik  = "KATOLIK"
+	ReligionHindu    = "HINDU"
+	ReligionBuddha   = "BUDDHA"
+	ReligionKonghucu = "KONGHUCU"
+)
+
+// THRPayoutLeadDays: THR dibayar paling lambat 7 hari sebelum hari raya
+const THRPayoutLeadDays = 7
+
+var ErrTHRScheduleNotFound = errors.New("THR schedule not found")
+
+// THRSchedule adalah hari raya dan tanggal pembayaran THR per agama untuk satu tahun
+type THRSchedule struct {
+	ID          uint      `json:"id" gorm:"primaryKey" example:"1"`
+	Year        int       `json:"year" gorm:"uniqueIndex:idx_thr_schedule" example:"2026"`
+	Religion    string    `json:"religion" gorm:"uniqueIndex:idx_thr_schedule" example:"ISLAM"`
+	HolidayName string    `json:"holiday_name" example:"Idul Fitri 1447 H"`
+	HolidayDate time.Time `json:"holiday_date" example:"2026-03-20T00:00:00Z"` // Masa kerja dihitung sampai tanggal ini
+	PayoutDate  time.Time `json:"payout_date" example:"2026-03-10T00:00:00Z"`  // Menentukan periode slip THR
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// THRRunRequest adalah parameter perhitungan THR massal
+type THRRunRequest struct {
+	Year     int    `json:"year" example:"2026"`
+	Religion string `json:"religion" example:"ISLAM"` // Kosong = semua agama yang punya jadwal
+	DryRun   bool   `json:"dry_run" example:"true"`   // true = hitung saja, slip tidak disimpan
+}
+
+// THRSkip adalah karyawan yang tidak dibuatkan slip THR beserta alasannya
+type THRSkip struct {
+	EmployeeID uint   `json:"employee_id" example:"3"`
+	Reason     string `json:"reason" example:"less than one month of service"`
+}
+
+// THRRunResult adalah hasil perhitungan THR massal
+type THRRunResult struct {
+	Year    int       `json:"year" example:"2026"`
+	DryRun  bool      `json:"dry_run" example:"true"`
+	Slips   []Payroll `json:"slips"`
+	Skipped []THRSkip `json:"skipped"`
+}
+
+// THRScheduleRepository mendefinisikan kontrak operasi data (Port)
+type THRScheduleRepository interface {
+	Save(ctx context.Context, schedule *THRSchedule) error
+	Update(ctx context.Context, schedule *THRSchedule) error
+	// FindByYearAndReligion mengembalikan nil jika jadwal belum dibuat
+	FindByYearAndReligion(ctx context.Context, year int, religion string) (*THRSchedule, error)
+	FindByYear(ctx context.Context, year int) ([]THRSchedule, error)
+}
+
+// THRService mendefinisikan kontrak Use Case
+type THRService interface {
+	// SaveSchedule membuat atau mengganti jadwal untuk tahun dan agama tersebut
+	SaveSchedule(ctx context.Context, schedule *THRSchedule) (*THRSchedule, error)
+	GetSchedules(ctx context.Context, year int) ([]THRSchedule, error)
+	RunTHR(ctx context.Context, req THRRunRequest) (*THRRunResult, error)
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// TimesheetRow adalah satu baris karyawan pada rekap absensi bulanan
+type TimesheetRow struct {
+	EmployeeID    uint     `json:"employee_id" example:"1"`
+	EmployeeName  string   `json:"employee_name" example:"John Doe"`
+	Department    string   `json:"department" example:"Engineering"`
+	Days          []string `json:"days" example:"PRESENT,PRESENT,,ABSENT"` // Kode status per tanggal (indeks 0 = tanggal 1), kosong = tidak ada record
+	Present       int      `json:"present" example:"20"`                   // Status yang dihitung hadir
+	Absent        int      `json:"absent" example:"1"`                     // Status ABSENT
+	Leave         int      `json:"leave" example:"1"`                      // Status tidak hadir lainnya (cuti, sakit, ...)
+	LateMinutes   int      `json:"late_minutes" example:"35"`
+	OvertimeHours float64  `json:"overtime_hours" example:"4.5"`
+	WorkedHours   float64  `json:"worked_hours" example:"168.25"`
+}
+
+// Timesheet adalah rekap absensi bulanan: karyawan sebagai baris, tanggal sebagai kolom
+type Timesheet struct {
+	Period      time.Time      `json:"period" example:"2025-11-01T00:00:00Z"`
+	Department  string         `json:"department" example:"Engineering"`
+	DaysInMonth int            `json:"days_in_month" example:"30"`
+	WorkStart   string         `json:"work_start" example:"09:00"` // Jam masuk untuk menghitung terlambat
+	WorkHours   float64        `json:"work_hours" example:"8"`     // Jam kerja normal per hari, kelebihannya dihitung lembur
+	Rows        []TimesheetRow `json:"rows"`
+}
+
+// TimesheetService mendefinisikan kontrak Use Case untuk rekap absensi
+type TimesheetService interface {
+	GetMonthlyTimesheet(ctx context.Context, period time.Time, department string) (*Timesheet, error)
+}
@@ -0,0 +1,12 @@
+package domain
+
+import "context"
+
+// TxManager menjalankan beberapa pemanggilan repository sebagai satu unit kerja (unit of work).
+// Repository yang menerima ctx dari fn otomatis memakai transaksi yang sama.
+type TxManager interface {
+	// WithinTransaction menjalankan fn di dalam transaksi: commit jika fn mengembalikan nil, rollback jika error.
+	// fn dapat dijalankan ulang jika transaksi gagal karena konflik serialisasi, jadi fn tidak boleh
+	// mengandalkan state di luar transaksi. Pemanggilan bersarang ikut transaksi terluar.
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,66 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"hr-payroll/internal/domain"
+	"log"
+	"time"
+)
+
+// AbsenceMarkingJob menjalankan penandaan ABSENT otomatis sekali sehari
+type AbsenceMarkingJob struct {
+	Service      domain.AbsenceMarkingService
+	RunAt        string // Jam harian "HH:MM" (waktu lokal server)
+	LookbackDays int    // Jumlah hari ke belakang yang diperiksa, untuk mengejar hari saat server mati
+}
+
+func NewAbsenceMarkingJob(s domain.AbsenceMarkingService, runAt string, lookbackDays int) (*AbsenceMarkingJob, error) {
+	if _, err := time.Parse("15:04", runAt); err != nil {
+		return nil, fmt.Errorf("invalid absence job time %q, use HH:MM", runAt)
+	}
+	if lookbackDays < 1 {
+		lookbackDays = 1
+	}
+	return &AbsenceMarkingJob{Service: s, RunAt: runAt, LookbackDays: lookbackDays}, nil
+}
+
+// Start menjalankan job di goroutine sampai ctx dibatalkan
+func (j *AbsenceMarkingJob) Start(ctx context.Context) {
+	go func() {
+		for {
+			wait := time.Until(j.nextRun(time.Now()))
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(wait):
+				j.RunOnce(ctx)
+			}
+		}
+	}()
+}
+
+// RunOnce memeriksa LookbackDays hari terakhir (sampai kemarin)
+func (j *AbsenceMarkingJob) RunOnce(ctx context.Context) {
+	today := time.Now()
+	from := today.AddDate(0, 0, -j.LookbackDays)
+	to := today.AddDate(0, 0, -1)
+
+	result, err := j.Service.MarkAbsences(ctx, from, to)
+	if err != nil {
+		log.Printf("Absence marking job failed: %v", err)
+		return
+	}
+	log.Printf("Absence marking job: %s to %s, %d working days checked, %d ABSENT records created",
+		result.From.Format("2006-01-02"), result.To.Format("2006-01-02"), result.WorkingDays, result.Created)
+}
+
+// nextRun menghitung waktu jalan berikutnya setelah now
+func (j *AbsenceMarkingJob) nextRun(now time.Time) time.Time {
+	at, _ := time.Parse("15:04", j.RunAt)
+	next := time.Date(now.Year(), now.Month(), now.Day(), at.Hour(), at.Minute(), 0, 0, now.Location())
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"hr-payroll/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// AttendanceCorrectionGormRepository implements domain.AttendanceCorrectionRepository
+type AttendanceCorrectionGormRepository struct {
+	DB *gorm.DB
+}
+
+func NewAttendanceCorrectionGormRepository(db *gorm.DB) domain.AttendanceCorrectionRepository {
+	return &AttendanceCorrectionGormRepository{DB: db}
+}
+
+// Save implements domain.AttendanceCorrectionRepository.
+func (r *AttendanceCorrectionGormRepository) Save(ctx context.Context, correction *domain.AttendanceCorrection) error {
+	return withContext(ctx, r.DB).Create(correction).Error
+}
+
+// Update implements domain.AttendanceCorrectionRepository.
+func (r *AttendanceCorrectionGormRepository) Update(ctx context.Context, correction *domain.AttendanceCorrection) error {
+	return withContext(ctx, r.DB).Save(correction).Error
+}
+
+// FindByID implements domain.AttendanceCorrectionRepository.
+func (r *AttendanceCorrectionGormRepository) FindByID(ctx context.Context, id uint) (*domain.AttendanceCorrection, error) {
+	var correction domain.AttendanceCorrection
+	err := withContext(ctx, r.DB).First(&correction, id).Error
+	return &correction, err
+}
+
+// FindAll implements domain.AttendanceCorrectionRepository.
+func (r *AttendanceCorrectionGormRepository) FindAll(ctx context.Context, employeeID uint, status string) ([]domain.AttendanceCorrection, error) {
+	var corrections []domain.AttendanceCorrection
+	query := withContext(ctx, r.DB).Order("created_at DESC")
+	if employeeID != 0 {
+		query = query.Where("employee_id = ?", employeeID)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Find(&corrections).Error
+	return corrections, err
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"hr-payroll/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AttendancePeriodGormRepository implements domain.AttendancePeriodRepository
+type AttendancePeriodGormRepository struct {
+	DB *gorm.DB
+}
+
+func NewAttendancePeriodGormRepository(db *gorm.DB) domain.AttendancePeriodRepository {
+	return &AttendancePeriodGormRepository{DB: db}
+}
+
+// Save implements domain.AttendancePeriodRepository.
+func (r *AttendancePeriodGormRepository) Save(ctx context.Context, period *domain.AttendancePeriod) error {
+	return withContext(ctx, r.DB).Create(period).Error
+}
+
+// Update implements domain.AttendancePeriodRepository.
+func (r *AttendancePeriodGormRepository) Update(ctx context.Context, period *domain.AttendancePeriod) error {
+	return withContext(ctx, r.DB).Save(period).Error
+}
+
+// FindByPeriod implements domain.AttendancePeriodRepository.
+func (r *AttendancePeriodGormRepository) FindByPeriod(ctx context.Context, period time.Time) (*domain.AttendancePeriod, error) {
+	var result domain.AttendancePeriod
+	err := withContext(ctx, r.DB).Where("period = ?", period).First(&result).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// FindAll implements domain.AttendancePeriodRepository.
+func (r *AttendancePeriodGormRepository) FindAll(ctx context.Context) ([]domain.AttendancePeriod, error) {
+	var periods []domain.AttendancePeriod
+	err := withContext(ctx, r.DB).Order("period DESC").Find(&periods).Error
+	return periods, err
+}
+
+// SaveUnlock implements domain.AttendancePeriodRepository.
+func (r *AttendancePeriodGormRepository) SaveUnlock(ctx context.Context, unlock *domain.AttendancePeriodUnlock) error {
+	return withContext(ctx, r.DB).Create(unlock).Error
+}
+
+// FindUnlocks implements domain.AttendancePeriodRepository.
+func (r *AttendancePeriodGormRepository) FindUnlocks(ctx context.Context, period time.Time) ([]domain.AttendancePeriodUnlock, error) {
+	var unlocks []domain.AttendancePeriodUnlock
+	query := withContext(ctx, r.DB).Order("created_at DESC")
+	if !period.IsZero() {
+		query = query.Where("period = ?", period)
+	}
+	err := query.Find(&unlocks).Error
+	return unlocks, err
+}
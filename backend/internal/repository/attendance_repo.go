@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"errors"
 	"hr-payroll/internal/domain"
 	"time"
@@ -18,20 +19,35 @@ func NewAttendanceGormRepository(db *gorm.DB) domain.AttendanceRepository {
 }
 
 // Save implements domain.AttendanceRepository.
-func (r *AttendanceGormRepository) Save(att *domain.Attendance) error {
-	return r.DB.Create(att).Error
+func (r *AttendanceGormRepository) Save(ctx context.Context, att *domain.Attendance) error {
+	return withContext(ctx, r.DB).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(att).Error; err != nil {
+			return err
+		}
+		return writeAudit(tx, domain.AuditEntityAttendance, att.ID, nil, att)
+	})
 }
 
 // Update implements domain.AttendanceRepository.
-func (r *AttendanceGormRepository) Update(att *domain.Attendance) error {
-	return r.DB.Model(att).Updates(att).Error
+// Semua kolom ditulis (termasuk nil/nol) agar koreksi bisa mengosongkan jam masuk/pulang.
+func (r *AttendanceGormRepository) Update(ctx context.Context, att *domain.Attendance) error {
+	return withContext(ctx, r.DB).Transaction(func(tx *gorm.DB) error {
+		var before domain.Attendance
+		if err := tx.First(&before, att.ID).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(att).Select("*").Omit("created_at").Updates(att).Error; err != nil {
+			return err
+		}
+		return writeAudit(tx, domain.AuditEntityAttendance, att.ID, &before, att)
+	})
 }
 
 // FindByEmployeeAndDate implements domain.AttendanceRepository.
-func (r *AttendanceGormRepository) FindByEmployeeAndDate(employeeID uint, date time.Time) (*domain.Attendance, error) {
+func (r *AttendanceGormRepository) FindByEmployeeAndDate(ctx context.Context, employeeID uint, date time.Time) (*domain.Attendance, error) {
 	var attendance domain.Attendance
 	// GORM query to find a record by employee_id and date
-	err := r.DB.Where("employee_id = ? AND date = ?", employeeID, date).First(&attendance).Error
+	err := withContext(ctx, r.DB).Where("employee_id = ? AND date = ?", employeeID, date).First(&attendance).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil // Return nil if not found, simplifying service layer error handling
@@ -42,9 +58,16 @@ func (r *AttendanceGormRepository) FindByEmployeeAndDate(employeeID uint, date t
 }
 
 // FindByPeriod implements domain.AttendanceRepository.
-func (r *AttendanceGormRepository) FindByPeriod(employeeID uint, dateFrom time.Time, dateTo time.Time) ([]domain.Attendance, error) {
+func (r *AttendanceGormRepository) FindByPeriod(ctx context.Context, employeeID uint, dateFrom time.Time, dateTo time.Time) ([]domain.Attendance, error) {
 	var attendances []domain.Attendance
 	// GORM query to find records within a date range for a specific employee
-	err := r.DB.Where("employee_id = ? AND date >= ? AND date <= ?", employeeID, dateFrom, dateTo).Find(&attendances).Error
+	err := withContext(ctx, r.DB).Where("employee_id = ? AND date >= ? AND date <= ?", employeeID, dateFrom, dateTo).Find(&attendances).Error
+	return attendances, err
+}
+
+// FindAllByPeriod implements domain.AttendanceRepository.
+func (r *AttendanceGormRepository) FindAllByPeriod(ctx context.Context, dateFrom time.Time, dateTo time.Time) ([]domain.Attendance, error) {
+	var attendances []domain.Attendance
+	err := withContext(ctx, r.DB).Where("date >= ? AND date <= ?", dateFrom, dateTo).Order("employee_id, date").Find(&attendances).Error
 	return attendances, err
 }
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"hr-payroll/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// AttendanceStatusGormRepository implements domain.AttendanceStatusRepository
+type AttendanceStatusGormRepository struct {
+	DB *gorm.DB
+}
+
+func NewAttendanceStatusGormRepository(db *gorm.DB) domain.AttendanceStatusRepository {
+	return &AttendanceStatusGormRepository{DB: db}
+}
+
+// Save implements domain.AttendanceStatusRepository.
+func (r *AttendanceStatusGormRepository) Save(ctx context.Context, status *domain.AttendanceStatus) error {
+	return withContext(ctx, r.DB).Create(status).Error
+}
+
+// Update implements domain.AttendanceStatusRepository.
+func (r *AttendanceStatusGormRepository) Update(ctx context.Context, status *domain.AttendanceStatus) error {
+	return withContext(ctx, r.DB).Save(status).Error
+}
+
+// FindByCode implements domain.AttendanceStatusRepository.
+func (r *AttendanceStatusGormRepository) FindByCode(ctx context.Context, code string) (*domain.AttendanceStatus, error) {
+	var status domain.AttendanceStatus
+	err := withContext(ctx, r.DB).Where("code = ?", code).First(&status).Error
+	return &status, err
+}
+
+// FindAll implements domain.AttendanceStatusRepository.
+func (r *AttendanceStatusGormRepository) FindAll(ctx context.Context) ([]domain.AttendanceStatus, error) {
+	var statuses []domain.AttendanceStatus
+	err := withContext(ctx, r.DB).Order("id").Find(&statuses).Error
+	return statuses, err
+}
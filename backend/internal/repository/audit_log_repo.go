@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"hr-payroll/internal/domain"
+	"reflect"
+
+	"gorm.io/gorm"
+)
+
+// AuditLogGormRepository implements domain.AuditLogRepository
+type AuditLogGormRepository struct {
+	DB *gorm.DB
+}
+
+func NewAuditLogGormRepository(db *gorm.DB) domain.AuditLogRepository {
+	return &AuditLogGormRepository{DB: db}
+}
+
+// FindAll implements domain.AuditLogRepository.
+func (r *AuditLogGormRepository) FindAll(ctx context.Context, filter domain.AuditLogFilter) ([]domain.AuditLog, error) {
+	var logs []domain.AuditLog
+	query := withContext(ctx, r.DB)
+	if filter.Entity != "" {
+		query = query.Where("entity = ?", filter.Entity)
+	}
+	if filter.EntityID != 0 {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.Actor != "" {
+		query = query.Where("actor = ?", filter.Actor)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at < ?", filter.To.AddDate(0, 0, 1))
+	}
+	err := query.Order("created_at DESC, id DESC").Find(&logs).Error
+	return logs, err
+}
+
+// auditIgnoredFields tidak ikut dibandingkan: identitas baris dan timestamp selalu berubah saat disimpan
+var auditIgnoredFields = map[string]bool{
+	"id":         true,
+	"payroll_id": true,
+	"created_at": true,
+	"updated_at": true,
+}
+
+// writeAudit mencatat satu perubahan di dalam transaksi tx. before nil = CREATE, after nil = DELETE.
+// UPDATE tanpa perubahan field tidak dicatat.
+func writeAudit(tx *gorm.DB, entity string, entityID uint, before any, after any) error {
+	changes, err := auditDiff(before, after)
+	if err != nil {
+		return err
+	}
+	action := domain.AuditActionUpdate
+	switch {
+	case before == nil:
+		action = domain.AuditActionCreate
+	case after == nil:
+		action = domain.AuditActionDelete
+	case len(changes) == 0:
+		return nil
+	}
+
+	meta := domain.AuditMetaFromContext(tx.Statement.Context)
+	return tx.Create(&domain.AuditLog{
+		Actor:     meta.Actor,
+		Action:    action,
+		Entity:    entity,
+		EntityID:  entityID,
+		Changes:   changes,
+		RequestID: meta.RequestID,
+		IP:        meta.IP,
+	}).Error
+}
+
+// auditDiff membandingkan representasi JSON before dan after per field tingkat atas
+func auditDiff(before any, after any) (domain.AuditChanges, error) {
+	oldFields, err := auditFields(before)
+	if err != nil {
+		return nil, err
+	}
+	newFields, err := auditFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := domain.AuditChanges{}
+	for key, oldValue := range oldFields {
+		if newValue, ok := newFields[key]; !ok || !reflect.DeepEqual(oldValue, newValue) {
+			changes[key] = domain.AuditChange{Before: oldValue, After: newFields[key]}
+		}
+	}
+	for key, newValue := range newFields {
+		if _, ok := oldFields[key]; !ok {
+			changes[key] = domain.AuditChange{Before: nil, After: newValue}
+		}
+	}
+	return changes, nil
+}
+
+// auditFields mengubah entitas menjadi map field JSON tanpa auditIgnoredFields (juga di dalam slice/objek bertingkat)
+func auditFields(entity any) (map[string]any, error) {
+	if entity == nil {
+		return map[string]any{}, nil
+	}
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	stripAuditIgnored(fields)
+	return fields, nil
+}
+
+func stripAuditIgnored(value any) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, nested := range v {
+			if auditIgnoredFields[key] {
+				delete(v, key)
+				continue
+			}
+			stripAuditIgnored(nested)
+		}
+	case []any:
+		for _, nested := range v {
+			stripAuditIgnored(nested)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"hr-payroll/internal/domain"
 
 	"gorm.io/gorm"
@@ -16,33 +17,56 @@ func NewEmployeeGormRepository(db *gorm.DB) domain.EmployeeRepository {
 }
 
 // Save implements domain.EmployeeRepository.
-func (r *EmployeeGormRepository) Save(emp *domain.Employee) error {
-	// Menciptakan karyawan baru
-	return r.DB.Create(emp).Error
+func (r *EmployeeGormRepository) Save(ctx context.Context, emp *domain.Employee) error {
+	// Menciptakan karyawan baru, dicatat di audit log dalam transaksi yang sama
+	return withContext(ctx, r.DB).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(emp).Error; err != nil {
+			return err
+		}
+		return writeAudit(tx, domain.AuditEntityEmployee, emp.ID, nil, emp)
+	})
 }
 
 // FindByID implements domain.EmployeeRepository.
-func (r *EmployeeGormRepository) FindByID(id uint) (*domain.Employee, error) {
+func (r *EmployeeGormRepository) FindByID(ctx context.Context, id uint) (*domain.Employee, error) {
 	var employee domain.Employee
 	// Mencari karyawan berdasarkan ID
-	if err := r.DB.First(&employee, id).Error; err != nil {
+	if err := withContext(ctx, r.DB).First(&employee, id).Error; err != nil {
 		return nil, err
 	}
 	return &employee, nil
 }
 
 // FindAll implements domain.EmployeeRepository.
-func (r *EmployeeGormRepository) FindAll() ([]domain.Employee, error) {
+func (r *EmployeeGormRepository) FindAll(ctx context.Context) ([]domain.Employee, error) {
 	var employees []domain.Employee
 	// Mengambil semua karyawan
-	if err := r.DB.Find(&employees).Error; err != nil {
+	if err := withContext(ctx, r.DB).Find(&employees).Error; err != nil {
+		return nil, err
+	}
+	return employees, nil
+}
+
+// FindByDepartment implements domain.EmployeeRepository.
+func (r *EmployeeGormRepository) FindByDepartment(ctx context.Context, department string) ([]domain.Employee, error) {
+	var employees []domain.Employee
+	if err := withContext(ctx, r.DB).Where("department = ?", department).Find(&employees).Error; err != nil {
 		return nil, err
 	}
 	return employees, nil
 }
 
 // Update implements domain.EmployeeRepository.
-func (r *EmployeeGormRepository) Update(emp *domain.Employee) error {
-	// Memperbarui data karyawan
-	return r.DB.Save(emp).Error
+func (r *EmployeeGormRepository) Update(ctx context.Context, emp *domain.Employee) error {
+	// Memperbarui data karyawan; nilai lama dibaca ulang agar diff audit akurat
+	return withContext(ctx, r.DB).Transaction(func(tx *gorm.DB) error {
+		var before domain.Employee
+		if err := tx.First(&before, emp.ID).Error; err != nil {
+			return err
+		}
+		if err := tx.Save(emp).Error; err != nil {
+			return err
+		}
+		return writeAudit(tx, domain.AuditEntityEmployee, emp.ID, &before, emp)
+	})
 }
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"hr-payroll/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// HolidayGormRepository implements domain.HolidayRepository
+type HolidayGormRepository struct {
+	DB *gorm.DB
+}
+
+func NewHolidayGormRepository(db *gorm.DB) domain.HolidayRepository {
+	return &HolidayGormRepository{DB: db}
+}
+
+// Save implements domain.HolidayRepository.
+func (r *HolidayGormRepository) Save(ctx context.Context, holiday *domain.Holiday) error {
+	return withContext(ctx, r.DB).Create(holiday).Error
+}
+
+// Delete implements domain.HolidayRepository.
+func (r *HolidayGormRepository) Delete(ctx context.Context, id uint) error {
+	result := withContext(ctx, r.DB).Delete(&domain.Holiday{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// FindByPeriod implements domain.HolidayRepository.
+func (r *HolidayGormRepository) FindByPeriod(ctx context.Context, dateFrom time.Time, dateTo time.Time) ([]domain.Holiday, error) {
+	var holidays []domain.Holiday
+	err := withContext(ctx, r.DB).Where("date >= ? AND date <= ?", dateFrom, dateTo).Order("date").Find(&holidays).Error
+	return holidays, err
+}
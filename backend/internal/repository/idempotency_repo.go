@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"hr-payroll/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IdempotencyGormRepository implements domain.IdempotencyRepository
+type IdempotencyGormRepository struct {
+	DB *gorm.DB
+}
+
+func NewIdempotencyGormRepository(db *gorm.DB) domain.IdempotencyRepository {
+	return &IdempotencyGormRepository{DB: db}
+}
+
+// Reserve implements domain.IdempotencyRepository.
+// INSERT ... ON CONFLICT DO NOTHING membuat dua request bersamaan dengan key yang sama tidak bisa sama-sama menang.
+func (r *IdempotencyGormRepository) Reserve(ctx context.Context, record *domain.IdempotencyRecord) (*domain.IdempotencyRecord, error) {
+	db := withContext(ctx, r.DB)
+	if err := db.Where("expires_at <= ?", time.Now()).Delete(&domain.IdempotencyRecord{}).Error; err != nil {
+		return nil, err
+	}
+
+	result := db.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "idempotency_key"}}, DoNothing: true}).Create(record)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return nil, nil
+	}
+
+	var existing domain.IdempotencyRecord
+	if err := db.Where("idempotency_key = ?", record.Key).First(&existing).Error; err != nil {
+		return nil, err
+	}
+	return &existing, nil
+}
+
+// Complete implements domain.IdempotencyRepository.
+func (r *IdempotencyGormRepository) Complete(ctx context.Context, record *domain.IdempotencyRecord) error {
+	return withContext(ctx, r.DB).Model(&domain.IdempotencyRecord{}).
+		Where("idempotency_key = ?", record.Key).
+		Updates(map[string]any{
+			"status_code":  record.StatusCode,
+			"content_type": record.ContentType,
+			"body":         record.Body,
+			"completed_at": record.CompletedAt,
+			"expires_at":   record.ExpiresAt,
+		}).Error
+}
+
+// Delete implements domain.IdempotencyRepository.
+func (r *IdempotencyGormRepository) Delete(ctx context.Context, key string) error {
+	return withContext(ctx, r.DB).Where("idempotency_key = ?", key).Delete(&domain.IdempotencyRecord{}).Error
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"hr-payroll/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// KioskTokenUseGormRepository implements domain.KioskTokenUseRepository
+type KioskTokenUseGormRepository struct {
+	DB *gorm.DB
+}
+
+func NewKioskTokenUseGormRepository(db *gorm.DB) domain.KioskTokenUseRepository {
+	return &KioskTokenUseGormRepository{DB: db}
+}
+
+// Save implements domain.KioskTokenUseRepository.
+func (r *KioskTokenUseGormRepository) Save(ctx context.Context, use *domain.KioskTokenUse) error {
+	return withContext(ctx, r.DB).Create(use).Error
+}
+
+// Exists implements domain.KioskTokenUseRepository.
+func (r *KioskTokenUseGormRepository) Exists(ctx context.Context, officeID uint, tokenWindow int64, employeeID uint) (bool, error) {
+	var count int64
+	err := withContext(ctx, r.DB).Model(&domain.KioskTokenUse{}).
+		Where("office_id = ? AND token_window = ? AND employee_id = ?", officeID, tokenWindow, employeeID).
+		Count(&count).Error
+	return count > 0, err
+}
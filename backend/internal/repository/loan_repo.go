@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"hr-payroll/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// LoanGormRepository implements domain.LoanRepository
+type LoanGormRepository struct {
+	DB *gorm.DB
+}
+
+func NewLoanGormRepository(db *gorm.DB) domain.LoanRepository {
+	return &LoanGormRepository{DB: db}
+}
+
+// Save implements domain.LoanRepository.
+func (r *LoanGormRepository) Save(ctx context.Context, loan *domain.Loan) error {
+	return withContext(ctx, r.DB).Create(loan).Error
+}
+
+// Update implements domain.LoanRepository.
+// Mutasi saldo disimpan terpisah lewat SaveTransaction.
+func (r *LoanGormRepository) Update(ctx context.Context, loan *domain.Loan) error {
+	return withContext(ctx, r.DB).Omit("Transactions").Save(loan).Error
+}
+
+// FindByID implements domain.LoanRepository.
+func (r *LoanGormRepository) FindByID(ctx context.Context, id uint) (*domain.Loan, error) {
+	var loan domain.Loan
+	err := withContext(ctx, r.DB).Preload("Transactions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at, id")
+	}).First(&loan, id).Error
+	return &loan, err
+}
+
+// FindByEmployee implements domain.LoanRepository.
+func (r *LoanGormRepository) FindByEmployee(ctx context.Context, employeeID uint) ([]domain.Loan, error) {
+	var loans []domain.Loan
+	query := withContext(ctx, r.DB).Order("created_at DESC")
+	if employeeID != 0 {
+		query = query.Where("employee_id = ?", employeeID)
+	}
+	err := query.Find(&loans).Error
+	return loans, err
+}
+
+// FindActiveByEmployee implements domain.LoanRepository.
+func (r *LoanGormRepository) FindActiveByEmployee(ctx context.Context, employeeID uint) ([]domain.Loan, error) {
+	var loans []domain.Loan
+	err := withContext(ctx, r.DB).Where("employee_id = ? AND status = ?", employeeID, domain.LoanStatusActive).
+		Order("start_period, id").Find(&loans).Error
+	return loans, err
+}
+
+// SaveTransaction implements domain.LoanRepository.
+func (r *LoanGormRepository) SaveTransaction(ctx context.Context, tx *domain.LoanTransaction) error {
+	return withContext(ctx, r.DB).Create(tx).Error
+}
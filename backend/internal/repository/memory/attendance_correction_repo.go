@@ -0,0 +1,80 @@
+package memory
+
+import (
+	"context"
+	"hr-payroll/internal/domain"
+	"slices"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// AttendanceCorrectionRepository implements domain.AttendanceCorrectionRepository
+type AttendanceCorrectionRepository struct {
+	mu     sync.RWMutex
+	rows   []domain.AttendanceCorrection
+	nextID uint
+}
+
+func NewAttendanceCorrectionRepository() domain.AttendanceCorrectionRepository {
+	return &AttendanceCorrectionRepository{}
+}
+
+// Save implements domain.AttendanceCorrectionRepository.
+func (r *AttendanceCorrectionRepository) Save(ctx context.Context, correction *domain.AttendanceCorrection) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	defaultString(&correction.Status, domain.CorrectionStatusPending)
+	r.nextID++
+	correction.ID = r.nextID
+	touch(&correction.CreatedAt, &correction.UpdatedAt)
+	r.rows = append(r.rows, *correction)
+	return nil
+}
+
+// Update implements domain.AttendanceCorrectionRepository.
+func (r *AttendanceCorrectionRepository) Update(ctx context.Context, correction *domain.AttendanceCorrection) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := index(r.rows, func(c *domain.AttendanceCorrection) bool { return c.ID == correction.ID })
+	if i < 0 {
+		return gorm.ErrRecordNotFound
+	}
+	touch(&correction.CreatedAt, &correction.UpdatedAt)
+	r.rows[i] = *correction
+	return nil
+}
+
+// FindByID implements domain.AttendanceCorrectionRepository.
+func (r *AttendanceCorrectionRepository) FindByID(ctx context.Context, id uint) (*domain.AttendanceCorrection, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	i := index(r.rows, func(c *domain.AttendanceCorrection) bool { return c.ID == id })
+	if i < 0 {
+		return &domain.AttendanceCorrection{}, gorm.ErrRecordNotFound
+	}
+	correction := r.rows[i]
+	return &correction, nil
+}
+
+// FindAll implements domain.AttendanceCorrectionRepository.
+func (r *AttendanceCorrectionRepository) FindAll(ctx context.Context, employeeID uint, status string) ([]domain.AttendanceCorrection, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	corrections := filter(r.rows, func(c *domain.AttendanceCorrection) bool {
+		return (employeeID == 0 || c.EmployeeID == employeeID) && (status == "" || c.Status == status)
+	})
+	return sortBy(corrections, func(a, b *domain.AttendanceCorrection) bool { return a.CreatedAt.After(b.CreatedAt) }), nil
+}
+
+// snapshot implements transactional.
+func (r *AttendanceCorrectionRepository) snapshot() func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rows, nextID := slices.Clone(r.rows), r.nextID
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.rows, r.nextID = rows, nextID
+	}
+}
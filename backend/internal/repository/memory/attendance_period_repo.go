@@ -0,0 +1,103 @@
+package memory
+
+import (
+	"context"
+	"hr-payroll/internal/domain"
+	"slices"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AttendancePeriodRepository implements domain.AttendancePeriodRepository
+type AttendancePeriodRepository struct {
+	mu           sync.RWMutex
+	rows         []domain.AttendancePeriod
+	unlocks      []domain.AttendancePeriodUnlock
+	nextID       uint
+	nextUnlockID uint
+}
+
+func NewAttendancePeriodRepository() domain.AttendancePeriodRepository {
+	return &AttendancePeriodRepository{}
+}
+
+// Save implements domain.AttendancePeriodRepository.
+func (r *AttendancePeriodRepository) Save(ctx context.Context, period *domain.AttendancePeriod) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if index(r.rows, func(p *domain.AttendancePeriod) bool { return p.Period.Equal(period.Period) }) >= 0 {
+		return gorm.ErrDuplicatedKey
+	}
+	defaultString(&period.Status, domain.AttendancePeriodOpen)
+	r.nextID++
+	period.ID = r.nextID
+	touch(&period.CreatedAt, &period.UpdatedAt)
+	r.rows = append(r.rows, *period)
+	return nil
+}
+
+// Update implements domain.AttendancePeriodRepository.
+func (r *AttendancePeriodRepository) Update(ctx context.Context, period *domain.AttendancePeriod) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := index(r.rows, func(p *domain.AttendancePeriod) bool { return p.ID == period.ID })
+	if i < 0 {
+		return gorm.ErrRecordNotFound
+	}
+	touch(&period.CreatedAt, &period.UpdatedAt)
+	r.rows[i] = *period
+	return nil
+}
+
+// FindByPeriod implements domain.AttendancePeriodRepository.
+func (r *AttendancePeriodRepository) FindByPeriod(ctx context.Context, period time.Time) (*domain.AttendancePeriod, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	i := index(r.rows, func(p *domain.AttendancePeriod) bool { return p.Period.Equal(period) })
+	if i < 0 {
+		return nil, nil
+	}
+	result := r.rows[i]
+	return &result, nil
+}
+
+// FindAll implements domain.AttendancePeriodRepository.
+func (r *AttendancePeriodRepository) FindAll(ctx context.Context) ([]domain.AttendancePeriod, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	periods := filter(r.rows, func(*domain.AttendancePeriod) bool { return true })
+	return sortBy(periods, func(a, b *domain.AttendancePeriod) bool { return a.Period.After(b.Period) }), nil
+}
+
+// SaveUnlock implements domain.AttendancePeriodRepository.
+func (r *AttendancePeriodRepository) SaveUnlock(ctx context.Context, unlock *domain.AttendancePeriodUnlock) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextUnlockID++
+	unlock.ID = r.nextUnlockID
+	touch(&unlock.CreatedAt, nil)
+	r.unlocks = append(r.unlocks, *unlock)
+	return nil
+}
+
+// FindUnlocks implements domain.AttendancePeriodRepository.
+func (r *AttendancePeriodRepository) FindUnlocks(ctx context.Context, period time.Time) ([]domain.AttendancePeriodUnlock, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	unlocks := filter(r.unlocks, func(u *domain.AttendancePeriodUnlock) bool { return period.IsZero() || u.Period.Equal(period) })
+	return sortBy(unlocks, func(a, b *domain.AttendancePeriodUnlock) bool { return a.CreatedAt.After(b.CreatedAt) }), nil
+}
+
+// snapshot implements transactional.
+func (r *AttendancePeriodRepository) snapshot() func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rows, unlocks, nextID, nextUnlockID := slices.Clone(r.rows), slices.Clone(r.unlocks), r.nextID, r.nextUnlockID
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.rows, r.unlocks, r.nextID, r.nextUnlockID = rows, unlocks, nextID, nextUnlockID
+	}
+}
@@ -0,0 +1,107 @@
+package memory
+
+import (
+	"context"
+	"hr-payroll/internal/domain"
+	"slices"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AttendanceRepository implements domain.AttendanceRepository
+type AttendanceRepository struct {
+	mu     sync.RWMutex
+	rows   []domain.Attendance
+	nextID uint
+}
+
+func NewAttendanceRepository() domain.AttendanceRepository {
+	return &AttendanceRepository{}
+}
+
+// Save implements domain.AttendanceRepository.
+// Unique index idx_employee_date: satu absensi per karyawan per hari.
+func (r *AttendanceRepository) Save(ctx context.Context, att *domain.Attendance) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.duplicate(att) {
+		return gorm.ErrDuplicatedKey
+	}
+	r.nextID++
+	att.ID = r.nextID
+	touch(&att.CreatedAt, nil)
+	r.rows = append(r.rows, *att)
+	return nil
+}
+
+// Update implements domain.AttendanceRepository.
+func (r *AttendanceRepository) Update(ctx context.Context, att *domain.Attendance) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := index(r.rows, func(a *domain.Attendance) bool { return a.ID == att.ID })
+	if i < 0 {
+		return gorm.ErrRecordNotFound
+	}
+	if r.duplicate(att) {
+		return gorm.ErrDuplicatedKey
+	}
+	row := *att
+	row.CreatedAt = r.rows[i].CreatedAt // created_at tidak ikut diperbarui
+	r.rows[i] = row
+	return nil
+}
+
+// FindByEmployeeAndDate implements domain.AttendanceRepository.
+func (r *AttendanceRepository) FindByEmployeeAndDate(ctx context.Context, employeeID uint, date time.Time) (*domain.Attendance, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	i := index(r.rows, func(a *domain.Attendance) bool { return a.EmployeeID == employeeID && a.Date.Equal(date) })
+	if i < 0 {
+		return nil, nil
+	}
+	attendance := r.rows[i]
+	return &attendance, nil
+}
+
+// FindByPeriod implements domain.AttendanceRepository.
+func (r *AttendanceRepository) FindByPeriod(ctx context.Context, employeeID uint, dateFrom time.Time, dateTo time.Time) ([]domain.Attendance, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return filter(r.rows, func(a *domain.Attendance) bool {
+		return a.EmployeeID == employeeID && inRange(a.Date, dateFrom, dateTo)
+	}), nil
+}
+
+// FindAllByPeriod implements domain.AttendanceRepository.
+func (r *AttendanceRepository) FindAllByPeriod(ctx context.Context, dateFrom time.Time, dateTo time.Time) ([]domain.Attendance, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	attendances := filter(r.rows, func(a *domain.Attendance) bool { return inRange(a.Date, dateFrom, dateTo) })
+	return sortBy(attendances, func(a, b *domain.Attendance) bool {
+		if a.EmployeeID != b.EmployeeID {
+			return a.EmployeeID < b.EmployeeID
+		}
+		return a.Date.Before(b.Date)
+	}), nil
+}
+
+// duplicate memeriksa unique index idx_employee_date terhadap baris lain
+func (r *AttendanceRepository) duplicate(att *domain.Attendance) bool {
+	return index(r.rows, func(a *domain.Attendance) bool {
+		return a.ID != att.ID && a.EmployeeID == att.EmployeeID && a.Date.Equal(att.Date)
+	}) >= 0
+}
+
+// snapshot implements transactional.
+func (r *AttendanceRepository) snapshot() func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rows, nextID := slices.Clone(r.rows), r.nextID
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.rows, r.nextID = rows, nextID
+	}
+}
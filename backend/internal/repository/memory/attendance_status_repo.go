@@ -0,0 +1,80 @@
+package memory
+
+import (
+	"context"
+	"hr-payroll/internal/domain"
+	"slices"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// AttendanceStatusRepository implements domain.AttendanceStatusRepository.
+// Katalog dimulai kosong; isi dengan domain.DefaultAttendanceStatuses seperti migrasi database jika perlu.
+type AttendanceStatusRepository struct {
+	mu     sync.RWMutex
+	rows   []domain.AttendanceStatus
+	nextID uint
+}
+
+func NewAttendanceStatusRepository() domain.AttendanceStatusRepository {
+	return &AttendanceStatusRepository{}
+}
+
+// Save implements domain.AttendanceStatusRepository.
+func (r *AttendanceStatusRepository) Save(ctx context.Context, status *domain.AttendanceStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if index(r.rows, func(s *domain.AttendanceStatus) bool { return s.Code == status.Code }) >= 0 {
+		return gorm.ErrDuplicatedKey
+	}
+	r.nextID++
+	status.ID = r.nextID
+	touch(&status.CreatedAt, &status.UpdatedAt)
+	r.rows = append(r.rows, *status)
+	return nil
+}
+
+// Update implements domain.AttendanceStatusRepository.
+func (r *AttendanceStatusRepository) Update(ctx context.Context, status *domain.AttendanceStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := index(r.rows, func(s *domain.AttendanceStatus) bool { return s.ID == status.ID })
+	if i < 0 {
+		return gorm.ErrRecordNotFound
+	}
+	touch(&status.CreatedAt, &status.UpdatedAt)
+	r.rows[i] = *status
+	return nil
+}
+
+// FindByCode implements domain.AttendanceStatusRepository.
+func (r *AttendanceStatusRepository) FindByCode(ctx context.Context, code string) (*domain.AttendanceStatus, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	i := index(r.rows, func(s *domain.AttendanceStatus) bool { return s.Code == code })
+	if i < 0 {
+		return &domain.AttendanceStatus{}, gorm.ErrRecordNotFound
+	}
+	status := r.rows[i]
+	return &status, nil
+}
+
+// FindAll implements domain.AttendanceStatusRepository.
+func (r *AttendanceStatusRepository) FindAll(ctx context.Context) ([]domain.AttendanceStatus, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return filter(r.rows, func(*domain.AttendanceStatus) bool { return true }), nil
+}
+
+// snapshot implements transactional.
+func (r *AttendanceStatusRepository) snapshot() func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rows, nextID := slices.Clone(r.rows), r.nextID
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.rows, r.nextID = rows, nextID
+	}
+}
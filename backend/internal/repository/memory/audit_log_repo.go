@@ -0,0 +1,58 @@
+package memory
+
+import (
+	"context"
+	"hr-payroll/internal/domain"
+	"slices"
+	"sync"
+)
+
+// AuditLogRepository implements domain.AuditLogRepository.
+// Repository memori lain tidak menulis audit log; baris ditambahkan lewat Append (mis. di test).
+type AuditLogRepository struct {
+	mu     sync.RWMutex
+	rows   []domain.AuditLog
+	nextID uint
+}
+
+func NewAuditLogRepository() *AuditLogRepository {
+	return &AuditLogRepository{}
+}
+
+// Append menyimpan satu baris audit log
+func (r *AuditLogRepository) Append(log domain.AuditLog) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextID++
+	log.ID = r.nextID
+	touch(&log.CreatedAt, nil)
+	r.rows = append(r.rows, log)
+}
+
+// FindAll implements domain.AuditLogRepository.
+func (r *AuditLogRepository) FindAll(ctx context.Context, filter domain.AuditLogFilter) ([]domain.AuditLog, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	logs := []domain.AuditLog{}
+	for i := len(r.rows) - 1; i >= 0; i-- {
+		log := r.rows[i]
+		if (filter.Entity == "" || log.Entity == filter.Entity) && (filter.EntityID == 0 || log.EntityID == filter.EntityID) &&
+			(filter.Actor == "" || log.Actor == filter.Actor) && (filter.From.IsZero() || !log.CreatedAt.Before(filter.From)) &&
+			(filter.To.IsZero() || log.CreatedAt.Before(filter.To.AddDate(0, 0, 1))) {
+			logs = append(logs, log)
+		}
+	}
+	return logs, nil
+}
+
+// snapshot implements transactional.
+func (r *AuditLogRepository) snapshot() func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rows, nextID := slices.Clone(r.rows), r.nextID
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.rows, r.nextID = rows, nextID
+	}
+}
@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"context"
+	"hr-payroll/internal/domain"
+	"slices"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// EmployeeRepository adalah adapter memori yang mengimplementasikan domain.EmployeeRepository
+type EmployeeRepository struct {
+	mu     sync.RWMutex
+	rows   []domain.Employee
+	nextID uint
+}
+
+func NewEmployeeRepository() domain.EmployeeRepository {
+	return &EmployeeRepository{}
+}
+
+// Save implements domain.EmployeeRepository.
+func (r *EmployeeRepository) Save(ctx context.Context, emp *domain.Employee) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextID++
+	emp.ID = r.nextID
+	touch(&emp.CreatedAt, &emp.UpdatedAt)
+	r.rows = append(r.rows, *emp)
+	return nil
+}
+
+// FindByID implements domain.EmployeeRepository.
+func (r *EmployeeRepository) FindByID(ctx context.Context, id uint) (*domain.Employee, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	i := index(r.rows, func(e *domain.Employee) bool { return e.ID == id })
+	if i < 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	employee := r.rows[i]
+	return &employee, nil
+}
+
+// FindAll implements domain.EmployeeRepository.
+func (r *EmployeeRepository) FindAll(ctx context.Context) ([]domain.Employee, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return filter(r.rows, func(*domain.Employee) bool { return true }), nil
+}
+
+// FindByDepartment implements domain.EmployeeRepository.
+func (r *EmployeeRepository) FindByDepartment(ctx context.Context, department string) ([]domain.Employee, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return filter(r.rows, func(e *domain.Employee) bool { return e.Department == department }), nil
+}
+
+// Update implements domain.EmployeeRepository.
+func (r *EmployeeRepository) Update(ctx context.Context, emp *domain.Employee) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := index(r.rows, func(e *domain.Employee) bool { return e.ID == emp.ID })
+	if i < 0 {
+		return gorm.ErrRecordNotFound
+	}
+	touch(&emp.CreatedAt, &emp.UpdatedAt)
+	r.rows[i] = *emp
+	return nil
+}
+
+// snapshot implements transactional.
+func (r *EmployeeRepository) snapshot() func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rows, nextID := slices.Clone(r.rows), r.nextID
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.rows, r.nextID = rows, nextID
+	}
+}
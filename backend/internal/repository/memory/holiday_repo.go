@@ -0,0 +1,70 @@
+package memory
+
+import (
+	"context"
+	"hr-payroll/internal/domain"
+	"slices"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// HolidayRepository implements domain.HolidayRepository
+type HolidayRepository struct {
+	mu     sync.RWMutex
+	rows   []domain.Holiday
+	nextID uint
+}
+
+func NewHolidayRepository() domain.HolidayRepository {
+	return &HolidayRepository{}
+}
+
+// Save implements domain.HolidayRepository.
+// Tanggal libur unik (unique index pada kolom date).
+func (r *HolidayRepository) Save(ctx context.Context, holiday *domain.Holiday) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if index(r.rows, func(h *domain.Holiday) bool { return h.Date.Equal(holiday.Date) }) >= 0 {
+		return gorm.ErrDuplicatedKey
+	}
+	r.nextID++
+	holiday.ID = r.nextID
+	touch(&holiday.CreatedAt, nil)
+	r.rows = append(r.rows, *holiday)
+	return nil
+}
+
+// Delete implements domain.HolidayRepository.
+// Mengembalikan gorm.ErrRecordNotFound jika tidak ada baris yang terhapus.
+func (r *HolidayRepository) Delete(ctx context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := index(r.rows, func(h *domain.Holiday) bool { return h.ID == id })
+	if i < 0 {
+		return gorm.ErrRecordNotFound
+	}
+	r.rows = append(r.rows[:i], r.rows[i+1:]...)
+	return nil
+}
+
+// FindByPeriod implements domain.HolidayRepository.
+func (r *HolidayRepository) FindByPeriod(ctx context.Context, dateFrom time.Time, dateTo time.Time) ([]domain.Holiday, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	holidays := filter(r.rows, func(h *domain.Holiday) bool { return inRange(h.Date, dateFrom, dateTo) })
+	return sortBy(holidays, func(a, b *domain.Holiday) bool { return a.Date.Before(b.Date) }), nil
+}
+
+// snapshot implements transactional.
+func (r *HolidayRepository) snapshot() func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rows, nextID := slices.Clone(r.rows), r.nextID
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.rows, r.nextID = rows, nextID
+	}
+}
@@ -0,0 +1,77 @@
+package memory
+
+import (
+	"context"
+	"hr-payroll/internal/domain"
+	"maps"
+	"sync"
+	"time"
+)
+
+// IdempotencyRepository implements domain.IdempotencyRepository
+type IdempotencyRepository struct {
+	mu     sync.Mutex
+	rows   map[string]domain.IdempotencyRecord
+	nextID uint
+}
+
+func NewIdempotencyRepository() domain.IdempotencyRepository {
+	return &IdempotencyRepository{rows: map[string]domain.IdempotencyRecord{}}
+}
+
+// Reserve implements domain.IdempotencyRepository.
+func (r *IdempotencyRepository) Reserve(ctx context.Context, record *domain.IdempotencyRecord) (*domain.IdempotencyRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	for key, stored := range r.rows {
+		if !stored.ExpiresAt.After(now) {
+			delete(r.rows, key)
+		}
+	}
+	if existing, ok := r.rows[record.Key]; ok {
+		return &existing, nil
+	}
+	r.nextID++
+	record.ID = r.nextID
+	touch(&record.CreatedAt, nil)
+	r.rows[record.Key] = *record
+	return nil, nil
+}
+
+// Complete implements domain.IdempotencyRepository.
+func (r *IdempotencyRepository) Complete(ctx context.Context, record *domain.IdempotencyRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.rows[record.Key]
+	if !ok {
+		return nil
+	}
+	stored.StatusCode = record.StatusCode
+	stored.ContentType = record.ContentType
+	stored.Body = record.Body
+	stored.CompletedAt = record.CompletedAt
+	stored.ExpiresAt = record.ExpiresAt
+	r.rows[record.Key] = stored
+	return nil
+}
+
+// Delete implements domain.IdempotencyRepository.
+func (r *IdempotencyRepository) Delete(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.rows, key)
+	return nil
+}
+
+// snapshot implements transactional.
+func (r *IdempotencyRepository) snapshot() func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rows, nextID := maps.Clone(r.rows), r.nextID
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.rows, r.nextID = rows, nextID
+	}
+}
@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"context"
+	"hr-payroll/internal/domain"
+	"slices"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// KioskTokenUseRepository implements domain.KioskTokenUseRepository
+type KioskTokenUseRepository struct {
+	mu     sync.RWMutex
+	rows   []domain.KioskTokenUse
+	nextID uint
+}
+
+func NewKioskTokenUseRepository() domain.KioskTokenUseRepository {
+	return &KioskTokenUseRepository{}
+}
+
+// Save implements domain.KioskTokenUseRepository.
+// Unique index idx_kiosk_token_use: satu pemakaian per kantor, jendela token dan karyawan.
+func (r *KioskTokenUseRepository) Save(ctx context.Context, use *domain.KioskTokenUse) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.exists(use.OfficeID, use.TokenWindow, use.EmployeeID) {
+		return gorm.ErrDuplicatedKey
+	}
+	r.nextID++
+	use.ID = r.nextID
+	r.rows = append(r.rows, *use)
+	return nil
+}
+
+// Exists implements domain.KioskTokenUseRepository.
+func (r *KioskTokenUseRepository) Exists(ctx context.Context, officeID uint, tokenWindow int64, employeeID uint) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.exists(officeID, tokenWindow, employeeID), nil
+}
+
+func (r *KioskTokenUseRepository) exists(officeID uint, tokenWindow int64, employeeID uint) bool {
+	return index(r.rows, func(u *domain.KioskTokenUse) bool {
+		return u.OfficeID == officeID && u.TokenWindow == tokenWindow && u.EmployeeID == employeeID
+	}) >= 0
+}
+
+// snapshot implements transactional.
+func (r *KioskTokenUseRepository) snapshot() func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rows, nextID := slices.Clone(r.rows), r.nextID
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.rows, r.nextID = rows, nextID
+	}
+}
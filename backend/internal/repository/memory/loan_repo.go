@@ -0,0 +1,116 @@
+package memory
+
+import (
+	"context"
+	"hr-payroll/internal/domain"
+	"slices"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// LoanRepository implements domain.LoanRepository
+type LoanRepository struct {
+	mu           sync.RWMutex
+	rows         []domain.Loan
+	transactions []domain.LoanTransaction
+	nextID       uint
+	nextTxID     uint
+}
+
+func NewLoanRepository() domain.LoanRepository {
+	return &LoanRepository{}
+}
+
+// Save implements domain.LoanRepository.
+func (r *LoanRepository) Save(ctx context.Context, loan *domain.Loan) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	defaultString(&loan.Status, domain.LoanStatusActive)
+	r.nextID++
+	loan.ID = r.nextID
+	touch(&loan.CreatedAt, &loan.UpdatedAt)
+	for i := range loan.Transactions {
+		loan.Transactions[i].LoanID = loan.ID
+		r.saveTransaction(&loan.Transactions[i])
+	}
+	row := *loan
+	row.Transactions = nil
+	r.rows = append(r.rows, row)
+	return nil
+}
+
+// Update implements domain.LoanRepository.
+// Transaksi tidak ikut disimpan; gunakan SaveTransaction.
+func (r *LoanRepository) Update(ctx context.Context, loan *domain.Loan) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := index(r.rows, func(l *domain.Loan) bool { return l.ID == loan.ID })
+	if i < 0 {
+		return gorm.ErrRecordNotFound
+	}
+	touch(&loan.CreatedAt, &loan.UpdatedAt)
+	row := *loan
+	row.Transactions = nil
+	r.rows[i] = row
+	return nil
+}
+
+// FindByID implements domain.LoanRepository.
+func (r *LoanRepository) FindByID(ctx context.Context, id uint) (*domain.Loan, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	i := index(r.rows, func(l *domain.Loan) bool { return l.ID == id })
+	if i < 0 {
+		return &domain.Loan{}, gorm.ErrRecordNotFound
+	}
+	loan := r.rows[i]
+	loan.Transactions = filter(r.transactions, func(t *domain.LoanTransaction) bool { return t.LoanID == id })
+	sortBy(loan.Transactions, func(a, b *domain.LoanTransaction) bool { return a.CreatedAt.Before(b.CreatedAt) })
+	return &loan, nil
+}
+
+// FindByEmployee implements domain.LoanRepository.
+func (r *LoanRepository) FindByEmployee(ctx context.Context, employeeID uint) ([]domain.Loan, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	loans := filter(r.rows, func(l *domain.Loan) bool { return employeeID == 0 || l.EmployeeID == employeeID })
+	return sortBy(loans, func(a, b *domain.Loan) bool { return a.CreatedAt.After(b.CreatedAt) }), nil
+}
+
+// FindActiveByEmployee implements domain.LoanRepository.
+func (r *LoanRepository) FindActiveByEmployee(ctx context.Context, employeeID uint) ([]domain.Loan, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	loans := filter(r.rows, func(l *domain.Loan) bool {
+		return l.EmployeeID == employeeID && l.Status == domain.LoanStatusActive
+	})
+	return sortBy(loans, func(a, b *domain.Loan) bool { return a.StartPeriod.Before(b.StartPeriod) }), nil
+}
+
+// SaveTransaction implements domain.LoanRepository.
+func (r *LoanRepository) SaveTransaction(ctx context.Context, tx *domain.LoanTransaction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.saveTransaction(tx)
+	return nil
+}
+
+func (r *LoanRepository) saveTransaction(tx *domain.LoanTransaction) {
+	r.nextTxID++
+	tx.ID = r.nextTxID
+	touch(&tx.CreatedAt, nil)
+	r.transactions = append(r.transactions, *tx)
+}
+
+// snapshot implements transactional.
+func (r *LoanRepository) snapshot() func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rows, transactions, nextID, nextTxID := slices.Clone(r.rows), slices.Clone(r.transactions), r.nextID, r.nextTxID
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.rows, r.transactions, r.nextID, r.nextTxID = rows, transactions, nextID, nextTxID
+	}
+}
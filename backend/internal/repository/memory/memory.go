@@ -0,0 +1,119 @@
+// Package memory berisi implementasi repository di memori untuk unit test dan
+// menjalankan service tanpa database. Perilakunya meniru adapter GORM:
+// ID auto-increment, default kolom, unique index, urutan hasil dan
+// gorm.ErrRecordNotFound / nil pada data yang tidak ditemukan.
+package memory
+
+import (
+	"context"
+	"fmt"
+	"hr-payroll/internal/domain"
+	"sort"
+	"sync"
+	"time"
+)
+
+// transactional diimplementasikan setiap repository memori: snapshot menyalin
+// isi repository dan mengembalikan fungsi untuk memulihkannya (rollback).
+type transactional interface {
+	snapshot() func()
+}
+
+// txContextKey menandai ctx yang sudah berada di dalam WithinTransaction
+type txContextKey struct{}
+
+// TxManager meniru transaksi database untuk repository memori: isi setiap
+// repository yang didaftarkan disalin sebelum fn dijalankan dan dipulihkan jika
+// fn mengembalikan error. Transaksi dijalankan berurutan (tidak ada isolasi
+// antar-transaksi); pemanggilan bersarang ikut transaksi terluar seperti
+// GormTxManager.
+type TxManager struct {
+	mu    sync.Mutex
+	repos []transactional
+}
+
+// NewTxManager menerima repository memori yang ikut di-rollback. Argumen yang
+// bukan repository memori dianggap kesalahan pemrograman dan memicu panic.
+func NewTxManager(repos ...any) domain.TxManager {
+	manager := &TxManager{}
+	for _, repo := range repos {
+		t, ok := repo.(transactional)
+		if !ok {
+			panic(fmt.Sprintf("memory.NewTxManager: %T tidak mendukung rollback", repo))
+		}
+		manager.repos = append(manager.repos, t)
+	}
+	return manager
+}
+
+// WithinTransaction implements domain.TxManager.
+func (m *TxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(txContextKey{}) != nil {
+		return fn(ctx)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	restores := make([]func(), 0, len(m.repos))
+	for _, repo := range m.repos {
+		restores = append(restores, repo.snapshot())
+	}
+	if err := fn(context.WithValue(ctx, txContextKey{}, true)); err != nil {
+		for _, restore := range restores {
+			restore()
+		}
+		return err
+	}
+	return nil
+}
+
+// filter mengembalikan salinan baris yang cocok dengan match (urutan dipertahankan)
+func filter[T any](rows []T, match func(*T) bool) []T {
+	result := []T{}
+	for i := range rows {
+		if match(&rows[i]) {
+			result = append(result, rows[i])
+		}
+	}
+	return result
+}
+
+// index mengembalikan posisi baris pertama yang cocok, -1 jika tidak ada
+func index[T any](rows []T, match func(*T) bool) int {
+	for i := range rows {
+		if match(&rows[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// sortBy mengurutkan rows secara stabil (setara ORDER BY tanpa kolom pemecah seri)
+func sortBy[T any](rows []T, less func(a, b *T) bool) []T {
+	sort.SliceStable(rows, func(i, j int) bool { return less(&rows[i], &rows[j]) })
+	return rows
+}
+
+// inRange memeriksa from <= t <= to (setara BETWEEN pada kolom tanggal)
+func inRange(t time.Time, from time.Time, to time.Time) bool {
+	return !t.Before(from) && !t.After(to)
+}
+
+// touch mengisi CreatedAt (jika kosong) dan UpdatedAt seperti GORM saat Create/Save
+func touch(createdAt *time.Time, updatedAt *time.Time) {
+	now := time.Now()
+	if createdAt != nil && createdAt.IsZero() {
+		*createdAt = now
+	}
+	if updatedAt != nil {
+		*updatedAt = now
+	}
+}
+
+// defaultString mengisi kolom string kosong dengan nilai default kolom (tag gorm default:...)
+func defaultString(value *string, def string) {
+	if *value == "" {
+		*value = def
+	}
+}
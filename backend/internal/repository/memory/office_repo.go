@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"context"
+	"hr-payroll/internal/domain"
+	"slices"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// OfficeRepository implements domain.OfficeRepository
+type OfficeRepository struct {
+	mu     sync.RWMutex
+	rows   []domain.Office
+	nextID uint
+}
+
+func NewOfficeRepository() domain.OfficeRepository {
+	return &OfficeRepository{}
+}
+
+// Save implements domain.OfficeRepository.
+func (r *OfficeRepository) Save(ctx context.Context, office *domain.Office) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	defaultString(&office.Policy, domain.GeofencePolicyReject)
+	r.nextID++
+	office.ID = r.nextID
+	touch(&office.CreatedAt, &office.UpdatedAt)
+	r.rows = append(r.rows, cloneOffice(office))
+	return nil
+}
+
+// Update implements domain.OfficeRepository.
+func (r *OfficeRepository) Update(ctx context.Context, office *domain.Office) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := index(r.rows, func(o *domain.Office) bool { return o.ID == office.ID })
+	if i < 0 {
+		return gorm.ErrRecordNotFound
+	}
+	touch(&office.CreatedAt, &office.UpdatedAt)
+	r.rows[i] = cloneOffice(office)
+	return nil
+}
+
+// FindByID implements domain.OfficeRepository.
+func (r *OfficeRepository) FindByID(ctx context.Context, id uint) (*domain.Office, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	i := index(r.rows, func(o *domain.Office) bool { return o.ID == id })
+	if i < 0 {
+		return &domain.Office{}, gorm.ErrRecordNotFound
+	}
+	office := cloneOffice(&r.rows[i])
+	return &office, nil
+}
+
+// FindAll implements domain.OfficeRepository.
+func (r *OfficeRepository) FindAll(ctx context.Context) ([]domain.Office, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	offices := filter(r.rows, func(*domain.Office) bool { return true })
+	for i := range offices {
+		offices[i] = cloneOffice(&offices[i])
+	}
+	return offices, nil
+}
+
+// cloneOffice menyalin kantor beserta titik polygon (kolom JSON di database)
+func cloneOffice(office *domain.Office) domain.Office {
+	clone := *office
+	if office.Polygon != nil {
+		clone.Polygon = append([]domain.GeoPoint{}, office.Polygon...)
+	}
+	return clone
+}
+
+// snapshot implements transactional.
+func (r *OfficeRepository) snapshot() func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rows, nextID := slices.Clone(r.rows), r.nextID
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.rows, r.nextID = rows, nextID
+	}
+}
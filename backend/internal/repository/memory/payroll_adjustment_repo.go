@@ -0,0 +1,99 @@
+package memory
+
+import (
+	"context"
+	"hr-payroll/internal/domain"
+	"slices"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PayrollAdjustmentRepository implements domain.PayrollAdjustmentRepository
+type PayrollAdjustmentRepository struct {
+	mu     sync.RWMutex
+	rows   []domain.PayrollAdjustment
+	nextID uint
+}
+
+func NewPayrollAdjustmentRepository() domain.PayrollAdjustmentRepository {
+	return &PayrollAdjustmentRepository{}
+}
+
+// Save implements domain.PayrollAdjustmentRepository.
+func (r *PayrollAdjustmentRepository) Save(ctx context.Context, adjustment *domain.PayrollAdjustment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	defaultString(&adjustment.Status, domain.AdjustmentPending)
+	r.nextID++
+	adjustment.ID = r.nextID
+	touch(&adjustment.CreatedAt, &adjustment.UpdatedAt)
+	r.rows = append(r.rows, *adjustment)
+	return nil
+}
+
+// Update implements domain.PayrollAdjustmentRepository.
+func (r *PayrollAdjustmentRepository) Update(ctx context.Context, adjustment *domain.PayrollAdjustment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := index(r.rows, func(a *domain.PayrollAdjustment) bool { return a.ID == adjustment.ID })
+	if i < 0 {
+		return gorm.ErrRecordNotFound
+	}
+	touch(&adjustment.CreatedAt, &adjustment.UpdatedAt)
+	r.rows[i] = *adjustment
+	return nil
+}
+
+// FindByID implements domain.PayrollAdjustmentRepository.
+func (r *PayrollAdjustmentRepository) FindByID(ctx context.Context, id uint) (*domain.PayrollAdjustment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	i := index(r.rows, func(a *domain.PayrollAdjustment) bool { return a.ID == id })
+	if i < 0 {
+		return &domain.PayrollAdjustment{}, gorm.ErrRecordNotFound
+	}
+	adjustment := r.rows[i]
+	return &adjustment, nil
+}
+
+// FindAll implements domain.PayrollAdjustmentRepository.
+func (r *PayrollAdjustmentRepository) FindAll(ctx context.Context, employeeID uint, period time.Time, status string) ([]domain.PayrollAdjustment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	adjustments := filter(r.rows, func(a *domain.PayrollAdjustment) bool {
+		return (employeeID == 0 || a.EmployeeID == employeeID) && (period.IsZero() || a.Period.Equal(period)) &&
+			(status == "" || a.Status == status)
+	})
+	return sortBy(adjustments, func(a, b *domain.PayrollAdjustment) bool { return a.Period.After(b.Period) }), nil
+}
+
+// FindPayable implements domain.PayrollAdjustmentRepository.
+func (r *PayrollAdjustmentRepository) FindPayable(ctx context.Context, employeeID uint, period time.Time, payrollID uint) ([]domain.PayrollAdjustment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return filter(r.rows, func(a *domain.PayrollAdjustment) bool {
+		return a.EmployeeID == employeeID && a.Period.Equal(period) && a.Status == domain.AdjustmentPending &&
+			(a.PayrollID == nil || *a.PayrollID == payrollID)
+	}), nil
+}
+
+// FindByPayroll implements domain.PayrollAdjustmentRepository.
+func (r *PayrollAdjustmentRepository) FindByPayroll(ctx context.Context, payrollID uint) ([]domain.PayrollAdjustment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return filter(r.rows, func(a *domain.PayrollAdjustment) bool { return a.PayrollID != nil && *a.PayrollID == payrollID }), nil
+}
+
+// snapshot implements transactional.
+func (r *PayrollAdjustmentRepository) snapshot() func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rows, nextID := slices.Clone(r.rows), r.nextID
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.rows, r.nextID = rows, nextID
+	}
+}
@@ -0,0 +1,182 @@
+package memory
+
+import (
+	"context"
+	"hr-payroll/internal/domain"
+	"slices"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PayrollRepository implements domain.PayrollRepository
+type PayrollRepository struct {
+	mu         sync.RWMutex
+	rows       []domain.Payroll
+	nextID     uint
+	nextItemID uint
+}
+
+func NewPayrollRepository() domain.PayrollRepository {
+	return &PayrollRepository{}
+}
+
+// Save implements domain.PayrollRepository.
+// Unique index idx_employee_period berlaku untuk slip yang tidak VOID.
+func (r *PayrollRepository) Save(ctx context.Context, payroll *domain.Payroll) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	defaultString(&payroll.Type, domain.PayrollTypeRegular)
+	defaultString(&payroll.Status, domain.PayrollStatusGenerated)
+	if r.duplicate(payroll) {
+		return gorm.ErrDuplicatedKey
+	}
+	r.nextID++
+	payroll.ID = r.nextID
+	r.assignItems(payroll)
+	r.rows = append(r.rows, clonePayroll(payroll))
+	return nil
+}
+
+// Update implements domain.PayrollRepository.
+// Baris item slip diganti seluruhnya dengan payroll.Items.
+func (r *PayrollRepository) Update(ctx context.Context, payroll *domain.Payroll) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := index(r.rows, func(p *domain.Payroll) bool { return p.ID == payroll.ID })
+	if i < 0 {
+		return gorm.ErrRecordNotFound
+	}
+	if r.duplicate(payroll) {
+		return gorm.ErrDuplicatedKey
+	}
+	for j := range payroll.Items {
+		payroll.Items[j].ID = 0
+	}
+	r.assignItems(payroll)
+	r.rows[i] = clonePayroll(payroll)
+	return nil
+}
+
+// FindByEmployeeAndPeriod implements domain.PayrollRepository.
+func (r *PayrollRepository) FindByEmployeeAndPeriod(ctx context.Context, employeeID uint, period time.Time) (*domain.Payroll, error) {
+	return r.FindByEmployeePeriodAndType(ctx, employeeID, period, domain.PayrollTypeRegular)
+}
+
+// FindByEmployeePeriodAndType implements domain.PayrollRepository.
+func (r *PayrollRepository) FindByEmployeePeriodAndType(ctx context.Context, employeeID uint, period time.Time, payrollType string) (*domain.Payroll, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	i := index(r.rows, func(p *domain.Payroll) bool {
+		return p.EmployeeID == employeeID && p.Period.Equal(period) && p.Type == payrollType && p.Status != domain.PayrollStatusVoid
+	})
+	if i < 0 {
+		return nil, nil
+	}
+	payroll := clonePayroll(&r.rows[i])
+	return &payroll, nil
+}
+
+// FindByEmployee implements domain.PayrollRepository.
+func (r *PayrollRepository) FindByEmployee(ctx context.Context, employeeID uint) ([]domain.Payroll, error) {
+	payrolls := r.find(func(p *domain.Payroll) bool {
+		return p.EmployeeID == employeeID && p.Type == domain.PayrollTypeRegular && p.Status != domain.PayrollStatusVoid
+	})
+	return sortBy(payrolls, func(a, b *domain.Payroll) bool { return a.Period.Before(b.Period) }), nil
+}
+
+// FindByPeriod implements domain.PayrollRepository.
+// Semua slip (tidak VOID) yang periodenya jatuh di bulan yang sama dengan period.
+func (r *PayrollRepository) FindByPeriod(ctx context.Context, period time.Time) ([]domain.Payroll, error) {
+	monthStart := time.Date(period.Year(), period.Month(), 1, 0, 0, 0, 0, time.UTC)
+	monthEnd := monthStart.AddDate(0, 1, 0)
+	payrolls := r.find(func(p *domain.Payroll) bool {
+		return !p.Period.Before(monthStart) && p.Period.Before(monthEnd) && p.Type == domain.PayrollTypeRegular && p.Status != domain.PayrollStatusVoid
+	})
+	return sortBy(payrolls, func(a, b *domain.Payroll) bool { return a.EmployeeID < b.EmployeeID }), nil
+}
+
+// FindAll implements domain.PayrollRepository.
+func (r *PayrollRepository) FindAll(ctx context.Context) ([]domain.Payroll, error) {
+	return r.find(func(p *domain.Payroll) bool { return p.Status != domain.PayrollStatusVoid }), nil
+}
+
+// FindPaidByYear implements domain.PayrollRepository.
+func (r *PayrollRepository) FindPaidByYear(ctx context.Context, year int) ([]domain.Payroll, error) {
+	yearStart := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+	yearEnd := yearStart.AddDate(1, 0, 0)
+	payrolls := r.find(func(p *domain.Payroll) bool {
+		return !p.Period.Before(yearStart) && p.Period.Before(yearEnd) && p.Status == domain.PayrollStatusPaid
+	})
+	return sortBy(payrolls, func(a, b *domain.Payroll) bool {
+		if a.EmployeeID != b.EmployeeID {
+			return a.EmployeeID < b.EmployeeID
+		}
+		return a.Period.Before(b.Period)
+	}), nil
+}
+
+// FindByID implements domain.PayrollRepository.
+func (r *PayrollRepository) FindByID(ctx context.Context, id uint) (*domain.Payroll, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	i := index(r.rows, func(p *domain.Payroll) bool { return p.ID == id })
+	if i < 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	payroll := clonePayroll(&r.rows[i])
+	return &payroll, nil
+}
+
+// find mengembalikan salinan slip (beserta item) yang cocok dengan match
+func (r *PayrollRepository) find(match func(*domain.Payroll) bool) []domain.Payroll {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	payrolls := filter(r.rows, match)
+	for i := range payrolls {
+		payrolls[i] = clonePayroll(&payrolls[i])
+	}
+	return payrolls
+}
+
+// duplicate memeriksa unique index idx_employee_period (where status <> 'VOID') terhadap slip lain
+func (r *PayrollRepository) duplicate(payroll *domain.Payroll) bool {
+	if payroll.Status == domain.PayrollStatusVoid {
+		return false
+	}
+	return index(r.rows, func(p *domain.Payroll) bool {
+		return p.ID != payroll.ID && p.EmployeeID == payroll.EmployeeID && p.Period.Equal(payroll.Period) &&
+			p.Type == payroll.Type && p.Status != domain.PayrollStatusVoid
+	}) >= 0
+}
+
+// assignItems memberi ID baru pada item yang belum tersimpan dan mengikatnya ke slip
+func (r *PayrollRepository) assignItems(payroll *domain.Payroll) {
+	for i := range payroll.Items {
+		if payroll.Items[i].ID == 0 {
+			r.nextItemID++
+			payroll.Items[i].ID = r.nextItemID
+		}
+		payroll.Items[i].PayrollID = payroll.ID
+	}
+}
+
+// clonePayroll menyalin slip beserta slice item agar pemanggil tidak mengubah data tersimpan
+func clonePayroll(payroll *domain.Payroll) domain.Payroll {
+	clone := *payroll
+	clone.Items = append([]domain.PayrollItem{}, payroll.Items...)
+	return clone
+}
+
+// snapshot implements transactional.
+func (r *PayrollRepository) snapshot() func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rows, nextID, nextItemID := slices.Clone(r.rows), r.nextID, r.nextItemID
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.rows, r.nextID, r.nextItemID = rows, nextID, nextItemID
+	}
+}
@@ -0,0 +1,108 @@
+package memory
+
+import (
+	"context"
+	"hr-payroll/internal/domain"
+	"slices"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PunchRepository implements domain.PunchRepository
+type PunchRepository struct {
+	mu     sync.RWMutex
+	rows   []domain.Punch
+	nextID uint
+}
+
+func NewPunchRepository() domain.PunchRepository {
+	return &PunchRepository{}
+}
+
+// Save implements domain.PunchRepository.
+// Unique index idx_punch_employee_time: satu punch per karyawan per waktu.
+func (r *PunchRepository) Save(ctx context.Context, punch *domain.Punch) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if index(r.rows, func(p *domain.Punch) bool {
+		return p.EmployeeID == punch.EmployeeID && p.Timestamp.Equal(punch.Timestamp)
+	}) >= 0 {
+		return gorm.ErrDuplicatedKey
+	}
+	r.nextID++
+	punch.ID = r.nextID
+	touch(&punch.CreatedAt, nil)
+	r.rows = append(r.rows, *punch)
+	return nil
+}
+
+// UpdateReview implements domain.PunchRepository.
+func (r *PunchRepository) UpdateReview(ctx context.Context, punch *domain.Punch) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := index(r.rows, func(p *domain.Punch) bool { return p.ID == punch.ID })
+	if i < 0 {
+		return gorm.ErrRecordNotFound
+	}
+	r.rows[i].ReviewStatus = punch.ReviewStatus
+	r.rows[i].ReviewedBy = punch.ReviewedBy
+	r.rows[i].ReviewNote = punch.ReviewNote
+	r.rows[i].ReviewedAt = punch.ReviewedAt
+	return nil
+}
+
+// FindByEmployeeAndDate implements domain.PunchRepository.
+// date adalah awal hari; hasil diurutkan berdasarkan waktu punch.
+func (r *PunchRepository) FindByEmployeeAndDate(ctx context.Context, employeeID uint, date time.Time) ([]domain.Punch, error) {
+	return r.find(func(p *domain.Punch) bool {
+		return p.EmployeeID == employeeID && !p.Timestamp.Before(date) && p.Timestamp.Before(date.AddDate(0, 0, 1))
+	}), nil
+}
+
+// FindByPeriod implements domain.PunchRepository.
+func (r *PunchRepository) FindByPeriod(ctx context.Context, employeeID uint, dateFrom time.Time, dateTo time.Time) ([]domain.Punch, error) {
+	return r.find(func(p *domain.Punch) bool {
+		return p.EmployeeID == employeeID && !p.Timestamp.Before(dateFrom) && p.Timestamp.Before(dateTo.AddDate(0, 0, 1))
+	}), nil
+}
+
+// FindByID implements domain.PunchRepository.
+func (r *PunchRepository) FindByID(ctx context.Context, id uint) (*domain.Punch, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	i := index(r.rows, func(p *domain.Punch) bool { return p.ID == id })
+	if i < 0 {
+		return &domain.Punch{}, gorm.ErrRecordNotFound
+	}
+	punch := r.rows[i]
+	return &punch, nil
+}
+
+// FindFlagged implements domain.PunchRepository.
+func (r *PunchRepository) FindFlagged(ctx context.Context, dateFrom time.Time, dateTo time.Time) ([]domain.Punch, error) {
+	return r.find(func(p *domain.Punch) bool {
+		return p.Flagged && !p.Timestamp.Before(dateFrom) && p.Timestamp.Before(dateTo.AddDate(0, 0, 1))
+	}), nil
+}
+
+// find mengembalikan punch yang cocok, diurutkan berdasarkan waktu punch
+func (r *PunchRepository) find(match func(*domain.Punch) bool) []domain.Punch {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	punches := filter(r.rows, match)
+	return sortBy(punches, func(a, b *domain.Punch) bool { return a.Timestamp.Before(b.Timestamp) })
+}
+
+// snapshot implements transactional.
+func (r *PunchRepository) snapshot() func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rows, nextID := slices.Clone(r.rows), r.nextID
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.rows, r.nextID = rows, nextID
+	}
+}
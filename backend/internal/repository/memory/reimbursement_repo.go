@@ -0,0 +1,162 @@
+package memory
+
+import (
+	"context"
+	"hr-payroll/internal/domain"
+	"slices"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ReimbursementRepository implements domain.ReimbursementRepository
+type ReimbursementRepository struct {
+	mu             sync.RWMutex
+	categories     []domain.ReimbursementCategory
+	rows           []domain.ReimbursementClaim
+	nextCategoryID uint
+	nextID         uint
+}
+
+func NewReimbursementRepository() domain.ReimbursementRepository {
+	return &ReimbursementRepository{}
+}
+
+// SaveCategory implements domain.ReimbursementRepository.
+func (r *ReimbursementRepository) SaveCategory(ctx context.Context, category *domain.ReimbursementCategory) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if index(r.categories, func(c *domain.ReimbursementCategory) bool { return c.Code == category.Code }) >= 0 {
+		return gorm.ErrDuplicatedKey
+	}
+	r.nextCategoryID++
+	category.ID = r.nextCategoryID
+	touch(&category.CreatedAt, &category.UpdatedAt)
+	r.categories = append(r.categories, *category)
+	return nil
+}
+
+// UpdateCategory implements domain.ReimbursementRepository.
+func (r *ReimbursementRepository) UpdateCategory(ctx context.Context, category *domain.ReimbursementCategory) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := index(r.categories, func(c *domain.ReimbursementCategory) bool { return c.ID == category.ID })
+	if i < 0 {
+		return gorm.ErrRecordNotFound
+	}
+	touch(&category.CreatedAt, &category.UpdatedAt)
+	r.categories[i] = *category
+	return nil
+}
+
+// FindCategoryByCode implements domain.ReimbursementRepository.
+func (r *ReimbursementRepository) FindCategoryByCode(ctx context.Context, code string) (*domain.ReimbursementCategory, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	i := index(r.categories, func(c *domain.ReimbursementCategory) bool { return c.Code == code })
+	if i < 0 {
+		return &domain.ReimbursementCategory{}, gorm.ErrRecordNotFound
+	}
+	category := r.categories[i]
+	return &category, nil
+}
+
+// FindCategories implements domain.ReimbursementRepository.
+func (r *ReimbursementRepository) FindCategories(ctx context.Context) ([]domain.ReimbursementCategory, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	categories := filter(r.categories, func(*domain.ReimbursementCategory) bool { return true })
+	return sortBy(categories, func(a, b *domain.ReimbursementCategory) bool { return a.Code < b.Code }), nil
+}
+
+// Save implements domain.ReimbursementRepository.
+func (r *ReimbursementRepository) Save(ctx context.Context, claim *domain.ReimbursementClaim) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	defaultString(&claim.Status, domain.ReimbursementSubmitted)
+	r.nextID++
+	claim.ID = r.nextID
+	touch(&claim.CreatedAt, &claim.UpdatedAt)
+	r.rows = append(r.rows, *claim)
+	return nil
+}
+
+// Update implements domain.ReimbursementRepository.
+func (r *ReimbursementRepository) Update(ctx context.Context, claim *domain.ReimbursementClaim) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := index(r.rows, func(c *domain.ReimbursementClaim) bool { return c.ID == claim.ID })
+	if i < 0 {
+		return gorm.ErrRecordNotFound
+	}
+	touch(&claim.CreatedAt, &claim.UpdatedAt)
+	r.rows[i] = *claim
+	return nil
+}
+
+// FindByID implements domain.ReimbursementRepository.
+func (r *ReimbursementRepository) FindByID(ctx context.Context, id uint) (*domain.ReimbursementClaim, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	i := index(r.rows, func(c *domain.ReimbursementClaim) bool { return c.ID == id })
+	if i < 0 {
+		return &domain.ReimbursementClaim{}, gorm.ErrRecordNotFound
+	}
+	claim := r.rows[i]
+	return &claim, nil
+}
+
+// FindAll implements domain.ReimbursementRepository.
+func (r *ReimbursementRepository) FindAll(ctx context.Context, employeeID uint, status string) ([]domain.ReimbursementClaim, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	claims := filter(r.rows, func(c *domain.ReimbursementClaim) bool {
+		return (employeeID == 0 || c.EmployeeID == employeeID) && (status == "" || c.Status == status)
+	})
+	return sortBy(claims, func(a, b *domain.ReimbursementClaim) bool { return a.CreatedAt.After(b.CreatedAt) }), nil
+}
+
+// SumClaimed implements domain.ReimbursementRepository.
+func (r *ReimbursementRepository) SumClaimed(ctx context.Context, employeeID uint, categoryCode string, year int, excludeID uint) (float64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	yearStart := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+	total := 0.0
+	for _, claim := range r.rows {
+		if claim.EmployeeID == employeeID && claim.CategoryCode == categoryCode && claim.Status != domain.ReimbursementRejected &&
+			claim.ID != excludeID && !claim.ExpenseDate.Before(yearStart) && claim.ExpenseDate.Before(yearStart.AddDate(1, 0, 0)) {
+			total += claim.Amount
+		}
+	}
+	return total, nil
+}
+
+// FindPayable implements domain.ReimbursementRepository.
+func (r *ReimbursementRepository) FindPayable(ctx context.Context, employeeID uint, payrollID uint) ([]domain.ReimbursementClaim, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	claims := filter(r.rows, func(c *domain.ReimbursementClaim) bool {
+		return c.EmployeeID == employeeID && c.Status == domain.ReimbursementApproved && (c.PayrollID == nil || *c.PayrollID == payrollID)
+	})
+	return sortBy(claims, func(a, b *domain.ReimbursementClaim) bool { return a.ExpenseDate.Before(b.ExpenseDate) }), nil
+}
+
+// FindByPayroll implements domain.ReimbursementRepository.
+func (r *ReimbursementRepository) FindByPayroll(ctx context.Context, payrollID uint) ([]domain.ReimbursementClaim, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return filter(r.rows, func(c *domain.ReimbursementClaim) bool { return c.PayrollID != nil && *c.PayrollID == payrollID }), nil
+}
+
+// snapshot implements transactional.
+func (r *ReimbursementRepository) snapshot() func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	categories, rows, nextCategoryID, nextID := slices.Clone(r.categories), slices.Clone(r.rows), r.nextCategoryID, r.nextID
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.categories, r.rows, r.nextCategoryID, r.nextID = categories, rows, nextCategoryID, nextID
+	}
+}
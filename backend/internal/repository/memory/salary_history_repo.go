@@ -0,0 +1,51 @@
+package memory
+
+import (
+	"context"
+	"hr-payroll/internal/domain"
+	"slices"
+	"sync"
+)
+
+// SalaryHistoryRepository implements domain.SalaryHistoryRepository
+type SalaryHistoryRepository struct {
+	mu     sync.RWMutex
+	rows   []domain.SalaryHistory
+	nextID uint
+}
+
+func NewSalaryHistoryRepository() domain.SalaryHistoryRepository {
+	return &SalaryHistoryRepository{}
+}
+
+// Save implements domain.SalaryHistoryRepository.
+func (r *SalaryHistoryRepository) Save(ctx context.Context, history *domain.SalaryHistory) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextID++
+	history.ID = r.nextID
+	touch(&history.CreatedAt, nil)
+	r.rows = append(r.rows, *history)
+	return nil
+}
+
+// FindByEmployee implements domain.SalaryHistoryRepository.
+// Hasil diurutkan dari effective_from paling lama; untuk tanggal yang sama, input terakhir di belakang.
+func (r *SalaryHistoryRepository) FindByEmployee(ctx context.Context, employeeID uint) ([]domain.SalaryHistory, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	histories := filter(r.rows, func(h *domain.SalaryHistory) bool { return h.EmployeeID == employeeID })
+	return sortBy(histories, func(a, b *domain.SalaryHistory) bool { return a.EffectiveFrom.Before(b.EffectiveFrom) }), nil
+}
+
+// snapshot implements transactional.
+func (r *SalaryHistoryRepository) snapshot() func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rows, nextID := slices.Clone(r.rows), r.nextID
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.rows, r.nextID = rows, nextID
+	}
+}
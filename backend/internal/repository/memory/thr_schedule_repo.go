@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"context"
+	"hr-payroll/internal/domain"
+	"slices"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// THRScheduleRepository implements domain.THRScheduleRepository
+type THRScheduleRepository struct {
+	mu     sync.RWMutex
+	rows   []domain.THRSchedule
+	nextID uint
+}
+
+func NewTHRScheduleRepository() domain.THRScheduleRepository {
+	return &THRScheduleRepository{}
+}
+
+// Save implements domain.THRScheduleRepository.
+// Unique index idx_thr_schedule: satu jadwal per tahun per agama.
+func (r *THRScheduleRepository) Save(ctx context.Context, schedule *domain.THRSchedule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if index(r.rows, func(s *domain.THRSchedule) bool { return s.Year == schedule.Year && s.Religion == schedule.Religion }) >= 0 {
+		return gorm.ErrDuplicatedKey
+	}
+	r.nextID++
+	schedule.ID = r.nextID
+	touch(&schedule.CreatedAt, &schedule.UpdatedAt)
+	r.rows = append(r.rows, *schedule)
+	return nil
+}
+
+// Update implements domain.THRScheduleRepository.
+func (r *THRScheduleRepository) Update(ctx context.Context, schedule *domain.THRSchedule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := index(r.rows, func(s *domain.THRSchedule) bool { return s.ID == schedule.ID })
+	if i < 0 {
+		return gorm.ErrRecordNotFound
+	}
+	touch(&schedule.CreatedAt, &schedule.UpdatedAt)
+	r.rows[i] = *schedule
+	return nil
+}
+
+// FindByYearAndReligion implements domain.THRScheduleRepository.
+func (r *THRScheduleRepository) FindByYearAndReligion(ctx context.Context, year int, religion string) (*domain.THRSchedule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	i := index(r.rows, func(s *domain.THRSchedule) bool { return s.Year == year && s.Religion == religion })
+	if i < 0 {
+		return nil, nil
+	}
+	schedule := r.rows[i]
+	return &schedule, nil
+}
+
+// FindByYear implements domain.THRScheduleRepository.
+func (r *THRScheduleRepository) FindByYear(ctx context.Context, year int) ([]domain.THRSchedule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	schedules := filter(r.rows, func(s *domain.THRSchedule) bool { return s.Year == year })
+	return sortBy(schedules, func(a, b *domain.THRSchedule) bool { return a.PayoutDate.Before(b.PayoutDate) }), nil
+}
+
+// snapshot implements transactional.
+func (r *THRScheduleRepository) snapshot() func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rows, nextID := slices.Clone(r.rows), r.nextID
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.rows, r.nextID = rows, nextID
+	}
+}
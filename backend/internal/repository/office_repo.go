@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"hr-payroll/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// OfficeGormRepository implements domain.OfficeRepository
+type OfficeGormRepository struct {
+	DB *gorm.DB
+}
+
+func NewOfficeGormRepository(db *gorm.DB) domain.OfficeRepository {
+	return &OfficeGormRepository{DB: db}
+}
+
+// Save implements domain.OfficeRepository.
+func (r *OfficeGormRepository) Save(ctx context.Context, office *domain.Office) error {
+	return withContext(ctx, r.DB).Create(office).Error
+}
+
+// Update implements domain.OfficeRepository.
+func (r *OfficeGormRepository) Update(ctx context.Context, office *domain.Office) error {
+	return withContext(ctx, r.DB).Save(office).Error
+}
+
+// FindByID implements domain.OfficeRepository.
+func (r *OfficeGormRepository) FindByID(ctx context.Context, id uint) (*domain.Office, error) {
+	var office domain.Office
+	err := withContext(ctx, r.DB).First(&office, id).Error
+	return &office, err
+}
+
+// FindAll implements domain.OfficeRepository.
+func (r *OfficeGormRepository) FindAll(ctx context.Context) ([]domain.Office, error) {
+	var offices []domain.Office
+	err := withContext(ctx, r.DB).Order("id").Find(&offices).Error
+	return offices, err
+}
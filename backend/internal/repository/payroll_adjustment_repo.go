@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"hr-payroll/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PayrollAdjustmentGormRepository implements domain.PayrollAdjustmentRepository
+type PayrollAdjustmentGormRepository struct {
+	DB *gorm.DB
+}
+
+func NewPayrollAdjustmentGormRepository(db *gorm.DB) domain.PayrollAdjustmentRepository {
+	return &PayrollAdjustmentGormRepository{DB: db}
+}
+
+// Save implements domain.PayrollAdjustmentRepository.
+func (r *PayrollAdjustmentGormRepository) Save(ctx context.Context, adjustment *domain.PayrollAdjustment) error {
+	return withContext(ctx, r.DB).Create(adjustment).Error
+}
+
+// Update implements domain.PayrollAdjustmentRepository.
+func (r *PayrollAdjustmentGormRepository) Update(ctx context.Context, adjustment *domain.PayrollAdjustment) error {
+	return withContext(ctx, r.DB).Save(adjustment).Error
+}
+
+// FindByID implements domain.PayrollAdjustmentRepository.
+func (r *PayrollAdjustmentGormRepository) FindByID(ctx context.Context, id uint) (*domain.PayrollAdjustment, error) {
+	var adjustment domain.PayrollAdjustment
+	err := withContext(ctx, r.DB).First(&adjustment, id).Error
+	return &adjustment, err
+}
+
+// FindAll implements domain.PayrollAdjustmentRepository.
+func (r *PayrollAdjustmentGormRepository) FindAll(ctx context.Context, employeeID uint, period time.Time, status string) ([]domain.PayrollAdjustment, error) {
+	var adjustments []domain.PayrollAdjustment
+	query := withContext(ctx, r.DB).Order("period DESC, id")
+	if employeeID != 0 {
+		query = query.Where("employee_id = ?", employeeID)
+	}
+	if !period.IsZero() {
+		query = query.Where("period = ?", period)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Find(&adjustments).Error
+	return adjustments, err
+}
+
+// FindPayable implements domain.PayrollAdjustmentRepository.
+func (r *PayrollAdjustmentGormRepository) FindPayable(ctx context.Context, employeeID uint, period time.Time, payrollID uint) ([]domain.PayrollAdjustment, error) {
+	var adjustments []domain.PayrollAdjustment
+	err := withContext(ctx, r.DB).Where("employee_id = ? AND period = ? AND status = ? AND (payroll_id IS NULL OR payroll_id = ?)", employeeID, period, domain.AdjustmentPending, payrollID).
+		Order("id").Find(&adjustments).Error
+	return adjustments, err
+}
+
+// FindByPayroll implements domain.PayrollAdjustmentRepository.
+func (r *PayrollAdjustmentGormRepository) FindByPayroll(ctx context.Context, payrollID uint) ([]domain.PayrollAdjustment, error) {
+	var adjustments []domain.PayrollAdjustment
+	err := withContext(ctx, r.DB).Where("payroll_id = ?", payrollID).Find(&adjustments).Error
+	return adjustments, err
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"errors"
 	"hr-payroll/internal/domain"
 	"time"
@@ -18,15 +19,49 @@ func NewPayrollGormRepository(db *gorm.DB) domain.PayrollRepository {
 }
 
 // Save implements domain.PayrollRepository.
-func (r *PayrollGormRepository) Save(payroll *domain.Payroll) error {
-	return r.DB.Create(payroll).Error
+func (r *PayrollGormRepository) Save(ctx context.Context, payroll *domain.Payroll) error {
+	return withContext(ctx, r.DB).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(payroll).Error; err != nil {
+			return err
+		}
+		return writeAudit(tx, domain.AuditEntityPayroll, payroll.ID, nil, payroll)
+	})
+}
+
+// Update implements domain.PayrollRepository.
+// Baris item slip diganti seluruhnya dengan payroll.Items.
+func (r *PayrollGormRepository) Update(ctx context.Context, payroll *domain.Payroll) error {
+	return withContext(ctx, r.DB).Transaction(func(tx *gorm.DB) error {
+		var before domain.Payroll
+		if err := tx.Preload("Items").First(&before, payroll.ID).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("payroll_id = ?", payroll.ID).Delete(&domain.PayrollItem{}).Error; err != nil {
+			return err
+		}
+		for i := range payroll.Items {
+			payroll.Items[i].ID = 0
+			payroll.Items[i].PayrollID = payroll.ID
+		}
+		if err := tx.Session(&gorm.Session{FullSaveAssociations: true}).Save(payroll).Error; err != nil {
+			return err
+		}
+		return writeAudit(tx, domain.AuditEntityPayroll, payroll.ID, &before, payroll)
+	})
 }
 
 // FindByEmployeeAndPeriod implements domain.PayrollRepository.
-func (r *PayrollGormRepository) FindByEmployeeAndPeriod(employeeID uint, period time.Time) (*domain.Payroll, error) {
+func (r *PayrollGormRepository) FindByEmployeeAndPeriod(ctx context.Context, employeeID uint, period time.Time) (*domain.Payroll, error) {
+	return r.FindByEmployeePeriodAndType(ctx, employeeID, period, domain.PayrollTypeRegular)
+}
+
+// FindByEmployeePeriodAndType implements domain.PayrollRepository.
+func (r *PayrollGormRepository) FindByEmployeePeriodAndType(ctx context.Context, employeeID uint, period time.Time, payrollType string) (*domain.Payroll, error) {
 	var payroll domain.Payroll
 	// GORM query to check for existing payroll based on unique constraint
-	err := r.DB.Where("employee_id = ? AND period = ?", employeeID, period).First(&payroll).Error
+	err := withContext(ctx, r.DB).Preload("Items").
+		Where("employee_id = ? AND period = ? AND type = ? AND status <> ?", employeeID, period, payrollType, domain.PayrollStatusVoid).
+		First(&payroll).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -36,17 +71,47 @@ func (r *PayrollGormRepository) FindByEmployeeAndPeriod(employeeID uint, period
 	return &payroll, nil
 }
 
+// FindByEmployee implements domain.PayrollRepository.
+func (r *PayrollGormRepository) FindByEmployee(ctx context.Context, employeeID uint) ([]domain.Payroll, error) {
+	var payrolls []domain.Payroll
+	err := withContext(ctx, r.DB).Preload("Items").
+		Where("employee_id = ? AND type = ? AND status <> ?", employeeID, domain.PayrollTypeRegular, domain.PayrollStatusVoid).
+		Order("period").Find(&payrolls).Error
+	return payrolls, err
+}
+
+// FindByPeriod implements domain.PayrollRepository.
+// Semua slip (tidak VOID) yang periodenya jatuh di bulan yang sama dengan period.
+func (r *PayrollGormRepository) FindByPeriod(ctx context.Context, period time.Time) ([]domain.Payroll, error) {
+	var payrolls []domain.Payroll
+	monthStart := time.Date(period.Year(), period.Month(), 1, 0, 0, 0, 0, time.UTC)
+	err := withContext(ctx, r.DB).Preload("Items").
+		Where("period >= ? AND period < ? AND type = ? AND status <> ?", monthStart, monthStart.AddDate(0, 1, 0), domain.PayrollTypeRegular, domain.PayrollStatusVoid).
+		Order("employee_id").Find(&payrolls).Error
+	return payrolls, err
+}
+
 // FindAll implements domain.PayrollRepository.
-func (r *PayrollGormRepository) FindAll() ([]domain.Payroll, error) {
+func (r *PayrollGormRepository) FindAll(ctx context.Context) ([]domain.Payroll, error) {
+	var payrolls []domain.Payroll
+	err := withContext(ctx, r.DB).Preload("Items").Where("status <> ?", domain.PayrollStatusVoid).Find(&payrolls).Error
+	return payrolls, err
+}
+
+// FindPaidByYear implements domain.PayrollRepository.
+func (r *PayrollGormRepository) FindPaidByYear(ctx context.Context, year int) ([]domain.Payroll, error) {
 	var payrolls []domain.Payroll
-	err := r.DB.Find(&payrolls).Error
+	yearStart := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+	err := withContext(ctx, r.DB).Preload("Items").
+		Where("period >= ? AND period < ? AND status = ?", yearStart, yearStart.AddDate(1, 0, 0), domain.PayrollStatusPaid).
+		Order("employee_id, period").Find(&payrolls).Error
 	return payrolls, err
 }
 
 // FindByID implements domain.PayrollRepository.
-func (r *PayrollGormRepository) FindByID(id uint) (*domain.Payroll, error) {
+func (r *PayrollGormRepository) FindByID(ctx context.Context, id uint) (*domain.Payroll, error) {
 	var payroll domain.Payroll
-	err := r.DB.First(&payroll, id).Error
+	err := withContext(ctx, r.DB).Preload("Items").First(&payroll, id).Error
 	if err != nil {
 		return nil, err
 	}
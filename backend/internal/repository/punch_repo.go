@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"hr-payroll/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PunchGormRepository implements domain.PunchRepository
+type PunchGormRepository struct {
+	DB *gorm.DB
+}
+
+func NewPunchGormRepository(db *gorm.DB) domain.PunchRepository {
+	return &PunchGormRepository{DB: db}
+}
+
+// Save implements domain.PunchRepository.
+func (r *PunchGormRepository) Save(ctx context.Context, punch *domain.Punch) error {
+	return withContext(ctx, r.DB).Create(punch).Error
+}
+
+// UpdateReview implements domain.PunchRepository.
+func (r *PunchGormRepository) UpdateReview(ctx context.Context, punch *domain.Punch) error {
+	return withContext(ctx, r.DB).Model(punch).Select("review_status", "reviewed_by", "review_note", "reviewed_at").Updates(punch).Error
+}
+
+// FindByEmployeeAndDate implements domain.PunchRepository.
+// date adalah awal hari; hasil diurutkan berdasarkan waktu punch.
+func (r *PunchGormRepository) FindByEmployeeAndDate(ctx context.Context, employeeID uint, date time.Time) ([]domain.Punch, error) {
+	var punches []domain.Punch
+	err := withContext(ctx, r.DB).Where("employee_id = ? AND timestamp >= ? AND timestamp < ?", employeeID, date, date.AddDate(0, 0, 1)).
+		Order("timestamp").Find(&punches).Error
+	return punches, err
+}
+
+// FindByPeriod implements domain.PunchRepository.
+func (r *PunchGormRepository) FindByPeriod(ctx context.Context, employeeID uint, dateFrom time.Time, dateTo time.Time) ([]domain.Punch, error) {
+	var punches []domain.Punch
+	err := withContext(ctx, r.DB).Where("employee_id = ? AND timestamp >= ? AND timestamp < ?", employeeID, dateFrom, dateTo.AddDate(0, 0, 1)).
+		Order("timestamp").Find(&punches).Error
+	return punches, err
+}
+
+// FindByID implements domain.PunchRepository.
+func (r *PunchGormRepository) FindByID(ctx context.Context, id uint) (*domain.Punch, error) {
+	var punch domain.Punch
+	err := withContext(ctx, r.DB).First(&punch, id).Error
+	return &punch, err
+}
+
+// FindFlagged implements domain.PunchRepository.
+func (r *PunchGormRepository) FindFlagged(ctx context.Context, dateFrom time.Time, dateTo time.Time) ([]domain.Punch, error) {
+	var punches []domain.Punch
+	err := withContext(ctx, r.DB).Where("flagged = ? AND timestamp >= ? AND timestamp < ?", true, dateFrom, dateTo.AddDate(0, 0, 1)).
+		Order("timestamp").Find(&punches).Error
+	return punches, err
+}
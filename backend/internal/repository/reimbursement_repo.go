@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"hr-payroll/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ReimbursementGormRepository implements domain.ReimbursementRepository
+type ReimbursementGormRepository struct {
+	DB *gorm.DB
+}
+
+func NewReimbursementGormRepository(db *gorm.DB) domain.ReimbursementRepository {
+	return &ReimbursementGormRepository{DB: db}
+}
+
+// SaveCategory implements domain.ReimbursementRepository.
+func (r *ReimbursementGormRepository) SaveCategory(ctx context.Context, category *domain.ReimbursementCategory) error {
+	return withContext(ctx, r.DB).Create(category).Error
+}
+
+// UpdateCategory implements domain.ReimbursementRepository.
+func (r *ReimbursementGormRepository) UpdateCategory(ctx context.Context, category *domain.ReimbursementCategory) error {
+	return withContext(ctx, r.DB).Save(category).Error
+}
+
+// FindCategoryByCode implements domain.ReimbursementRepository.
+func (r *ReimbursementGormRepository) FindCategoryByCode(ctx context.Context, code string) (*domain.ReimbursementCategory, error) {
+	var category domain.ReimbursementCategory
+	err := withContext(ctx, r.DB).Where("code = ?", code).First(&category).Error
+	return &category, err
+}
+
+// FindCategories implements domain.ReimbursementRepository.
+func (r *ReimbursementGormRepository) FindCategories(ctx context.Context) ([]domain.ReimbursementCategory, error) {
+	var categories []domain.ReimbursementCategory
+	err := withContext(ctx, r.DB).Order("code").Find(&categories).Error
+	return categories, err
+}
+
+// Save implements domain.ReimbursementRepository.
+func (r *ReimbursementGormRepository) Save(ctx context.Context, claim *domain.ReimbursementClaim) error {
+	return withContext(ctx, r.DB).Create(claim).Error
+}
+
+// Update implements domain.ReimbursementRepository.
+func (r *ReimbursementGormRepository) Update(ctx context.Context, claim *domain.ReimbursementClaim) error {
+	return withContext(ctx, r.DB).Save(claim).Error
+}
+
+// FindByID implements domain.ReimbursementRepository.
+func (r *ReimbursementGormRepository) FindByID(ctx context.Context, id uint) (*domain.ReimbursementClaim, error) {
+	var claim domain.ReimbursementClaim
+	err := withContext(ctx, r.DB).First(&claim, id).Error
+	return &claim, err
+}
+
+// FindAll implements domain.ReimbursementRepository.
+func (r *ReimbursementGormRepository) FindAll(ctx context.Context, employeeID uint, status string) ([]domain.ReimbursementClaim, error) {
+	var claims []domain.ReimbursementClaim
+	query := withContext(ctx, r.DB).Order("created_at DESC")
+	if employeeID != 0 {
+		query = query.Where("employee_id = ?", employeeID)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Find(&claims).Error
+	return claims, err
+}
+
+// SumClaimed implements domain.ReimbursementRepository.
+func (r *ReimbursementGormRepository) SumClaimed(ctx context.Context, employeeID uint, categoryCode string, year int, excludeID uint) (float64, error) {
+	var total float64
+	yearStart := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+	err := withContext(ctx, r.DB).Model(&domain.ReimbursementClaim{}).
+		Where("employee_id = ? AND category_code = ? AND status <> ? AND id <> ?", employeeID, categoryCode, domain.ReimbursementRejected, excludeID).
+		Where("expense_date >= ? AND expense_date < ?", yearStart, yearStart.AddDate(1, 0, 0)).
+		Select("COALESCE(SUM(amount), 0)").Scan(&total).Error
+	return total, err
+}
+
+// FindPayable implements domain.ReimbursementRepository.
+func (r *ReimbursementGormRepository) FindPayable(ctx context.Context, employeeID uint, payrollID uint) ([]domain.ReimbursementClaim, error) {
+	var claims []domain.ReimbursementClaim
+	err := withContext(ctx, r.DB).Where("employee_id = ? AND status = ? AND (payroll_id IS NULL OR payroll_id = ?)", employeeID, domain.ReimbursementApproved, payrollID).
+		Order("expense_date, id").Find(&claims).Error
+	return claims, err
+}
+
+// FindByPayroll implements domain.ReimbursementRepository.
+func (r *ReimbursementGormRepository) FindByPayroll(ctx context.Context, payrollID uint) ([]domain.ReimbursementClaim, error) {
+	var claims []domain.ReimbursementClaim
+	err := withContext(ctx, r.DB).Where("payroll_id = ?", payrollID).Find(&claims).Error
+	return claims, err
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"hr-payroll/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// SalaryHistoryGormRepository implements domain.SalaryHistoryRepository
+type SalaryHistoryGormRepository struct {
+	DB *gorm.DB
+}
+
+func NewSalaryHistoryGormRepository(db *gorm.DB) domain.SalaryHistoryRepository {
+	return &SalaryHistoryGormRepository{DB: db}
+}
+
+// Save implements domain.SalaryHistoryRepository.
+func (r *SalaryHistoryGormRepository) Save(ctx context.Context, history *domain.SalaryHistory) error {
+	return withContext(ctx, r.DB).Create(history).Error
+}
+
+// FindByEmployee implements domain.SalaryHistoryRepository.
+// Hasil diurutkan dari effective_from paling lama; untuk tanggal yang sama, input terakhir di belakang.
+func (r *SalaryHistoryGormRepository) FindByEmployee(ctx context.Context, employeeID uint) ([]domain.SalaryHistory, error) {
+	var histories []domain.SalaryHistory
+	err := withContext(ctx, r.DB).Where("employee_id = ?", employeeID).Order("effective_from, id").Find(&histories).Error
+	return histories, err
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"hr-payroll/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// THRScheduleGormRepository implements domain.THRScheduleRepository
+type THRScheduleGormRepository struct {
+	DB *gorm.DB
+}
+
+func NewTHRScheduleGormRepository(db *gorm.DB) domain.THRScheduleRepository {
+	return &THRScheduleGormRepository{DB: db}
+}
+
+// Save implements domain.THRScheduleRepository.
+func (r *THRScheduleGormRepository) Save(ctx context.Context, schedule *domain.THRSchedule) error {
+	return withContext(ctx, r.DB).Create(schedule).Error
+}
+
+// Update implements domain.THRScheduleRepository.
+func (r *THRScheduleGormRepository) Update(ctx context.Context, schedule *domain.THRSchedule) error {
+	return withContext(ctx, r.DB).Save(schedule).Error
+}
+
+// FindByYearAndReligion implements domain.THRScheduleRepository.
+func (r *THRScheduleGormRepository) FindByYearAndReligion(ctx context.Context, year int, religion string) (*domain.THRSchedule, error) {
+	var schedule domain.THRSchedule
+	err := withContext(ctx, r.DB).Where("year = ? AND religion = ?", year, religion).First(&schedule).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+// FindByYear implements domain.THRScheduleRepository.
+func (r *THRScheduleGormRepository) FindByYear(ctx context.Context, year int) ([]domain.THRSchedule, error) {
+	var schedules []domain.THRSchedule
+	err := withContext(ctx, r.DB).Where("year = ?", year).Order("payout_date").Find(&schedules).Error
+	return schedules, err
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hr-payroll/internal/domain"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// SQLSTATE Postgres yang aman untuk diulang: serialization_failure dan deadlock_detected
+var retryableSQLStates = map[string]bool{
+	"40001": true,
+	"40P01": true,
+}
+
+// txRetryBackoff adalah jeda dasar sebelum percobaan ulang (dikalikan nomor percobaan)
+const txRetryBackoff = 20 * time.Millisecond
+
+type txContextKey struct{}
+
+// TxManagerConfig mengatur isolasi dan jumlah percobaan ulang transaksi
+type TxManagerConfig struct {
+	Isolation  sql.IsolationLevel
+	MaxRetries int // Percobaan ulang setelah percobaan pertama gagal karena konflik serialisasi
+}
+
+// GormTxManager implements domain.TxManager
+type GormTxManager struct {
+	DB     *gorm.DB
+	Config TxManagerConfig
+}
+
+func NewGormTxManager(db *gorm.DB, cfg TxManagerConfig) domain.TxManager {
+	return &GormTxManager{DB: db, Config: cfg}
+}
+
+// WithinTransaction implements domain.TxManager.
+func (m *GormTxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txContextKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := m.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return fn(context.WithValue(ctx, txContextKey{}, tx))
+		}, m.txOptions())
+		if err == nil || !isRetryableTxError(err) || attempt >= m.Config.MaxRetries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(attempt+1) * txRetryBackoff):
+		}
+	}
+}
+
+// txOptions mengembalikan level isolasi transaksi; SQLite selalu serializable sehingga memakai default driver
+func (m *GormTxManager) txOptions() *sql.TxOptions {
+	if m.DB.Dialector.Name() == "sqlite" {
+		return nil
+	}
+	return &sql.TxOptions{Isolation: m.Config.Isolation}
+}
+
+// withContext mengembalikan transaksi aktif dari ctx (dari TxManager) atau db biasa, terikat ke ctx
+func withContext(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txContextKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+// isRetryableTxError hanya mengenali konflik Postgres; di SQLite penulis lain ditunggu lewat busy timeout
+func isRetryableTxError(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && retryableSQLStates[pgErr.Code]
+}
+
+// ParseIsolationLevel membaca nama level isolasi dari konfigurasi (SERIALIZABLE, REPEATABLE_READ, READ_COMMITTED)
+func ParseIsolationLevel(name string) (sql.IsolationLevel, error) {
+	switch strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(name), " ", "_")) {
+	case "SERIALIZABLE":
+		return sql.LevelSerializable, nil
+	case "REPEATABLE_READ":
+		return sql.LevelRepeatableRead, nil
+	case "READ_COMMITTED":
+		return sql.LevelReadCommitted, nil
+	default:
+		return sql.LevelDefault, fmt.Errorf("unknown transaction isolation level %q", name)
+	}
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"hr-payroll/internal/domain"
+	"time"
+)
+
+// AbsenceMarkingServiceImpl mengimplementasikan domain.AbsenceMarkingService
+type AbsenceMarkingServiceImpl struct {
+	AttService  domain.AttendanceService
+	AttRepo     domain.AttendanceRepository
+	EmpRepo     domain.EmployeeRepository
+	HolidayRepo domain.HolidayRepository
+}
+
+func NewAbsenceMarkingServiceImpl(as domain.AttendanceService, ar domain.AttendanceRepository, er domain.EmployeeRepository, hr domain.HolidayRepository) domain.AbsenceMarkingService {
+	return &AbsenceMarkingServiceImpl{AttService: as, AttRepo: ar, EmpRepo: er, HolidayRepo: hr}
+}
+
+// MarkAbsences implements domain.AbsenceMarkingService
+func (s *AbsenceMarkingServiceImpl) MarkAbsences(ctx context.Context, dateFrom time.Time, dateTo time.Time) (*domain.AbsenceMarkingResult, error) {
+	// 1. Hanya hari yang sudah lewat (hari ini belum selesai)
+	dateFrom, dateTo = truncateToDay(dateFrom), truncateToDay(dateTo)
+	yesterday := truncateToDay(time.Now()).AddDate(0, 0, -1)
+	if dateTo.After(yesterday) {
+		dateTo = yesterday
+	}
+	if dateTo.Before(dateFrom) {
+		return nil, errors.New("date range must contain at least one past day")
+	}
+
+	result := &domain.AbsenceMarkingResult{From: dateFrom, To: dateTo, Records: []domain.Attendance{}}
+
+	// 2. Hari kerja dalam rentang
+	holidays, err := s.HolidayRepo.FindByPeriod(ctx, dateFrom, dateTo)
+	if err != nil {
+		return nil, err
+	}
+	holidayDays := holidaySet(holidays)
+	var workingDays []time.Time
+	for d := dateFrom; !d.After(dateTo); d = d.AddDate(0, 0, 1) {
+		if isWorkingDay(d, holidayDays) {
+			workingDays = append(workingDays, d)
+		}
+	}
+	result.WorkingDays = len(workingDays)
+	if len(workingDays) == 0 {
+		return result, nil
+	}
+
+	employees, err := s.EmpRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, emp := range employees {
+		// 3. Hari yang sudah punya record (PRESENT, ABSENT, LEAVE) dilewati
+		attendances, err := s.AttRepo.FindByPeriod(ctx, emp.ID, dateFrom, dateTo)
+		if err != nil {
+			return nil, err
+		}
+		recorded := make(map[time.Time]bool, len(attendances))
+		for _, att := range attendances {
+			recorded[truncateToDay(att.Date)] = true
+		}
+
+		for _, day := range workingDays {
+			if !activeOn(&emp, day) {
+				continue
+			}
+			result.Checked++
+			if recorded[day] {
+				continue
+			}
+
+			// 4. Tulis lewat AttendanceService; jika record muncul bersamaan (unique idx_employee_date), lewati
+			att := &domain.Attendance{EmployeeID: emp.ID, Date: day, Status: domain.AttendanceStatusAbsent}
+			if _, err := s.AttService.RecordAttendance(ctx, att); err != nil {
+				// Periode yang sudah dikunci tidak diubah lagi
+				if errors.Is(err, domain.ErrAttendancePeriodLocked) || errors.Is(err, domain.ErrPayrollPeriodLocked) {
+					continue
+				}
+				if existing, _ := s.AttRepo.FindByEmployeeAndDate(ctx, emp.ID, day); existing != nil {
+					continue
+				}
+				return nil, err
+			}
+			result.Created++
+			result.Records = append(result.Records, *att)
+		}
+	}
+	return result, nil
+}
+
+// activeOn: karyawan sudah masuk dan belum resign pada tanggal tersebut.
+// Tanpa join_date, tanggal data karyawan dibuat dipakai sebagai tanggal masuk agar hari sebelumnya tidak ditandai ABSENT.
+func activeOn(emp *domain.Employee, day time.Time) bool {
+	joined := emp.CreatedAt
+	if emp.JoinDate != nil {
+		joined = *emp.JoinDate
+	}
+	if !joined.IsZero() && truncateToDay(joined).After(day) {
+		return false
+	}
+	if emp.ResignDate != nil && truncateToDay(*emp.ResignDate).Before(day) {
+		return false
+	}
+	return true
+}
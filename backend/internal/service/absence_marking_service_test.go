@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"hr-payroll/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestActiveOn(t *testing.T) {
+	joinDate := day(2025, 11, 12)
+	resignDate := day(2025, 11, 13)
+	tests := []struct {
+		name string
+		emp  domain.Employee
+		day  time.Time
+		want bool
+	}{
+		{name: "before join date", emp: domain.Employee{JoinDate: &joinDate}, day: day(2025, 11, 11), want: false},
+		{name: "on join date", emp: domain.Employee{JoinDate: &joinDate}, day: day(2025, 11, 12), want: true},
+		{name: "after resign date", emp: domain.Employee{JoinDate: &joinDate, ResignDate: &resignDate}, day: day(2025, 11, 14), want: false},
+		{name: "join date wins over created at", emp: domain.Employee{JoinDate: &joinDate, CreatedAt: day(2025, 11, 20)}, day: day(2025, 11, 12), want: true},
+		{name: "without join date, before created at", emp: domain.Employee{CreatedAt: day(2025, 11, 12).Add(15 * time.Hour)}, day: day(2025, 11, 11), want: false},
+		{name: "without join date, on created at", emp: domain.Employee{CreatedAt: day(2025, 11, 12).Add(15 * time.Hour)}, day: day(2025, 11, 12), want: true},
+		{name: "without any date", emp: domain.Employee{}, day: day(2025, 11, 11), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := activeOn(&tt.emp, tt.day); got != tt.want {
+				t.Errorf("activeOn() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkAbsencesWithoutJoinDate(t *testing.T) {
+	ctx := context.Background()
+	repos := newTestRepos(t)
+	// Data karyawan dibuat Rabu tanpa join_date: Senin dan Selasa sebelumnya tidak ditandai ABSENT
+	emp := repos.saveEmployee(t, domain.Employee{Name: "Budi", BaseSalary: 4400000, CreatedAt: day(2025, 11, 12).Add(9 * time.Hour)})
+
+	service := NewAbsenceMarkingServiceImpl(repos.attendanceService(), repos.Attendance, repos.Employee, repos.Holiday)
+	result, err := service.MarkAbsences(ctx, day(2025, 11, 10), day(2025, 11, 14))
+	if err != nil {
+		t.Fatalf("MarkAbsences() error = %v", err)
+	}
+	if result.Created != 3 {
+		t.Errorf("created = %d, want 3 (12-14 November)", result.Created)
+	}
+	for _, date := range []time.Time{day(2025, 11, 10), day(2025, 11, 11)} {
+		if att, _ := repos.Attendance.FindByEmployeeAndDate(ctx, emp.ID, date); att != nil {
+			t.Errorf("attendance on %s = %+v, want none before the employee was created", date.Format("2006-01-02"), att)
+		}
+	}
+}
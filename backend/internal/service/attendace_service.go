@@ -1,55 +1,110 @@
 package service
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"hr-payroll/internal/domain"
+	"sort"
 	"time"
 )
 
+// AttendanceConfig menampung pengaturan absensi
+type AttendanceConfig struct {
+	PairingRule string // domain.PairingFirstInLastOut atau domain.PairingSessions
+}
+
 type AttendanceServiceImpl struct {
-	Repo domain.AttendanceRepository
+	Repo       domain.AttendanceRepository
+	PunchRepo  domain.PunchRepository
+	StatusRepo domain.AttendanceStatusRepository
+	PeriodRepo domain.AttendancePeriodRepository
+	PayRepo    domain.PayrollRepository
+	Tx         domain.TxManager
+	Config     AttendanceConfig
 }
 
-func NewAttendanceServiceImpl(repo domain.AttendanceRepository) domain.AttendanceService {
-	return &AttendanceServiceImpl{Repo: repo}
+func NewAttendanceServiceImpl(repo domain.AttendanceRepository, punchRepo domain.PunchRepository, statusRepo domain.AttendanceStatusRepository, periodRepo domain.AttendancePeriodRepository, payRepo domain.PayrollRepository, tx domain.TxManager, cfg AttendanceConfig) domain.AttendanceService {
+	if cfg.PairingRule == "" {
+		cfg.PairingRule = domain.PairingFirstInLastOut
+	}
+	return &AttendanceServiceImpl{Repo: repo, PunchRepo: punchRepo, StatusRepo: statusRepo, PeriodRepo: periodRepo, PayRepo: payRepo, Tx: tx, Config: cfg}
 }
 
-// RecordAttendance implements domain.AttendanceService
-func (s *AttendanceServiceImpl) RecordAttendance(att *domain.Attendance) (*domain.Attendance, error) {
+// ValidateAttendance implements domain.AttendanceService
+func (s *AttendanceServiceImpl) ValidateAttendance(ctx context.Context, att *domain.Attendance) error {
+	// 0. Periode yang terkunci (ditutup atau payroll sudah dibayar) tidak bisa diubah
+	if err := s.ensurePeriodOpen(ctx, att.EmployeeID, att.Date); err != nil {
+		return err
+	}
+
 	// 1. Cek apakah sudah ada absensi untuk employee dan tanggal ini
-	existingAtt, _ := s.Repo.FindByEmployeeAndDate(att.EmployeeID, att.Date)
+	existingAtt, _ := s.Repo.FindByEmployeeAndDate(ctx, att.EmployeeID, att.Date)
 
 	if existingAtt != nil && existingAtt.ID != 0 {
-		return nil, errors.New("attendance already recorded for this employee on this date")
+		return errors.New("attendance already recorded for this employee on this date")
 	}
 
-	// Record does not exist. This is a new attendance record (check-in, absent, or leave).
-	// 2. Validasi: Status valid
-	if att.Status != "PRESENT" && att.Status != "ABSENT" && att.Status != "LEAVE" {
-		return nil, errors.New("invalid attendance status: must be PRESENT, ABSENT, or LEAVE")
+	// Record does not exist. This is a new attendance record.
+	// 2. Validasi: Status harus ada dan aktif di katalog
+	status, err := activeStatus(ctx, s.StatusRepo, att.Status)
+	if err != nil {
+		return err
 	}
 
-	// 3. Validasi: Jika status PRESENT, waktu_datang wajib diisi
-	if att.Status == "PRESENT" {
-		if att.CheckIn == nil {
-			return nil, errors.New("check-in time is mandatory for PRESENT status")
-		}
+	// 3. Validasi aturan status: check-in wajib (mis. PRESENT), dokumen wajib (mis. SICK)
+	return validateStatusRules(status, att)
+}
+
+// RecordAttendance implements domain.AttendanceService.
+// Ringkasan harian dan punch-nya disimpan dalam satu transaksi, sehingga punch yang gagal tidak meninggalkan ringkasan tanpa log punch.
+func (s *AttendanceServiceImpl) RecordAttendance(ctx context.Context, att *domain.Attendance) (*domain.Attendance, error) {
+	return inTransaction(ctx, s.Tx, func(ctx context.Context) (*domain.Attendance, error) {
+		return s.recordAttendance(ctx, att)
+	})
+}
+
+func (s *AttendanceServiceImpl) recordAttendance(ctx context.Context, att *domain.Attendance) (*domain.Attendance, error) {
+	if err := s.ValidateAttendance(ctx, att); err != nil {
+		return nil, err
 	}
 
 	// Simpan ke repository
-	if err := s.Repo.Save(att); err != nil {
+	if err := s.Repo.Save(ctx, att); err != nil {
 		return nil, err
 	}
-	return att, nil
+
+	// Catat juga sebagai punch agar ringkasan harian bisa dihitung ulang dari log punch
+	var punches []domain.Punch
+	if att.CheckIn != nil {
+		punches = append(punches, domain.Punch{Timestamp: *att.CheckIn, Direction: domain.PunchIn, Source: domain.PunchSourceManual})
+	}
+	if att.CheckOut != nil {
+		punches = append(punches, domain.Punch{Timestamp: *att.CheckOut, Direction: domain.PunchOut, Source: domain.PunchSourceManual})
+	}
+	if len(punches) == 0 {
+		return att, nil
+	}
+	return s.RecordPunches(ctx, att.EmployeeID, att.Date, punches)
+}
+
+// RecordCheckout implements domain.AttendanceService.
+// Jam pulang dan punch OUT disimpan dalam satu transaksi.
+func (s *AttendanceServiceImpl) RecordCheckout(ctx context.Context, employeeID uint, checkOutTime time.Time) (*domain.Attendance, error) {
+	return inTransaction(ctx, s.Tx, func(ctx context.Context) (*domain.Attendance, error) {
+		return s.recordCheckout(ctx, employeeID, checkOutTime)
+	})
 }
 
-// RecordCheckout implements domain.AttendanceService
-func (s *AttendanceServiceImpl) RecordCheckout(employeeID uint, checkOutTime time.Time) (*domain.Attendance, error) {
+func (s *AttendanceServiceImpl) recordCheckout(ctx context.Context, employeeID uint, checkOutTime time.Time) (*domain.Attendance, error) {
 	// 1. Find today's attendance record for the employee
 	today := time.Now()
 	normalizedDate := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	if err := s.ensurePeriodOpen(ctx, employeeID, normalizedDate); err != nil {
+		return nil, err
+	}
 
-	existingAtt, err := s.Repo.FindByEmployeeAndDate(employeeID, normalizedDate)
+	existingAtt, err := s.Repo.FindByEmployeeAndDate(ctx, employeeID, normalizedDate)
 	if err != nil {
 		return nil, err
 	}
@@ -66,14 +121,195 @@ func (s *AttendanceServiceImpl) RecordCheckout(employeeID uint, checkOutTime tim
 	existingAtt.CheckOut = &checkOutTime
 
 	// 4. Save updated record
-	if err := s.Repo.Update(existingAtt); err != nil {
+	if err := s.Repo.Update(ctx, existingAtt); err != nil {
 		return nil, err
 	}
 
-	return existingAtt, nil
+	// 5. Catat punch OUT dan hitung ulang jam kerja. Record tanpa log punch (dibuat sebelum log punch ada
+	// atau langsung lewat repository) dilengkapi punch IN dari check-in tersimpan agar pairing tidak
+	// menganggap punch OUT sebagai check-in.
+	stored, err := s.PunchRepo.FindByEmployeeAndDate(ctx, employeeID, normalizedDate)
+	if err != nil {
+		return nil, err
+	}
+	var punches []domain.Punch
+	if len(stored) == 0 && existingAtt.CheckIn != nil {
+		punches = append(punches, domain.Punch{Timestamp: *existingAtt.CheckIn, Direction: domain.PunchIn, Source: domain.PunchSourceManual})
+	}
+	punches = append(punches, domain.Punch{Timestamp: checkOutTime, Direction: domain.PunchOut, Source: domain.PunchSourceManual})
+	return s.RecordPunches(ctx, employeeID, normalizedDate, punches)
 }
 
 // Implementasi GetAttendanceByPeriod
-func (s *AttendanceServiceImpl) GetAttendanceByPeriod(employeeID uint, dateFrom time.Time, dateTo time.Time) ([]domain.Attendance, error) {
-	return s.Repo.FindByPeriod(employeeID, dateFrom, dateTo)
+func (s *AttendanceServiceImpl) GetAttendanceByPeriod(ctx context.Context, employeeID uint, dateFrom time.Time, dateTo time.Time) ([]domain.Attendance, error) {
+	return s.Repo.FindByPeriod(ctx, employeeID, dateFrom, dateTo)
+}
+
+// RecordPunch implements domain.AttendanceService
+func (s *AttendanceServiceImpl) RecordPunch(ctx context.Context, punch *domain.Punch) (*domain.Attendance, error) {
+	if punch.Timestamp.IsZero() {
+		punch.Timestamp = time.Now()
+	}
+	if punch.Source == "" {
+		punch.Source = domain.PunchSourceDevice
+	}
+	return s.RecordPunches(ctx, punch.EmployeeID, truncateToDay(punch.Timestamp), []domain.Punch{*punch})
+}
+
+// RecordPunches implements domain.AttendanceService.
+// Punch dengan waktu yang sudah tercatat untuk karyawan yang sama dilewati, sehingga impor ulang aman.
+// Hasilnya nil jika hari itu hanya punya punch yang ditandai dan belum direview.
+func (s *AttendanceServiceImpl) RecordPunches(ctx context.Context, employeeID uint, date time.Time, punches []domain.Punch) (*domain.Attendance, error) {
+	date = truncateToDay(date)
+	if err := s.ensurePeriodOpen(ctx, employeeID, date); err != nil {
+		return nil, err
+	}
+	stored, err := s.PunchRepo.FindByEmployeeAndDate(ctx, employeeID, date)
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. Append punch baru (append-only, tidak ada update/delete)
+	for _, punch := range newPunches(stored, employeeID, date, punches) {
+		if err := validatePunch(&punch); err != nil {
+			return nil, err
+		}
+		if err := s.PunchRepo.Save(ctx, &punch); err != nil {
+			return nil, err
+		}
+	}
+
+	// 2. Hitung ulang ringkasan harian dari seluruh punch
+	return s.recompute(ctx, employeeID, date)
+}
+
+// SummarizePunches menghitung ringkasan harian dari punch tersimpan + punch baru tanpa menyimpan apa pun
+func (s *AttendanceServiceImpl) SummarizePunches(ctx context.Context, employeeID uint, date time.Time, punches []domain.Punch) (*domain.Attendance, error) {
+	date = truncateToDay(date)
+	if err := s.ensurePeriodOpen(ctx, employeeID, date); err != nil {
+		return nil, err
+	}
+	stored, err := s.PunchRepo.FindByEmployeeAndDate(ctx, employeeID, date)
+	if err != nil {
+		return nil, err
+	}
+	for _, punch := range newPunches(stored, employeeID, date, punches) {
+		if err := validatePunch(&punch); err != nil {
+			return nil, err
+		}
+		stored = append(stored, punch)
+	}
+	sort.Slice(stored, func(i, j int) bool { return stored[i].Timestamp.Before(stored[j].Timestamp) })
+
+	existing, err := s.Repo.FindByEmployeeAndDate(ctx, employeeID, date)
+	if err != nil {
+		return nil, err
+	}
+	return s.applySummary(existing, employeeID, date, countedPunches(stored))
+}
+
+// RecomputeAttendance implements domain.AttendanceService
+func (s *AttendanceServiceImpl) RecomputeAttendance(ctx context.Context, employeeID uint, date time.Time) (*domain.Attendance, error) {
+	date = truncateToDay(date)
+	if err := s.ensurePeriodOpen(ctx, employeeID, date); err != nil {
+		return nil, err
+	}
+	return s.recompute(ctx, employeeID, date)
+}
+
+// recompute menghitung ulang dan menyimpan ringkasan harian; pemeriksaan kunci periode dilakukan pemanggil
+func (s *AttendanceServiceImpl) recompute(ctx context.Context, employeeID uint, date time.Time) (*domain.Attendance, error) {
+	punches, err := s.PunchRepo.FindByEmployeeAndDate(ctx, employeeID, date)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.Repo.FindByEmployeeAndDate(ctx, employeeID, date)
+	if err != nil {
+		return nil, err
+	}
+	if len(punches) == 0 {
+		if existing == nil {
+			return nil, errors.New("no punches or attendance recorded for this employee on this date")
+		}
+		return existing, nil
+	}
+	// Punch yang ditandai menunggu review: ringkasan (boleh belum ada) tidak diubah sampai punch disetujui
+	counted := countedPunches(punches)
+	if len(counted) == 0 {
+		return existing, nil
+	}
+
+	att, err := s.applySummary(existing, employeeID, date, counted)
+	if err != nil {
+		return nil, err
+	}
+	if att.ID == 0 {
+		err = s.Repo.Save(ctx, att)
+	} else {
+		err = s.Repo.Update(ctx, att)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return att, nil
+}
+
+// GetPunchesByPeriod implements domain.AttendanceService
+func (s *AttendanceServiceImpl) GetPunchesByPeriod(ctx context.Context, employeeID uint, dateFrom time.Time, dateTo time.Time) ([]domain.Punch, error) {
+	return s.PunchRepo.FindByPeriod(ctx, employeeID, dateFrom, dateTo)
+}
+
+// ensurePeriodOpen menolak penulisan absensi ke periode yang terkunci
+func (s *AttendanceServiceImpl) ensurePeriodOpen(ctx context.Context, employeeID uint, date time.Time) error {
+	return ensureAttendancePeriodOpen(ctx, s.PeriodRepo, s.PayRepo, employeeID, date)
+}
+
+// applySummary mengisi attendance harian (baru atau yang sudah ada) dari hasil pairing punch
+func (s *AttendanceServiceImpl) applySummary(existing *domain.Attendance, employeeID uint, date time.Time, punches []domain.Punch) (*domain.Attendance, error) {
+	summary, err := pairPunches(s.Config.PairingRule, punches)
+	if err != nil {
+		return nil, err
+	}
+
+	att := existing
+	if att == nil {
+		att = &domain.Attendance{EmployeeID: employeeID, Date: date, Status: domain.AttendanceStatusPresent}
+	}
+	// Ada punch berarti hadir; status lain (cuti, WFH, dinas, ...) dibiarkan
+	if att.Status == domain.AttendanceStatusAbsent {
+		att.Status = domain.AttendanceStatusPresent
+	}
+	att.CheckIn = summary.CheckIn
+	att.CheckOut = summary.CheckOut
+	att.WorkedMinutes = summary.WorkedMinutes
+	return att, nil
+}
+
+// newPunches mengembalikan punch yang belum tersimpan (berdasarkan waktu), dinormalkan ke karyawan & tanggal
+func newPunches(stored []domain.Punch, employeeID uint, date time.Time, punches []domain.Punch) []domain.Punch {
+	seen := make(map[time.Time]bool, len(stored)+len(punches))
+	for _, punch := range stored {
+		seen[punch.Timestamp.UTC()] = true
+	}
+
+	var result []domain.Punch
+	for _, punch := range punches {
+		if seen[punch.Timestamp.UTC()] {
+			continue
+		}
+		seen[punch.Timestamp.UTC()] = true
+		punch.ID = 0
+		punch.EmployeeID = employeeID
+		result = append(result, punch)
+	}
+	return result
+}
+
+// validatePunch memastikan arah punch dikenali
+func validatePunch(punch *domain.Punch) error {
+	switch punch.Direction {
+	case domain.PunchIn, domain.PunchOut, domain.PunchUnknown:
+		return nil
+	}
+	return fmt.Errorf("invalid punch direction %q: must be IN, OUT or empty", punch.Direction)
 }
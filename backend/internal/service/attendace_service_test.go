@@ -0,0 +1,253 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"hr-payroll/internal/domain"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecordAttendance(t *testing.T) {
+	date := day(2025, 11, 10)
+	checkIn := date.Add(8 * time.Hour)
+
+	tests := []struct {
+		name    string
+		setup   func(t *testing.T, repos *testRepos, employeeID uint)
+		att     domain.Attendance
+		wantErr string
+	}{
+		{
+			name: "present with check-in",
+			att:  domain.Attendance{Date: date, Status: domain.AttendanceStatusPresent, CheckIn: &checkIn},
+		},
+		{
+			name: "duplicate for the same date",
+			setup: func(t *testing.T, repos *testRepos, employeeID uint) {
+				if err := repos.Attendance.Save(context.Background(), &domain.Attendance{EmployeeID: employeeID, Date: date, Status: domain.AttendanceStatusLeave}); err != nil {
+					t.Fatalf("save attendance: %v", err)
+				}
+			},
+			att:     domain.Attendance{Date: date, Status: domain.AttendanceStatusPresent, CheckIn: &checkIn},
+			wantErr: "attendance already recorded",
+		},
+		{
+			name:    "present without check-in",
+			att:     domain.Attendance{Date: date, Status: domain.AttendanceStatusPresent},
+			wantErr: "check-in time is mandatory",
+		},
+		{
+			name:    "sick without document",
+			att:     domain.Attendance{Date: date, Status: "SICK"},
+			wantErr: "supporting document is required",
+		},
+		{
+			name: "sick with document",
+			att:  domain.Attendance{Date: date, Status: "SICK", DocumentURL: "https://files.example.com/surat-dokter.pdf"},
+		},
+		{
+			name:    "status not in catalogue",
+			att:     domain.Attendance{Date: date, Status: "HOLIDAY"},
+			wantErr: "invalid attendance status",
+		},
+		{
+			name: "locked period",
+			setup: func(t *testing.T, repos *testRepos, employeeID uint) {
+				if err := repos.Period.Save(context.Background(), &domain.AttendancePeriod{Period: day(2025, 11, 1), Status: domain.AttendancePeriodLocked}); err != nil {
+					t.Fatalf("save period: %v", err)
+				}
+			},
+			att:     domain.Attendance{Date: date, Status: domain.AttendanceStatusLeave},
+			wantErr: domain.ErrAttendancePeriodLocked.Error(),
+		},
+		{
+			name: "paid payroll for the month",
+			setup: func(t *testing.T, repos *testRepos, employeeID uint) {
+				if err := repos.Payroll.Save(context.Background(), &domain.Payroll{EmployeeID: employeeID, Period: day(2025, 11, 1), Status: domain.PayrollStatusPaid}); err != nil {
+					t.Fatalf("save payroll: %v", err)
+				}
+			},
+			att:     domain.Attendance{Date: date, Status: domain.AttendanceStatusLeave},
+			wantErr: domain.ErrPayrollPeriodLocked.Error(),
+		},
+		{
+			name: "paid payroll generated with a mid-month date",
+			setup: func(t *testing.T, repos *testRepos, employeeID uint) {
+				ctx := context.Background()
+				service := repos.payrollService()
+				slip, err := service.GenerateMonthlyPayroll(ctx, employeeID, day(2025, 11, 15))
+				if err != nil {
+					t.Fatalf("GenerateMonthlyPayroll() error = %v", err)
+				}
+				if !slip.Period.Equal(day(2025, 11, 1)) {
+					t.Fatalf("slip period = %v, want 2025-11-01", slip.Period)
+				}
+				if _, err := service.MarkPayrollPaid(ctx, slip.ID); err != nil {
+					t.Fatalf("MarkPayrollPaid() error = %v", err)
+				}
+			},
+			att:     domain.Attendance{Date: date, Status: domain.AttendanceStatusLeave},
+			wantErr: domain.ErrPayrollPeriodLocked.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos := newTestRepos(t)
+			emp := repos.saveEmployee(t, domain.Employee{Name: "Budi", BaseSalary: 4400000})
+			if tt.setup != nil {
+				tt.setup(t, repos, emp.ID)
+			}
+
+			tt.att.EmployeeID = emp.ID
+			att, err := repos.attendanceService().RecordAttendance(context.Background(), &tt.att)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("RecordAttendance() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RecordAttendance() error = %v", err)
+			}
+			if att.ID == 0 || att.Status != tt.att.Status {
+				t.Errorf("attendance = %+v, want stored record with status %s", att, tt.att.Status)
+			}
+		})
+	}
+}
+
+func TestRecordCheckout(t *testing.T) {
+	// RecordCheckout selalu memakai tanggal hari ini
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	checkIn := today.Add(8 * time.Hour)
+	checkOut := today.Add(17 * time.Hour)
+
+	tests := []struct {
+		name        string
+		setup       func(t *testing.T, repos *testRepos, employeeID uint)
+		wantErr     string
+		wantMinutes int
+	}{
+		{
+			name:    "no check-in today",
+			wantErr: "no check-in record found for today",
+		},
+		{
+			name: "check-in on another day only",
+			setup: func(t *testing.T, repos *testRepos, employeeID uint) {
+				yesterday := today.AddDate(0, 0, -1)
+				in := yesterday.Add(8 * time.Hour)
+				recordAttendance(t, repos, domain.Attendance{EmployeeID: employeeID, Date: yesterday, Status: domain.AttendanceStatusPresent, CheckIn: &in})
+			},
+			wantErr: "no check-in record found for today",
+		},
+		{
+			name: "already checked out",
+			setup: func(t *testing.T, repos *testRepos, employeeID uint) {
+				out := today.Add(16 * time.Hour)
+				recordAttendance(t, repos, domain.Attendance{EmployeeID: employeeID, Date: today, Status: domain.AttendanceStatusPresent, CheckIn: &checkIn, CheckOut: &out})
+			},
+			wantErr: "already checked out for today",
+		},
+		{
+			name: "checkout after check-in",
+			setup: func(t *testing.T, repos *testRepos, employeeID uint) {
+				recordAttendance(t, repos, domain.Attendance{EmployeeID: employeeID, Date: today, Status: domain.AttendanceStatusPresent, CheckIn: &checkIn})
+			},
+			wantMinutes: 9 * 60,
+		},
+		{
+			name: "check-in without punch log",
+			setup: func(t *testing.T, repos *testRepos, employeeID uint) {
+				if err := repos.Attendance.Save(context.Background(), &domain.Attendance{EmployeeID: employeeID, Date: today, Status: domain.AttendanceStatusPresent, CheckIn: &checkIn}); err != nil {
+					t.Fatalf("save attendance: %v", err)
+				}
+			},
+			wantMinutes: 9 * 60,
+		},
+		{
+			name: "locked period",
+			setup: func(t *testing.T, repos *testRepos, employeeID uint) {
+				recordAttendance(t, repos, domain.Attendance{EmployeeID: employeeID, Date: today, Status: domain.AttendanceStatusPresent, CheckIn: &checkIn})
+				monthStart := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+				if err := repos.Period.Save(context.Background(), &domain.AttendancePeriod{Period: monthStart, Status: domain.AttendancePeriodLocked}); err != nil {
+					t.Fatalf("save period: %v", err)
+				}
+			},
+			wantErr: domain.ErrAttendancePeriodLocked.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos := newTestRepos(t)
+			emp := repos.saveEmployee(t, domain.Employee{Name: "Budi", BaseSalary: 4400000})
+			if tt.setup != nil {
+				tt.setup(t, repos, emp.ID)
+			}
+
+			att, err := repos.attendanceService().RecordCheckout(context.Background(), emp.ID, checkOut)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("RecordCheckout() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RecordCheckout() error = %v", err)
+			}
+			if att.CheckIn == nil || !att.CheckIn.Equal(checkIn) {
+				t.Errorf("CheckIn = %v, want %v", att.CheckIn, checkIn)
+			}
+			if att.CheckOut == nil || !att.CheckOut.Equal(checkOut) {
+				t.Errorf("CheckOut = %v, want %v", att.CheckOut, checkOut)
+			}
+			if att.WorkedMinutes != tt.wantMinutes {
+				t.Errorf("WorkedMinutes = %d, want %d", att.WorkedMinutes, tt.wantMinutes)
+			}
+
+			stored, err := repos.Attendance.FindByEmployeeAndDate(context.Background(), emp.ID, today)
+			if err != nil || stored == nil || stored.CheckOut == nil {
+				t.Errorf("stored attendance = %+v (err %v), want checkout saved", stored, err)
+			}
+		})
+	}
+}
+
+// recordAttendance mencatat absensi lewat service agar punch IN/OUT ikut tersimpan
+func recordAttendance(t *testing.T, repos *testRepos, att domain.Attendance) {
+	t.Helper()
+	if _, err := repos.attendanceService().RecordAttendance(context.Background(), &att); err != nil {
+		t.Fatalf("record attendance: %v", err)
+	}
+}
+
+// failingPunchSave mensimulasikan kegagalan database saat punch disimpan
+type failingPunchSave struct {
+	domain.PunchRepository
+}
+
+func (failingPunchSave) Save(ctx context.Context, punch *domain.Punch) error {
+	return errors.New("disk full")
+}
+
+func TestRecordAttendanceRollsBackWhenPunchFails(t *testing.T) {
+	ctx := context.Background()
+	repos := newTestRepos(t)
+	emp := repos.saveEmployee(t, domain.Employee{Name: "Budi", BaseSalary: 4400000})
+	repos.Punch = failingPunchSave{repos.Punch}
+	date := day(2025, 11, 10)
+	checkIn := date.Add(8 * time.Hour)
+
+	if _, err := repos.attendanceService().RecordAttendance(ctx, &domain.Attendance{EmployeeID: emp.ID, Date: date, Status: domain.AttendanceStatusPresent, CheckIn: &checkIn}); err == nil {
+		t.Fatal("RecordAttendance() error = nil, want the punch error")
+	}
+	// Ringkasan harian tidak boleh tertinggal tanpa log punch
+	if att, _ := repos.Attendance.FindByEmployeeAndDate(ctx, emp.ID, date); att != nil {
+		t.Errorf("stored attendance = %+v, want none", att)
+	}
+}
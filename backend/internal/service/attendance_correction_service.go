@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"hr-payroll/internal/domain"
+	"strings"
+	"time"
+)
+
+// AttendanceCorrectionServiceImpl mengimplementasikan domain.AttendanceCorrectionService
+type AttendanceCorrectionServiceImpl struct {
+	Repo       domain.AttendanceCorrectionRepository
+	AttRepo    domain.AttendanceRepository
+	EmpRepo    domain.EmployeeRepository
+	PayRepo    domain.PayrollRepository
+	StatusRepo domain.AttendanceStatusRepository
+	PeriodRepo domain.AttendancePeriodRepository
+	AttService domain.AttendanceService // Menulis punch koreksi dan menghitung ulang ringkasan harian
+}
+
+func NewAttendanceCorrectionServiceImpl(repo domain.AttendanceCorrectionRepository, ar domain.AttendanceRepository, er domain.EmployeeRepository, pr domain.PayrollRepository, sr domain.AttendanceStatusRepository, apr domain.AttendancePeriodRepository, as domain.AttendanceService) domain.AttendanceCorrectionService {
+	return &AttendanceCorrectionServiceImpl{Repo: repo, AttRepo: ar, EmpRepo: er, PayRepo: pr, StatusRepo: sr, PeriodRepo: apr, AttService: as}
+}
+
+// SubmitCorrection implements domain.AttendanceCorrectionService
+func (s *AttendanceCorrectionServiceImpl) SubmitCorrection(ctx context.Context, correction *domain.AttendanceCorrection) (*domain.AttendanceCorrection, error) {
+	// 1. Validasi isi pengajuan
+	if _, err := s.EmpRepo.FindByID(ctx, correction.EmployeeID); err != nil {
+		return nil, errors.New("employee not found")
+	}
+	if correction.Date.IsZero() {
+		return nil, errors.New("date is required")
+	}
+	correction.Date = truncateToDay(correction.Date)
+	if correction.Date.After(time.Now()) {
+		return nil, errors.New("cannot request a correction for a future date")
+	}
+	if strings.TrimSpace(correction.Reason) == "" {
+		return nil, errors.New("reason is required")
+	}
+	if correction.RequestedStatus == "" && correction.RequestedCheckIn == nil && correction.RequestedCheckOut == nil {
+		return nil, errors.New("nothing to correct: set requested_status, requested_check_in or requested_check_out")
+	}
+	if correction.RequestedStatus != "" {
+		if _, err := activeStatus(ctx, s.StatusRepo, correction.RequestedStatus); err != nil {
+			return nil, err
+		}
+	}
+
+	// 2. Periode yang terkunci (ditutup atau payroll sudah dibayar) tidak bisa dikoreksi
+	if err := ensureAttendancePeriodOpen(ctx, s.PeriodRepo, s.PayRepo, correction.EmployeeID, correction.Date); err != nil {
+		return nil, err
+	}
+
+	// 3. Simpan sebagai PENDING, field review & audit diisi saat diproses
+	correction.ID = 0
+	correction.Status = domain.CorrectionStatusPending
+	correction.ReviewedBy, correction.ReviewNote, correction.ReviewedAt = "", "", nil
+	correction.AttendanceID = nil
+	correction.BeforeStatus, correction.BeforeCheckIn, correction.BeforeCheckOut = "", nil, nil
+	correction.AfterStatus, correction.AfterCheckIn, correction.AfterCheckOut = "", nil, nil
+	if err := s.Repo.Save(ctx, correction); err != nil {
+		return nil, err
+	}
+	return correction, nil
+}
+
+// ApproveCorrection implements domain.AttendanceCorrectionService
+func (s *AttendanceCorrectionServiceImpl) ApproveCorrection(ctx context.Context, id uint, reviewer string, note string) (*domain.AttendanceCorrection, error) {
+	correction, err := s.pendingCorrection(ctx, id, reviewer)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureAttendancePeriodOpen(ctx, s.PeriodRepo, s.PayRepo, correction.EmployeeID, correction.Date); err != nil {
+		return nil, err
+	}
+
+	// 1. Ambil record absensi saat ini (boleh belum ada, misal hari yang terlewat)
+	existing, err := s.AttRepo.FindByEmployeeAndDate(ctx, correction.EmployeeID, correction.Date)
+	if err != nil {
+		return nil, err
+	}
+	att := existing
+	if att == nil {
+		att = &domain.Attendance{EmployeeID: correction.EmployeeID, Date: correction.Date, Status: correction.RequestedStatus}
+	} else {
+		correction.BeforeStatus = att.Status
+		correction.BeforeCheckIn = att.CheckIn
+		correction.BeforeCheckOut = att.CheckOut
+	}
+
+	// 2. Terapkan perubahan yang diminta
+	if correction.RequestedStatus != "" {
+		att.Status = correction.RequestedStatus
+	}
+	if att.Status == "" {
+		return nil, errors.New("requested status is required when no attendance is recorded for this date")
+	}
+	status, err := activeStatus(ctx, s.StatusRepo, att.Status)
+	if err != nil {
+		return nil, err
+	}
+	// Jam masuk/pulang dicatat sebagai punch CORRECTION agar hitung ulang dari log punch tidak membatalkannya.
+	// Punch bersifat append-only, jadi jam yang diminta harus tetap menjadi hasil pairing bersama punch yang sudah ada.
+	var punches []domain.Punch
+	if status.CountsAsPresent {
+		if correction.RequestedCheckIn != nil && correction.RequestedCheckOut != nil && !correction.RequestedCheckOut.After(*correction.RequestedCheckIn) {
+			return nil, errors.New("check-out time must be after check-in time")
+		}
+		if correction.RequestedCheckIn != nil {
+			punches = append(punches, domain.Punch{Timestamp: *correction.RequestedCheckIn, Direction: domain.PunchIn, Source: domain.PunchSourceCorrection})
+		}
+		if correction.RequestedCheckOut != nil {
+			punches = append(punches, domain.Punch{Timestamp: *correction.RequestedCheckOut, Direction: domain.PunchOut, Source: domain.PunchSourceCorrection})
+		}
+	} else {
+		// Jam masuk/pulang hanya relevan untuk status yang dihitung hadir
+		att.CheckIn, att.CheckOut, att.WorkedMinutes = nil, nil, 0
+	}
+	if len(punches) > 0 {
+		summary, err := s.AttService.SummarizePunches(ctx, correction.EmployeeID, correction.Date, punches)
+		if err != nil {
+			return nil, err
+		}
+		if correction.RequestedCheckIn != nil && (summary.CheckIn == nil || !summary.CheckIn.Equal(*correction.RequestedCheckIn)) {
+			return nil, errors.New("requested check-in conflicts with an earlier recorded punch")
+		}
+		if correction.RequestedCheckOut != nil && (summary.CheckOut == nil || !summary.CheckOut.Equal(*correction.RequestedCheckOut)) {
+			return nil, errors.New("requested check-out conflicts with the recorded punches")
+		}
+		att.CheckIn, att.CheckOut, att.WorkedMinutes = summary.CheckIn, summary.CheckOut, summary.WorkedMinutes
+	}
+	if correction.DocumentURL != "" {
+		att.DocumentURL = correction.DocumentURL
+	}
+	if err := validateStatusRules(status, att); err != nil {
+		return nil, err
+	}
+
+	// 3. Simpan status & dokumen, lalu catat punch koreksi dan hitung ulang ringkasan harian
+	if existing == nil {
+		err = s.AttRepo.Save(ctx, att)
+	} else {
+		err = s.AttRepo.Update(ctx, att)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(punches) > 0 {
+		if att, err = s.AttService.RecordPunches(ctx, correction.EmployeeID, correction.Date, punches); err != nil {
+			return nil, err
+		}
+	}
+
+	// 4. Catat nilai sesudah dan hasil review
+	now := time.Now()
+	correction.AttendanceID = &att.ID
+	correction.AfterStatus = att.Status
+	correction.AfterCheckIn = att.CheckIn
+	correction.AfterCheckOut = att.CheckOut
+	correction.Status = domain.CorrectionStatusApproved
+	correction.ReviewedBy = reviewer
+	correction.ReviewNote = note
+	correction.ReviewedAt = &now
+	if err := s.Repo.Update(ctx, correction); err != nil {
+		return nil, err
+	}
+	return correction, nil
+}
+
+// RejectCorrection implements domain.AttendanceCorrectionService
+func (s *AttendanceCorrectionServiceImpl) RejectCorrection(ctx context.Context, id uint, reviewer string, note string) (*domain.AttendanceCorrection, error) {
+	correction, err := s.pendingCorrection(ctx, id, reviewer)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	correction.Status = domain.CorrectionStatusRejected
+	correction.ReviewedBy = reviewer
+	correction.ReviewNote = note
+	correction.ReviewedAt = &now
+	if err := s.Repo.Update(ctx, correction); err != nil {
+		return nil, err
+	}
+	return correction, nil
+}
+
+// GetCorrections implements domain.AttendanceCorrectionService
+func (s *AttendanceCorrectionServiceImpl) GetCorrections(ctx context.Context, employeeID uint, status string) ([]domain.AttendanceCorrection, error) {
+	return s.Repo.FindAll(ctx, employeeID, strings.ToUpper(status))
+}
+
+// GetCorrection implements domain.AttendanceCorrectionService
+func (s *AttendanceCorrectionServiceImpl) GetCorrection(ctx context.Context, id uint) (*domain.AttendanceCorrection, error) {
+	correction, err := s.Repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, domain.ErrCorrectionNotFound
+	}
+	return correction, nil
+}
+
+// pendingCorrection mengambil pengajuan yang masih bisa direview
+func (s *AttendanceCorrectionServiceImpl) pendingCorrection(ctx context.Context, id uint, reviewer string) (*domain.AttendanceCorrection, error) {
+	if strings.TrimSpace(reviewer) == "" {
+		return nil, errors.New("reviewer is required")
+	}
+	correction, err := s.Repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, domain.ErrCorrectionNotFound
+	}
+	if correction.Status != domain.CorrectionStatusPending {
+		return nil, domain.ErrCorrectionNotPending
+	}
+	return correction, nil
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"hr-payroll/internal/domain"
+	"hr-payroll/internal/repository/memory"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestApproveCorrection(t *testing.T) {
+	date := day(2025, 11, 10)
+	at := func(hour, minute int) *time.Time {
+		ts := date.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+		return &ts
+	}
+
+	tests := []struct {
+		name         string
+		punches      []*time.Time // Punch mesin yang sudah tercatat
+		correction   domain.AttendanceCorrection
+		wantErr      string
+		wantCheckIn  *time.Time
+		wantCheckOut *time.Time
+	}{
+		{
+			name:         "forgot to check in",
+			punches:      []*time.Time{at(17, 5)},
+			correction:   domain.AttendanceCorrection{RequestedCheckIn: at(8, 55)},
+			wantCheckIn:  at(8, 55),
+			wantCheckOut: at(17, 5),
+		},
+		{
+			name:         "missed day without punches",
+			correction:   domain.AttendanceCorrection{RequestedStatus: domain.AttendanceStatusPresent, RequestedCheckIn: at(8, 0), RequestedCheckOut: at(17, 0)},
+			wantCheckIn:  at(8, 0),
+			wantCheckOut: at(17, 0),
+		},
+		{
+			name:       "check-in later than a recorded punch",
+			punches:    []*time.Time{at(8, 0), at(17, 0)},
+			correction: domain.AttendanceCorrection{RequestedCheckIn: at(9, 0)},
+			wantErr:    "requested check-in conflicts",
+		},
+		{
+			name:       "check-out before check-in",
+			correction: domain.AttendanceCorrection{RequestedStatus: domain.AttendanceStatusPresent, RequestedCheckIn: at(17, 0), RequestedCheckOut: at(8, 0)},
+			wantErr:    "check-out time must be after check-in time",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos := newTestRepos(t)
+			emp := repos.saveEmployee(t, domain.Employee{Name: "Budi", BaseSalary: 4400000})
+			attendanceService := repos.attendanceService()
+			for _, ts := range tt.punches {
+				if _, err := attendanceService.RecordPunch(ctx, &domain.Punch{EmployeeID: emp.ID, Timestamp: *ts}); err != nil {
+					t.Fatalf("RecordPunch() error = %v", err)
+				}
+			}
+			correctionService := NewAttendanceCorrectionServiceImpl(memory.NewAttendanceCorrectionRepository(), repos.Attendance, repos.Employee, repos.Payroll, repos.Status, repos.Period, attendanceService)
+
+			correction := tt.correction
+			correction.EmployeeID, correction.Date, correction.Reason = emp.ID, date, "Mesin fingerprint error"
+			submitted, err := correctionService.SubmitCorrection(ctx, &correction)
+			if err != nil {
+				t.Fatalf("SubmitCorrection() error = %v", err)
+			}
+			approved, err := correctionService.ApproveCorrection(ctx, submitted.ID, "manager@example.com", "")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ApproveCorrection() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ApproveCorrection() error = %v", err)
+			}
+			if !approved.AfterCheckIn.Equal(*tt.wantCheckIn) || !approved.AfterCheckOut.Equal(*tt.wantCheckOut) {
+				t.Errorf("after = %v - %v, want %v - %v", approved.AfterCheckIn, approved.AfterCheckOut, tt.wantCheckIn, tt.wantCheckOut)
+			}
+
+			// Hitung ulang dari log punch tidak boleh membatalkan koreksi
+			att, err := attendanceService.RecomputeAttendance(ctx, emp.ID, date)
+			if err != nil {
+				t.Fatalf("RecomputeAttendance() error = %v", err)
+			}
+			if !att.CheckIn.Equal(*tt.wantCheckIn) || att.CheckOut == nil || !att.CheckOut.Equal(*tt.wantCheckOut) {
+				t.Errorf("recomputed = %v - %v, want %v - %v", att.CheckIn, att.CheckOut, tt.wantCheckIn, tt.wantCheckOut)
+			}
+			if want := int(tt.wantCheckOut.Sub(*tt.wantCheckIn).Minutes()); att.WorkedMinutes != want {
+				t.Errorf("WorkedMinutes = %d, want %d", att.WorkedMinutes, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"hr-payroll/internal/domain"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AttendanceImportServiceImpl mengimplementasikan domain.AttendanceImportService
+type AttendanceImportServiceImpl struct {
+	AttService domain.AttendanceService
+	EmpRepo    domain.EmployeeRepository
+}
+
+func NewAttendanceImportServiceImpl(as domain.AttendanceService, er domain.EmployeeRepository) domain.AttendanceImportService {
+	return &AttendanceImportServiceImpl{AttService: as, EmpRepo: er}
+}
+
+// punchDay mengelompokkan punch satu karyawan dalam satu hari
+type punchDay struct {
+	EmployeeID   uint
+	DeviceUserID string
+	Date         time.Time
+	Punches      []rawPunch
+}
+
+// Import implements domain.AttendanceImportService
+func (s *AttendanceImportServiceImpl) Import(ctx context.Context, r io.Reader, format string, dryRun bool) (*domain.AttendanceImportResult, error) {
+	format = strings.ToUpper(format)
+
+	// 1. Baca punch mentah dari file
+	punches, rowErrors, err := parsePunches(r, format)
+	if err != nil {
+		return nil, err
+	}
+	if len(punches) == 0 && len(rowErrors) == 0 {
+		return nil, errors.New("file contains no punches")
+	}
+
+	// 2. Petakan device user ID ke karyawan
+	employees, err := s.EmpRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	employeeByDevice := make(map[string]uint, len(employees))
+	for _, emp := range employees {
+		if emp.DeviceUserID != "" {
+			employeeByDevice[emp.DeviceUserID] = emp.ID
+		}
+	}
+
+	// 3. Kelompokkan punch per karyawan per hari
+	days := map[string]*punchDay{}
+	for _, punch := range punches {
+		employeeID, ok := employeeByDevice[punch.DeviceUserID]
+		if !ok {
+			rowErrors = append(rowErrors, domain.AttendanceImportRowError{
+				Row: punch.Row, DeviceUserID: punch.DeviceUserID, Date: punch.Time.Format("2006-01-02"),
+				Error: "device user ID is not mapped to any employee",
+			})
+			continue
+		}
+		date := truncateToDay(punch.Time)
+		key := punch.DeviceUserID + "|" + date.Format("2006-01-02")
+		if days[key] == nil {
+			days[key] = &punchDay{EmployeeID: employeeID, DeviceUserID: punch.DeviceUserID, Date: date}
+		}
+		days[key].Punches = append(days[key].Punches, punch)
+	}
+
+	ordered := make([]*punchDay, 0, len(days))
+	for _, day := range days {
+		sort.Slice(day.Punches, func(i, j int) bool { return day.Punches[i].Time.Before(day.Punches[j].Time) })
+		ordered = append(ordered, day)
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		if !ordered[i].Date.Equal(ordered[j].Date) {
+			return ordered[i].Date.Before(ordered[j].Date)
+		}
+		return ordered[i].EmployeeID < ordered[j].EmployeeID
+	})
+
+	// 4. Tulis punch ke log punch lewat AttendanceService; ringkasan harian dihitung dari aturan pairing
+	result := &domain.AttendanceImportResult{
+		Format:  format,
+		DryRun:  dryRun,
+		Punches: len(punches),
+		Days:    len(ordered),
+		Records: []domain.Attendance{},
+	}
+	for _, day := range ordered {
+		dayPunches := make([]domain.Punch, 0, len(day.Punches))
+		for _, punch := range day.Punches {
+			dayPunches = append(dayPunches, domain.Punch{
+				EmployeeID: day.EmployeeID,
+				Timestamp:  punch.Time,
+				Direction:  punch.Direction,
+				Source:     domain.PunchSourceImport,
+			})
+		}
+
+		var att *domain.Attendance
+		if dryRun {
+			att, err = s.AttService.SummarizePunches(ctx, day.EmployeeID, day.Date, dayPunches)
+		} else {
+			att, err = s.AttService.RecordPunches(ctx, day.EmployeeID, day.Date, dayPunches)
+		}
+		if err != nil {
+			result.Failed++
+			rowErrors = append(rowErrors, domain.AttendanceImportRowError{
+				Row: day.Punches[0].Row, DeviceUserID: day.DeviceUserID, Date: day.Date.Format("2006-01-02"), Error: err.Error(),
+			})
+			continue
+		}
+		result.Imported++
+		result.Records = append(result.Records, *att)
+	}
+
+	sort.SliceStable(rowErrors, func(i, j int) bool { return rowErrors[i].Row < rowErrors[j].Row })
+	result.RowErrors = rowErrors
+	if result.RowErrors == nil {
+		result.RowErrors = []domain.AttendanceImportRowError{}
+	}
+	return result, nil
+}
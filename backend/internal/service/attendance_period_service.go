@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"hr-payroll/internal/domain"
+	"strings"
+	"time"
+)
+
+// AttendancePeriodServiceImpl mengimplementasikan domain.AttendancePeriodService
+type AttendancePeriodServiceImpl struct {
+	Repo    domain.AttendancePeriodRepository
+	PayRepo domain.PayrollRepository
+}
+
+func NewAttendancePeriodServiceImpl(repo domain.AttendancePeriodRepository, pr domain.PayrollRepository) domain.AttendancePeriodService {
+	return &AttendancePeriodServiceImpl{Repo: repo, PayRepo: pr}
+}
+
+// LockPeriod implements domain.AttendancePeriodService.
+// Mengunci bulan yang sudah LOCKED tidak mengubah apa pun.
+func (s *AttendancePeriodServiceImpl) LockPeriod(ctx context.Context, period time.Time, actor string) (*domain.AttendancePeriod, error) {
+	if strings.TrimSpace(actor) == "" {
+		return nil, errors.New("actor is required")
+	}
+	monthStart, _ := monthBounds(period)
+	existing, err := s.Repo.FindByPeriod(ctx, monthStart)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && existing.Status == domain.AttendancePeriodLocked {
+		return existing, nil
+	}
+
+	now := time.Now()
+	locked := existing
+	if locked == nil {
+		locked = &domain.AttendancePeriod{Period: monthStart}
+	}
+	locked.Status = domain.AttendancePeriodLocked
+	locked.LockedBy = actor
+	locked.LockedAt = &now
+	if existing == nil {
+		err = s.Repo.Save(ctx, locked)
+	} else {
+		err = s.Repo.Update(ctx, locked)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return locked, nil
+}
+
+// UnlockPeriod implements domain.AttendancePeriodService.
+// Membuka kunci eksplisit sekaligus kunci dari slip yang sudah PAID; setiap pembukaan dicatat.
+func (s *AttendancePeriodServiceImpl) UnlockPeriod(ctx context.Context, period time.Time, actor string, reason string) (*domain.AttendancePeriod, error) {
+	if strings.TrimSpace(actor) == "" {
+		return nil, errors.New("actor is required")
+	}
+	if strings.TrimSpace(reason) == "" {
+		return nil, errors.New("unlock reason is required")
+	}
+	monthStart, _ := monthBounds(period)
+	existing, err := s.Repo.FindByPeriod(ctx, monthStart)
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. Periode harus sedang terkunci, baik eksplisit maupun karena slip PAID
+	if existing == nil || existing.Status != domain.AttendancePeriodLocked {
+		slips, err := s.PayRepo.FindByPeriod(ctx, monthStart)
+		if err != nil {
+			return nil, err
+		}
+		paidLocked := false
+		for _, slip := range slips {
+			if paidSlipLocks(&slip, existing) {
+				paidLocked = true
+				break
+			}
+		}
+		if !paidLocked {
+			return nil, domain.ErrAttendancePeriodNotLocked
+		}
+	}
+
+	// 2. Buka periode
+	now := time.Now()
+	opened := existing
+	if opened == nil {
+		opened = &domain.AttendancePeriod{Period: monthStart}
+	}
+	opened.Status = domain.AttendancePeriodOpen
+	opened.UnlockedAt = &now
+	if existing == nil {
+		err = s.Repo.Save(ctx, opened)
+	} else {
+		err = s.Repo.Update(ctx, opened)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Catat pembukaan
+	unlock := &domain.AttendancePeriodUnlock{Period: monthStart, UnlockedBy: actor, Reason: reason}
+	if err := s.Repo.SaveUnlock(ctx, unlock); err != nil {
+		return nil, err
+	}
+	return opened, nil
+}
+
+// GetPeriods implements domain.AttendancePeriodService
+func (s *AttendancePeriodServiceImpl) GetPeriods(ctx context.Context) ([]domain.AttendancePeriod, error) {
+	return s.Repo.FindAll(ctx)
+}
+
+// GetUnlocks implements domain.AttendancePeriodService
+func (s *AttendancePeriodServiceImpl) GetUnlocks(ctx context.Context, period time.Time) ([]domain.AttendancePeriodUnlock, error) {
+	if !period.IsZero() {
+		period, _ = monthBounds(period)
+	}
+	return s.Repo.FindUnlocks(ctx, period)
+}
+
+// ensureAttendancePeriodOpen menolak perubahan absensi karyawan pada tanggal yang periodenya terkunci:
+// dikunci eksplisit, atau slip gaji karyawan untuk bulan itu sudah PAID setelah pembukaan terakhir
+func ensureAttendancePeriodOpen(ctx context.Context, periodRepo domain.AttendancePeriodRepository, payRepo domain.PayrollRepository, employeeID uint, date time.Time) error {
+	monthStart, _ := monthBounds(date)
+	period, err := periodRepo.FindByPeriod(ctx, monthStart)
+	if err != nil {
+		return err
+	}
+	if period != nil && period.Status == domain.AttendancePeriodLocked {
+		return domain.ErrAttendancePeriodLocked
+	}
+
+	// Cukup slip reguler karyawan untuk bulan itu; periode slip selalu awal bulan
+	slip, err := payRepo.FindByEmployeeAndPeriod(ctx, employeeID, monthStart)
+	if err != nil {
+		return err
+	}
+	if slip != nil && paidSlipLocks(slip, period) {
+		return domain.ErrPayrollPeriodLocked
+	}
+	return nil
+}
+
+// paidSlipLocks: slip PAID mengunci absensi kecuali sudah dibayar sebelum periode dibuka terakhir kali
+func paidSlipLocks(slip *domain.Payroll, period *domain.AttendancePeriod) bool {
+	if slip.Status != domain.PayrollStatusPaid {
+		return false
+	}
+	if period == nil || period.UnlockedAt == nil {
+		return true
+	}
+	return slip.PaidAt != nil && slip.PaidAt.After(*period.UnlockedAt)
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hr-payroll/internal/domain"
+	"strings"
+)
+
+// AttendanceStatusServiceImpl mengimplementasikan domain.AttendanceStatusService
+type AttendanceStatusServiceImpl struct {
+	Repo domain.AttendanceStatusRepository
+}
+
+func NewAttendanceStatusServiceImpl(repo domain.AttendanceStatusRepository) domain.AttendanceStatusService {
+	return &AttendanceStatusServiceImpl{Repo: repo}
+}
+
+// CreateStatus implements domain.AttendanceStatusService
+func (s *AttendanceStatusServiceImpl) CreateStatus(ctx context.Context, status *domain.AttendanceStatus) (*domain.AttendanceStatus, error) {
+	status.Code = strings.ToUpper(strings.TrimSpace(status.Code))
+	if status.Code == "" {
+		return nil, errors.New("status code is required")
+	}
+	if existing, err := s.Repo.FindByCode(ctx, status.Code); err == nil && existing.ID != 0 {
+		return nil, errors.New("status code already exists")
+	}
+	if err := validateAttendanceStatus(status); err != nil {
+		return nil, err
+	}
+
+	status.ID = 0
+	status.Active = true
+	if err := s.Repo.Save(ctx, status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// UpdateStatus implements domain.AttendanceStatusService.
+// Kode tidak bisa diubah karena sudah tersimpan di record absensi.
+func (s *AttendanceStatusServiceImpl) UpdateStatus(ctx context.Context, code string, status *domain.AttendanceStatus) (*domain.AttendanceStatus, error) {
+	existing, err := s.Repo.FindByCode(ctx, strings.ToUpper(code))
+	if err != nil {
+		return nil, domain.ErrAttendanceStatusNotFound
+	}
+	if err := validateAttendanceStatus(status); err != nil {
+		return nil, err
+	}
+	if !status.Active && isBuiltInStatus(existing.Code) {
+		return nil, errors.New("built-in statuses PRESENT, ABSENT and LEAVE cannot be deactivated")
+	}
+
+	existing.Name = status.Name
+	existing.CountsAsPresent = status.CountsAsPresent
+	existing.DeductionWeight = status.DeductionWeight
+	existing.CheckInRequired = status.CheckInRequired
+	existing.DocumentRequired = status.DocumentRequired
+	existing.Active = status.Active
+	if err := s.Repo.Update(ctx, existing); err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+// GetStatuses implements domain.AttendanceStatusService
+func (s *AttendanceStatusServiceImpl) GetStatuses(ctx context.Context) ([]domain.AttendanceStatus, error) {
+	return s.Repo.FindAll(ctx)
+}
+
+func validateAttendanceStatus(status *domain.AttendanceStatus) error {
+	if strings.TrimSpace(status.Name) == "" {
+		return errors.New("status name is required")
+	}
+	if status.DeductionWeight != 0 && status.DeductionWeight != 0.5 && status.DeductionWeight != 1 {
+		return errors.New("deduction_weight must be 0, 0.5 or 1")
+	}
+	return nil
+}
+
+func isBuiltInStatus(code string) bool {
+	return code == domain.AttendanceStatusPresent || code == domain.AttendanceStatusAbsent || code == domain.AttendanceStatusLeave
+}
+
+// statusCatalogue memuat katalog status sebagai lookup per kode
+func statusCatalogue(ctx context.Context, repo domain.AttendanceStatusRepository) (map[string]domain.AttendanceStatus, error) {
+	statuses, err := repo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	catalogue := make(map[string]domain.AttendanceStatus, len(statuses))
+	for _, status := range statuses {
+		catalogue[status.Code] = status
+	}
+	return catalogue, nil
+}
+
+// activeStatus mencari status aktif di katalog
+func activeStatus(ctx context.Context, repo domain.AttendanceStatusRepository, code string) (*domain.AttendanceStatus, error) {
+	status, err := repo.FindByCode(ctx, code)
+	if err != nil || !status.Active {
+		return nil, fmt.Errorf("invalid attendance status %q: not an active status in the catalogue", code)
+	}
+	return status, nil
+}
+
+// validateStatusRules memeriksa aturan status (check-in, dokumen) pada record absensi
+func validateStatusRules(status *domain.AttendanceStatus, att *domain.Attendance) error {
+	if status.CheckInRequired && att.CheckIn == nil {
+		return fmt.Errorf("check-in time is mandatory for %s status", status.Code)
+	}
+	if status.DocumentRequired && strings.TrimSpace(att.DocumentURL) == "" {
+		return fmt.Errorf("a supporting document is required for %s status", status.Code)
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"context"
+	"hr-payroll/internal/domain"
+)
+
+// AuditLogServiceImpl mengimplementasikan domain.AuditLogService
+type AuditLogServiceImpl struct {
+	Repo domain.AuditLogRepository
+}
+
+func NewAuditLogServiceImpl(repo domain.AuditLogRepository) domain.AuditLogService {
+	return &AuditLogServiceImpl{Repo: repo}
+}
+
+// GetAuditLogs implements domain.AuditLogService
+func (s *AuditLogServiceImpl) GetAuditLogs(ctx context.Context, filter domain.AuditLogFilter) ([]domain.AuditLog, error) {
+	switch filter.Entity {
+	case "", domain.AuditEntityEmployee, domain.AuditEntityAttendance, domain.AuditEntityPayroll:
+	default:
+		return nil, domain.ErrInvalidAuditEntity
+	}
+	return s.Repo.FindAll(ctx, filter)
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"hr-payroll/internal/domain"
+	"time"
+)
+
+// truncateToDay menormalkan waktu ke awal hari (UTC), sama seperti kolom date di attendances
+func truncateToDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// monthBounds mengembalikan hari pertama dan hari terakhir bulan dari period
+func monthBounds(period time.Time) (time.Time, time.Time) {
+	first := time.Date(period.Year(), period.Month(), 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1)
+	return first, last
+}
+
+// holidaySet mengubah daftar libur menjadi lookup per tanggal
+func holidaySet(holidays []domain.Holiday) map[time.Time]bool {
+	set := make(map[time.Time]bool, len(holidays))
+	for _, h := range holidays {
+		set[truncateToDay(h.Date)] = true
+	}
+	return set
+}
+
+// isWorkingDay: Senin-Jumat dan bukan hari libur
+func isWorkingDay(day time.Time, holidays map[time.Time]bool) bool {
+	if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+		return false
+	}
+	return !holidays[truncateToDay(day)]
+}
+
+// countCalendarDays menghitung jumlah hari dari..sampai (inklusif)
+func countCalendarDays(from, to time.Time) int {
+	if to.Before(from) {
+		return 0
+	}
+	return int(truncateToDay(to).Sub(truncateToDay(from)).Hours()/24) + 1
+}
+
+// countWorkingDays menghitung hari kerja dari..sampai (inklusif)
+func countWorkingDays(from, to time.Time, holidays map[time.Time]bool) int {
+	count := 0
+	for d := truncateToDay(from); !d.After(to); d = d.AddDate(0, 0, 1) {
+		if isWorkingDay(d, holidays) {
+			count++
+		}
+	}
+	return count
+}
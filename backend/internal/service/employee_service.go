@@ -1,30 +1,46 @@
 package service
 
-import "hr-payroll/internal/domain"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hr-payroll/internal/domain"
+	"strings"
+	"time"
+)
 
 // EmployeeServiceImpl mengimplementasikan domain.EmployeeService
 type EmployeeServiceImpl struct {
-	Repo domain.EmployeeRepository // Dependency pada Interface Repository
+	Repo       domain.EmployeeRepository // Dependency pada Interface Repository
+	SalaryRepo domain.SalaryHistoryRepository
 }
 
-func NewEmployeeServiceImpl(repo domain.EmployeeRepository) domain.EmployeeService {
-	return &EmployeeServiceImpl{Repo: repo}
+func NewEmployeeServiceImpl(repo domain.EmployeeRepository, salaryRepo domain.SalaryHistoryRepository) domain.EmployeeService {
+	return &EmployeeServiceImpl{Repo: repo, SalaryRepo: salaryRepo}
 }
 
 // CreateEmployee implements domain.EmployeeService
-func (s *EmployeeServiceImpl) CreateEmployee(emp *domain.Employee) (*domain.Employee, error) {
+func (s *EmployeeServiceImpl) CreateEmployee(ctx context.Context, emp *domain.Employee) (*domain.Employee, error) {
 	// *LOGIKA BISNIS/VALIDASI di sini, jika ada
 	// Contoh: memastikan gaji tidak negatif
+	if err := normalizeEmployeeTaxData(emp); err != nil {
+		return nil, err
+	}
+
+	if err := s.Repo.Save(ctx, emp); err != nil {
+		return nil, err
+	}
 
-	if err := s.Repo.Save(emp); err != nil {
+	// Catat gaji awal sebagai riwayat pertama
+	if err := s.SalaryRepo.Save(ctx, baselineSalary(emp)); err != nil {
 		return nil, err
 	}
 	return emp, nil
 }
 
 // GetEmployeeByID implements domain.EmployeeService
-func (s *EmployeeServiceImpl) GetEmployeeByID(id uint) (*domain.Employee, error) {
-	employee, err := s.Repo.FindByID(id)
+func (s *EmployeeServiceImpl) GetEmployeeByID(ctx context.Context, id uint) (*domain.Employee, error) {
+	employee, err := s.Repo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err // GORM akan mengembalikan gorm.ErrRecordNotFound jika tidak ditemukan
 	}
@@ -32,8 +48,8 @@ func (s *EmployeeServiceImpl) GetEmployeeByID(id uint) (*domain.Employee, error)
 }
 
 // GetAllEmployees implements domain.EmployeeService
-func (s *EmployeeServiceImpl) GetAllEmployees() ([]domain.Employee, error) {
-	employees, err := s.Repo.FindAll()
+func (s *EmployeeServiceImpl) GetAllEmployees(ctx context.Context) ([]domain.Employee, error) {
+	employees, err := s.Repo.FindAll(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -41,22 +57,150 @@ func (s *EmployeeServiceImpl) GetAllEmployees() ([]domain.Employee, error) {
 }
 
 // UpdateEmployee implements domain.EmployeeService
-func (s *EmployeeServiceImpl) UpdateEmployee(id uint, newEmp *domain.Employee) (*domain.Employee, error) {
+func (s *EmployeeServiceImpl) UpdateEmployee(ctx context.Context, id uint, newEmp *domain.Employee) (*domain.Employee, error) {
 	// 1. Cek keberadaan
-	existingEmp, err := s.Repo.FindByID(id)
+	existingEmp, err := s.Repo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	// 2. Update field
+	// 2. Perubahan gaji lewat update biasa dicatat berlaku mulai hari ini.
+	//    Untuk tanggal berlaku lain (termasuk mundur), gunakan AddSalaryChange.
+	salaryChanged := existingEmp.BaseSalary != newEmp.BaseSalary || existingEmp.Allowance != newEmp.Allowance
+	if salaryChanged {
+		if err := s.ensureBaselineSalary(ctx, existingEmp); err != nil {
+			return nil, err
+		}
+	}
+
+	// 3. Update field
 	existingEmp.Name = newEmp.Name
 	existingEmp.BaseSalary = newEmp.BaseSalary
 	existingEmp.Allowance = newEmp.Allowance
 	existingEmp.Position = newEmp.Position
+	existingEmp.Department = newEmp.Department
+	existingEmp.DeviceUserID = newEmp.DeviceUserID
+	existingEmp.OfficeID = newEmp.OfficeID
+	existingEmp.JoinDate = newEmp.JoinDate
+	existingEmp.ResignDate = newEmp.ResignDate
+	existingEmp.Religion = newEmp.Religion
+	existingEmp.TaxStatus = newEmp.TaxStatus
+	existingEmp.NPWP = newEmp.NPWP
+	existingEmp.NIK = newEmp.NIK
+	if err := normalizeEmployeeTaxData(existingEmp); err != nil {
+		return nil, err
+	}
 
-	// 3. Simpan perubahan
-	if err := s.Repo.Update(existingEmp); err != nil {
+	// 4. Simpan perubahan
+	if err := s.Repo.Update(ctx, existingEmp); err != nil {
 		return nil, err
 	}
+
+	if salaryChanged {
+		history := &domain.SalaryHistory{
+			EmployeeID:    existingEmp.ID,
+			BaseSalary:    existingEmp.BaseSalary,
+			Allowance:     existingEmp.Allowance,
+			EffectiveFrom: truncateToDay(time.Now()),
+			Note:          "Perubahan data karyawan",
+		}
+		if err := s.SalaryRepo.Save(ctx, history); err != nil {
+			return nil, err
+		}
+	}
 	return existingEmp, nil
 }
+
+// AddSalaryChange implements domain.EmployeeService
+func (s *EmployeeServiceImpl) AddSalaryChange(ctx context.Context, employeeID uint, change *domain.SalaryHistory) (*domain.SalaryHistory, error) {
+	// 1. Validasi
+	if change.BaseSalary < 0 || change.Allowance < 0 {
+		return nil, errors.New("base salary and allowance must not be negative")
+	}
+	if change.EffectiveFrom.IsZero() {
+		return nil, errors.New("effective_from is required")
+	}
+
+	employee, err := s.Repo.FindByID(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Simpan riwayat (gaji lama dicatat dulu jika belum ada riwayat)
+	if err := s.ensureBaselineSalary(ctx, employee); err != nil {
+		return nil, err
+	}
+	change.ID = 0
+	change.EmployeeID = employeeID
+	change.EffectiveFrom = truncateToDay(change.EffectiveFrom)
+	if err := s.SalaryRepo.Save(ctx, change); err != nil {
+		return nil, err
+	}
+
+	// 3. Sinkronkan gaji "saat ini" di data karyawan dengan riwayat yang berlaku hari ini
+	histories, err := s.SalaryRepo.FindByEmployee(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	base, allowance := salaryAt(employee, histories, truncateToDay(time.Now()))
+	if base != employee.BaseSalary || allowance != employee.Allowance {
+		employee.BaseSalary = base
+		employee.Allowance = allowance
+		if err := s.Repo.Update(ctx, employee); err != nil {
+			return nil, err
+		}
+	}
+	return change, nil
+}
+
+// GetSalaryHistory implements domain.EmployeeService
+func (s *EmployeeServiceImpl) GetSalaryHistory(ctx context.Context, employeeID uint) ([]domain.SalaryHistory, error) {
+	if _, err := s.Repo.FindByID(ctx, employeeID); err != nil {
+		return nil, err
+	}
+	return s.SalaryRepo.FindByEmployee(ctx, employeeID)
+}
+
+// ensureBaselineSalary mencatat gaji saat ini sebagai riwayat awal untuk karyawan lama yang belum punya riwayat
+func (s *EmployeeServiceImpl) ensureBaselineSalary(ctx context.Context, emp *domain.Employee) error {
+	histories, err := s.SalaryRepo.FindByEmployee(ctx, emp.ID)
+	if err != nil {
+		return err
+	}
+	if len(histories) > 0 {
+		return nil
+	}
+	return s.SalaryRepo.Save(ctx, baselineSalary(emp))
+}
+
+// normalizeEmployeeTaxData menyeragamkan dan memvalidasi agama (jadwal THR) dan status PTKP
+func normalizeEmployeeTaxData(emp *domain.Employee) error {
+	emp.Religion = strings.ToUpper(strings.TrimSpace(emp.Religion))
+	if emp.Religion != "" && !validReligion(emp.Religion) {
+		return fmt.Errorf("%w: unknown religion %q", domain.ErrInvalidEmployee, emp.Religion)
+	}
+	emp.TaxStatus = strings.ToUpper(strings.ReplaceAll(emp.TaxStatus, " ", ""))
+	if _, err := ptkpAnnual(emp.TaxStatus); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInvalidEmployee, err)
+	}
+	// NPWP dan NIK disimpan sebagai digit saja (tanpa titik dan strip)
+	emp.NPWP = digitsOnly(emp.NPWP)
+	if emp.NPWP != "" && len(emp.NPWP) != 15 && len(emp.NPWP) != 16 {
+		return fmt.Errorf("%w: npwp must have 15 or 16 digits", domain.ErrInvalidEmployee)
+	}
+	emp.NIK = digitsOnly(emp.NIK)
+	if emp.NIK != "" && len(emp.NIK) != 16 {
+		return fmt.Errorf("%w: nik must have 16 digits", domain.ErrInvalidEmployee)
+	}
+	return nil
+}
+
+// digitsOnly membuang semua karakter selain angka
+func digitsOnly(value string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, value)
+}
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"hr-payroll/internal/domain"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func TestCreateEmployee(t *testing.T) {
+	joinDate := day(2025, 1, 6)
+
+	tests := []struct {
+		name    string
+		emp     domain.Employee
+		want    domain.Employee // field hasil normalisasi yang diperiksa
+		wantErr error
+	}{
+		{
+			name: "normalizes tax data",
+			emp:  domain.Employee{Name: "Budi", BaseSalary: 5000000, Religion: " islam ", TaxStatus: "k / 1", NPWP: "01.234.567.8-901.234", NIK: "3171-0123-4567-0001", JoinDate: &joinDate},
+			want: domain.Employee{Religion: "ISLAM", TaxStatus: "K/1", NPWP: "012345678901234", NIK: "3171012345670001"},
+		},
+		{
+			name: "empty tax data is allowed",
+			emp:  domain.Employee{Name: "Sari", BaseSalary: 5000000},
+		},
+		{
+			name:    "unknown religion",
+			emp:     domain.Employee{Name: "Budi", Religion: "JEDI"},
+			wantErr: domain.ErrInvalidEmployee,
+		},
+		{
+			name:    "unknown PTKP status",
+			emp:     domain.Employee{Name: "Budi", TaxStatus: "K/9"},
+			wantErr: domain.ErrInvalidEmployee,
+		},
+		{
+			name:    "NPWP with wrong length",
+			emp:     domain.Employee{Name: "Budi", NPWP: "12345"},
+			wantErr: domain.ErrInvalidEmployee,
+		},
+		{
+			name:    "NIK with wrong length",
+			emp:     domain.Employee{Name: "Budi", NIK: "317101"},
+			wantErr: domain.ErrInvalidEmployee,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos := newTestRepos(t)
+
+			emp, err := repos.employeeService().CreateEmployee(ctx, &tt.emp)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateEmployee() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if emp.Religion != tt.want.Religion || emp.TaxStatus != tt.want.TaxStatus || emp.NPWP != tt.want.NPWP || emp.NIK != tt.want.NIK {
+				t.Errorf("employee = %+v, want normalized %+v", emp, tt.want)
+			}
+
+			// Gaji awal dicatat sebagai riwayat pertama
+			histories, err := repos.SalaryHistory.FindByEmployee(ctx, emp.ID)
+			if err != nil {
+				t.Fatalf("FindByEmployee() error = %v", err)
+			}
+			if len(histories) != 1 || histories[0].BaseSalary != tt.emp.BaseSalary {
+				t.Errorf("salary histories = %+v, want one baseline entry", histories)
+			}
+		})
+	}
+}
+
+func TestUpdateEmployee(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            uint // 0 = karyawan yang dibuat di test
+		update        domain.Employee
+		wantErr       error
+		wantHistories int
+	}{
+		{
+			name:          "profile change keeps salary history",
+			update:        domain.Employee{Name: "Budi Santoso", BaseSalary: 5000000, Allowance: 500000, Position: "Lead"},
+			wantHistories: 1,
+		},
+		{
+			name:          "salary change is recorded",
+			update:        domain.Employee{Name: "Budi", BaseSalary: 6000000, Allowance: 500000},
+			wantHistories: 2,
+		},
+		{
+			name:    "invalid NIK",
+			update:  domain.Employee{Name: "Budi", BaseSalary: 5000000, Allowance: 500000, NIK: "123"},
+			wantErr: domain.ErrInvalidEmployee,
+		},
+		{
+			name:    "unknown employee",
+			id:      99,
+			update:  domain.Employee{Name: "Budi"},
+			wantErr: gorm.ErrRecordNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos := newTestRepos(t)
+			service := repos.employeeService()
+			created, err := service.CreateEmployee(ctx, &domain.Employee{Name: "Budi", BaseSalary: 5000000, Allowance: 500000})
+			if err != nil {
+				t.Fatalf("CreateEmployee() error = %v", err)
+			}
+			id := tt.id
+			if id == 0 {
+				id = created.ID
+			}
+
+			emp, err := service.UpdateEmployee(ctx, id, &tt.update)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateEmployee() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if emp.Name != tt.update.Name || emp.BaseSalary != tt.update.BaseSalary {
+				t.Errorf("employee = %+v, want fields from %+v", emp, tt.update)
+			}
+			histories, err := service.GetSalaryHistory(ctx, id)
+			if err != nil {
+				t.Fatalf("GetSalaryHistory() error = %v", err)
+			}
+			if len(histories) != tt.wantHistories {
+				t.Errorf("salary histories = %d, want %d", len(histories), tt.wantHistories)
+			}
+		})
+	}
+}
+
+func TestAddSalaryChange(t *testing.T) {
+	today := day(time.Now().Year(), time.Now().Month(), time.Now().Day())
+
+	tests := []struct {
+		name       string
+		change     domain.SalaryHistory
+		wantErr    bool
+		wantSalary float64 // gaji karyawan "saat ini" setelah perubahan
+	}{
+		{
+			name:       "effective today updates current salary",
+			change:     domain.SalaryHistory{BaseSalary: 6000000, Allowance: 500000, EffectiveFrom: today},
+			wantSalary: 6000000,
+		},
+		{
+			name:       "future change keeps current salary",
+			change:     domain.SalaryHistory{BaseSalary: 7000000, Allowance: 500000, EffectiveFrom: today.AddDate(0, 1, 0)},
+			wantSalary: 5000000,
+		},
+		{
+			name:    "negative salary",
+			change:  domain.SalaryHistory{BaseSalary: -1, EffectiveFrom: today},
+			wantErr: true,
+		},
+		{
+			name:    "missing effective date",
+			change:  domain.SalaryHistory{BaseSalary: 6000000},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos := newTestRepos(t)
+			service := repos.employeeService()
+			joinDate := day(2025, 1, 6)
+			created, err := service.CreateEmployee(ctx, &domain.Employee{Name: "Budi", BaseSalary: 5000000, Allowance: 500000, JoinDate: &joinDate})
+			if err != nil {
+				t.Fatalf("CreateEmployee() error = %v", err)
+			}
+
+			_, err = service.AddSalaryChange(ctx, created.ID, &tt.change)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AddSalaryChange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			emp, err := service.GetEmployeeByID(ctx, created.ID)
+			if err != nil {
+				t.Fatalf("GetEmployeeByID() error = %v", err)
+			}
+			if emp.BaseSalary != tt.wantSalary {
+				t.Errorf("BaseSalary = %v, want %v", emp.BaseSalary, tt.wantSalary)
+			}
+		})
+	}
+}
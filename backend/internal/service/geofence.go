@@ -0,0 +1,66 @@
+package service
+
+import (
+	"hr-payroll/internal/domain"
+	"math"
+)
+
+const earthRadiusMeters = 6371000
+
+// haversineMeters menghitung jarak dua titik GPS dalam meter
+func haversineMeters(a, b domain.GeoPoint) float64 {
+	lat1, lat2 := a.Latitude*math.Pi/180, b.Latitude*math.Pi/180
+	dLat := lat2 - lat1
+	dLng := (b.Longitude - a.Longitude) * math.Pi / 180
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusMeters * math.Asin(math.Sqrt(h))
+}
+
+// pointInPolygon: ray casting pada koordinat lat/lng (cukup akurat untuk area seukuran kantor)
+func pointInPolygon(p domain.GeoPoint, polygon []domain.GeoPoint) bool {
+	inside := false
+	for i, j := 0, len(polygon)-1; i < len(polygon); j, i = i, i+1 {
+		a, b := polygon[i], polygon[j]
+		if (a.Latitude > p.Latitude) != (b.Latitude > p.Latitude) &&
+			p.Longitude < (b.Longitude-a.Longitude)*(p.Latitude-a.Latitude)/(b.Latitude-a.Latitude)+a.Longitude {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// distanceToSegmentMeters: jarak titik ke ruas garis a-b (proyeksi equirectangular lokal)
+func distanceToSegmentMeters(p, a, b domain.GeoPoint) float64 {
+	cosLat := math.Cos(p.Latitude * math.Pi / 180)
+	toXY := func(g domain.GeoPoint) (float64, float64) {
+		return (g.Longitude - p.Longitude) * math.Pi / 180 * earthRadiusMeters * cosLat,
+			(g.Latitude - p.Latitude) * math.Pi / 180 * earthRadiusMeters
+	}
+	ax, ay := toXY(a)
+	bx, by := toXY(b)
+	dx, dy := bx-ax, by-ay
+	t := 0.0
+	if lengthSq := dx*dx + dy*dy; lengthSq > 0 {
+		t = math.Max(0, math.Min(1, -(ax*dx+ay*dy)/lengthSq))
+	}
+	return math.Hypot(ax+t*dx, ay+t*dy)
+}
+
+// checkGeofence mengembalikan apakah titik berada di dalam geofence kantor dan jaraknya
+// (ke pusat untuk RADIUS, ke tepi terdekat untuk POLYGON; 0 jika di dalam poligon)
+func checkGeofence(office *domain.Office, p domain.GeoPoint) (bool, float64) {
+	if office.GeofenceType == domain.GeofencePolygon {
+		if pointInPolygon(p, office.Polygon) {
+			return true, 0
+		}
+		nearest := math.Inf(1)
+		for i := range office.Polygon {
+			next := office.Polygon[(i+1)%len(office.Polygon)]
+			nearest = math.Min(nearest, distanceToSegmentMeters(p, office.Polygon[i], next))
+		}
+		return false, nearest
+	}
+
+	distance := haversineMeters(domain.GeoPoint{Latitude: office.Latitude, Longitude: office.Longitude}, p)
+	return distance <= office.RadiusMeters, distance
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"hr-payroll/internal/domain"
+	"math"
+	"testing"
+)
+
+// metersPerDegree adalah panjang satu derajat lintang dengan jari-jari bumi earthRadiusMeters
+const metersPerDegree = earthRadiusMeters * math.Pi / 180
+
+func TestHaversineMeters(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b domain.GeoPoint
+		want float64
+	}{
+		{name: "same point", a: domain.GeoPoint{Latitude: -6.2088, Longitude: 106.8456}, b: domain.GeoPoint{Latitude: -6.2088, Longitude: 106.8456}, want: 0},
+		{name: "one degree of latitude", a: domain.GeoPoint{Latitude: 0, Longitude: 106}, b: domain.GeoPoint{Latitude: 1, Longitude: 106}, want: metersPerDegree},
+		{name: "one degree of longitude on the equator", a: domain.GeoPoint{Latitude: 0, Longitude: 106}, b: domain.GeoPoint{Latitude: 0, Longitude: 107}, want: metersPerDegree},
+		{name: "one degree of longitude at 60 degrees", a: domain.GeoPoint{Latitude: 60, Longitude: 0}, b: domain.GeoPoint{Latitude: 60, Longitude: 1}, want: 55596},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := haversineMeters(tt.a, tt.b); math.Abs(got-tt.want) > 1 {
+				t.Errorf("haversineMeters() = %.1f, want %.1f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckGeofence(t *testing.T) {
+	radius := &domain.Office{GeofenceType: domain.GeofenceRadius, Latitude: -6.2088, Longitude: 106.8456, RadiusMeters: 150}
+	// Persegi sekitar 1,1 km x 1,1 km
+	polygon := &domain.Office{GeofenceType: domain.GeofencePolygon, Polygon: []domain.GeoPoint{
+		{Latitude: -6.21, Longitude: 106.84},
+		{Latitude: -6.21, Longitude: 106.85},
+		{Latitude: -6.20, Longitude: 106.85},
+		{Latitude: -6.20, Longitude: 106.84},
+	}}
+	cosLat := math.Cos(6.199 * math.Pi / 180)
+
+	tests := []struct {
+		name         string
+		office       *domain.Office
+		point        domain.GeoPoint
+		wantInside   bool
+		wantDistance float64
+	}{
+		{name: "radius centre", office: radius, point: domain.GeoPoint{Latitude: -6.2088, Longitude: 106.8456}, wantInside: true, wantDistance: 0},
+		{name: "radius inside", office: radius, point: domain.GeoPoint{Latitude: -6.2078, Longitude: 106.8456}, wantInside: true, wantDistance: 0.001 * metersPerDegree},
+		{name: "radius outside", office: radius, point: domain.GeoPoint{Latitude: -6.2068, Longitude: 106.8456}, wantInside: false, wantDistance: 0.002 * metersPerDegree},
+		{name: "polygon inside", office: polygon, point: domain.GeoPoint{Latitude: -6.205, Longitude: 106.845}, wantInside: true, wantDistance: 0},
+		{name: "polygon outside an edge", office: polygon, point: domain.GeoPoint{Latitude: -6.199, Longitude: 106.845}, wantInside: false, wantDistance: 0.001 * metersPerDegree},
+		{name: "polygon outside a corner", office: polygon, point: domain.GeoPoint{Latitude: -6.199, Longitude: 106.851}, wantInside: false,
+			wantDistance: math.Hypot(0.001*metersPerDegree, 0.001*metersPerDegree*cosLat)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inside, distance := checkGeofence(tt.office, tt.point)
+			if inside != tt.wantInside {
+				t.Errorf("inside = %v, want %v", inside, tt.wantInside)
+			}
+			if math.Abs(distance-tt.wantDistance) > 1 {
+				t.Errorf("distance = %.1f m, want %.1f m", distance, tt.wantDistance)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+	"hr-payroll/internal/domain"
+	"time"
+)
+
+// HolidayServiceImpl mengimplementasikan domain.HolidayService
+type HolidayServiceImpl struct {
+	Repo domain.HolidayRepository
+}
+
+func NewHolidayServiceImpl(repo domain.HolidayRepository) domain.HolidayService {
+	return &HolidayServiceImpl{Repo: repo}
+}
+
+// CreateHoliday implements domain.HolidayService
+func (s *HolidayServiceImpl) CreateHoliday(ctx context.Context, holiday *domain.Holiday) (*domain.Holiday, error) {
+	// Normalisasi ke awal hari agar cocok dengan tanggal absensi
+	holiday.Date = truncateToDay(holiday.Date)
+
+	if err := s.Repo.Save(ctx, holiday); err != nil {
+		return nil, err
+	}
+	return holiday, nil
+}
+
+// DeleteHoliday implements domain.HolidayService
+func (s *HolidayServiceImpl) DeleteHoliday(ctx context.Context, id uint) error {
+	return s.Repo.Delete(ctx, id)
+}
+
+// GetHolidaysByPeriod implements domain.HolidayService
+func (s *HolidayServiceImpl) GetHolidaysByPeriod(ctx context.Context, dateFrom time.Time, dateTo time.Time) ([]domain.Holiday, error) {
+	return s.Repo.FindByPeriod(ctx, dateFrom, dateTo)
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"hr-payroll/internal/domain"
+	"time"
+)
+
+// IdempotencyConfig menampung pengaturan idempotency key
+type IdempotencyConfig struct {
+	TTL   time.Duration // Masa berlaku respons tersimpan; retry setelahnya diproses sebagai request baru
+	Lease time.Duration // Masa berlaku reservasi yang belum selesai; jika proses mati di tengah jalan key bisa dipakai lagi setelahnya
+}
+
+// IdempotencyServiceImpl mengimplementasikan domain.IdempotencyService
+type IdempotencyServiceImpl struct {
+	Repo   domain.IdempotencyRepository
+	Config IdempotencyConfig
+}
+
+func NewIdempotencyServiceImpl(repo domain.IdempotencyRepository, cfg IdempotencyConfig) domain.IdempotencyService {
+	if cfg.TTL <= 0 {
+		cfg.TTL = 24 * time.Hour
+	}
+	if cfg.Lease <= 0 {
+		cfg.Lease = 5 * time.Minute
+	}
+	return &IdempotencyServiceImpl{Repo: repo, Config: cfg}
+}
+
+// Begin implements domain.IdempotencyService
+func (s *IdempotencyServiceImpl) Begin(ctx context.Context, key string, fingerprint string) (*domain.IdempotencyRecord, error) {
+	existing, err := s.Repo.Reserve(ctx, &domain.IdempotencyRecord{
+		Key:         key,
+		Fingerprint: fingerprint,
+		ExpiresAt:   time.Now().Add(s.Config.Lease),
+	})
+	if err != nil || existing == nil {
+		return nil, err
+	}
+
+	switch {
+	case existing.Fingerprint != fingerprint:
+		return nil, domain.ErrIdempotencyKeyReused
+	case existing.CompletedAt == nil:
+		return nil, domain.ErrIdempotencyKeyInFlight
+	}
+	return existing, nil
+}
+
+// Complete implements domain.IdempotencyService.
+// Respons tersimpan berlaku selama TTL sejak selesai, menggantikan lease reservasi.
+func (s *IdempotencyServiceImpl) Complete(ctx context.Context, key string, statusCode int, contentType string, body []byte) error {
+	now := time.Now()
+	return s.Repo.Complete(ctx, &domain.IdempotencyRecord{
+		Key:         key,
+		StatusCode:  statusCode,
+		ContentType: contentType,
+		Body:        string(body),
+		CompletedAt: &now,
+		ExpiresAt:   now.Add(s.Config.TTL),
+	})
+}
+
+// Abandon implements domain.IdempotencyService
+func (s *IdempotencyServiceImpl) Abandon(ctx context.Context, key string) error {
+	return s.Repo.Delete(ctx, key)
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"hr-payroll/internal/domain"
+	"hr-payroll/internal/repository/memory"
+	"testing"
+	"time"
+)
+
+func TestIdempotencyLease(t *testing.T) {
+	ctx := context.Background()
+	service := NewIdempotencyServiceImpl(memory.NewIdempotencyRepository(), IdempotencyConfig{TTL: time.Hour, Lease: 20 * time.Millisecond})
+
+	// Reservasi yang tidak pernah selesai (proses mati) ditolak selama lease, lalu dilepas
+	if record, err := service.Begin(ctx, "stuck", "a"); err != nil || record != nil {
+		t.Fatalf("Begin(stuck) = %+v, %v; want new reservation", record, err)
+	}
+	if _, err := service.Begin(ctx, "stuck", "a"); !errors.Is(err, domain.ErrIdempotencyKeyInFlight) {
+		t.Fatalf("Begin(stuck) during lease error = %v, want %v", err, domain.ErrIdempotencyKeyInFlight)
+	}
+
+	// Respons yang selesai berlaku selama TTL, bukan lease
+	if _, err := service.Begin(ctx, "done", "b"); err != nil {
+		t.Fatalf("Begin(done) error = %v", err)
+	}
+	if err := service.Complete(ctx, "done", 201, "application/json", []byte(`{}`)); err != nil {
+		t.Fatalf("Complete(done) error = %v", err)
+	}
+
+	time.Sleep(40 * time.Millisecond)
+	if record, err := service.Begin(ctx, "stuck", "a"); err != nil || record != nil {
+		t.Errorf("Begin(stuck) after lease = %+v, %v; want a fresh reservation", record, err)
+	}
+	if record, err := service.Begin(ctx, "done", "b"); err != nil || record == nil || record.StatusCode != 201 {
+		t.Errorf("Begin(done) after lease = %+v, %v; want the stored response", record, err)
+	}
+}
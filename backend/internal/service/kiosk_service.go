@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hr-payroll/internal/domain"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// kioskTokenGrace memberi kelonggaran untuk token yang dipindai tepat saat rotasi
+const kioskTokenGrace = 5 * time.Second
+
+// KioskConfig menampung pengaturan QR kiosk
+type KioskConfig struct {
+	Secret []byte // Kunci HMAC untuk menandatangani token
+}
+
+// KioskServiceImpl mengimplementasikan domain.KioskService
+type KioskServiceImpl struct {
+	AttService domain.AttendanceService
+	OfficeRepo domain.OfficeRepository
+	EmpRepo    domain.EmployeeRepository
+	UseRepo    domain.KioskTokenUseRepository
+	Tx         domain.TxManager
+	Config     KioskConfig
+}
+
+func NewKioskServiceImpl(as domain.AttendanceService, or domain.OfficeRepository, er domain.EmployeeRepository, ur domain.KioskTokenUseRepository, tx domain.TxManager, cfg KioskConfig) domain.KioskService {
+	if len(cfg.Secret) == 0 {
+		// Tanpa KIOSK_TOKEN_SECRET token hanya valid selama proses ini berjalan
+		log.Println("KIOSK_TOKEN_SECRET is not set, using a random secret")
+		cfg.Secret = make([]byte, 32)
+		if _, err := rand.Read(cfg.Secret); err != nil {
+			log.Fatalf("Failed to generate kiosk secret: %v", err)
+		}
+	}
+	return &KioskServiceImpl{AttService: as, OfficeRepo: or, EmpRepo: er, UseRepo: ur, Tx: tx, Config: cfg}
+}
+
+// IssueKioskKey implements domain.KioskService.
+// Kunci baru menggantikan kunci lama; hanya hash-nya yang disimpan.
+func (s *KioskServiceImpl) IssueKioskKey(ctx context.Context, officeID uint) (*domain.KioskKey, error) {
+	office, err := s.OfficeRepo.FindByID(ctx, officeID)
+	if err != nil {
+		return nil, domain.ErrOfficeNotFound
+	}
+
+	raw := make([]byte, 24)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, err
+	}
+	key := hex.EncodeToString(raw)
+	office.KioskKeyHash = hashKioskKey(key)
+	if err := s.OfficeRepo.Update(ctx, office); err != nil {
+		return nil, err
+	}
+	return &domain.KioskKey{OfficeID: office.ID, Key: key}, nil
+}
+
+// CurrentToken implements domain.KioskService
+func (s *KioskServiceImpl) CurrentToken(ctx context.Context, officeID uint, kioskKey string) (*domain.KioskToken, error) {
+	office, err := s.OfficeRepo.FindByID(ctx, officeID)
+	if err != nil {
+		return nil, domain.ErrOfficeNotFound
+	}
+	// Hanya tablet kiosk yang memegang kunci yang boleh mengambil token (bukti kehadiran fisik)
+	if office.KioskKeyHash == "" || subtle.ConstantTimeCompare([]byte(office.KioskKeyHash), []byte(hashKioskKey(kioskKey))) != 1 {
+		return nil, domain.ErrKioskKeyInvalid
+	}
+
+	window := time.Now().Unix() / int64(domain.KioskTokenPeriod.Seconds())
+	issuedAt, expiresAt := windowBounds(window)
+	return &domain.KioskToken{
+		OfficeID:  office.ID,
+		Token:     s.sign(office.ID, window),
+		IssuedAt:  issuedAt,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// Scan implements domain.KioskService
+func (s *KioskServiceImpl) Scan(ctx context.Context, req domain.KioskScanRequest) (*domain.Attendance, error) {
+	action := strings.ToUpper(req.Action)
+	if action != domain.KioskActionCheckIn && action != domain.KioskActionCheckOut {
+		return nil, errors.New("invalid action: must be CHECK_IN or CHECK_OUT")
+	}
+
+	// 1. Verifikasi tanda tangan dan masa berlaku token
+	officeID, window, err := s.verify(req.Token)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if _, expiresAt := windowBounds(window); now.After(expiresAt.Add(kioskTokenGrace)) {
+		return nil, domain.ErrKioskTokenExpired
+	}
+
+	// 2. Karyawan yang terikat ke kantor lain tidak boleh memakai kiosk ini
+	employee, err := s.EmpRepo.FindByID(ctx, req.EmployeeID)
+	if err != nil {
+		return nil, errors.New("employee not found")
+	}
+	if employee.OfficeID != nil && *employee.OfficeID != officeID {
+		return nil, errors.New("employee is not assigned to this office")
+	}
+
+	// 3. Pemakaian token dan penulisan absensi dalam satu transaksi: token baru terpakai jika absensi tersimpan
+	att, err := inTransaction(ctx, s.Tx, func(ctx context.Context) (*domain.Attendance, error) {
+		// Satu token hanya bisa dipakai sekali per karyawan
+		used, err := s.UseRepo.Exists(ctx, officeID, window, employee.ID)
+		if err != nil {
+			return nil, err
+		}
+		if used {
+			return nil, domain.ErrKioskTokenReplayed
+		}
+		use := &domain.KioskTokenUse{OfficeID: officeID, TokenWindow: window, EmployeeID: employee.ID, Action: action, UsedAt: now}
+		if err := s.UseRepo.Save(ctx, use); err != nil {
+			return nil, err
+		}
+
+		// 4. Catat lewat AttendanceService: check-in sebagai punch IN agar hari yang sudah punya punch tetap bisa dipindai
+		if action == domain.KioskActionCheckOut {
+			return s.AttService.RecordCheckout(ctx, employee.ID, now)
+		}
+		return s.AttService.RecordPunch(ctx, &domain.Punch{
+			EmployeeID: employee.ID,
+			Timestamp:  now,
+			Direction:  domain.PunchIn,
+			Source:     domain.PunchSourceDevice,
+			DeviceID:   fmt.Sprintf("KIOSK-%d", officeID),
+		})
+	})
+	if err != nil && !errors.Is(err, domain.ErrKioskTokenReplayed) {
+		// Unique idx_kiosk_token_use: pemindaian yang sama terkirim bersamaan dan yang lain sudah di-commit
+		if used, _ := s.UseRepo.Exists(ctx, officeID, window, employee.ID); used {
+			return nil, domain.ErrKioskTokenReplayed
+		}
+	}
+	return att, err
+}
+
+// sign membuat token "<officeID>.<window>.<signature>"
+func (s *KioskServiceImpl) sign(officeID uint, window int64) string {
+	payload := fmt.Sprintf("%d.%d", officeID, window)
+	mac := hmac.New(sha256.New, s.Config.Secret)
+	mac.Write([]byte(payload))
+	return payload + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// verify memeriksa tanda tangan token dan mengembalikan kantor dan nomor jendelanya
+func (s *KioskServiceImpl) verify(token string) (uint, int64, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return 0, 0, domain.ErrKioskTokenInvalid
+	}
+	officeID, err := strconv.ParseUint(parts[0], 10, 32)
+	if err != nil {
+		return 0, 0, domain.ErrKioskTokenInvalid
+	}
+	window, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, domain.ErrKioskTokenInvalid
+	}
+	if !hmac.Equal([]byte(s.sign(uint(officeID), window)), []byte(token)) {
+		return 0, 0, domain.ErrKioskTokenInvalid
+	}
+	return uint(officeID), window, nil
+}
+
+// windowBounds mengembalikan awal dan akhir jendela token
+func windowBounds(window int64) (time.Time, time.Time) {
+	start := time.Unix(window*int64(domain.KioskTokenPeriod.Seconds()), 0)
+	return start, start.Add(domain.KioskTokenPeriod)
+}
+
+func hashKioskKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
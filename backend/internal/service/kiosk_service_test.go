@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"hr-payroll/internal/domain"
+	"hr-payroll/internal/repository/memory"
+	"testing"
+)
+
+func TestKioskScanRollsBackTokenUseWhenAttendanceFails(t *testing.T) {
+	ctx := context.Background()
+	repos := newTestRepos(t)
+	offices := memory.NewOfficeRepository()
+	uses := memory.NewKioskTokenUseRepository()
+	tx := memory.NewTxManager(repos.Attendance, repos.Punch, uses)
+	office := domain.Office{Name: "Kantor Pusat"}
+	if err := offices.Save(ctx, &office); err != nil {
+		t.Fatalf("save office: %v", err)
+	}
+	emp := repos.saveEmployee(t, domain.Employee{Name: "Budi", BaseSalary: 4400000})
+	attendance := NewAttendanceServiceImpl(repos.Attendance, failingPunchSave{repos.Punch}, repos.Status, repos.Period, repos.Payroll, tx, AttendanceConfig{})
+	service := NewKioskServiceImpl(attendance, offices, repos.Employee, uses, tx, KioskConfig{Secret: []byte("test-kiosk-secret")})
+
+	key, err := service.IssueKioskKey(ctx, office.ID)
+	if err != nil {
+		t.Fatalf("IssueKioskKey() error = %v", err)
+	}
+	token, err := service.CurrentToken(ctx, office.ID, key.Key)
+	if err != nil {
+		t.Fatalf("CurrentToken() error = %v", err)
+	}
+
+	if _, err := service.Scan(ctx, domain.KioskScanRequest{EmployeeID: emp.ID, Token: token.Token, Action: domain.KioskActionCheckIn}); err == nil {
+		t.Fatal("Scan() error = nil, want the punch error")
+	}
+	// Token yang gagal dicatat tidak boleh dianggap terpakai, agar karyawan bisa memindai ulang
+	attendance = NewAttendanceServiceImpl(repos.Attendance, repos.Punch, repos.Status, repos.Period, repos.Payroll, tx, AttendanceConfig{})
+	service = NewKioskServiceImpl(attendance, offices, repos.Employee, uses, tx, KioskConfig{Secret: []byte("test-kiosk-secret")})
+	if _, err := service.Scan(ctx, domain.KioskScanRequest{EmployeeID: emp.ID, Token: token.Token, Action: domain.KioskActionCheckIn}); err != nil {
+		t.Fatalf("Scan() retry error = %v, want the token to be usable again", err)
+	}
+}
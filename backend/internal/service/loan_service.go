@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hr-payroll/internal/domain"
+	"math"
+	"time"
+)
+
+// LoanServiceImpl mengimplementasikan domain.LoanService
+type LoanServiceImpl struct {
+	Repo    domain.LoanRepository
+	EmpRepo domain.EmployeeRepository
+	Tx      domain.TxManager
+}
+
+func NewLoanServiceImpl(repo domain.LoanRepository, er domain.EmployeeRepository, tx domain.TxManager) domain.LoanService {
+	return &LoanServiceImpl{Repo: repo, EmpRepo: er, Tx: tx}
+}
+
+// CreateLoan implements domain.LoanService
+func (s *LoanServiceImpl) CreateLoan(ctx context.Context, loan *domain.Loan) (*domain.Loan, error) {
+	// 1. Validasi
+	if _, err := s.EmpRepo.FindByID(ctx, loan.EmployeeID); err != nil {
+		return nil, errors.New("employee not found")
+	}
+	if loan.Principal <= 0 {
+		return nil, errors.New("principal must be greater than zero")
+	}
+	if loan.InstallmentAmount < 0 || loan.InstallmentCount < 0 {
+		return nil, errors.New("installment amount and count must not be negative")
+	}
+	if loan.InstallmentAmount == 0 && loan.InstallmentCount == 0 {
+		return nil, errors.New("set installment_amount or installment_count")
+	}
+	if loan.StartPeriod.IsZero() {
+		return nil, errors.New("start_period is required")
+	}
+
+	// 2. Lengkapi cicilan: jumlah dari banyak cicilan atau sebaliknya (dibulatkan ke atas)
+	if loan.InstallmentAmount == 0 {
+		loan.InstallmentAmount = math.Ceil(loan.Principal/float64(loan.InstallmentCount)*100) / 100
+	} else {
+		loan.InstallmentCount = int(math.Ceil(loan.Principal / loan.InstallmentAmount))
+	}
+
+	loan.ID = 0
+	loan.StartPeriod, _ = monthBounds(loan.StartPeriod)
+	loan.OutstandingBalance = loan.Principal
+	loan.Status = domain.LoanStatusActive
+	loan.SettledAt = nil
+	loan.Transactions = nil
+	if err := s.Repo.Save(ctx, loan); err != nil {
+		return nil, err
+	}
+	return loan, nil
+}
+
+// GetLoans implements domain.LoanService
+func (s *LoanServiceImpl) GetLoans(ctx context.Context, employeeID uint) ([]domain.Loan, error) {
+	return s.Repo.FindByEmployee(ctx, employeeID)
+}
+
+// GetLoan implements domain.LoanService
+func (s *LoanServiceImpl) GetLoan(ctx context.Context, id uint) (*domain.Loan, error) {
+	loan, err := s.Repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, domain.ErrLoanNotFound
+	}
+	return loan, nil
+}
+
+// SettleLoan implements domain.LoanService.
+// Slip GENERATED yang sudah memuat cicilan pinjaman ini harus dihitung ulang sebelum dibayar.
+// Transaksi pelunasan dan saldo pinjaman disimpan dalam satu transaksi database.
+func (s *LoanServiceImpl) SettleLoan(ctx context.Context, id uint, req domain.LoanSettlementRequest) (*domain.Loan, error) {
+	return inTransaction(ctx, s.Tx, func(ctx context.Context) (*domain.Loan, error) {
+		return s.settleLoan(ctx, id, req)
+	})
+}
+
+func (s *LoanServiceImpl) settleLoan(ctx context.Context, id uint, req domain.LoanSettlementRequest) (*domain.Loan, error) {
+	loan, err := s.Repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, domain.ErrLoanNotFound
+	}
+	if loan.Status != domain.LoanStatusActive {
+		return nil, domain.ErrLoanNotActive
+	}
+
+	tx := &domain.LoanTransaction{
+		LoanID:       loan.ID,
+		Type:         domain.LoanTxSettlement,
+		Amount:       loan.OutstandingBalance,
+		BalanceAfter: 0,
+		Note:         req.Note,
+	}
+	if err := s.Repo.SaveTransaction(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	loan.OutstandingBalance = 0
+	loan.Status = domain.LoanStatusSettled
+	loan.SettledAt = &now
+	if err := s.Repo.Update(ctx, loan); err != nil {
+		return nil, err
+	}
+	loan.Transactions = append(loan.Transactions, *tx)
+	return loan, nil
+}
+
+// loanInstallments membuat baris potongan cicilan untuk pinjaman ACTIVE yang sudah mulai dicicil.
+// Total potongan tidak boleh membuat take-home pay di bawah floor; sisa cicilan tidak ditagih bulan ini.
+func loanInstallments(loans []domain.Loan, period time.Time, takeHomePay float64, floor float64) []domain.PayrollItem {
+	available := takeHomePay - floor
+	var items []domain.PayrollItem
+	for i := range loans {
+		loan := &loans[i]
+		if available <= 0 {
+			break
+		}
+		if loan.Status != domain.LoanStatusActive || loan.StartPeriod.After(period) {
+			continue
+		}
+		amount := math.Min(math.Min(loan.InstallmentAmount, loan.OutstandingBalance), available)
+		amount = math.Floor(amount*100) / 100
+		if amount <= 0 {
+			continue
+		}
+		available -= amount
+		items = append(items, domain.PayrollItem{
+			Code:        domain.PayrollItemCodeLoan,
+			Type:        domain.PayrollItemDeduction,
+			Description: fmt.Sprintf("Cicilan pinjaman #%d", loan.ID),
+			Amount:      amount,
+			RefID:       &loan.ID,
+		})
+	}
+	return items
+}
+
+// applyLoanInstallments mengurangi saldo pinjaman sesuai baris LOAN slip yang dibayar
+func applyLoanInstallments(ctx context.Context, repo domain.LoanRepository, slip *domain.Payroll) error {
+	// 1. Pastikan saldo masih cukup untuk semua cicilan sebelum ada yang diubah
+	loans := map[uint]*domain.Loan{}
+	for _, item := range slip.Items {
+		if item.Code != domain.PayrollItemCodeLoan || item.RefID == nil {
+			continue
+		}
+		loan, ok := loans[*item.RefID]
+		if !ok {
+			found, err := repo.FindByID(ctx, *item.RefID)
+			if err != nil {
+				return domain.ErrLoanNotFound
+			}
+			loan = found
+			loans[loan.ID] = loan
+		}
+		if loan.Status != domain.LoanStatusActive || loan.OutstandingBalance+0.005 < item.Amount {
+			return domain.ErrLoanBalanceChange
+		}
+	}
+
+	// 2. Catat cicilan dan perbarui saldo
+	for _, item := range slip.Items {
+		if item.Code != domain.PayrollItemCodeLoan || item.RefID == nil {
+			continue
+		}
+		loan := loans[*item.RefID]
+		loan.OutstandingBalance = math.Max(0, math.Round((loan.OutstandingBalance-item.Amount)*100)/100)
+		if err := repo.SaveTransaction(ctx, &domain.LoanTransaction{
+			LoanID: loan.ID, PayrollID: &slip.ID, Type: domain.LoanTxInstallment,
+			Amount: item.Amount, BalanceAfter: loan.OutstandingBalance,
+		}); err != nil {
+			return err
+		}
+		if loan.OutstandingBalance == 0 {
+			now := time.Now()
+			loan.Status = domain.LoanStatusSettled
+			loan.SettledAt = &now
+		}
+		if err := repo.Update(ctx, loan); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reverseLoanInstallments mengembalikan saldo pinjaman dari baris LOAN slip yang di-void
+func reverseLoanInstallments(ctx context.Context, repo domain.LoanRepository, slip *domain.Payroll) error {
+	for _, item := range slip.Items {
+		if item.Code != domain.PayrollItemCodeLoan || item.RefID == nil {
+			continue
+		}
+		loan, err := repo.FindByID(ctx, *item.RefID)
+		if err != nil {
+			return domain.ErrLoanNotFound
+		}
+		loan.OutstandingBalance = math.Round((loan.OutstandingBalance+item.Amount)*100) / 100
+		loan.Status = domain.LoanStatusActive
+		loan.SettledAt = nil
+		if err := repo.SaveTransaction(ctx, &domain.LoanTransaction{
+			LoanID: loan.ID, PayrollID: &slip.ID, Type: domain.LoanTxReversal,
+			Amount: -item.Amount, BalanceAfter: loan.OutstandingBalance,
+			Note: "Slip di-void: " + slip.VoidReason,
+		}); err != nil {
+			return err
+		}
+		if err := repo.Update(ctx, loan); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"hr-payroll/internal/domain"
+	"testing"
+)
+
+func TestLoanInstallments(t *testing.T) {
+	period := day(2025, 11, 1)
+	loan := func(id uint, installment, outstanding float64, modify func(*domain.Loan)) domain.Loan {
+		l := domain.Loan{ID: id, InstallmentAmount: installment, OutstandingBalance: outstanding, StartPeriod: period, Status: domain.LoanStatusActive}
+		if modify != nil {
+			modify(&l)
+		}
+		return l
+	}
+
+	tests := []struct {
+		name        string
+		loans       []domain.Loan
+		takeHomePay float64
+		floor       float64
+		want        []float64
+	}{
+		{name: "full installment", loans: []domain.Loan{loan(1, 500000, 3000000, nil)}, takeHomePay: 5000000, want: []float64{500000}},
+		{name: "last installment is the remaining balance", loans: []domain.Loan{loan(1, 500000, 200000, nil)}, takeHomePay: 5000000, want: []float64{200000}},
+		{name: "not started yet", loans: []domain.Loan{loan(1, 500000, 3000000, func(l *domain.Loan) { l.StartPeriod = day(2025, 12, 1) })}, takeHomePay: 5000000},
+		{name: "settled loan", loans: []domain.Loan{loan(1, 500000, 0, func(l *domain.Loan) { l.Status = domain.LoanStatusSettled })}, takeHomePay: 5000000},
+		{name: "two loans", loans: []domain.Loan{loan(1, 500000, 3000000, nil), loan(2, 250000, 1000000, nil)}, takeHomePay: 5000000, want: []float64{500000, 250000}},
+		{name: "floor cuts the second loan", loans: []domain.Loan{loan(1, 500000, 3000000, nil), loan(2, 500000, 3000000, nil)},
+			takeHomePay: 1300000, floor: 1000000, want: []float64{300000}},
+		{name: "take-home already at the floor", loans: []domain.Loan{loan(1, 500000, 3000000, nil)}, takeHomePay: 1000000, floor: 1000000},
+		{name: "take-home below the floor", loans: []domain.Loan{loan(1, 500000, 3000000, nil)}, takeHomePay: 800000, floor: 1000000},
+		{name: "partial amount rounded down to the cent", loans: []domain.Loan{loan(1, 500000, 3000000, nil)},
+			takeHomePay: 1000333.339, floor: 1000000, want: []float64{333.33}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := loanInstallments(tt.loans, period, tt.takeHomePay, tt.floor)
+			if len(items) != len(tt.want) {
+				t.Fatalf("items = %+v, want %d installment(s)", items, len(tt.want))
+			}
+			for i, item := range items {
+				if item.Code != domain.PayrollItemCodeLoan || item.Type != domain.PayrollItemDeduction || item.Amount != tt.want[i] {
+					t.Errorf("item %d = %s %s %v, want LOAN deduction %v", i, item.Code, item.Type, item.Amount, tt.want[i])
+				}
+				if item.RefID == nil || *item.RefID != tt.loans[i].ID {
+					t.Errorf("item %d RefID = %v, want loan %d", i, item.RefID, tt.loans[i].ID)
+				}
+			}
+		})
+	}
+}
+
+// failingLoanUpdate mensimulasikan kegagalan database saat saldo pinjaman diperbarui
+type failingLoanUpdate struct {
+	domain.LoanRepository
+}
+
+func (failingLoanUpdate) Update(ctx context.Context, loan *domain.Loan) error {
+	return errors.New("disk full")
+}
+
+func TestSettleLoanRollsBackWhenUpdateFails(t *testing.T) {
+	ctx := context.Background()
+	repos := newTestRepos(t)
+	emp := repos.saveEmployee(t, domain.Employee{Name: "Budi", BaseSalary: 4400000})
+	loan := domain.Loan{EmployeeID: emp.ID, Principal: 3000000, InstallmentAmount: 500000, StartPeriod: day(2025, 11, 1), OutstandingBalance: 3000000}
+	if err := repos.Loan.Save(ctx, &loan); err != nil {
+		t.Fatalf("save loan: %v", err)
+	}
+	service := NewLoanServiceImpl(failingLoanUpdate{repos.Loan}, repos.Employee, repos.Tx)
+
+	if _, err := service.SettleLoan(ctx, loan.ID, domain.LoanSettlementRequest{Note: "Dilunasi tunai"}); err == nil {
+		t.Fatal("SettleLoan() error = nil, want the update error")
+	}
+	// Mutasi SETTLEMENT yang sudah tersimpan ikut dibatalkan
+	stored, err := repos.Loan.FindByID(ctx, loan.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if stored.Status != domain.LoanStatusActive || stored.OutstandingBalance != 3000000 || len(stored.Transactions) != 0 {
+		t.Errorf("loan = %s %v with %d transaction(s), want ACTIVE 3000000 without transactions", stored.Status, stored.OutstandingBalance, len(stored.Transactions))
+	}
+}
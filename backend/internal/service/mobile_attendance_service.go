@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hr-payroll/internal/domain"
+	"io"
+	"math"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Ekstensi selfie yang diterima
+var selfieExtensions = map[string]bool{".jpg": true, ".jpeg": true, ".png": true}
+
+// MobileAttendanceServiceImpl mengimplementasikan domain.MobileAttendanceService
+type MobileAttendanceServiceImpl struct {
+	AttService domain.AttendanceService
+	PunchRepo  domain.PunchRepository
+	EmpRepo    domain.EmployeeRepository
+	OfficeRepo domain.OfficeRepository
+	Storage    domain.FileStorage
+	PeriodRepo domain.AttendancePeriodRepository
+	PayRepo    domain.PayrollRepository
+}
+
+func NewMobileAttendanceServiceImpl(as domain.AttendanceService, pr domain.PunchRepository, er domain.EmployeeRepository, or domain.OfficeRepository, fs domain.FileStorage, apr domain.AttendancePeriodRepository, payr domain.PayrollRepository) domain.MobileAttendanceService {
+	return &MobileAttendanceServiceImpl{AttService: as, PunchRepo: pr, EmpRepo: er, OfficeRepo: or, Storage: fs, PeriodRepo: apr, PayRepo: payr}
+}
+
+// RecordMobilePunch implements domain.MobileAttendanceService
+func (s *MobileAttendanceServiceImpl) RecordMobilePunch(ctx context.Context, req domain.MobilePunchRequest) (*domain.Attendance, *domain.Punch, error) {
+	// 1. Validasi input
+	employee, err := s.EmpRepo.FindByID(ctx, req.EmployeeID)
+	if err != nil {
+		return nil, nil, errors.New("employee not found")
+	}
+	if !validCoordinate(req.Latitude, req.Longitude) {
+		return nil, nil, errors.New("invalid GPS coordinates")
+	}
+	if req.AccuracyMeters < 0 {
+		return nil, nil, errors.New("accuracy cannot be negative")
+	}
+	ext := strings.ToLower(filepath.Ext(req.SelfieName))
+	if req.Selfie != nil && !selfieExtensions[ext] {
+		return nil, nil, errors.New("selfie must be a JPG or PNG image")
+	}
+
+	// 2. Kantor yang berlaku: kantor karyawan, atau semua kantor jika belum ditentukan
+	var offices []domain.Office
+	if employee.OfficeID != nil {
+		office, err := s.OfficeRepo.FindByID(ctx, *employee.OfficeID)
+		if err != nil {
+			return nil, nil, domain.ErrOfficeNotFound
+		}
+		offices = []domain.Office{*office}
+	} else if offices, err = s.OfficeRepo.FindAll(ctx); err != nil {
+		return nil, nil, err
+	}
+	if len(offices) == 0 {
+		return nil, nil, errors.New("no office geofence configured")
+	}
+
+	// 3. Cek geofence: pakai kantor yang memuat lokasi, jika tidak ada pakai yang terdekat
+	location := domain.GeoPoint{Latitude: req.Latitude, Longitude: req.Longitude}
+	var matched *domain.Office
+	inside, distance := false, math.Inf(1)
+	for i := range offices {
+		in, d := checkGeofence(&offices[i], location)
+		if in && !inside || in == inside && d < distance {
+			matched, inside, distance = &offices[i], in, d
+		}
+	}
+
+	status := domain.GeofenceInside
+	switch {
+	case !inside:
+		status = domain.GeofenceOutside
+	case matched.MaxAccuracyMeters > 0 && req.AccuracyMeters > matched.MaxAccuracyMeters:
+		status = domain.GeofenceLowAccuracy
+	}
+
+	// 4. Terapkan kebijakan kantor: tolak, atau simpan dengan tanda untuk direview.
+	// Punch yang ditandai tidak dihitung di ringkasan harian sampai disetujui lewat ApproveFlaggedPunch.
+	flagged, reviewStatus := false, ""
+	if status != domain.GeofenceInside {
+		if matched.Policy != domain.GeofencePolicyFlag {
+			if status == domain.GeofenceLowAccuracy {
+				return nil, nil, fmt.Errorf("%w (%.0f m, max %.0f m)", domain.ErrGPSAccuracyTooLow, req.AccuracyMeters, matched.MaxAccuracyMeters)
+			}
+			return nil, nil, fmt.Errorf("%w (%.0f m from %s)", domain.ErrOutsideGeofence, distance, matched.Name)
+		}
+		flagged, reviewStatus = true, domain.PunchReviewPending
+	}
+
+	// 5. Simpan selfie (opsional)
+	now := time.Now().Truncate(time.Microsecond) // presisi kolom timestamptz
+	selfiePath := ""
+	if req.Selfie != nil {
+		suffix := make([]byte, 6)
+		if _, err := rand.Read(suffix); err != nil {
+			return nil, nil, err
+		}
+		name := fmt.Sprintf("selfies/%d/%s-%s%s", employee.ID, now.Format("20060102-150405"), hex.EncodeToString(suffix), ext)
+		if selfiePath, err = s.Storage.Save(name, req.Selfie); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// 6. Catat punch (waktu server, bukan waktu perangkat) lewat AttendanceService
+	officeID := matched.ID
+	latitude, longitude, accuracy := req.Latitude, req.Longitude, req.AccuracyMeters
+	distanceMeters := math.Round(distance*10) / 10
+	punch := domain.Punch{
+		EmployeeID:     employee.ID,
+		Timestamp:      now,
+		Direction:      strings.ToUpper(req.Direction),
+		Source:         domain.PunchSourceMobile,
+		Location:       matched.Name,
+		Latitude:       &latitude,
+		Longitude:      &longitude,
+		AccuracyMeters: &accuracy,
+		OfficeID:       &officeID,
+		DistanceMeters: &distanceMeters,
+		GeofenceStatus: status,
+		Flagged:        flagged,
+		ReviewStatus:   reviewStatus,
+		SelfiePath:     selfiePath,
+	}
+	att, err := s.AttService.RecordPunch(ctx, &punch)
+	if err != nil {
+		// Punch tidak tersimpan: jangan tinggalkan selfie tanpa pemilik di storage
+		if selfiePath != "" {
+			_ = s.Storage.Delete(selfiePath)
+		}
+		return nil, nil, err
+	}
+
+	// RecordPunch menyimpan salinan punch; ambil kembali untuk mendapatkan ID-nya
+	saved, err := s.PunchRepo.FindByEmployeeAndDate(ctx, employee.ID, truncateToDay(now))
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range saved {
+		if saved[i].Timestamp.Equal(now) {
+			return att, &saved[i], nil
+		}
+	}
+	return att, &punch, nil
+}
+
+// GetFlaggedPunches implements domain.MobileAttendanceService
+func (s *MobileAttendanceServiceImpl) GetFlaggedPunches(ctx context.Context, dateFrom time.Time, dateTo time.Time) ([]domain.Punch, error) {
+	return s.PunchRepo.FindFlagged(ctx, dateFrom, dateTo)
+}
+
+// ApproveFlaggedPunch implements domain.MobileAttendanceService.
+// Punch yang disetujui ikut dihitung dan ringkasan harian dihitung ulang.
+func (s *MobileAttendanceServiceImpl) ApproveFlaggedPunch(ctx context.Context, punchID uint, reviewer string, note string) (*domain.Punch, error) {
+	punch, err := s.reviewPunch(ctx, punchID, reviewer, note, domain.PunchReviewApproved)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.AttService.RecomputeAttendance(ctx, punch.EmployeeID, truncateToDay(punch.Timestamp)); err != nil {
+		return nil, err
+	}
+	return punch, nil
+}
+
+// RejectFlaggedPunch implements domain.MobileAttendanceService.
+// Punch yang ditolak tetap tersimpan (append-only) tetapi tidak pernah dihitung.
+func (s *MobileAttendanceServiceImpl) RejectFlaggedPunch(ctx context.Context, punchID uint, reviewer string, note string) (*domain.Punch, error) {
+	return s.reviewPunch(ctx, punchID, reviewer, note, domain.PunchReviewRejected)
+}
+
+// reviewPunch menyimpan hasil review punch yang ditandai; periode absensi harus masih terbuka
+func (s *MobileAttendanceServiceImpl) reviewPunch(ctx context.Context, punchID uint, reviewer string, note string, status string) (*domain.Punch, error) {
+	if strings.TrimSpace(reviewer) == "" {
+		return nil, errors.New("reviewer is required")
+	}
+	punch, err := s.PunchRepo.FindByID(ctx, punchID)
+	if err != nil {
+		return nil, domain.ErrPunchNotFound
+	}
+	if !punch.Flagged || punch.ReviewStatus == domain.PunchReviewApproved || punch.ReviewStatus == domain.PunchReviewRejected {
+		return nil, domain.ErrPunchNotPendingReview
+	}
+	if err := ensureAttendancePeriodOpen(ctx, s.PeriodRepo, s.PayRepo, punch.EmployeeID, truncateToDay(punch.Timestamp)); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	punch.ReviewStatus = status
+	punch.ReviewedBy = reviewer
+	punch.ReviewNote = note
+	punch.ReviewedAt = &now
+	if err := s.PunchRepo.UpdateReview(ctx, punch); err != nil {
+		return nil, err
+	}
+	return punch, nil
+}
+
+// OpenSelfie implements domain.MobileAttendanceService
+func (s *MobileAttendanceServiceImpl) OpenSelfie(ctx context.Context, punchID uint) (io.ReadCloser, error) {
+	punch, err := s.PunchRepo.FindByID(ctx, punchID)
+	if err != nil || punch.SelfiePath == "" {
+		return nil, errors.New("selfie not found")
+	}
+	return s.Storage.Open(punch.SelfiePath)
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"hr-payroll/internal/domain"
+	"strings"
+)
+
+// OfficeServiceImpl mengimplementasikan domain.OfficeService
+type OfficeServiceImpl struct {
+	Repo domain.OfficeRepository
+}
+
+func NewOfficeServiceImpl(repo domain.OfficeRepository) domain.OfficeService {
+	return &OfficeServiceImpl{Repo: repo}
+}
+
+// CreateOffice implements domain.OfficeService
+func (s *OfficeServiceImpl) CreateOffice(ctx context.Context, office *domain.Office) (*domain.Office, error) {
+	if err := validateOffice(office); err != nil {
+		return nil, err
+	}
+	if err := s.Repo.Save(ctx, office); err != nil {
+		return nil, err
+	}
+	return office, nil
+}
+
+// UpdateOffice implements domain.OfficeService
+func (s *OfficeServiceImpl) UpdateOffice(ctx context.Context, id uint, office *domain.Office) (*domain.Office, error) {
+	existing, err := s.Repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, domain.ErrOfficeNotFound
+	}
+	if err := validateOffice(office); err != nil {
+		return nil, err
+	}
+
+	existing.Name = office.Name
+	existing.GeofenceType = office.GeofenceType
+	existing.Latitude = office.Latitude
+	existing.Longitude = office.Longitude
+	existing.RadiusMeters = office.RadiusMeters
+	existing.Polygon = office.Polygon
+	existing.MaxAccuracyMeters = office.MaxAccuracyMeters
+	existing.Policy = office.Policy
+	if err := s.Repo.Update(ctx, existing); err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+// GetAllOffices implements domain.OfficeService
+func (s *OfficeServiceImpl) GetAllOffices(ctx context.Context) ([]domain.Office, error) {
+	return s.Repo.FindAll(ctx)
+}
+
+// validateOffice memeriksa dan menormalkan konfigurasi geofence
+func validateOffice(office *domain.Office) error {
+	if strings.TrimSpace(office.Name) == "" {
+		return errors.New("office name is required")
+	}
+	office.GeofenceType = strings.ToUpper(office.GeofenceType)
+	office.Policy = strings.ToUpper(office.Policy)
+	if office.Policy == "" {
+		office.Policy = domain.GeofencePolicyReject
+	}
+	if office.Policy != domain.GeofencePolicyReject && office.Policy != domain.GeofencePolicyFlag {
+		return errors.New("invalid policy: must be REJECT or FLAG")
+	}
+	if office.MaxAccuracyMeters < 0 {
+		return errors.New("max_accuracy_meters cannot be negative")
+	}
+
+	switch office.GeofenceType {
+	case domain.GeofenceRadius:
+		if !validCoordinate(office.Latitude, office.Longitude) {
+			return errors.New("invalid office coordinates")
+		}
+		if office.RadiusMeters <= 0 {
+			return errors.New("radius_meters must be greater than zero")
+		}
+		office.Polygon = nil
+	case domain.GeofencePolygon:
+		if len(office.Polygon) < 3 {
+			return errors.New("polygon geofence needs at least 3 points")
+		}
+		for _, p := range office.Polygon {
+			if !validCoordinate(p.Latitude, p.Longitude) {
+				return errors.New("invalid polygon coordinates")
+			}
+		}
+		office.RadiusMeters = 0
+	default:
+		return errors.New("invalid geofence_type: must be RADIUS or POLYGON")
+	}
+	return nil
+}
+
+func validCoordinate(lat, lng float64) bool {
+	return lat >= -90 && lat <= 90 && lng >= -180 && lng <= 180
+}
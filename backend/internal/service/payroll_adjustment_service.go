@@ -0,0 +1,324 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"hr-payroll/internal/domain"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PayrollAdjustmentServiceImpl mengimplementasikan domain.PayrollAdjustmentService
+type PayrollAdjustmentServiceImpl struct {
+	Repo    domain.PayrollAdjustmentRepository
+	EmpRepo domain.EmployeeRepository
+	PayRepo domain.PayrollRepository
+}
+
+func NewPayrollAdjustmentServiceImpl(repo domain.PayrollAdjustmentRepository, er domain.EmployeeRepository, pr domain.PayrollRepository) domain.PayrollAdjustmentService {
+	return &PayrollAdjustmentServiceImpl{Repo: repo, EmpRepo: er, PayRepo: pr}
+}
+
+// CreateAdjustment implements domain.PayrollAdjustmentService
+func (s *PayrollAdjustmentServiceImpl) CreateAdjustment(ctx context.Context, adjustment *domain.PayrollAdjustment) (*domain.PayrollAdjustment, error) {
+	adjustment.Source = domain.AdjustmentSourceAPI
+	if err := s.prepare(ctx, adjustment); err != nil {
+		return nil, err
+	}
+	if err := s.Repo.Save(ctx, adjustment); err != nil {
+		return nil, err
+	}
+	return adjustment, nil
+}
+
+// ImportCSV implements domain.PayrollAdjustmentService
+func (s *PayrollAdjustmentServiceImpl) ImportCSV(ctx context.Context, r io.Reader, enteredBy string, dryRun bool) (*domain.AdjustmentImportResult, error) {
+	if strings.TrimSpace(enteredBy) == "" {
+		return nil, errors.New("entered_by is required")
+	}
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV file: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("CSV file is empty")
+	}
+
+	// 1. Header wajib: employee_id, period, component, amount; note opsional
+	header := make(map[string]int, len(rows[0]))
+	for i, name := range rows[0] {
+		header[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"employee_id", "period", "component", "amount"} {
+		if _, ok := header[name]; !ok {
+			return nil, fmt.Errorf("missing %s column in header", name)
+		}
+	}
+	noteCol := findColumn(header, []string{"note"})
+
+	// 2. Setiap baris divalidasi sendiri; baris yang gagal dilaporkan, baris valid disimpan
+	result := &domain.AdjustmentImportResult{DryRun: dryRun, Adjustments: []domain.PayrollAdjustment{}, RowErrors: []domain.AdjustmentImportRowError{}}
+	for i, record := range rows[1:] {
+		row := i + 2 // baris 1 adalah header
+		if len(strings.Join(record, "")) == 0 {
+			continue
+		}
+		result.Rows++
+		employeeIDStr := cell(record, header["employee_id"])
+		rowError := func(err error) {
+			result.Failed++
+			result.RowErrors = append(result.RowErrors, domain.AdjustmentImportRowError{Row: row, EmployeeID: employeeIDStr, Error: err.Error()})
+		}
+
+		employeeID, err := strconv.ParseUint(employeeIDStr, 10, 32)
+		if err != nil {
+			rowError(errors.New("invalid employee_id"))
+			continue
+		}
+		period, err := time.Parse("2006-01", cell(record, header["period"]))
+		if err != nil {
+			rowError(errors.New("invalid period, use YYYY-MM"))
+			continue
+		}
+		amount, err := strconv.ParseFloat(cell(record, header["amount"]), 64)
+		if err != nil {
+			rowError(errors.New("invalid amount"))
+			continue
+		}
+
+		adjustment := domain.PayrollAdjustment{
+			EmployeeID: uint(employeeID),
+			Period:     period,
+			Component:  cell(record, header["component"]),
+			Amount:     amount,
+			Note:       cell(record, noteCol),
+			EnteredBy:  enteredBy,
+			Source:     domain.AdjustmentSourceCSV,
+		}
+		if err := s.prepare(ctx, &adjustment); err != nil {
+			rowError(err)
+			continue
+		}
+		if !dryRun {
+			if err := s.Repo.Save(ctx, &adjustment); err != nil {
+				rowError(err)
+				continue
+			}
+		}
+		result.Imported++
+		result.Adjustments = append(result.Adjustments, adjustment)
+	}
+	return result, nil
+}
+
+// CancelAdjustment implements domain.PayrollAdjustmentService.
+// Slip GENERATED yang sudah memuat penyesuaian ini harus dihitung ulang sebelum dibayar.
+func (s *PayrollAdjustmentServiceImpl) CancelAdjustment(ctx context.Context, id uint, cancelledBy string) (*domain.PayrollAdjustment, error) {
+	adjustment, err := s.Repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, domain.ErrAdjustmentNotFound
+	}
+	if adjustment.Status != domain.AdjustmentPending {
+		return nil, domain.ErrAdjustmentNotPending
+	}
+	if strings.TrimSpace(cancelledBy) == "" {
+		return nil, errors.New("cancelled_by is required")
+	}
+
+	now := time.Now()
+	adjustment.Status = domain.AdjustmentCancelled
+	adjustment.CancelledBy = strings.TrimSpace(cancelledBy)
+	adjustment.CancelledAt = &now
+	adjustment.PayrollID = nil
+	if err := s.Repo.Update(ctx, adjustment); err != nil {
+		return nil, err
+	}
+	return adjustment, nil
+}
+
+// GetAdjustments implements domain.PayrollAdjustmentService
+func (s *PayrollAdjustmentServiceImpl) GetAdjustments(ctx context.Context, employeeID uint, period time.Time, status string) ([]domain.PayrollAdjustment, error) {
+	if !period.IsZero() {
+		period, _ = monthBounds(period)
+	}
+	return s.Repo.FindAll(ctx, employeeID, period, strings.ToUpper(status))
+}
+
+// GetAdjustment implements domain.PayrollAdjustmentService
+func (s *PayrollAdjustmentServiceImpl) GetAdjustment(ctx context.Context, id uint) (*domain.PayrollAdjustment, error) {
+	adjustment, err := s.Repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, domain.ErrAdjustmentNotFound
+	}
+	return adjustment, nil
+}
+
+// prepare memvalidasi penyesuaian dan melengkapi jenis serta perlakuan pajak dari katalog komponen
+func (s *PayrollAdjustmentServiceImpl) prepare(ctx context.Context, adjustment *domain.PayrollAdjustment) error {
+	// 1. Validasi input
+	if _, err := s.EmpRepo.FindByID(ctx, adjustment.EmployeeID); err != nil {
+		return errors.New("employee not found")
+	}
+	if adjustment.Period.IsZero() {
+		return errors.New("period is required")
+	}
+	adjustment.Component = strings.ToUpper(strings.TrimSpace(adjustment.Component))
+	component := domain.FindAdjustmentComponent(adjustment.Component)
+	if component == nil {
+		return fmt.Errorf("%w: %s", domain.ErrUnknownAdjustmentComponent, adjustment.Component)
+	}
+	if adjustment.Amount <= 0 {
+		return errors.New("amount must be greater than zero")
+	}
+	adjustment.Amount = math.Round(adjustment.Amount*100) / 100
+	adjustment.EnteredBy = strings.TrimSpace(adjustment.EnteredBy)
+	if adjustment.EnteredBy == "" {
+		return errors.New("entered_by is required")
+	}
+
+	// 2. Slip periode tersebut yang sudah dibayar tidak bisa diubah lagi
+	adjustment.Period, _ = monthBounds(adjustment.Period)
+	slips, err := s.PayRepo.FindByEmployee(ctx, adjustment.EmployeeID)
+	if err != nil {
+		return err
+	}
+	for _, slip := range slips {
+		if start, _ := monthBounds(slip.Period); start.Equal(adjustment.Period) && slip.Status == domain.PayrollStatusPaid {
+			return domain.ErrAdjustmentPeriodClosed
+		}
+	}
+
+	adjustment.ID = 0
+	adjustment.Type = component.Type
+	adjustment.Taxable = component.Taxable
+	adjustment.Status = domain.AdjustmentPending
+	adjustment.PayrollID = nil
+	adjustment.AppliedAt = nil
+	adjustment.CancelledBy = ""
+	adjustment.CancelledAt = nil
+	return nil
+}
+
+// adjustmentItems membuat baris slip untuk penyesuaian, kode baris = kode komponen
+func adjustmentItems(adjustments []domain.PayrollAdjustment) []domain.PayrollItem {
+	items := make([]domain.PayrollItem, 0, len(adjustments))
+	for i := range adjustments {
+		adjustment := &adjustments[i]
+		description := adjustment.Component
+		if component := domain.FindAdjustmentComponent(adjustment.Component); component != nil {
+			description = component.Name
+		}
+		if adjustment.Note != "" {
+			description += ": " + adjustment.Note
+		}
+		items = append(items, domain.PayrollItem{
+			Code:        adjustment.Component,
+			Type:        adjustment.Type,
+			Description: description,
+			Amount:      adjustment.Amount,
+			RefID:       &adjustment.ID,
+			Taxable:     adjustment.Taxable,
+		})
+	}
+	return items
+}
+
+// isAdjustmentItem: baris slip berasal dari penyesuaian payroll
+func isAdjustmentItem(item domain.PayrollItem) bool {
+	return item.RefID != nil && domain.FindAdjustmentComponent(item.Code) != nil
+}
+
+// linkAdjustments mengikat penyesuaian pada slip agar tidak ikut slip lain
+func linkAdjustments(ctx context.Context, repo domain.PayrollAdjustmentRepository, slip *domain.Payroll) error {
+	for _, item := range slip.Items {
+		if !isAdjustmentItem(item) {
+			continue
+		}
+		adjustment, err := repo.FindByID(ctx, *item.RefID)
+		if err != nil {
+			return domain.ErrAdjustmentNotFound
+		}
+		adjustment.PayrollID = &slip.ID
+		if err := repo.Update(ctx, adjustment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkAdjustments memastikan penyesuaian di slip masih sama dengan saat slip dihitung
+// (tidak ada yang dibatalkan atau ditambahkan); jika berubah, slip harus dihitung ulang dulu.
+func checkAdjustments(ctx context.Context, repo domain.PayrollAdjustmentRepository, slip *domain.Payroll) error {
+	onSlip := map[uint]bool{}
+	for _, item := range slip.Items {
+		if isAdjustmentItem(item) {
+			onSlip[*item.RefID] = true
+		}
+	}
+	linked, err := repo.FindByPayroll(ctx, slip.ID)
+	if err != nil {
+		return err
+	}
+	if len(linked) != len(onSlip) {
+		return domain.ErrAdjustmentChanged
+	}
+	for _, adjustment := range linked {
+		if !onSlip[adjustment.ID] || adjustment.Status != domain.AdjustmentPending {
+			return domain.ErrAdjustmentChanged
+		}
+	}
+	if slip.Type != domain.PayrollTypeRegular {
+		return nil
+	}
+	period, _ := monthBounds(slip.Period)
+	pending, err := repo.FindPayable(ctx, slip.EmployeeID, period, slip.ID)
+	if err != nil {
+		return err
+	}
+	if len(pending) != len(onSlip) {
+		return domain.ErrAdjustmentChanged
+	}
+	return nil
+}
+
+// applyAdjustments menandai penyesuaian di slip yang dibayar sebagai APPLIED
+func applyAdjustments(ctx context.Context, repo domain.PayrollAdjustmentRepository, slip *domain.Payroll) error {
+	adjustments, err := repo.FindByPayroll(ctx, slip.ID)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for i := range adjustments {
+		adjustments[i].Status = domain.AdjustmentApplied
+		adjustments[i].AppliedAt = &now
+		if err := repo.Update(ctx, &adjustments[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// releaseAdjustments mengembalikan penyesuaian dari slip yang di-void ke PENDING agar dimuat slip pengganti
+func releaseAdjustments(ctx context.Context, repo domain.PayrollAdjustmentRepository, slip *domain.Payroll) error {
+	adjustments, err := repo.FindByPayroll(ctx, slip.ID)
+	if err != nil {
+		return err
+	}
+	for i := range adjustments {
+		adjustments[i].Status = domain.AdjustmentPending
+		adjustments[i].PayrollID = nil
+		adjustments[i].AppliedAt = nil
+		if err := repo.Update(ctx, &adjustments[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
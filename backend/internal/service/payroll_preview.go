@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"hr-payroll/internal/domain"
+	"time"
+)
+
+// PreviewPayroll implements domain.PayrollService.
+// Perhitungan sama persis dengan GenerateMonthlyPayroll, tetapi hasilnya tidak disimpan.
+func (s *PayrollServiceImpl) PreviewPayroll(ctx context.Context, req domain.PayrollPreviewRequest) ([]domain.PayrollPreview, error) {
+	if req.Overrides != nil && req.Overrides.ExtraAbsences < 0 {
+		return nil, errors.New("extra_absences must not be negative")
+	}
+	req.Period, _ = monthBounds(req.Period)
+
+	// 1. Tentukan karyawan yang disimulasikan
+	var employees []domain.Employee
+	switch {
+	case req.EmployeeID != 0:
+		employee, err := s.EmpRepo.FindByID(ctx, req.EmployeeID)
+		if err != nil {
+			return nil, errors.New("employee not found")
+		}
+		employees = []domain.Employee{*employee}
+	case req.Department != "":
+		found, err := s.EmpRepo.FindByDepartment(ctx, req.Department)
+		if err != nil {
+			return nil, err
+		}
+		employees = found
+	default:
+		found, err := s.EmpRepo.FindAll(ctx)
+		if err != nil {
+			return nil, err
+		}
+		employees = found
+	}
+
+	// 2. Hitung per karyawan; kegagalan satu karyawan tidak menggagalkan yang lain
+	previews := make([]domain.PayrollPreview, 0, len(employees))
+	for i := range employees {
+		employee := &employees[i]
+		preview := domain.PayrollPreview{EmployeeID: employee.ID, EmployeeName: employee.Name}
+
+		// Slip yang sudah ada untuk periode ini dihitung dengan ID-nya, agar reimbursement dan
+		// adjustment yang sudah terikat ke slip tersebut tetap ikut (sama seperti RecalculatePayroll)
+		existing, err := s.PayRepo.FindByEmployeeAndPeriod(ctx, employee.ID, req.Period)
+		if err != nil {
+			return nil, err
+		}
+		var payrollID uint
+		if existing != nil {
+			payrollID = existing.ID
+		}
+
+		payroll, err := s.calculatePayroll(ctx, employee, req.Period, payrollID, req.Overrides)
+		if err != nil {
+			preview.Error = err.Error()
+			previews = append(previews, preview)
+			continue
+		}
+		preview.Payroll = payroll
+		preview.Lines = payrollLines(payroll)
+
+		// 3. Bandingkan dengan slip terakhir yang sudah digenerate
+		last, err := s.lastSlip(ctx, employee.ID, req.Period)
+		if err != nil {
+			return nil, err
+		}
+		if last != nil {
+			preview.LastSlip = last
+			preview.Diff = &domain.PayrollDiff{
+				BaseSalary:       payroll.BaseSalary - last.BaseSalary,
+				Allowance:        payroll.Allowance - last.Allowance,
+				TotalAbsent:      payroll.TotalAbsent - last.TotalAbsent,
+				AbsenceDeduction: payroll.AbsenceDeduction - last.AbsenceDeduction,
+				RetroAdjustment:  payroll.RetroAdjustment - last.RetroAdjustment,
+				TakeHomePay:      payroll.TakeHomePay - last.TakeHomePay,
+			}
+		}
+		previews = append(previews, preview)
+	}
+	return previews, nil
+}
+
+// lastSlip mengembalikan slip terakhir (tidak VOID) dengan periode <= period
+func (s *PayrollServiceImpl) lastSlip(ctx context.Context, employeeID uint, period time.Time) (*domain.Payroll, error) {
+	slips, err := s.PayRepo.FindByEmployee(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	var last *domain.Payroll
+	for i := range slips {
+		if slips[i].Period.After(period) {
+			break
+		}
+		last = &slips[i]
+	}
+	return last, nil
+}
+
+// payrollLines merinci slip menjadi baris penerimaan dan potongan
+func payrollLines(p *domain.Payroll) []domain.PayrollLine {
+	lines := []domain.PayrollLine{
+		{Code: "BASE_SALARY", Type: domain.PayrollItemEarning, Description: "Gaji pokok (pro-rata)", Amount: p.ProratedBase},
+		{Code: "ALLOWANCE", Type: domain.PayrollItemEarning, Description: "Tunjangan (pro-rata)", Amount: p.ProratedAllowance},
+	}
+	if p.AbsenceDeduction != 0 {
+		lines = append(lines, domain.PayrollLine{Code: "ABSENCE", Type: domain.PayrollItemDeduction, Description: "Potongan absen", Amount: p.AbsenceDeduction})
+	}
+	for _, item := range p.Items {
+		lines = append(lines, domain.PayrollLine{Code: item.Code, Type: item.Type, Description: item.Description, Amount: item.Amount})
+	}
+	return lines
+}
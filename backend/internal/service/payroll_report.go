@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"hr-payroll/internal/domain"
+	"math"
+	"sort"
+	"time"
+)
+
+// GetVarianceReport implements domain.PayrollService.
+// Membandingkan slip yang tersimpan (tidak VOID) di periodFrom dan periodTo per karyawan dan secara agregat.
+func (s *PayrollServiceImpl) GetVarianceReport(ctx context.Context, periodFrom time.Time, periodTo time.Time, threshold float64) (*domain.PayrollVarianceReport, error) {
+	if threshold < 0 {
+		return nil, errors.New("threshold must not be negative")
+	}
+
+	// 1. Ambil slip kedua periode
+	fromSlips, err := s.PayRepo.FindByPeriod(ctx, periodFrom)
+	if err != nil {
+		return nil, err
+	}
+	toSlips, err := s.PayRepo.FindByPeriod(ctx, periodTo)
+	if err != nil {
+		return nil, err
+	}
+	names, err := s.employeeNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.PayrollVarianceReport{
+		PeriodFrom: time.Date(periodFrom.Year(), periodFrom.Month(), 1, 0, 0, 0, 0, time.UTC),
+		PeriodTo:   time.Date(periodTo.Year(), periodTo.Month(), 1, 0, 0, 0, 0, time.UTC),
+		Threshold:  threshold,
+		Rows:       []domain.PayrollVarianceRow{},
+	}
+
+	// 2. Gabungkan per karyawan
+	from := make(map[uint]domain.Payroll, len(fromSlips))
+	for _, slip := range fromSlips {
+		from[slip.EmployeeID] = slip
+		report.Summary.EmployeesFrom++
+		report.Summary.TotalBaseSalaryFrom += slip.BaseSalary
+		report.Summary.TotalAllowanceFrom += slip.Allowance
+		report.Summary.TotalAbsenceDeductionFrom += slip.AbsenceDeduction
+		report.Summary.TotalTakeHomePayFrom += slip.TakeHomePay
+	}
+	to := make(map[uint]domain.Payroll, len(toSlips))
+	for _, slip := range toSlips {
+		to[slip.EmployeeID] = slip
+		report.Summary.EmployeesTo++
+		report.Summary.TotalBaseSalaryTo += slip.BaseSalary
+		report.Summary.TotalAllowanceTo += slip.Allowance
+		report.Summary.TotalAbsenceDeductionTo += slip.AbsenceDeduction
+		report.Summary.TotalTakeHomePayTo += slip.TakeHomePay
+	}
+	report.Summary.TotalTakeHomePayDelta = report.Summary.TotalTakeHomePayTo - report.Summary.TotalTakeHomePayFrom
+
+	employeeIDs := make([]uint, 0, len(from)+len(to))
+	for id := range from {
+		employeeIDs = append(employeeIDs, id)
+	}
+	for id := range to {
+		if _, ok := from[id]; !ok {
+			employeeIDs = append(employeeIDs, id)
+		}
+	}
+	sort.Slice(employeeIDs, func(i, j int) bool { return employeeIDs[i] < employeeIDs[j] })
+
+	// 3. Baris per karyawan: karyawan baru/keluar selalu dilaporkan, sisanya hanya jika ada perubahan
+	for _, id := range employeeIDs {
+		before, inFrom := from[id]
+		after, inTo := to[id]
+		row := domain.PayrollVarianceRow{
+			EmployeeID:            id,
+			EmployeeName:          names[id],
+			BaseSalaryFrom:        before.BaseSalary,
+			BaseSalaryTo:          after.BaseSalary,
+			BaseSalaryDelta:       after.BaseSalary - before.BaseSalary,
+			AllowanceFrom:         before.Allowance,
+			AllowanceTo:           after.Allowance,
+			AllowanceDelta:        after.Allowance - before.Allowance,
+			AbsenceDeductionFrom:  before.AbsenceDeduction,
+			AbsenceDeductionTo:    after.AbsenceDeduction,
+			AbsenceDeductionDelta: after.AbsenceDeduction - before.AbsenceDeduction,
+			TakeHomePayFrom:       before.TakeHomePay,
+			TakeHomePayTo:         after.TakeHomePay,
+			TakeHomePayDelta:      after.TakeHomePay - before.TakeHomePay,
+		}
+
+		switch {
+		case !inFrom:
+			row.Change = domain.VarianceNew
+			report.Summary.NewEmployees++
+		case !inTo:
+			row.Change = domain.VarianceDeparted
+			report.Summary.DepartedEmployees++
+		default:
+			row.Change = domain.VarianceChanged
+			if row.BaseSalaryDelta != 0 {
+				row.Reasons = append(row.Reasons, "BASE_SALARY")
+			}
+			if row.AllowanceDelta != 0 {
+				row.Reasons = append(row.Reasons, "ALLOWANCE")
+			}
+			if row.AbsenceDeductionDelta != 0 {
+				row.Reasons = append(row.Reasons, "ABSENCE_DEDUCTION")
+			}
+			if math.Abs(row.TakeHomePayDelta) > threshold {
+				row.Reasons = append(row.Reasons, "TAKE_HOME_PAY")
+			}
+			if len(row.Reasons) == 0 {
+				continue
+			}
+		}
+		report.Rows = append(report.Rows, row)
+	}
+	return report, nil
+}
+
+// employeeNames membuat lookup nama karyawan per ID
+func (s *PayrollServiceImpl) employeeNames(ctx context.Context) (map[uint]string, error) {
+	employees, err := s.EmpRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[uint]string, len(employees))
+	for _, emp := range employees {
+		names[emp.ID] = emp.Name
+	}
+	return names, nil
+}
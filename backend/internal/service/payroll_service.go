@@ -1,76 +1,340 @@
 package service
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"hr-payroll/internal/domain"
+	"math"
+	"strconv"
+	"strings"
 	"time"
 )
 
+// workingDaysInMonth adalah pembagi gaji harian untuk potongan absen [cite: 41]
+const workingDaysInMonth = 22
+
+// PayrollConfig menampung pengaturan perhitungan payroll
+type PayrollConfig struct {
+	ProrationMethod string  // domain.ProrationCalendarDays, domain.ProrationWorkingDays, atau domain.ProrationFixed30
+	THRWageBase     string  // domain.THRWageBaseOnly atau domain.THRWageBasePlusAllowance (untuk hitung ulang slip THR)
+	TakeHomeFloor   float64 // Cicilan pinjaman tidak boleh membuat take-home pay di bawah nilai ini
+	TaxWithholding  string  // domain.TaxWithholdingNone atau domain.TaxWithholdingTER
+}
+
 type PayrollServiceImpl struct {
-	EmpRepo domain.EmployeeRepository
-	AttRepo domain.AttendanceRepository
-	PayRepo domain.PayrollRepository
+	EmpRepo     domain.EmployeeRepository
+	AttRepo     domain.AttendanceRepository
+	PayRepo     domain.PayrollRepository
+	HolidayRepo domain.HolidayRepository
+	SalaryRepo  domain.SalaryHistoryRepository
+	StatusRepo  domain.AttendanceStatusRepository
+	LoanRepo    domain.LoanRepository
+	ReimbRepo   domain.ReimbursementRepository
+	AdjRepo     domain.PayrollAdjustmentRepository
+	Tx          domain.TxManager
+	Config      PayrollConfig
+}
+
+func NewPayrollServiceImpl(er domain.EmployeeRepository, ar domain.AttendanceRepository, pr domain.PayrollRepository, hr domain.HolidayRepository, sr domain.SalaryHistoryRepository, str domain.AttendanceStatusRepository, lr domain.LoanRepository, rr domain.ReimbursementRepository, adr domain.PayrollAdjustmentRepository, tx domain.TxManager, cfg PayrollConfig) domain.PayrollService {
+	if cfg.ProrationMethod == "" {
+		cfg.ProrationMethod = domain.ProrationCalendarDays
+	}
+	if cfg.THRWageBase == "" {
+		cfg.THRWageBase = domain.THRWageBasePlusAllowance
+	}
+	if cfg.TaxWithholding == "" {
+		cfg.TaxWithholding = domain.TaxWithholdingNone
+	}
+	return &PayrollServiceImpl{EmpRepo: er, AttRepo: ar, PayRepo: pr, HolidayRepo: hr, SalaryRepo: sr, StatusRepo: str, LoanRepo: lr, ReimbRepo: rr, AdjRepo: adr, Tx: tx, Config: cfg}
 }
 
-func NewPayrollServiceImpl(er domain.EmployeeRepository, ar domain.AttendanceRepository, pr domain.PayrollRepository) domain.PayrollService {
-	return &PayrollServiceImpl{EmpRepo: er, AttRepo: ar, PayRepo: pr}
+// GenerateMonthlyPayroll implements domain.PayrollService.
+// Pengecekan duplikat dan penyimpanan berjalan dalam satu transaksi, sehingga dua request bersamaan
+// untuk karyawan & periode yang sama tidak bisa sama-sama lolos; yang kalah diulang lalu mendapat ErrPayrollAlreadyGenerated.
+func (s *PayrollServiceImpl) GenerateMonthlyPayroll(ctx context.Context, employeeID uint, period time.Time) (*domain.Payroll, error) {
+	return inTransaction(ctx, s.Tx, func(ctx context.Context) (*domain.Payroll, error) {
+		return s.generateMonthlyPayroll(ctx, employeeID, period)
+	})
 }
 
-// GenerateMonthlyPayroll implements domain.PayrollService
-func (s *PayrollServiceImpl) GenerateMonthlyPayroll(employeeID uint, period time.Time) (*domain.Payroll, error) {
+func (s *PayrollServiceImpl) generateMonthlyPayroll(ctx context.Context, employeeID uint, period time.Time) (*domain.Payroll, error) {
+	// Periode slip selalu awal bulan (UTC), apa pun tanggal yang dikirim klien
+	period, _ = monthBounds(period)
+
 	// 1. Validasi Unik: Payroll untuk kombinasi employee_id + period hanya boleh satu [cite: 42]
-	existingPayroll, _ := s.PayRepo.FindByEmployeeAndPeriod(employeeID, period)
+	existingPayroll, err := s.PayRepo.FindByEmployeeAndPeriod(ctx, employeeID, period)
+	if err != nil {
+		return nil, err
+	}
 	if existingPayroll != nil && existingPayroll.ID != 0 {
-		return nil, errors.New("payroll already generated for this employee and period [cite: 42]")
+		return nil, domain.ErrPayrollAlreadyGenerated
 	}
 
 	// 2. Ambil data Employee
-	employee, err := s.EmpRepo.FindByID(employeeID)
+	employee, err := s.EmpRepo.FindByID(ctx, employeeID)
 	if err != nil {
 		return nil, errors.New("employee not found")
 	}
 
+	// 3-4. Hitung komponen slip
+	payroll, err := s.calculatePayroll(ctx, employee, period, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// 5. Simpan entitas Payroll
+	if err := s.PayRepo.Save(ctx, payroll); err != nil {
+		return nil, err
+	}
+	if err := linkReimbursements(ctx, s.ReimbRepo, payroll); err != nil {
+		return nil, err
+	}
+	if err := linkAdjustments(ctx, s.AdjRepo, payroll); err != nil {
+		return nil, err
+	}
+	return payroll, nil
+}
+
+// calculatePayroll menghitung slip gaji untuk satu karyawan dan periode tanpa menyimpannya.
+// payrollID adalah slip yang dihitung ulang atau di-preview (0 untuk slip baru), agar klaim reimbursement
+// yang sudah terikat ke slip tersebut tetap ikut. overrides hanya dipakai untuk simulasi (preview), nil untuk perhitungan sebenarnya.
+func (s *PayrollServiceImpl) calculatePayroll(ctx context.Context, employee *domain.Employee, period time.Time, payrollID uint, overrides *domain.PayrollOverrides) (*domain.Payroll, error) {
+	employeeID := employee.ID
+
 	// Tentukan periode attendance (Asumsi: sebulan penuh sebelum 'period')
 	dateFrom := time.Date(period.Year(), period.Month(), 1, 0, 0, 0, 0, time.UTC)
 	dateTo := dateFrom.AddDate(0, 1, 0).Add(-time.Second) // Akhir bulan
 
-	// 3. Ambil data Attendance dan hitung total absent
-	attendances, _ := s.AttRepo.FindByPeriod(employeeID, dateFrom, dateTo)
-	totalAbsent := 0
+	// Hitung pro-rata untuk karyawan yang masuk/keluar di tengah periode
+	periodStart, periodEnd := monthBounds(period)
+	holidays, err := s.HolidayRepo.FindByPeriod(ctx, periodStart, periodEnd)
+	if err != nil {
+		return nil, err
+	}
+	prorate, err := calculateProration(s.Config.ProrationMethod, employee, periodStart, periodEnd, holidaySet(holidays))
+	if err != nil {
+		return nil, err
+	}
+
+	// Gaji yang berlaku untuk periode ini diambil dari riwayat gaji (per hari terakhir yang dihitung)
+	histories, err := s.SalaryRepo.FindByEmployee(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	baseSalary, allowance := salaryAt(employee, histories, prorate.ActiveTo)
+	if overrides != nil && overrides.BaseSalary != nil {
+		baseSalary = *overrides.BaseSalary
+	}
+	if overrides != nil && overrides.Allowance != nil {
+		allowance = *overrides.Allowance
+	}
+
+	// Rapel untuk periode yang sudah dibayar jika ada kenaikan gaji berlaku mundur
+	previousSlips, err := s.PayRepo.FindByEmployee(ctx, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	items := retroAdjustments(employee, histories, previousSlips, periodStart)
+	retroAdjustment := 0.0
+	for _, item := range items {
+		retroAdjustment += item.Amount
+	}
+
+	// 3. Ambil data Attendance dan hitung hari potongan dari bobot status di katalog (ABSENT = 1, HALF_DAY = 0.5, ...)
+	attendances, err := s.AttRepo.FindByPeriod(ctx, employeeID, dateFrom, dateTo)
+	if err != nil {
+		return nil, err
+	}
+	catalogue, err := statusCatalogue(ctx, s.StatusRepo)
+	if err != nil {
+		return nil, err
+	}
+	totalAbsent := 0.0
 	for _, att := range attendances {
-		if att.Status == "ABSENT" {
-			totalAbsent++
-		}
+		totalAbsent += catalogue[att.Status].DeductionWeight
+	}
+	if overrides != nil {
+		totalAbsent += float64(overrides.ExtraAbsences)
 	}
 
 	// 4. Hitung Deduction dan Take Home Pay [cite: 41]
-	const workingDaysInMonth = 22
-	dailySalary := employee.BaseSalary / workingDaysInMonth
-	absenceDeduction := dailySalary * float64(totalAbsent)                     // (base_salary / 22) * total_absent [cite: 41]
-	takeHomePay := employee.BaseSalary + employee.Allowance - absenceDeduction // base_salary + allowance - absence_deduction [cite: 41]
+	dailySalary := baseSalary / workingDaysInMonth
+	absenceDeduction := dailySalary * totalAbsent // (base_salary / 22) * total_absent [cite: 41]
+	proratedBase := baseSalary * prorate.Factor
+	proratedAllowance := allowance * prorate.Factor
+	takeHomePay := proratedBase + proratedAllowance - absenceDeduction + retroAdjustment // base_salary + allowance - absence_deduction (setelah pro-rata) + rapel [cite: 41]
 
-	// 5. Buat dan simpan entitas Payroll
-	payroll := &domain.Payroll{
-		EmployeeID:       employeeID,
-		Period:           period,
-		BaseSalary:       employee.BaseSalary,
-		Allowance:        employee.Allowance,
-		TotalAbsent:      totalAbsent,
-		AbsenceDeduction: absenceDeduction,
-		TakeHomePay:      takeHomePay,
-		GeneratedAt:      time.Now(),
+	// Klaim reimbursement yang sudah disetujui finance dibayar di slip berikutnya (tidak kena pajak)
+	claims, err := s.ReimbRepo.FindPayable(ctx, employeeID, payrollID)
+	if err != nil {
+		return nil, err
+	}
+	reimbursement := 0.0
+	for _, item := range reimbursementItems(claims) {
+		reimbursement += item.Amount
+		items = append(items, item)
 	}
+	takeHomePay += reimbursement
 
-	if err := s.PayRepo.Save(payroll); err != nil {
+	// Bonus, komisi, insentif dan denda satu kali untuk periode ini
+	adjustments, err := s.AdjRepo.FindPayable(ctx, employeeID, periodStart, payrollID)
+	if err != nil {
+		return nil, err
+	}
+	adjustment, taxableIncome := 0.0, 0.0
+	for _, item := range adjustmentItems(adjustments) {
+		if item.Type == domain.PayrollItemDeduction {
+			adjustment -= item.Amount
+		} else {
+			adjustment += item.Amount
+		}
+		if item.Taxable {
+			taxableIncome += item.Amount
+		}
+		items = append(items, item)
+	}
+	takeHomePay += adjustment
+
+	// PPh 21 sesuai PAYROLL_TAX_WITHHOLDING. PTKP memakai status awal tahun pajak.
+	taxStatus := yearTaxStatus(employee, previousSlips, periodStart.Year())
+	regularIncome := proratedBase + proratedAllowance - absenceDeduction + retroAdjustment
+	tax, taxMethod, taxItems, err := s.withholdingTax(ctx, employee, payrollID, periodStart, periodEnd, baseSalary+allowance, regularIncome, taxableIncome, taxStatus)
+	if err != nil {
+		return nil, err
+	}
+	items = append(items, taxItems...)
+	takeHomePay -= tax
+
+	// Cicilan pinjaman / kasbon, dibatasi agar take-home pay tidak di bawah floor
+	loans, err := s.LoanRepo.FindActiveByEmployee(ctx, employeeID)
+	if err != nil {
 		return nil, err
 	}
+	loanDeduction := 0.0
+	for _, item := range loanInstallments(loans, periodStart, takeHomePay, s.Config.TakeHomeFloor) {
+		loanDeduction += item.Amount
+		items = append(items, item)
+	}
+	takeHomePay -= loanDeduction
+
+	// 5. Buat entitas Payroll
+	payroll := &domain.Payroll{
+		EmployeeID:        employeeID,
+		Period:            periodStart, // Slip lama bertanggal tengah bulan ikut dinormalisasi saat dihitung ulang
+		Type:              domain.PayrollTypeRegular,
+		BaseSalary:        baseSalary,
+		Allowance:         allowance,
+		ProrationMethod:   prorate.Method,
+		ProrationFactor:   prorate.Factor,
+		DaysCounted:       prorate.DaysCounted,
+		DaysInPeriod:      prorate.DaysInPeriod,
+		ActiveFrom:        prorate.ActiveFrom,
+		ActiveTo:          prorate.ActiveTo,
+		ProratedBase:      proratedBase,
+		ProratedAllowance: proratedAllowance,
+		TotalAbsent:       totalAbsent,
+		AbsenceDeduction:  absenceDeduction,
+		RetroAdjustment:   retroAdjustment,
+		Reimbursement:     reimbursement,
+		Adjustment:        adjustment,
+		Tax:               tax,
+		TaxMethod:         taxMethod,
+		TaxStatus:         taxStatus,
+		LoanDeduction:     loanDeduction,
+		TakeHomePay:       takeHomePay,
+		GeneratedAt:       time.Now(),
+		Status:            domain.PayrollStatusGenerated,
+		Items:             items,
+	}
 	return payroll, nil
 }
 
+// withholdingTax menghitung PPh 21 slip REGULAR sesuai PAYROLL_TAX_WITHHOLDING:
+//   - NONE: gaji teratur tidak dipotong; penerimaan kena pajak (bonus, komisi, insentif) dipotong dengan metode
+//     selisih di atas gaji bulanan penuh, sehingga gaji bersih tetap sesuai rumus tanpa PPh 21 gaji.
+//   - TER: Januari-November tarif efektif rata-rata x bruto sebulan (gaji teratur + penerimaan kena pajak);
+//     Desember atau bulan resign memakai pajak setahun Pasal 17 dikurangi PPh 21 di slip PAID tahun itu.
+func (s *PayrollServiceImpl) withholdingTax(ctx context.Context, employee *domain.Employee, payrollID uint, periodStart, periodEnd time.Time, fullMonthly, regularIncome, taxableIncome float64, taxStatus string) (float64, string, []domain.PayrollItem, error) {
+	switch s.Config.TaxWithholding {
+	case domain.TaxWithholdingNone:
+		if taxableIncome <= 0 {
+			return 0, "", nil, nil
+		}
+		tax, err := irregularIncomeTax(fullMonthly, taxableIncome, taxStatus)
+		if err != nil || tax <= 0 {
+			return 0, domain.TaxMethodIrregularIncome, nil, err
+		}
+		return tax, domain.TaxMethodIrregularIncome, []domain.PayrollItem{{
+			Code:        domain.PayrollItemCodePPh21,
+			Type:        domain.PayrollItemDeduction,
+			Description: "PPh 21 atas bonus/komisi/insentif",
+			Amount:      tax,
+		}}, nil
+	case domain.TaxWithholdingTER:
+		return s.terWithholdingTax(ctx, employee, payrollID, periodStart, periodEnd, regularIncome+taxableIncome, taxStatus)
+	default:
+		return 0, "", nil, fmt.Errorf("unknown tax withholding: %s", s.Config.TaxWithholding)
+	}
+}
+
+// terWithholdingTax menghitung PPh 21 TER atas bruto sebulan, atau pajak setahun di masa pajak terakhir
+func (s *PayrollServiceImpl) terWithholdingTax(ctx context.Context, employee *domain.Employee, payrollID uint, periodStart, periodEnd time.Time, gross float64, taxStatus string) (float64, string, []domain.PayrollItem, error) {
+	resigned := employee.ResignDate != nil && !employee.ResignDate.Before(periodStart) && !employee.ResignDate.After(periodEnd)
+	if periodStart.Month() != time.December && !resigned {
+		tax, rate, err := terIncomeTax(gross, taxStatus)
+		if err != nil || tax <= 0 {
+			return 0, domain.TaxMethodTER, nil, err
+		}
+		return tax, domain.TaxMethodTER, []domain.PayrollItem{{
+			Code:        domain.PayrollItemCodePPh21,
+			Type:        domain.PayrollItemDeduction,
+			Description: fmt.Sprintf("PPh 21 TER %s%%", strconv.FormatFloat(rate*100, 'f', -1, 64)),
+			Amount:      tax,
+		}}, nil
+	}
+
+	// Masa pajak terakhir: bruto dan pajak slip PAID lain di tahun yang sama, seperti di 1721-A1
+	paid, err := s.PayRepo.FindPaidByYear(ctx, periodStart.Year())
+	if err != nil {
+		return 0, "", nil, err
+	}
+	firstMonth, withheld := periodStart.Month(), 0.0
+	for _, slip := range paid {
+		if slip.EmployeeID != employee.ID || slip.ID == payrollID || !slip.Period.Before(periodStart) {
+			continue
+		}
+		gross += slipGrossIncome(slip)
+		withheld += slip.Tax
+		if slip.Period.Month() < firstMonth {
+			firstMonth = slip.Period.Month()
+		}
+	}
+	tax, err := annualTaxDue(math.Round(gross), int(periodStart.Month()-firstMonth)+1, taxStatus, math.Round(withheld))
+	if err != nil || tax == 0 {
+		return 0, domain.TaxMethodAnnual, nil, err
+	}
+	if tax < 0 {
+		// Kelebihan potong dikembalikan di slip ini: Tax negatif menambah take-home pay
+		return tax, domain.TaxMethodAnnual, []domain.PayrollItem{{
+			Code:        domain.PayrollItemCodePPh21,
+			Type:        domain.PayrollItemEarning,
+			Description: fmt.Sprintf("Pengembalian kelebihan PPh 21 %d", periodStart.Year()),
+			Amount:      -tax,
+		}}, nil
+	}
+	return tax, domain.TaxMethodAnnual, []domain.PayrollItem{{
+		Code:        domain.PayrollItemCodePPh21,
+		Type:        domain.PayrollItemDeduction,
+		Description: fmt.Sprintf("PPh 21 setahun %d dikurangi yang sudah dipotong", periodStart.Year()),
+		Amount:      tax,
+	}}, nil
+}
+
 // GetPayrollSlips implements domain.PayrollService
-func (s *PayrollServiceImpl) GetPayrollSlips() ([]domain.Payroll, error) {
+func (s *PayrollServiceImpl) GetPayrollSlips(ctx context.Context) ([]domain.Payroll, error) {
 	// Memanggil repository untuk mengambil semua slip gaji
-	payrolls, err := s.PayRepo.FindAll()
+	payrolls, err := s.PayRepo.FindAll(ctx)
 	if err != nil {
 		// Logika penanganan error khusus bisa ditambahkan di sini
 		return nil, err
@@ -79,12 +343,198 @@ func (s *PayrollServiceImpl) GetPayrollSlips() ([]domain.Payroll, error) {
 }
 
 // GetPayrollDetail implements domain.PayrollService
-func (s *PayrollServiceImpl) GetPayrollDetail(id uint) (*domain.Payroll, error) {
+func (s *PayrollServiceImpl) GetPayrollDetail(ctx context.Context, id uint) (*domain.Payroll, error) {
 	// Memanggil repository untuk mengambil detail slip gaji berdasarkan ID
-	payroll, err := s.PayRepo.FindByID(id)
+	payroll, err := s.PayRepo.FindByID(ctx, id)
 	if err != nil {
 		// Asumsi GORM/Repo mengembalikan error spesifik jika tidak ditemukan
 		return nil, errors.New("payroll slip not found or database error")
 	}
 	return payroll, nil
 }
+
+// RecalculatePayroll implements domain.PayrollService
+func (s *PayrollServiceImpl) RecalculatePayroll(ctx context.Context, id uint) (*domain.Payroll, error) {
+	return inTransaction(ctx, s.Tx, func(ctx context.Context) (*domain.Payroll, error) {
+		return s.recalculatePayroll(ctx, id)
+	})
+}
+
+func (s *PayrollServiceImpl) recalculatePayroll(ctx context.Context, id uint) (*domain.Payroll, error) {
+	// 1. Hanya slip yang belum dibayar yang boleh dihitung ulang
+	existing, err := s.PayRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, domain.ErrPayrollNotFound
+	}
+	if existing.Status != domain.PayrollStatusGenerated {
+		return nil, domain.ErrPayrollNotEditable
+	}
+
+	employee, err := s.EmpRepo.FindByID(ctx, existing.EmployeeID)
+	if err != nil {
+		return nil, errors.New("employee not found")
+	}
+
+	// 2. Hitung ulang dengan data absensi & gaji terbaru, ID slip tetap sama
+	payroll, err := s.recalculateSlip(ctx, employee, existing)
+	if err != nil {
+		return nil, err
+	}
+	payroll.ID = existing.ID
+	payroll.ReplacesID = existing.ReplacesID
+
+	if err := s.PayRepo.Update(ctx, payroll); err != nil {
+		return nil, err
+	}
+	if err := linkReimbursements(ctx, s.ReimbRepo, payroll); err != nil {
+		return nil, err
+	}
+	if err := linkAdjustments(ctx, s.AdjRepo, payroll); err != nil {
+		return nil, err
+	}
+	return payroll, nil
+}
+
+// MarkPayrollPaid implements domain.PayrollService.
+// Saldo pinjaman, klaim reimbursement, penyesuaian dan status slip diperbarui dalam satu transaksi.
+func (s *PayrollServiceImpl) MarkPayrollPaid(ctx context.Context, id uint) (*domain.Payroll, error) {
+	return inTransaction(ctx, s.Tx, func(ctx context.Context) (*domain.Payroll, error) {
+		return s.markPayrollPaid(ctx, id)
+	})
+}
+
+func (s *PayrollServiceImpl) markPayrollPaid(ctx context.Context, id uint) (*domain.Payroll, error) {
+	payroll, err := s.PayRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, domain.ErrPayrollNotFound
+	}
+	if payroll.Status != domain.PayrollStatusGenerated {
+		return nil, domain.ErrPayrollNotEditable
+	}
+
+	// Penyesuaian yang dibatalkan/ditambahkan sejak slip dihitung mengharuskan hitung ulang
+	if err := checkAdjustments(ctx, s.AdjRepo, payroll); err != nil {
+		return nil, err
+	}
+
+	// Slip final: kurangi saldo pinjaman sesuai cicilan yang dipotong
+	if err := applyLoanInstallments(ctx, s.LoanRepo, payroll); err != nil {
+		return nil, err
+	}
+	if err := markReimbursementsPaid(ctx, s.ReimbRepo, payroll); err != nil {
+		return nil, err
+	}
+	if err := applyAdjustments(ctx, s.AdjRepo, payroll); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	payroll.Status = domain.PayrollStatusPaid
+	payroll.PaidAt = &now
+
+	if err := s.PayRepo.Update(ctx, payroll); err != nil {
+		return nil, err
+	}
+	return payroll, nil
+}
+
+// VoidAndReissuePayroll implements domain.PayrollService.
+// Void slip lama dan penerbitan slip pengganti berjalan dalam satu transaksi.
+func (s *PayrollServiceImpl) VoidAndReissuePayroll(ctx context.Context, id uint, reason string) (*domain.Payroll, error) {
+	return inTransaction(ctx, s.Tx, func(ctx context.Context) (*domain.Payroll, error) {
+		return s.voidAndReissuePayroll(ctx, id, reason)
+	})
+}
+
+func (s *PayrollServiceImpl) voidAndReissuePayroll(ctx context.Context, id uint, reason string) (*domain.Payroll, error) {
+	// 1. Validasi: hanya slip PAID, alasan wajib diisi
+	if strings.TrimSpace(reason) == "" {
+		return nil, errors.New("void reason is required")
+	}
+	old, err := s.PayRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, domain.ErrPayrollNotFound
+	}
+	if old.Status != domain.PayrollStatusPaid {
+		return nil, domain.ErrPayrollNotPaid
+	}
+
+	employee, err := s.EmpRepo.FindByID(ctx, old.EmployeeID)
+	if err != nil {
+		return nil, errors.New("employee not found")
+	}
+
+	// 2. Void slip lama (slip VOID tidak lagi dihitung oleh unique index & laporan)
+	now := time.Now()
+	old.Status = domain.PayrollStatusVoid
+	old.VoidedAt = &now
+	old.VoidReason = reason
+	if err := s.PayRepo.Update(ctx, old); err != nil {
+		return nil, err
+	}
+	// Kembalikan saldo pinjaman yang dicicil di slip lama
+	if err := reverseLoanInstallments(ctx, s.LoanRepo, old); err != nil {
+		return nil, err
+	}
+	// Klaim reimbursement kembali APPROVED dan penyesuaian kembali PENDING, lalu dimuat slip pengganti
+	if err := releaseReimbursements(ctx, s.ReimbRepo, old); err != nil {
+		return nil, err
+	}
+	if err := releaseAdjustments(ctx, s.AdjRepo, old); err != nil {
+		return nil, err
+	}
+
+	// 3. Terbitkan slip pengganti dengan data terbaru
+	replacement, err := s.recalculateSlip(ctx, employee, old)
+	if err != nil {
+		return nil, err
+	}
+	replacement.ReplacesID = &old.ID
+	if err := s.PayRepo.Save(ctx, replacement); err != nil {
+		return nil, err
+	}
+	if err := linkReimbursements(ctx, s.ReimbRepo, replacement); err != nil {
+		return nil, err
+	}
+	if err := linkAdjustments(ctx, s.AdjRepo, replacement); err != nil {
+		return nil, err
+	}
+
+	// 4. Hubungkan slip lama ke penggantinya
+	old.ReplacedByID = &replacement.ID
+	if err := s.PayRepo.Update(ctx, old); err != nil {
+		return nil, err
+	}
+	return replacement, nil
+}
+
+// recalculateSlip menghitung ulang slip sesuai jenisnya; slip THR memakai hari raya (ActiveTo) yang sama.
+// Jika selisih kenaikan gaji berlaku mundur untuk periode slip sudah dibayar sebagai rapel di slip PAID berikutnya,
+// slip dihitung ulang dengan gaji slip semula agar selisih itu tidak dibayar dua kali.
+func (s *PayrollServiceImpl) recalculateSlip(ctx context.Context, employee *domain.Employee, slip *domain.Payroll) (*domain.Payroll, error) {
+	if slip.Type != domain.PayrollTypeTHR {
+		slips, err := s.PayRepo.FindByEmployee(ctx, employee.ID)
+		if err != nil {
+			return nil, err
+		}
+		var overrides *domain.PayrollOverrides
+		if retroPaidFor(slips, slip.Period) {
+			baseSalary, allowance := slip.BaseSalary, slip.Allowance
+			overrides = &domain.PayrollOverrides{BaseSalary: &baseSalary, Allowance: &allowance}
+		}
+		return s.calculatePayroll(ctx, employee, slip.Period, slip.ID, overrides)
+	}
+	histories, err := s.SalaryRepo.FindByEmployee(ctx, employee.ID)
+	if err != nil {
+		return nil, err
+	}
+	slips, err := s.PayRepo.FindByEmployee(ctx, employee.ID)
+	if err != nil {
+		return nil, err
+	}
+	taxStatus := slip.TaxStatus
+	if taxStatus == "" {
+		taxStatus = yearTaxStatus(employee, slips, slip.Period.Year())
+	}
+	return calculateTHR(employee, histories, taxStatus, slip.ActiveTo, slip.Period, s.Config.THRWageBase, s.Config.TaxWithholding)
+}
@@ -0,0 +1,380 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"hr-payroll/internal/domain"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerateMonthlyPayrollAbsenceDeduction(t *testing.T) {
+	period := day(2025, 11, 1)
+
+	tests := []struct {
+		name          string
+		attendances   []domain.Attendance
+		wantAbsent    float64
+		wantDeduction float64
+	}{
+		{
+			name: "no attendance records",
+		},
+		{
+			name: "two absent days",
+			attendances: []domain.Attendance{
+				{Date: day(2025, 11, 3), Status: domain.AttendanceStatusAbsent},
+				{Date: day(2025, 11, 4), Status: domain.AttendanceStatusAbsent},
+			},
+			wantAbsent:    2,
+			wantDeduction: 400000, // (4.400.000 / 22) * 2
+		},
+		{
+			name: "half day counts by catalogue weight",
+			attendances: []domain.Attendance{
+				{Date: day(2025, 11, 3), Status: domain.AttendanceStatusAbsent},
+				{Date: day(2025, 11, 4), Status: "HALF_DAY"},
+			},
+			wantAbsent:    1.5,
+			wantDeduction: 300000,
+		},
+		{
+			name: "statuses without deduction weight",
+			attendances: []domain.Attendance{
+				{Date: day(2025, 11, 3), Status: domain.AttendanceStatusPresent},
+				{Date: day(2025, 11, 4), Status: domain.AttendanceStatusLeave},
+				{Date: day(2025, 11, 5), Status: "SICK"},
+				{Date: day(2025, 11, 6), Status: "WFH"},
+			},
+		},
+		{
+			name: "absences outside the period are ignored",
+			attendances: []domain.Attendance{
+				{Date: day(2025, 10, 31), Status: domain.AttendanceStatusAbsent},
+				{Date: day(2025, 11, 30), Status: domain.AttendanceStatusAbsent},
+				{Date: day(2025, 12, 1), Status: domain.AttendanceStatusAbsent},
+			},
+			wantAbsent:    1,
+			wantDeduction: 200000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos := newTestRepos(t)
+			emp := repos.saveEmployee(t, domain.Employee{Name: "Budi", BaseSalary: 4400000, Allowance: 600000})
+			for _, att := range tt.attendances {
+				att.EmployeeID = emp.ID
+				if err := repos.Attendance.Save(ctx, &att); err != nil {
+					t.Fatalf("save attendance: %v", err)
+				}
+			}
+
+			payroll, err := repos.payrollService().GenerateMonthlyPayroll(ctx, emp.ID, period)
+			if err != nil {
+				t.Fatalf("GenerateMonthlyPayroll() error = %v", err)
+			}
+			if payroll.TotalAbsent != tt.wantAbsent {
+				t.Errorf("TotalAbsent = %v, want %v", payroll.TotalAbsent, tt.wantAbsent)
+			}
+			if payroll.AbsenceDeduction != tt.wantDeduction {
+				t.Errorf("AbsenceDeduction = %v, want %v", payroll.AbsenceDeduction, tt.wantDeduction)
+			}
+			if want := 4400000 + 600000 - tt.wantDeduction; payroll.TakeHomePay != want {
+				t.Errorf("TakeHomePay = %v, want %v", payroll.TakeHomePay, want)
+			}
+		})
+	}
+}
+
+func TestGenerateMonthlyPayrollDuplicatePrevention(t *testing.T) {
+	period := day(2025, 11, 1)
+
+	tests := []struct {
+		name     string
+		existing *domain.Payroll // slip yang sudah ada untuk karyawan yang sama
+		wantErr  error
+	}{
+		{
+			name: "first slip for the period",
+		},
+		{
+			name:     "generated slip already exists",
+			existing: &domain.Payroll{Period: period, Status: domain.PayrollStatusGenerated},
+			wantErr:  domain.ErrPayrollAlreadyGenerated,
+		},
+		{
+			name:     "paid slip already exists",
+			existing: &domain.Payroll{Period: period, Status: domain.PayrollStatusPaid},
+			wantErr:  domain.ErrPayrollAlreadyGenerated,
+		},
+		{
+			name:     "void slip does not block",
+			existing: &domain.Payroll{Period: period, Status: domain.PayrollStatusVoid},
+		},
+		{
+			name:     "THR slip in the same period does not block",
+			existing: &domain.Payroll{Period: period, Type: domain.PayrollTypeTHR},
+		},
+		{
+			name:     "slip for another period does not block",
+			existing: &domain.Payroll{Period: day(2025, 10, 1)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos := newTestRepos(t)
+			emp := repos.saveEmployee(t, domain.Employee{Name: "Budi", BaseSalary: 4400000})
+			if tt.existing != nil {
+				tt.existing.EmployeeID = emp.ID
+				if err := repos.Payroll.Save(ctx, tt.existing); err != nil {
+					t.Fatalf("save existing payroll: %v", err)
+				}
+			}
+
+			payroll, err := repos.payrollService().GenerateMonthlyPayroll(ctx, emp.ID, period)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GenerateMonthlyPayroll() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (payroll.ID == 0 || payroll.Status != domain.PayrollStatusGenerated) {
+				t.Errorf("payroll = %+v, want a stored GENERATED slip", payroll)
+			}
+		})
+	}
+}
+
+func TestGenerateMonthlyPayrollTwice(t *testing.T) {
+	ctx := context.Background()
+	repos := newTestRepos(t)
+	emp := repos.saveEmployee(t, domain.Employee{Name: "Budi", BaseSalary: 4400000})
+	service := repos.payrollService()
+
+	if _, err := service.GenerateMonthlyPayroll(ctx, emp.ID, day(2025, 11, 1)); err != nil {
+		t.Fatalf("first GenerateMonthlyPayroll() error = %v", err)
+	}
+	if _, err := service.GenerateMonthlyPayroll(ctx, emp.ID, day(2025, 11, 1)); !errors.Is(err, domain.ErrPayrollAlreadyGenerated) {
+		t.Fatalf("second GenerateMonthlyPayroll() error = %v, want %v", err, domain.ErrPayrollAlreadyGenerated)
+	}
+
+	slips, err := service.GetPayrollSlips(ctx)
+	if err != nil {
+		t.Fatalf("GetPayrollSlips() error = %v", err)
+	}
+	if len(slips) != 1 {
+		t.Errorf("stored slips = %d, want 1", len(slips))
+	}
+}
+
+func TestGenerateMonthlyPayrollTaxWithholding(t *testing.T) {
+	tests := []struct {
+		name        string
+		withholding string
+		bonus       float64
+		wantTax     float64
+		wantMethod  string
+		wantErr     bool
+	}{
+		{name: "none leaves regular salary untaxed", withholding: "", wantTax: 0, wantMethod: ""},
+		{name: "none taxes only the bonus", withholding: domain.TaxWithholdingNone, bonus: 10000000, wantTax: 1500000, wantMethod: domain.TaxMethodIrregularIncome},
+		{name: "TER on regular salary", withholding: domain.TaxWithholdingTER, wantTax: 200000, wantMethod: domain.TaxMethodTER},
+		{name: "TER on salary plus bonus", withholding: domain.TaxWithholdingTER, bonus: 10000000, wantTax: 1800000, wantMethod: domain.TaxMethodTER},
+		{name: "unknown setting", withholding: "ANNUALIZED", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos := newTestRepos(t)
+			emp := repos.saveEmployee(t, domain.Employee{Name: "Budi", BaseSalary: 10000000, TaxStatus: "TK/0"})
+			if tt.bonus > 0 {
+				if err := repos.Adjustment.Save(ctx, &domain.PayrollAdjustment{EmployeeID: emp.ID, Period: day(2025, 11, 1), Component: "BONUS", Type: domain.PayrollItemEarning, Taxable: true, Amount: tt.bonus}); err != nil {
+					t.Fatalf("save adjustment: %v", err)
+				}
+			}
+			service := NewPayrollServiceImpl(repos.Employee, repos.Attendance, repos.Payroll, repos.Holiday, repos.SalaryHistory, repos.Status, repos.Loan, repos.Reimbursement, repos.Adjustment, repos.Tx, PayrollConfig{TaxWithholding: tt.withholding})
+
+			payroll, err := service.GenerateMonthlyPayroll(ctx, emp.ID, day(2025, 11, 1))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GenerateMonthlyPayroll() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if payroll.Tax != tt.wantTax || payroll.TaxMethod != tt.wantMethod {
+				t.Errorf("tax = %v (%q), want %v (%q)", payroll.Tax, payroll.TaxMethod, tt.wantTax, tt.wantMethod)
+			}
+			if want := 10000000 + tt.bonus - tt.wantTax; payroll.TakeHomePay != want {
+				t.Errorf("TakeHomePay = %v, want %v", payroll.TakeHomePay, want)
+			}
+		})
+	}
+}
+
+func TestGenerateMonthlyPayrollUnknownEmployee(t *testing.T) {
+	repos := newTestRepos(t)
+	if _, err := repos.payrollService().GenerateMonthlyPayroll(context.Background(), 99, day(2025, 11, 1)); err == nil {
+		t.Fatal("GenerateMonthlyPayroll() error = nil, want employee not found")
+	}
+}
+
+// failingAttendanceRepository mensimulasikan kegagalan database saat membaca absensi
+type failingAttendanceRepository struct {
+	domain.AttendanceRepository
+}
+
+func (failingAttendanceRepository) FindByPeriod(ctx context.Context, employeeID uint, dateFrom time.Time, dateTo time.Time) ([]domain.Attendance, error) {
+	return nil, errors.New("connection reset")
+}
+
+func TestGenerateMonthlyPayrollAttendanceError(t *testing.T) {
+	repos := newTestRepos(t)
+	emp := repos.saveEmployee(t, domain.Employee{Name: "Budi", BaseSalary: 4400000})
+	repos.Attendance = failingAttendanceRepository{repos.Attendance}
+
+	// Slip tanpa potongan absen tidak boleh dibuat hanya karena absensi gagal dibaca
+	if _, err := repos.payrollService().GenerateMonthlyPayroll(context.Background(), emp.ID, day(2025, 11, 1)); err == nil || !strings.Contains(err.Error(), "connection reset") {
+		t.Fatalf("GenerateMonthlyPayroll() error = %v, want the attendance error", err)
+	}
+	if slips, _ := repos.Payroll.FindByEmployee(context.Background(), emp.ID); len(slips) != 0 {
+		t.Errorf("stored slips = %d, want 0", len(slips))
+	}
+}
+
+func TestPreviewPayrollKeepsLinkedReimbursements(t *testing.T) {
+	ctx := context.Background()
+	repos := newTestRepos(t)
+	emp := repos.saveEmployee(t, domain.Employee{Name: "Budi", BaseSalary: 4400000})
+	claim := domain.ReimbursementClaim{EmployeeID: emp.ID, CategoryCode: "MEDICAL", ExpenseDate: day(2025, 11, 3), Amount: 350000, Status: domain.ReimbursementApproved}
+	if err := repos.Reimbursement.Save(ctx, &claim); err != nil {
+		t.Fatalf("save claim: %v", err)
+	}
+	service := repos.payrollService()
+	slip, err := service.GenerateMonthlyPayroll(ctx, emp.ID, day(2025, 11, 1))
+	if err != nil {
+		t.Fatalf("GenerateMonthlyPayroll() error = %v", err)
+	}
+
+	// Klaim sudah terikat ke slip; preview periode yang sama harus tetap menghitungnya
+	previews, err := service.PreviewPayroll(ctx, domain.PayrollPreviewRequest{EmployeeID: emp.ID, Period: day(2025, 11, 1)})
+	if err != nil {
+		t.Fatalf("PreviewPayroll() error = %v", err)
+	}
+	if len(previews) != 1 || previews[0].Payroll == nil {
+		t.Fatalf("previews = %+v, want one calculated preview", previews)
+	}
+	preview := previews[0]
+	if preview.Payroll.TakeHomePay != slip.TakeHomePay {
+		t.Errorf("preview TakeHomePay = %v, want %v (same as the stored slip)", preview.Payroll.TakeHomePay, slip.TakeHomePay)
+	}
+	if preview.Diff == nil || preview.Diff.TakeHomePay != 0 {
+		t.Errorf("preview diff = %+v, want no difference", preview.Diff)
+	}
+	found := false
+	for _, line := range preview.Lines {
+		found = found || line.Code == domain.PayrollItemCodeReimbursement
+	}
+	if !found {
+		t.Errorf("preview lines = %+v, want the linked reimbursement", preview.Lines)
+	}
+}
+
+func TestVoidAndReissueAfterBackdatedRaise(t *testing.T) {
+	tests := []struct {
+		name         string
+		payNovember  bool    // Slip November (berisi rapel Oktober) sudah dibayar sebelum Oktober di-void
+		wantOctober  float64 // Gaji pokok slip Oktober pengganti
+		wantTotalPay float64 // Total Oktober (pengganti) + November
+	}{
+		{name: "rapel already paid keeps the original salary", payNovember: true, wantOctober: 5000000, wantTotalPay: 5000000 + 6000000 + 1000000},
+		{name: "no rapel yet uses the raised salary", wantOctober: 6000000, wantTotalPay: 6000000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos := newTestRepos(t)
+			joinDate := day(2025, 1, 1)
+			emp := repos.saveEmployee(t, domain.Employee{Name: "Budi", BaseSalary: 5000000, JoinDate: &joinDate})
+			if err := repos.SalaryHistory.Save(ctx, &domain.SalaryHistory{EmployeeID: emp.ID, BaseSalary: 5000000, EffectiveFrom: joinDate, Baseline: true, CreatedAt: joinDate}); err != nil {
+				t.Fatalf("save baseline salary: %v", err)
+			}
+			service := repos.payrollService()
+
+			october, err := service.GenerateMonthlyPayroll(ctx, emp.ID, day(2025, 10, 1))
+			if err != nil {
+				t.Fatalf("GenerateMonthlyPayroll(October) error = %v", err)
+			}
+			if _, err := service.MarkPayrollPaid(ctx, october.ID); err != nil {
+				t.Fatalf("MarkPayrollPaid(October) error = %v", err)
+			}
+			// Kenaikan gaji berlaku mundur ke Oktober, dicatat setelah slip Oktober dibayar
+			if err := repos.SalaryHistory.Save(ctx, &domain.SalaryHistory{EmployeeID: emp.ID, BaseSalary: 6000000, EffectiveFrom: day(2025, 10, 1), CreatedAt: time.Now().Add(time.Minute)}); err != nil {
+				t.Fatalf("save raise: %v", err)
+			}
+
+			totalPay := 0.0
+			if tt.payNovember {
+				november, err := service.GenerateMonthlyPayroll(ctx, emp.ID, day(2025, 11, 1))
+				if err != nil {
+					t.Fatalf("GenerateMonthlyPayroll(November) error = %v", err)
+				}
+				if november.RetroAdjustment != 1000000 {
+					t.Fatalf("November RetroAdjustment = %v, want 1000000", november.RetroAdjustment)
+				}
+				if _, err := service.MarkPayrollPaid(ctx, november.ID); err != nil {
+					t.Fatalf("MarkPayrollPaid(November) error = %v", err)
+				}
+				totalPay += november.TakeHomePay
+			}
+
+			replacement, err := service.VoidAndReissuePayroll(ctx, october.ID, "Koreksi rekening")
+			if err != nil {
+				t.Fatalf("VoidAndReissuePayroll() error = %v", err)
+			}
+			totalPay += replacement.TakeHomePay
+			if replacement.BaseSalary != tt.wantOctober || totalPay != tt.wantTotalPay {
+				t.Errorf("October base salary = %v, total paid = %v; want %v and %v", replacement.BaseSalary, totalPay, tt.wantOctober, tt.wantTotalPay)
+			}
+		})
+	}
+}
+
+// failingPayrollUpdate mensimulasikan kegagalan database saat slip diperbarui
+type failingPayrollUpdate struct {
+	domain.PayrollRepository
+}
+
+func (failingPayrollUpdate) Update(ctx context.Context, payroll *domain.Payroll) error {
+	return errors.New("disk full")
+}
+
+func TestMarkPayrollPaidRollsBackWhenUpdateFails(t *testing.T) {
+	ctx := context.Background()
+	repos := newTestRepos(t)
+	emp := repos.saveEmployee(t, domain.Employee{Name: "Budi", BaseSalary: 4400000})
+	loan := domain.Loan{EmployeeID: emp.ID, Principal: 3000000, InstallmentAmount: 500000, StartPeriod: day(2025, 11, 1), OutstandingBalance: 3000000}
+	if err := repos.Loan.Save(ctx, &loan); err != nil {
+		t.Fatalf("save loan: %v", err)
+	}
+	slip, err := repos.payrollService().GenerateMonthlyPayroll(ctx, emp.ID, day(2025, 11, 1))
+	if err != nil {
+		t.Fatalf("GenerateMonthlyPayroll() error = %v", err)
+	}
+
+	repos.Payroll = failingPayrollUpdate{repos.Payroll}
+	if _, err := repos.payrollService().MarkPayrollPaid(ctx, slip.ID); err == nil {
+		t.Fatal("MarkPayrollPaid() error = nil, want the update error")
+	}
+	// Cicilan yang sudah dipotong dari saldo pinjaman ikut dibatalkan
+	stored, err := repos.Loan.FindByID(ctx, loan.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if stored.OutstandingBalance != 3000000 || len(stored.Transactions) != 0 {
+		t.Errorf("loan balance = %v with %d transaction(s), want 3000000 without transactions", stored.OutstandingBalance, len(stored.Transactions))
+	}
+}
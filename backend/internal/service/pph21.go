@@ -0,0 +1,330 @@
+package service
+
+import (
+	"fmt"
+	"hr-payroll/internal/domain"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Parameter PPh 21 (UU HPP, PP 101/2016 untuk PTKP)
+const (
+	ptkpSelf          = 54_000_000.0 // PTKP wajib pajak sendiri per tahun
+	ptkpPerDependent  = 4_500_000.0  // Tambahan kawin dan per tanggungan (maks. 3)
+	maxDependents     = 3
+	jobExpenseRate    = 0.05        // Biaya jabatan 5% dari penghasilan bruto
+	maxJobExpenseYear = 6_000_000.0 // Maksimal biaya jabatan per tahun
+)
+
+// pph21Brackets adalah tarif progresif Pasal 17 atas penghasilan kena pajak setahun
+var pph21Brackets = []struct {
+	Upto float64 // Batas atas lapisan, 0 = tanpa batas
+	Rate float64
+}{
+	{60_000_000, 0.05},
+	{250_000_000, 0.15},
+	{500_000_000, 0.25},
+	{5_000_000_000, 0.30},
+	{0, 0.35},
+}
+
+// ptkpAnnual mengembalikan PTKP setahun untuk status TK/0..TK/3 atau K/0..K/3 (kosong = TK/0)
+func ptkpAnnual(taxStatus string) (float64, error) {
+	status := strings.ToUpper(strings.TrimSpace(taxStatus))
+	if status == "" {
+		status = "TK/0"
+	}
+	parts := strings.Split(status, "/")
+	if len(parts) != 2 || (parts[0] != "TK" && parts[0] != "K") {
+		return 0, fmt.Errorf("invalid tax status %q: use TK/0-TK/3 or K/0-K/3", taxStatus)
+	}
+	dependents, err := strconv.Atoi(parts[1])
+	if err != nil || dependents < 0 || dependents > maxDependents {
+		return 0, fmt.Errorf("invalid tax status %q: use TK/0-TK/3 or K/0-K/3", taxStatus)
+	}
+
+	ptkp := ptkpSelf + float64(dependents)*ptkpPerDependent
+	if parts[0] == "K" {
+		ptkp += ptkpPerDependent
+	}
+	return ptkp, nil
+}
+
+// annualIncomeTax menghitung PPh 21 setahun dari penghasilan bruto setahun
+func annualIncomeTax(grossAnnual float64, ptkp float64) float64 {
+	return progressiveTax(taxableIncome(grossAnnual-jobExpense(grossAnnual, 12), ptkp))
+}
+
+// jobExpense menghitung biaya jabatan 5% dari bruto, maksimal 500 ribu per bulan masa kerja
+func jobExpense(gross float64, months int) float64 {
+	return math.Min(gross*jobExpenseRate, maxJobExpenseYear/12*float64(months))
+}
+
+// taxableIncome mengembalikan PKP (neto dikurangi PTKP) yang dibulatkan ke bawah dalam ribuan penuh
+func taxableIncome(netAnnual float64, ptkp float64) float64 {
+	return math.Max(0, math.Floor((netAnnual-ptkp)/1000)*1000)
+}
+
+// progressiveTax menerapkan tarif Pasal 17 atas PKP setahun
+func progressiveTax(taxable float64) float64 {
+	if taxable <= 0 {
+		return 0
+	}
+	tax, lower := 0.0, 0.0
+	for _, bracket := range pph21Brackets {
+		upper := bracket.Upto
+		if upper == 0 || taxable < upper {
+			upper = taxable
+		}
+		tax += (upper - lower) * bracket.Rate
+		if upper == taxable {
+			break
+		}
+		lower = upper
+	}
+	return tax
+}
+
+// terBracket adalah satu baris tabel tarif efektif rata-rata bulanan (TER) PP 58/2023
+type terBracket struct {
+	Upto float64 // Batas atas penghasilan bruto sebulan (inklusif), 0 = tanpa batas
+	Rate float64
+}
+
+// terRates adalah tabel TER per kategori: A (TK/0, TK/1, K/0), B (TK/2, TK/3, K/1, K/2), C (K/3)
+var terRates = map[string][]terBracket{
+	"A": {
+		{5_400_000, 0},
+		{5_650_000, 0.0025},
+		{5_950_000, 0.005},
+		{6_300_000, 0.0075},
+		{6_750_000, 0.01},
+		{7_500_000, 0.0125},
+		{8_550_000, 0.015},
+		{9_650_000, 0.0175},
+		{10_050_000, 0.02},
+		{10_350_000, 0.0225},
+		{10_700_000, 0.025},
+		{11_050_000, 0.03},
+		{11_600_000, 0.035},
+		{12_500_000, 0.04},
+		{13_750_000, 0.05},
+		{15_100_000, 0.06},
+		{16_950_000, 0.07},
+		{19_750_000, 0.08},
+		{24_150_000, 0.09},
+		{26_450_000, 0.1},
+		{28_000_000, 0.11},
+		{30_050_000, 0.12},
+		{32_400_000, 0.13},
+		{35_400_000, 0.14},
+		{39_100_000, 0.15},
+		{43_850_000, 0.16},
+		{47_800_000, 0.17},
+		{51_400_000, 0.18},
+		{56_300_000, 0.19},
+		{62_200_000, 0.2},
+		{68_600_000, 0.21},
+		{77_500_000, 0.22},
+		{89_000_000, 0.23},
+		{103_000_000, 0.24},
+		{125_000_000, 0.25},
+		{157_000_000, 0.26},
+		{206_000_000, 0.27},
+		{337_000_000, 0.28},
+		{454_000_000, 0.29},
+		{550_000_000, 0.3},
+		{695_000_000, 0.31},
+		{910_000_000, 0.32},
+		{1_400_000_000, 0.33},
+		{0, 0.34},
+	},
+	"B": {
+		{6_200_000, 0},
+		{6_500_000, 0.0025},
+		{6_850_000, 0.005},
+		{7_300_000, 0.0075},
+		{9_200_000, 0.01},
+		{10_750_000, 0.015},
+		{11_250_000, 0.02},
+		{11_600_000, 0.025},
+		{12_600_000, 0.03},
+		{13_600_000, 0.04},
+		{14_950_000, 0.05},
+		{16_400_000, 0.06},
+		{18_450_000, 0.07},
+		{21_850_000, 0.08},
+		{26_000_000, 0.09},
+		{27_700_000, 0.1},
+		{29_350_000, 0.11},
+		{31_450_000, 0.12},
+		{33_950_000, 0.13},
+		{37_100_000, 0.14},
+		{41_100_000, 0.15},
+		{45_800_000, 0.16},
+		{49_500_000, 0.17},
+		{53_800_000, 0.18},
+		{58_500_000, 0.19},
+		{64_000_000, 0.2},
+		{71_000_000, 0.21},
+		{80_000_000, 0.22},
+		{93_000_000, 0.23},
+		{109_000_000, 0.24},
+		{129_000_000, 0.25},
+		{163_000_000, 0.26},
+		{211_000_000, 0.27},
+		{374_000_000, 0.28},
+		{459_000_000, 0.29},
+		{555_000_000, 0.3},
+		{704_000_000, 0.31},
+		{957_000_000, 0.32},
+		{1_405_000_000, 0.33},
+		{0, 0.34},
+	},
+	"C": {
+		{6_600_000, 0},
+		{6_950_000, 0.0025},
+		{7_350_000, 0.005},
+		{7_800_000, 0.0075},
+		{8_850_000, 0.01},
+		{9_800_000, 0.0125},
+		{10_950_000, 0.015},
+		{11_200_000, 0.0175},
+		{12_050_000, 0.02},
+		{12_950_000, 0.03},
+		{14_150_000, 0.04},
+		{15_550_000, 0.05},
+		{17_050_000, 0.06},
+		{19_500_000, 0.07},
+		{22_700_000, 0.08},
+		{26_600_000, 0.09},
+		{28_100_000, 0.1},
+		{30_100_000, 0.11},
+		{32_600_000, 0.12},
+		{35_400_000, 0.13},
+		{38_900_000, 0.14},
+		{43_000_000, 0.15},
+		{47_400_000, 0.16},
+		{51_200_000, 0.17},
+		{55_800_000, 0.18},
+		{60_400_000, 0.19},
+		{66_700_000, 0.2},
+		{74_500_000, 0.21},
+		{83_200_000, 0.22},
+		{95_600_000, 0.23},
+		{110_000_000, 0.24},
+		{134_000_000, 0.25},
+		{169_000_000, 0.26},
+		{221_000_000, 0.27},
+		{390_000_000, 0.28},
+		{463_000_000, 0.29},
+		{561_000_000, 0.3},
+		{709_000_000, 0.31},
+		{965_000_000, 0.32},
+		{1_419_000_000, 0.33},
+		{0, 0.34},
+	},
+}
+
+// terCategory mengembalikan kategori TER untuk status PTKP (kosong = TK/0)
+func terCategory(taxStatus string) (string, error) {
+	if _, err := ptkpAnnual(taxStatus); err != nil {
+		return "", err
+	}
+	switch strings.ToUpper(strings.TrimSpace(taxStatus)) {
+	case "TK/2", "TK/3", "K/1", "K/2":
+		return "B", nil
+	case "K/3":
+		return "C", nil
+	default:
+		return "A", nil
+	}
+}
+
+// terRate mengembalikan tarif efektif bulanan untuk penghasilan bruto sebulan
+func terRate(category string, grossMonthly float64) float64 {
+	brackets := terRates[category]
+	for _, bracket := range brackets {
+		if bracket.Upto == 0 || grossMonthly <= bracket.Upto {
+			return bracket.Rate
+		}
+	}
+	return brackets[len(brackets)-1].Rate
+}
+
+// terIncomeTax menghitung PPh 21 bulanan Januari-November: tarif efektif x penghasilan bruto sebulan
+func terIncomeTax(grossMonthly float64, taxStatus string) (tax float64, rate float64, err error) {
+	category, err := terCategory(taxStatus)
+	if err != nil {
+		return 0, 0, err
+	}
+	if grossMonthly <= 0 {
+		return 0, 0, nil
+	}
+	rate = terRate(category, grossMonthly)
+	return math.Round(grossMonthly * rate), rate, nil
+}
+
+// annualTaxDue menghitung PPh 21 masa pajak terakhir: pajak setahun Pasal 17 atas bruto setahun
+// (biaya jabatan sesuai jumlah bulan bekerja) dikurangi PPh 21 yang sudah dipotong di masa sebelumnya.
+// Hasil negatif adalah kelebihan potong yang dikembalikan bersama gaji masa pajak terakhir (PMK 168/2023).
+func annualTaxDue(grossYear float64, months int, taxStatus string, withheld float64) (float64, error) {
+	ptkp, err := ptkpAnnual(taxStatus)
+	if err != nil {
+		return 0, err
+	}
+	net := grossYear - math.Round(jobExpense(grossYear, months))
+	annual := math.Round(progressiveTax(taxableIncome(net, ptkp)))
+	return annual - withheld, nil
+}
+
+// slipGrossIncome mengembalikan penghasilan bruto kena pajak satu slip, sama seperti penjumlahan di 1721-A1:
+// gaji dan tunjangan slip REGULAR ditambah baris penerimaan kena pajak (THR, bonus, komisi, insentif)
+func slipGrossIncome(slip domain.Payroll) float64 {
+	gross := 0.0
+	if slip.Type == domain.PayrollTypeRegular {
+		gross += slip.ProratedBase + slip.ProratedAllowance - slip.AbsenceDeduction + slip.RetroAdjustment
+	}
+	for _, item := range slip.Items {
+		if item.Taxable && item.Type == domain.PayrollItemEarning {
+			gross += item.Amount
+		}
+	}
+	return gross
+}
+
+// yearTaxStatus mengembalikan status PTKP yang berlaku untuk tahun pajak: status yang tercatat pada slip
+// pertama tahun itu (PTKP ditentukan keadaan awal tahun), atau status karyawan saat ini jika belum ada slip
+func yearTaxStatus(emp *domain.Employee, slips []domain.Payroll, year int) string {
+	status := ""
+	var first time.Time
+	for _, slip := range slips {
+		if slip.Period.Year() != year || slip.TaxStatus == "" || slip.Status == domain.PayrollStatusVoid {
+			continue
+		}
+		if status == "" || slip.Period.Before(first) {
+			status, first = slip.TaxStatus, slip.Period
+		}
+	}
+	if status == "" {
+		status = strings.ToUpper(strings.TrimSpace(emp.TaxStatus))
+	}
+	if status == "" {
+		status = "TK/0"
+	}
+	return status
+}
+
+// irregularIncomeTax menghitung PPh 21 atas penghasilan tidak teratur (THR, bonus):
+// selisih pajak setahun atas gaji teratur + penghasilan tidak teratur dengan pajak atas gaji teratur saja
+func irregularIncomeTax(regularMonthly float64, irregular float64, taxStatus string) (float64, error) {
+	ptkp, err := ptkpAnnual(taxStatus)
+	if err != nil {
+		return 0, err
+	}
+	regularAnnual := regularMonthly * 12
+	tax := annualIncomeTax(regularAnnual+irregular, ptkp) - annualIncomeTax(regularAnnual, ptkp)
+	return math.Round(tax), nil
+}
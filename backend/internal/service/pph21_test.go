@@ -0,0 +1,149 @@
+package service
+
+import "testing"
+
+func TestPTKPAnnual(t *testing.T) {
+	tests := []struct {
+		status  string
+		want    float64
+		wantErr bool
+	}{
+		{status: "", want: 54000000},
+		{status: "TK/0", want: 54000000},
+		{status: "tk/1", want: 58500000},
+		{status: "K/0", want: 58500000},
+		{status: " K/2 ", want: 67500000},
+		{status: "K/3", want: 72000000},
+		{status: "K/4", wantErr: true},
+		{status: "TK/-1", wantErr: true},
+		{status: "TK", wantErr: true},
+		{status: "X/1", wantErr: true},
+		{status: "TK/a", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			got, err := ptkpAnnual(tt.status)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ptkpAnnual(%q) error = %v, wantErr %v", tt.status, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ptkpAnnual(%q) = %v, want %v", tt.status, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProgressiveTax(t *testing.T) {
+	tests := []struct {
+		name    string
+		taxable float64
+		want    float64
+	}{
+		{name: "zero", taxable: 0, want: 0},
+		{name: "negative", taxable: -1000, want: 0},
+		{name: "inside first bracket", taxable: 10000000, want: 500000},
+		{name: "top of first bracket", taxable: 60000000, want: 3000000},
+		{name: "second bracket", taxable: 100000000, want: 9000000},
+		{name: "top of second bracket", taxable: 250000000, want: 31500000},
+		{name: "top of third bracket", taxable: 500000000, want: 94000000},
+		{name: "top of fourth bracket", taxable: 5000000000, want: 1444000000},
+		{name: "top bracket", taxable: 6000000000, want: 1794000000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := progressiveTax(tt.taxable); !almostEqual(got, tt.want) {
+				t.Errorf("progressiveTax(%v) = %v, want %v", tt.taxable, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTERIncomeTax(t *testing.T) {
+	tests := []struct {
+		name     string
+		monthly  float64
+		status   string
+		want     float64
+		wantRate float64
+		wantErr  bool
+	}{
+		{name: "no income", monthly: 0, status: "TK/0", want: 0},
+		{name: "upper bound is inclusive", monthly: 5400000, status: "TK/0", want: 0},
+		{name: "first taxed bracket", monthly: 5400001, status: "TK/0", want: 13500, wantRate: 0.0025},
+		{name: "category A", monthly: 10000000, status: "TK/0", want: 200000, wantRate: 0.02},
+		{name: "category B", monthly: 10000000, status: "K/1", want: 150000, wantRate: 0.015},
+		{name: "category C", monthly: 10000000, status: "K/3", want: 150000, wantRate: 0.015},
+		{name: "top bracket", monthly: 2000000000, status: "TK/0", want: 680000000, wantRate: 0.34},
+		{name: "invalid status", monthly: 10000000, status: "K/9", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, rate, err := terIncomeTax(tt.monthly, tt.status)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("terIncomeTax() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want || rate != tt.wantRate {
+				t.Errorf("terIncomeTax(%v, %s) = %v at %v, want %v at %v", tt.monthly, tt.status, got, rate, tt.want, tt.wantRate)
+			}
+		})
+	}
+}
+
+func TestAnnualTaxDue(t *testing.T) {
+	tests := []struct {
+		name     string
+		gross    float64
+		months   int
+		withheld float64
+		want     float64
+	}{
+		{name: "full year minus TER already withheld", gross: 120000000, months: 12, withheld: 2200000, want: 800000},
+		{name: "over-withheld is refunded", gross: 120000000, months: 12, withheld: 3500000, want: -500000},
+		{name: "job expense follows months worked", gross: 60000000, months: 6, withheld: 0, want: 150000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := annualTaxDue(tt.gross, tt.months, "TK/0", tt.withheld)
+			if err != nil {
+				t.Fatalf("annualTaxDue() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("annualTaxDue(%v, %d) = %v, want %v", tt.gross, tt.months, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIrregularIncomeTax(t *testing.T) {
+	tests := []struct {
+		name      string
+		monthly   float64
+		irregular float64
+		status    string
+		want      float64
+		wantErr   bool
+	}{
+		{name: "both below PTKP", monthly: 3000000, irregular: 3000000, status: "TK/0", want: 0},
+		{name: "bonus crosses PTKP", monthly: 4000000, irregular: 12000000, status: "TK/0", want: 150000},
+		{name: "bonus crosses into second bracket", monthly: 10000000, irregular: 10000000, status: "TK/0", want: 1500000},
+		{name: "dependents lower the tax", monthly: 10000000, irregular: 10000000, status: "K/3", want: 500000},
+		{name: "no irregular income", monthly: 10000000, irregular: 0, status: "TK/0", want: 0},
+		{name: "invalid status", monthly: 10000000, irregular: 10000000, status: "XX", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := irregularIncomeTax(tt.monthly, tt.irregular, tt.status)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("irregularIncomeTax() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("irregularIncomeTax(%v, %v, %s) = %v, want %v", tt.monthly, tt.irregular, tt.status, got, tt.want)
+			}
+		})
+	}
+}
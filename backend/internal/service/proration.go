@@ -0,0 +1,72 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"hr-payroll/internal/domain"
+	"time"
+)
+
+// proration adalah hasil perhitungan pro-rata gaji untuk satu periode
+type proration struct {
+	Method       string
+	Factor       float64
+	DaysCounted  int
+	DaysInPeriod int
+	ActiveFrom   time.Time
+	ActiveTo     time.Time
+}
+
+// validProrationMethod memeriksa apakah metode pro-rata dikenali
+func validProrationMethod(method string) bool {
+	switch method {
+	case domain.ProrationCalendarDays, domain.ProrationWorkingDays, domain.ProrationFixed30:
+		return true
+	}
+	return false
+}
+
+// calculateProration menghitung faktor pro-rata berdasarkan tanggal aktif karyawan di dalam periode
+func calculateProration(method string, emp *domain.Employee, periodStart, periodEnd time.Time, holidays map[time.Time]bool) (*proration, error) {
+	if !validProrationMethod(method) {
+		return nil, fmt.Errorf("unknown proration method: %s", method)
+	}
+
+	// 1. Tentukan rentang aktif karyawan di dalam periode
+	activeFrom, activeTo := periodStart, periodEnd
+	if emp.JoinDate != nil && truncateToDay(*emp.JoinDate).After(activeFrom) {
+		activeFrom = truncateToDay(*emp.JoinDate)
+	}
+	if emp.ResignDate != nil && truncateToDay(*emp.ResignDate).Before(activeTo) {
+		activeTo = truncateToDay(*emp.ResignDate)
+	}
+	if activeTo.Before(activeFrom) {
+		return nil, errors.New("employee is not active in this period")
+	}
+
+	p := &proration{Method: method, ActiveFrom: activeFrom, ActiveTo: activeTo}
+	fullPeriod := activeFrom.Equal(periodStart) && activeTo.Equal(periodEnd)
+
+	// 2. Hitung hari yang dihitung dan pembagi sesuai metode
+	switch method {
+	case domain.ProrationCalendarDays:
+		p.DaysCounted = countCalendarDays(activeFrom, activeTo)
+		p.DaysInPeriod = countCalendarDays(periodStart, periodEnd)
+	case domain.ProrationWorkingDays:
+		p.DaysCounted = countWorkingDays(activeFrom, activeTo, holidays)
+		p.DaysInPeriod = countWorkingDays(periodStart, periodEnd, holidays)
+	case domain.ProrationFixed30:
+		p.DaysInPeriod = 30
+		p.DaysCounted = countCalendarDays(activeFrom, activeTo)
+		if fullPeriod || p.DaysCounted > 30 {
+			p.DaysCounted = 30
+		}
+	}
+
+	if fullPeriod {
+		p.Factor = 1
+	} else if p.DaysInPeriod > 0 {
+		p.Factor = float64(p.DaysCounted) / float64(p.DaysInPeriod)
+	}
+	return p, nil
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"hr-payroll/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestCalculateProration(t *testing.T) {
+	date := func(year int, month time.Month, d int) *time.Time {
+		ts := day(year, month, d)
+		return &ts
+	}
+	// November 2025: 30 hari kalender, 20 hari kerja (1 November hari Sabtu)
+	novStart, novEnd := monthBounds(day(2025, 11, 1))
+
+	tests := []struct {
+		name        string
+		method      string
+		emp         domain.Employee
+		periodStart time.Time
+		periodEnd   time.Time
+		holidays    []time.Time
+		wantCounted int
+		wantDays    int
+		wantFactor  float64
+		wantErr     bool
+	}{
+		{name: "calendar days full month", method: domain.ProrationCalendarDays, wantCounted: 30, wantDays: 30, wantFactor: 1},
+		{name: "working days full month", method: domain.ProrationWorkingDays, wantCounted: 20, wantDays: 20, wantFactor: 1},
+		{name: "fixed 30 full month", method: domain.ProrationFixed30, wantCounted: 30, wantDays: 30, wantFactor: 1},
+		{name: "calendar days mid-month join", method: domain.ProrationCalendarDays, emp: domain.Employee{JoinDate: date(2025, 11, 17)},
+			wantCounted: 14, wantDays: 30, wantFactor: 14.0 / 30},
+		{name: "working days mid-month join", method: domain.ProrationWorkingDays, emp: domain.Employee{JoinDate: date(2025, 11, 17)},
+			wantCounted: 10, wantDays: 20, wantFactor: 0.5},
+		{name: "working days mid-month join with holiday", method: domain.ProrationWorkingDays, emp: domain.Employee{JoinDate: date(2025, 11, 17)},
+			holidays: []time.Time{day(2025, 11, 18)}, wantCounted: 9, wantDays: 19, wantFactor: 9.0 / 19},
+		{name: "fixed 30 mid-month join", method: domain.ProrationFixed30, emp: domain.Employee{JoinDate: date(2025, 11, 17)},
+			wantCounted: 14, wantDays: 30, wantFactor: 14.0 / 30},
+		{name: "calendar days mid-month leave", method: domain.ProrationCalendarDays, emp: domain.Employee{ResignDate: date(2025, 11, 14)},
+			wantCounted: 14, wantDays: 30, wantFactor: 14.0 / 30},
+		{name: "working days mid-month leave", method: domain.ProrationWorkingDays, emp: domain.Employee{ResignDate: date(2025, 11, 14)},
+			wantCounted: 10, wantDays: 20, wantFactor: 0.5},
+		{name: "join and leave in the same month", method: domain.ProrationCalendarDays,
+			emp:         domain.Employee{JoinDate: date(2025, 11, 10), ResignDate: date(2025, 11, 19)},
+			wantCounted: 10, wantDays: 30, wantFactor: 10.0 / 30},
+		{name: "fixed 30 in February", method: domain.ProrationFixed30, emp: domain.Employee{JoinDate: date(2025, 2, 15)},
+			periodStart: day(2025, 2, 1), periodEnd: day(2025, 2, 28), wantCounted: 14, wantDays: 30, wantFactor: 14.0 / 30},
+		{name: "fixed 30 caps a 31-day month at 30", method: domain.ProrationFixed30, emp: domain.Employee{JoinDate: date(2025, 1, 2)},
+			periodStart: day(2025, 1, 1), periodEnd: day(2025, 1, 31), wantCounted: 30, wantDays: 30, wantFactor: 1},
+		{name: "joined before the period", method: domain.ProrationCalendarDays, emp: domain.Employee{JoinDate: date(2024, 3, 1)},
+			wantCounted: 30, wantDays: 30, wantFactor: 1},
+		{name: "resigned before the period", method: domain.ProrationCalendarDays, emp: domain.Employee{ResignDate: date(2025, 10, 31)}, wantErr: true},
+		{name: "joins after the period", method: domain.ProrationCalendarDays, emp: domain.Employee{JoinDate: date(2025, 12, 1)}, wantErr: true},
+		{name: "unknown method", method: "HOURLY", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			periodStart, periodEnd := tt.periodStart, tt.periodEnd
+			if periodStart.IsZero() {
+				periodStart, periodEnd = novStart, novEnd
+			}
+			holidays := map[time.Time]bool{}
+			for _, h := range tt.holidays {
+				holidays[h] = true
+			}
+
+			got, err := calculateProration(tt.method, &tt.emp, periodStart, periodEnd, holidays)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("calculateProration() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.DaysCounted != tt.wantCounted || got.DaysInPeriod != tt.wantDays {
+				t.Errorf("days = %d/%d, want %d/%d", got.DaysCounted, got.DaysInPeriod, tt.wantCounted, tt.wantDays)
+			}
+			if !almostEqual(got.Factor, tt.wantFactor) {
+				t.Errorf("Factor = %v, want %v", got.Factor, tt.wantFactor)
+			}
+		})
+	}
+}
+
+// almostEqual membandingkan float hasil pembagian
+func almostEqual(a, b float64) bool {
+	const epsilon = 1e-9
+	return a-b < epsilon && b-a < epsilon
+}
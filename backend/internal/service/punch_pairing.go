@@ -0,0 +1,83 @@
+package service
+
+import (
+	"fmt"
+	"hr-payroll/internal/domain"
+	"time"
+)
+
+// dailySummary adalah ringkasan absensi harian hasil pairing punch
+type dailySummary struct {
+	CheckIn       *time.Time
+	CheckOut      *time.Time
+	WorkedMinutes int
+}
+
+// validPairingRule memeriksa apakah aturan pairing dikenali
+func validPairingRule(rule string) bool {
+	return rule == domain.PairingFirstInLastOut || rule == domain.PairingSessions
+}
+
+// countedPunches membuang punch yang ditandai (kebijakan FLAG) dan belum disetujui, sehingga tidak ikut ringkasan harian
+func countedPunches(punches []domain.Punch) []domain.Punch {
+	counted := make([]domain.Punch, 0, len(punches))
+	for _, punch := range punches {
+		if !punch.Flagged || punch.ReviewStatus == domain.PunchReviewApproved {
+			counted = append(counted, punch)
+		}
+	}
+	return counted
+}
+
+// pairPunches menghitung check-in, check-out dan menit kerja dari punch satu hari (urut waktu)
+func pairPunches(rule string, punches []domain.Punch) (dailySummary, error) {
+	var summary dailySummary
+	if !validPairingRule(rule) {
+		return summary, fmt.Errorf("unknown pairing rule: %s", rule)
+	}
+	if len(punches) == 0 {
+		return summary, nil
+	}
+
+	first := punches[0].Timestamp
+	summary.CheckIn = &first
+
+	switch rule {
+	case domain.PairingFirstInLastOut:
+		// Punch pertama masuk, punch terakhir pulang, arah punch diabaikan
+		last := punches[len(punches)-1].Timestamp
+		if len(punches) > 1 && last.After(first) {
+			summary.CheckOut = &last
+			summary.WorkedMinutes = int(last.Sub(first).Minutes())
+		}
+	case domain.PairingSessions:
+		// Setiap IN dipasangkan dengan OUT berikutnya. Punch tanpa arah dianggap bergantian IN/OUT.
+		// IN tanpa pasangan (lupa punch pulang) tidak dihitung jam kerjanya.
+		var open *time.Time
+		worked := time.Duration(0)
+		for _, punch := range punches {
+			direction := punch.Direction
+			if direction == domain.PunchUnknown {
+				direction = domain.PunchIn
+				if open != nil {
+					direction = domain.PunchOut
+				}
+			}
+
+			ts := punch.Timestamp
+			if direction == domain.PunchIn {
+				if open == nil {
+					open = &ts
+				}
+				continue
+			}
+			if open != nil {
+				worked += ts.Sub(*open)
+				summary.CheckOut = &ts
+				open = nil
+			}
+		}
+		summary.WorkedMinutes = int(worked.Minutes())
+	}
+	return summary, nil
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"hr-payroll/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestPairPunches(t *testing.T) {
+	date := day(2025, 11, 10)
+	at := func(hour, minute int, direction string) domain.Punch {
+		return domain.Punch{Timestamp: date.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute), Direction: direction}
+	}
+	clock := func(hour, minute int) *time.Time {
+		ts := date.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+		return &ts
+	}
+
+	tests := []struct {
+		name         string
+		rule         string
+		punches      []domain.Punch
+		wantCheckIn  *time.Time
+		wantCheckOut *time.Time
+		wantMinutes  int
+		wantErr      bool
+	}{
+		{name: "no punches", rule: domain.PairingFirstInLastOut},
+		{name: "first in last out single punch", rule: domain.PairingFirstInLastOut, punches: []domain.Punch{at(8, 0, "")}, wantCheckIn: clock(8, 0)},
+		{name: "first in last out ignores breaks", rule: domain.PairingFirstInLastOut,
+			punches:     []domain.Punch{at(8, 0, domain.PunchIn), at(12, 0, domain.PunchOut), at(13, 0, domain.PunchIn), at(17, 0, domain.PunchOut)},
+			wantCheckIn: clock(8, 0), wantCheckOut: clock(17, 0), wantMinutes: 540},
+		{name: "first in last out ignores directions", rule: domain.PairingFirstInLastOut,
+			punches:     []domain.Punch{at(8, 0, domain.PunchOut), at(16, 30, domain.PunchIn)},
+			wantCheckIn: clock(8, 0), wantCheckOut: clock(16, 30), wantMinutes: 510},
+		{name: "sessions exclude the break", rule: domain.PairingSessions,
+			punches:     []domain.Punch{at(8, 0, domain.PunchIn), at(12, 0, domain.PunchOut), at(13, 0, domain.PunchIn), at(17, 0, domain.PunchOut)},
+			wantCheckIn: clock(8, 0), wantCheckOut: clock(17, 0), wantMinutes: 480},
+		{name: "sessions alternate punches without direction", rule: domain.PairingSessions,
+			punches:     []domain.Punch{at(8, 0, ""), at(12, 0, ""), at(13, 0, ""), at(17, 0, "")},
+			wantCheckIn: clock(8, 0), wantCheckOut: clock(17, 0), wantMinutes: 480},
+		{name: "sessions skip an unmatched IN", rule: domain.PairingSessions,
+			punches:     []domain.Punch{at(8, 0, domain.PunchIn), at(12, 0, domain.PunchOut), at(13, 0, domain.PunchIn)},
+			wantCheckIn: clock(8, 0), wantCheckOut: clock(12, 0), wantMinutes: 240},
+		{name: "sessions keep the first of repeated INs", rule: domain.PairingSessions,
+			punches:     []domain.Punch{at(8, 0, domain.PunchIn), at(9, 0, domain.PunchIn), at(12, 0, domain.PunchOut)},
+			wantCheckIn: clock(8, 0), wantCheckOut: clock(12, 0), wantMinutes: 240},
+		{name: "sessions ignore an OUT without IN", rule: domain.PairingSessions,
+			punches:     []domain.Punch{at(7, 0, domain.PunchOut), at(8, 0, domain.PunchIn), at(12, 0, domain.PunchOut)},
+			wantCheckIn: clock(7, 0), wantCheckOut: clock(12, 0), wantMinutes: 240},
+		{name: "unknown rule", rule: "LONGEST_SESSION", punches: []domain.Punch{at(8, 0, "")}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := pairPunches(tt.rule, tt.punches)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("pairPunches() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !sameTime(got.CheckIn, tt.wantCheckIn) || !sameTime(got.CheckOut, tt.wantCheckOut) {
+				t.Errorf("summary = %v - %v, want %v - %v", got.CheckIn, got.CheckOut, tt.wantCheckIn, tt.wantCheckOut)
+			}
+			if got.WorkedMinutes != tt.wantMinutes {
+				t.Errorf("WorkedMinutes = %d, want %d", got.WorkedMinutes, tt.wantMinutes)
+			}
+		})
+	}
+}
+
+func TestCountedPunches(t *testing.T) {
+	punches := []domain.Punch{
+		{ID: 1},
+		{ID: 2, Flagged: true, ReviewStatus: domain.PunchReviewPending},
+		{ID: 3, Flagged: true, ReviewStatus: domain.PunchReviewApproved},
+		{ID: 4, Flagged: true, ReviewStatus: domain.PunchReviewRejected},
+		{ID: 5, Flagged: true}, // Ditandai sebelum ada review
+	}
+	counted := countedPunches(punches)
+	if len(counted) != 2 || counted[0].ID != 1 || counted[1].ID != 3 {
+		t.Errorf("countedPunches() = %+v, want punches 1 and 3", counted)
+	}
+}
+
+// sameTime membandingkan dua waktu opsional
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
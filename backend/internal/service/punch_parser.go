@@ -0,0 +1,239 @@
+package service
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"hr-payroll/internal/domain"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// rawPunch adalah satu tap jari dari file ekspor mesin absensi
+type rawPunch struct {
+	Row          int
+	DeviceUserID string
+	Time         time.Time
+	Direction    string // domain.PunchIn, domain.PunchOut, atau kosong jika mesin tidak mencatat
+}
+
+// Format waktu yang umum dipakai ekspor mesin ZKTeco / Solution
+var punchTimeLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04:05",
+	"2006/01/02 15:04:05",
+	"2006/01/02 15:04",
+	"02/01/2006 15:04:05",
+	"02/01/2006 15:04",
+	"02-01-2006 15:04:05",
+	"02-01-2006 15:04",
+}
+
+// Nama kolom header yang dikenali (huruf kecil)
+var (
+	userIDHeaders    = []string{"device_user_id", "user_id", "userid", "pin", "ac-no.", "ac-no", "no.", "enroll_number"}
+	timestampHeaders = []string{"timestamp", "datetime", "date_time", "date/time", "checktime", "check_time"}
+	directionHeaders = []string{"direction", "state", "in_out", "checktype", "check_type"}
+)
+
+// Kode status ZKTeco: 0 check-in, 1 check-out, 2 break-out, 3 break-in, 4 OT-in, 5 OT-out
+var punchStateDirections = map[string]string{
+	"0": domain.PunchIn, "1": domain.PunchOut, "2": domain.PunchOut,
+	"3": domain.PunchIn, "4": domain.PunchIn, "5": domain.PunchOut,
+	"i": domain.PunchIn, "o": domain.PunchOut,
+	"in": domain.PunchIn, "out": domain.PunchOut,
+	"c/in": domain.PunchIn, "c/out": domain.PunchOut,
+	"check in": domain.PunchIn, "check out": domain.PunchOut,
+	"checkin": domain.PunchIn, "checkout": domain.PunchOut,
+	"masuk": domain.PunchIn, "pulang": domain.PunchOut,
+}
+
+// parsePunches membaca punch mentah sesuai format. Baris yang tidak bisa dibaca dilaporkan sebagai row error.
+func parsePunches(r io.Reader, format string) ([]rawPunch, []domain.AttendanceImportRowError, error) {
+	switch format {
+	case domain.ImportFormatCSV:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		rows, err := reader.ReadAll()
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid CSV file: %w", err)
+		}
+		return punchesFromTable(rows)
+	case domain.ImportFormatXLSX:
+		return parseXLSXPunches(r)
+	case domain.ImportFormatATTLOG:
+		return parseATTLOGPunches(r)
+	}
+	return nil, nil, fmt.Errorf("unsupported import format: %s", format)
+}
+
+// parseATTLOGPunches membaca file ATTLOG .dat: kolom dipisah tab/spasi, kolom 1 user ID, kolom 2-3 tanggal & jam,
+// kolom 5 (opsional) status in/out
+func parseATTLOGPunches(r io.Reader) ([]rawPunch, []domain.AttendanceImportRowError, error) {
+	var punches []rawPunch
+	var rowErrors []domain.AttendanceImportRowError
+
+	scanner := bufio.NewScanner(r)
+	row := 0
+	for scanner.Scan() {
+		row++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 3 {
+			rowErrors = append(rowErrors, domain.AttendanceImportRowError{Row: row, DeviceUserID: fields[0], Error: "expected user ID, date and time"})
+			continue
+		}
+
+		ts, err := parsePunchTime(fields[1] + " " + fields[2])
+		if err != nil {
+			rowErrors = append(rowErrors, domain.AttendanceImportRowError{Row: row, DeviceUserID: fields[0], Error: err.Error()})
+			continue
+		}
+		direction := domain.PunchUnknown
+		if len(fields) > 4 {
+			direction = parsePunchDirection(fields[4])
+		}
+		punches = append(punches, rawPunch{Row: row, DeviceUserID: fields[0], Time: ts, Direction: direction})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("invalid ATTLOG file: %w", err)
+	}
+	return punches, rowErrors, nil
+}
+
+// parseXLSXPunches membaca sheet pertama template XLSX
+func parseXLSXPunches(r io.Reader) ([]rawPunch, []domain.AttendanceImportRowError, error) {
+	file, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid XLSX file: %w", err)
+	}
+	defer file.Close()
+
+	sheets := file.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, nil, errors.New("XLSX file has no sheets")
+	}
+	// Nilai mentah agar kolom tanggal Excel terbaca sebagai serial number, bukan teks berformat
+	rows, err := file.GetRows(sheets[0], excelize.Options{RawCellValue: true})
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid XLSX file: %w", err)
+	}
+	return punchesFromTable(rows)
+}
+
+// punchesFromTable membaca tabel (CSV/XLSX) dengan baris pertama sebagai header
+func punchesFromTable(rows [][]string) ([]rawPunch, []domain.AttendanceImportRowError, error) {
+	if len(rows) == 0 {
+		return nil, nil, errors.New("file is empty")
+	}
+
+	header := make(map[string]int, len(rows[0]))
+	for i, name := range rows[0] {
+		header[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	userCol := findColumn(header, userIDHeaders)
+	timestampCol := findColumn(header, timestampHeaders)
+	dateCol := findColumn(header, []string{"date"})
+	timeCol := findColumn(header, []string{"time"})
+	directionCol := findColumn(header, directionHeaders)
+	if userCol < 0 {
+		return nil, nil, errors.New("missing device_user_id column in header")
+	}
+	if timestampCol < 0 && (dateCol < 0 || timeCol < 0) {
+		return nil, nil, errors.New("missing timestamp column (or date and time columns) in header")
+	}
+
+	var punches []rawPunch
+	var rowErrors []domain.AttendanceImportRowError
+	for i, record := range rows[1:] {
+		row := i + 2 // baris 1 adalah header
+		userID := cell(record, userCol)
+		if userID == "" && cell(record, timestampCol) == "" && cell(record, dateCol) == "" {
+			continue // baris kosong
+		}
+
+		var ts time.Time
+		var err error
+		if timestampCol >= 0 {
+			ts, err = parsePunchTime(cell(record, timestampCol))
+		} else {
+			ts, err = parseDateAndTime(cell(record, dateCol), cell(record, timeCol))
+		}
+		if err != nil {
+			rowErrors = append(rowErrors, domain.AttendanceImportRowError{Row: row, DeviceUserID: userID, Error: err.Error()})
+			continue
+		}
+		if userID == "" {
+			rowErrors = append(rowErrors, domain.AttendanceImportRowError{Row: row, Error: "device user ID is empty"})
+			continue
+		}
+		punches = append(punches, rawPunch{Row: row, DeviceUserID: userID, Time: ts, Direction: parsePunchDirection(cell(record, directionCol))})
+	}
+	return punches, rowErrors, nil
+}
+
+// parsePunchTime menerima teks tanggal-jam atau serial number tanggal Excel
+func parsePunchTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if serial, err := strconv.ParseFloat(value, 64); err == nil {
+		return excelSerialToTime(serial)
+	}
+	for _, layout := range punchTimeLayouts {
+		if ts, err := time.Parse(layout, value); err == nil {
+			return ts, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid timestamp %q", value)
+}
+
+// parseDateAndTime menggabungkan kolom tanggal dan jam yang terpisah
+func parseDateAndTime(dateValue, timeValue string) (time.Time, error) {
+	dateValue, timeValue = strings.TrimSpace(dateValue), strings.TrimSpace(timeValue)
+
+	// Excel: tanggal = serial hari, jam = pecahan hari
+	dateSerial, dateErr := strconv.ParseFloat(dateValue, 64)
+	timeSerial, timeErr := strconv.ParseFloat(timeValue, 64)
+	if dateErr == nil && timeErr == nil {
+		return excelSerialToTime(dateSerial + timeSerial)
+	}
+	return parsePunchTime(dateValue + " " + timeValue)
+}
+
+// excelSerialToTime mengubah serial number tanggal Excel ke waktu, dibulatkan ke detik
+func excelSerialToTime(serial float64) (time.Time, error) {
+	ts, err := excelize.ExcelDateToTime(serial, false)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return ts.Round(time.Second), nil
+}
+
+// parsePunchDirection memetakan kode status mesin ke arah punch; nilai tak dikenal dianggap tanpa arah
+func parsePunchDirection(value string) string {
+	return punchStateDirections[strings.ToLower(strings.TrimSpace(value))]
+}
+
+func findColumn(header map[string]int, names []string) int {
+	for _, name := range names {
+		if idx, ok := header[name]; ok {
+			return idx
+		}
+	}
+	return -1
+}
+
+func cell(record []string, idx int) string {
+	if idx < 0 || idx >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[idx])
+}
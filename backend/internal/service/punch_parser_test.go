@@ -0,0 +1,193 @@
+package service
+
+import (
+	"hr-payroll/internal/domain"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParsePunchTime(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "2025-11-10 08:01:23", want: time.Date(2025, 11, 10, 8, 1, 23, 0, time.UTC)},
+		{value: "2025-11-10 08:01", want: time.Date(2025, 11, 10, 8, 1, 0, 0, time.UTC)},
+		{value: "2025-11-10T08:01:23", want: time.Date(2025, 11, 10, 8, 1, 23, 0, time.UTC)},
+		{value: "2025/11/10 08:01:23", want: time.Date(2025, 11, 10, 8, 1, 23, 0, time.UTC)},
+		{value: "10/11/2025 08:01", want: time.Date(2025, 11, 10, 8, 1, 0, 0, time.UTC)},
+		{value: "10-11-2025 08:01:23", want: time.Date(2025, 11, 10, 8, 1, 23, 0, time.UTC)},
+		{value: " 2025-11-10 08:01:23 ", want: time.Date(2025, 11, 10, 8, 1, 23, 0, time.UTC)},
+		{value: "45971.5", want: time.Date(2025, 11, 10, 12, 0, 0, 0, time.UTC)}, // Serial tanggal Excel
+		{value: "11/10/2025 08:01 PM", wantErr: true},
+		{value: "kemarin", wantErr: true},
+		{value: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parsePunchTime(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePunchTime(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parsePunchTime(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDateAndTime(t *testing.T) {
+	tests := []struct {
+		name      string
+		dateValue string
+		timeValue string
+		want      time.Time
+		wantErr   bool
+	}{
+		{name: "text columns", dateValue: "2025-11-10", timeValue: "08:01:23", want: time.Date(2025, 11, 10, 8, 1, 23, 0, time.UTC)},
+		{name: "excel serials", dateValue: "45971", timeValue: "0.3340625", want: time.Date(2025, 11, 10, 8, 1, 3, 0, time.UTC)},
+		{name: "invalid time", dateValue: "2025-11-10", timeValue: "pagi", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDateAndTime(tt.dateValue, tt.timeValue)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseDateAndTime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseDateAndTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePunchDirection(t *testing.T) {
+	tests := map[string]string{
+		"0":      domain.PunchIn,
+		"1":      domain.PunchOut,
+		"2":      domain.PunchOut,
+		"3":      domain.PunchIn,
+		"C/Out":  domain.PunchOut,
+		" IN ":   domain.PunchIn,
+		"masuk":  domain.PunchIn,
+		"Pulang": domain.PunchOut,
+		"9":      domain.PunchUnknown,
+		"":       domain.PunchUnknown,
+	}
+	for value, want := range tests {
+		if got := parsePunchDirection(value); got != want {
+			t.Errorf("parsePunchDirection(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
+
+func TestParsePunches(t *testing.T) {
+	tests := []struct {
+		name          string
+		format        string
+		content       string
+		wantPunches   []rawPunch
+		wantRowErrors []int // Nomor baris yang gagal dibaca
+		wantErr       string
+	}{
+		{
+			name:   "CSV with timestamp column",
+			format: domain.ImportFormatCSV,
+			content: "device_user_id,timestamp,direction\n" +
+				"101,2025-11-10 08:01:23,0\n" +
+				"\n" +
+				"102,2025-11-10 17:05:00,C/Out\n",
+			wantPunches: []rawPunch{
+				{Row: 2, DeviceUserID: "101", Time: time.Date(2025, 11, 10, 8, 1, 23, 0, time.UTC), Direction: domain.PunchIn},
+				{Row: 3, DeviceUserID: "102", Time: time.Date(2025, 11, 10, 17, 5, 0, 0, time.UTC), Direction: domain.PunchOut},
+			},
+		},
+		{
+			name:   "CSV with separate date and time columns",
+			format: domain.ImportFormatCSV,
+			content: "AC-No.,Date,Time,State\n" +
+				"101,10/11/2025,08:01,Masuk\n" +
+				"102,10/11/2025,jam 8,Masuk\n" +
+				",10/11/2025,08:03,Masuk\n",
+			wantPunches: []rawPunch{
+				{Row: 2, DeviceUserID: "101", Time: time.Date(2025, 11, 10, 8, 1, 0, 0, time.UTC), Direction: domain.PunchIn},
+			},
+			wantRowErrors: []int{3, 4},
+		},
+		{
+			name:    "CSV without a user ID column",
+			format:  domain.ImportFormatCSV,
+			content: "name,timestamp\nBudi,2025-11-10 08:01:23\n",
+			wantErr: "missing device_user_id column",
+		},
+		{
+			name:    "CSV without a time column",
+			format:  domain.ImportFormatCSV,
+			content: "device_user_id,date\n101,2025-11-10\n",
+			wantErr: "missing timestamp column",
+		},
+		{
+			name:    "empty CSV",
+			format:  domain.ImportFormatCSV,
+			wantErr: "file is empty",
+		},
+		{
+			name:   "ATTLOG",
+			format: domain.ImportFormatATTLOG,
+			content: "  101\t2025-11-10 08:01:23\t1\t0\t0\t0\n" +
+				"  101\t2025-11-10 17:02:10\t1\t1\t0\t0\n" +
+				"\n" +
+				"  102\t2025-11-10\n" +
+				"  103\t2025-13-40 08:00:00\t1\t0\t0\t0\n" +
+				"  104\t2025-11-10 08:15:00\n",
+			wantPunches: []rawPunch{
+				{Row: 1, DeviceUserID: "101", Time: time.Date(2025, 11, 10, 8, 1, 23, 0, time.UTC), Direction: domain.PunchIn},
+				{Row: 2, DeviceUserID: "101", Time: time.Date(2025, 11, 10, 17, 2, 10, 0, time.UTC), Direction: domain.PunchOut},
+				{Row: 6, DeviceUserID: "104", Time: time.Date(2025, 11, 10, 8, 15, 0, 0, time.UTC), Direction: domain.PunchUnknown},
+			},
+			wantRowErrors: []int{4, 5},
+		},
+		{
+			name:    "unsupported format",
+			format:  "JSON",
+			wantErr: "unsupported import format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			punches, rowErrors, err := parsePunches(strings.NewReader(tt.content), tt.format)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parsePunches() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parsePunches() error = %v", err)
+			}
+
+			if len(punches) != len(tt.wantPunches) {
+				t.Fatalf("punches = %+v, want %+v", punches, tt.wantPunches)
+			}
+			for i, punch := range punches {
+				want := tt.wantPunches[i]
+				if punch.Row != want.Row || punch.DeviceUserID != want.DeviceUserID || !punch.Time.Equal(want.Time) || punch.Direction != want.Direction {
+					t.Errorf("punch %d = %+v, want %+v", i, punch, want)
+				}
+			}
+			if len(rowErrors) != len(tt.wantRowErrors) {
+				t.Fatalf("row errors = %+v, want rows %v", rowErrors, tt.wantRowErrors)
+			}
+			for i, rowErr := range rowErrors {
+				if rowErr.Row != tt.wantRowErrors[i] {
+					t.Errorf("row error %d on row %d, want row %d", i, rowErr.Row, tt.wantRowErrors[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,314 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hr-payroll/internal/domain"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Ekstensi bukti pembayaran yang diterima
+var receiptExtensions = map[string]bool{".jpg": true, ".jpeg": true, ".png": true, ".pdf": true}
+
+// ReimbursementServiceImpl mengimplementasikan domain.ReimbursementService
+type ReimbursementServiceImpl struct {
+	Repo    domain.ReimbursementRepository
+	EmpRepo domain.EmployeeRepository
+	Storage domain.FileStorage
+}
+
+func NewReimbursementServiceImpl(repo domain.ReimbursementRepository, er domain.EmployeeRepository, fs domain.FileStorage) domain.ReimbursementService {
+	return &ReimbursementServiceImpl{Repo: repo, EmpRepo: er, Storage: fs}
+}
+
+// CreateCategory implements domain.ReimbursementService
+func (s *ReimbursementServiceImpl) CreateCategory(ctx context.Context, category *domain.ReimbursementCategory) (*domain.ReimbursementCategory, error) {
+	category.Code = strings.ToUpper(strings.TrimSpace(category.Code))
+	if category.Code == "" {
+		return nil, errors.New("category code is required")
+	}
+	if existing, err := s.Repo.FindCategoryByCode(ctx, category.Code); err == nil && existing.ID != 0 {
+		return nil, errors.New("category code already exists")
+	}
+	if err := validateReimbursementCategory(category); err != nil {
+		return nil, err
+	}
+
+	category.ID = 0
+	category.Active = true
+	if err := s.Repo.SaveCategory(ctx, category); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// UpdateCategory implements domain.ReimbursementService.
+// Batas baru hanya berlaku untuk klaim yang diajukan atau disetujui setelahnya.
+func (s *ReimbursementServiceImpl) UpdateCategory(ctx context.Context, code string, category *domain.ReimbursementCategory) (*domain.ReimbursementCategory, error) {
+	existing, err := s.Repo.FindCategoryByCode(ctx, strings.ToUpper(code))
+	if err != nil {
+		return nil, domain.ErrReimbursementCategoryNotFound
+	}
+	if err := validateReimbursementCategory(category); err != nil {
+		return nil, err
+	}
+
+	existing.Name = category.Name
+	existing.YearlyLimit = category.YearlyLimit
+	existing.Active = category.Active
+	if err := s.Repo.UpdateCategory(ctx, existing); err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+// GetCategories implements domain.ReimbursementService
+func (s *ReimbursementServiceImpl) GetCategories(ctx context.Context) ([]domain.ReimbursementCategory, error) {
+	return s.Repo.FindCategories(ctx)
+}
+
+// SubmitClaim implements domain.ReimbursementService
+func (s *ReimbursementServiceImpl) SubmitClaim(ctx context.Context, req domain.ReimbursementSubmission) (*domain.ReimbursementClaim, error) {
+	// 1. Validasi
+	employee, err := s.EmpRepo.FindByID(ctx, req.EmployeeID)
+	if err != nil {
+		return nil, errors.New("employee not found")
+	}
+	if req.Amount <= 0 {
+		return nil, errors.New("amount must be greater than zero")
+	}
+	if req.ExpenseDate.IsZero() {
+		return nil, errors.New("expense_date is required")
+	}
+	expenseDate := truncateToDay(req.ExpenseDate)
+	if expenseDate.After(truncateToDay(time.Now())) {
+		return nil, errors.New("expense_date cannot be in the future")
+	}
+	if req.Receipt == nil {
+		return nil, errors.New("receipt is required")
+	}
+	ext := strings.ToLower(filepath.Ext(req.ReceiptName))
+	if !receiptExtensions[ext] {
+		return nil, errors.New("receipt must be a JPG, PNG or PDF file")
+	}
+
+	// 2. Kategori aktif dan batas tahunan
+	category, err := s.Repo.FindCategoryByCode(ctx, strings.ToUpper(strings.TrimSpace(req.CategoryCode)))
+	if err != nil || !category.Active {
+		return nil, domain.ErrReimbursementCategoryNotFound
+	}
+	claim := &domain.ReimbursementClaim{
+		EmployeeID:   employee.ID,
+		CategoryCode: category.Code,
+		ExpenseDate:  expenseDate,
+		Amount:       req.Amount,
+		Description:  strings.TrimSpace(req.Description),
+		Status:       domain.ReimbursementSubmitted,
+	}
+	if err := s.checkLimit(ctx, category, claim); err != nil {
+		return nil, err
+	}
+
+	// 3. Simpan bukti pembayaran
+	suffix := make([]byte, 6)
+	if _, err := rand.Read(suffix); err != nil {
+		return nil, err
+	}
+	name := fmt.Sprintf("receipts/%d/%s-%s%s", employee.ID, time.Now().Format("20060102-150405"), hex.EncodeToString(suffix), ext)
+	if claim.ReceiptPath, err = s.Storage.Save(name, req.Receipt); err != nil {
+		return nil, err
+	}
+
+	if err := s.Repo.Save(ctx, claim); err != nil {
+		// Klaim tidak tersimpan: jangan tinggalkan bukti pembayaran tanpa pemilik di storage
+		_ = s.Storage.Delete(claim.ReceiptPath)
+		return nil, err
+	}
+	return claim, nil
+}
+
+// ApproveClaim implements domain.ReimbursementService
+func (s *ReimbursementServiceImpl) ApproveClaim(ctx context.Context, id uint, reviewer string, note string) (*domain.ReimbursementClaim, error) {
+	claim, err := s.Repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, domain.ErrReimbursementNotFound
+	}
+	reviewer = strings.TrimSpace(reviewer)
+	if reviewer == "" {
+		return nil, errors.New("reviewed_by is required")
+	}
+
+	now := time.Now()
+	switch claim.Status {
+	case domain.ReimbursementSubmitted:
+		claim.Status = domain.ReimbursementManagerApproved
+		claim.ManagerBy, claim.ManagerNote, claim.ManagerAt = reviewer, note, &now
+	case domain.ReimbursementManagerApproved:
+		// Persetujuan finance harus dari orang yang berbeda dengan manajer
+		if strings.EqualFold(reviewer, claim.ManagerBy) {
+			return nil, errors.New("finance approval must come from a different reviewer than the manager")
+		}
+		// Batas dicek ulang: klaim lain bisa saja disetujui sejak pengajuan
+		category, err := s.Repo.FindCategoryByCode(ctx, claim.CategoryCode)
+		if err != nil {
+			return nil, domain.ErrReimbursementCategoryNotFound
+		}
+		if err := s.checkLimit(ctx, category, claim); err != nil {
+			return nil, err
+		}
+		claim.Status = domain.ReimbursementApproved
+		claim.FinanceBy, claim.FinanceNote, claim.FinanceAt = reviewer, note, &now
+	default:
+		return nil, domain.ErrReimbursementNotReviewable
+	}
+
+	if err := s.Repo.Update(ctx, claim); err != nil {
+		return nil, err
+	}
+	return claim, nil
+}
+
+// RejectClaim implements domain.ReimbursementService
+func (s *ReimbursementServiceImpl) RejectClaim(ctx context.Context, id uint, reviewer string, reason string) (*domain.ReimbursementClaim, error) {
+	claim, err := s.Repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, domain.ErrReimbursementNotFound
+	}
+	if claim.Status != domain.ReimbursementSubmitted && claim.Status != domain.ReimbursementManagerApproved {
+		return nil, domain.ErrReimbursementNotReviewable
+	}
+	reviewer = strings.TrimSpace(reviewer)
+	if reviewer == "" || strings.TrimSpace(reason) == "" {
+		return nil, errors.New("reviewed_by and reason are required")
+	}
+
+	claim.Status = domain.ReimbursementRejected
+	claim.RejectedBy = reviewer
+	claim.RejectionReason = reason
+	if err := s.Repo.Update(ctx, claim); err != nil {
+		return nil, err
+	}
+	return claim, nil
+}
+
+// GetClaims implements domain.ReimbursementService
+func (s *ReimbursementServiceImpl) GetClaims(ctx context.Context, employeeID uint, status string) ([]domain.ReimbursementClaim, error) {
+	return s.Repo.FindAll(ctx, employeeID, strings.ToUpper(status))
+}
+
+// GetClaim implements domain.ReimbursementService
+func (s *ReimbursementServiceImpl) GetClaim(ctx context.Context, id uint) (*domain.ReimbursementClaim, error) {
+	claim, err := s.Repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, domain.ErrReimbursementNotFound
+	}
+	return claim, nil
+}
+
+// OpenReceipt implements domain.ReimbursementService
+func (s *ReimbursementServiceImpl) OpenReceipt(ctx context.Context, id uint) (io.ReadCloser, error) {
+	claim, err := s.Repo.FindByID(ctx, id)
+	if err != nil || claim.ReceiptPath == "" {
+		return nil, errors.New("receipt not found")
+	}
+	return s.Storage.Open(claim.ReceiptPath)
+}
+
+// checkLimit memastikan total klaim karyawan di kategori dan tahun yang sama tidak melewati batas
+func (s *ReimbursementServiceImpl) checkLimit(ctx context.Context, category *domain.ReimbursementCategory, claim *domain.ReimbursementClaim) error {
+	if category.YearlyLimit <= 0 {
+		return nil
+	}
+	claimed, err := s.Repo.SumClaimed(ctx, claim.EmployeeID, category.Code, claim.ExpenseDate.Year(), claim.ID)
+	if err != nil {
+		return err
+	}
+	if claimed+claim.Amount > category.YearlyLimit {
+		return fmt.Errorf("%w: %.2f already claimed of %.2f in %d", domain.ErrReimbursementLimitExceeded, claimed, category.YearlyLimit, claim.ExpenseDate.Year())
+	}
+	return nil
+}
+
+func validateReimbursementCategory(category *domain.ReimbursementCategory) error {
+	if strings.TrimSpace(category.Name) == "" {
+		return errors.New("category name is required")
+	}
+	if category.YearlyLimit < 0 {
+		return errors.New("yearly_limit must not be negative")
+	}
+	return nil
+}
+
+// reimbursementItems membuat baris pendapatan tidak kena pajak untuk klaim yang sudah disetujui finance
+func reimbursementItems(claims []domain.ReimbursementClaim) []domain.PayrollItem {
+	items := make([]domain.PayrollItem, 0, len(claims))
+	for i := range claims {
+		claim := &claims[i]
+		items = append(items, domain.PayrollItem{
+			Code:        domain.PayrollItemCodeReimbursement,
+			Type:        domain.PayrollItemEarning,
+			Description: fmt.Sprintf("Reimbursement %s #%d (tidak kena pajak)", claim.CategoryCode, claim.ID),
+			Amount:      claim.Amount,
+			RefID:       &claim.ID,
+		})
+	}
+	return items
+}
+
+// linkReimbursements mengikat klaim pada baris REIMBURSEMENT ke slip agar tidak ikut slip lain
+func linkReimbursements(ctx context.Context, repo domain.ReimbursementRepository, slip *domain.Payroll) error {
+	for _, item := range slip.Items {
+		if item.Code != domain.PayrollItemCodeReimbursement || item.RefID == nil {
+			continue
+		}
+		claim, err := repo.FindByID(ctx, *item.RefID)
+		if err != nil {
+			return domain.ErrReimbursementNotFound
+		}
+		claim.PayrollID = &slip.ID
+		if err := repo.Update(ctx, claim); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// markReimbursementsPaid menandai klaim di slip yang dibayar sebagai PAID
+func markReimbursementsPaid(ctx context.Context, repo domain.ReimbursementRepository, slip *domain.Payroll) error {
+	claims, err := repo.FindByPayroll(ctx, slip.ID)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for i := range claims {
+		claims[i].Status = domain.ReimbursementPaid
+		claims[i].PaidAt = &now
+		if err := repo.Update(ctx, &claims[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// releaseReimbursements mengembalikan klaim dari slip yang di-void ke APPROVED agar dibayar slip pengganti
+func releaseReimbursements(ctx context.Context, repo domain.ReimbursementRepository, slip *domain.Payroll) error {
+	claims, err := repo.FindByPayroll(ctx, slip.ID)
+	if err != nil {
+		return err
+	}
+	for i := range claims {
+		claims[i].Status = domain.ReimbursementApproved
+		claims[i].PayrollID = nil
+		claims[i].PaidAt = nil
+		if err := repo.Update(ctx, &claims[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"hr-payroll/internal/domain"
+	"hr-payroll/internal/storage"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// failingClaimSave mensimulasikan kegagalan database saat klaim disimpan
+type failingClaimSave struct {
+	domain.ReimbursementRepository
+}
+
+func (failingClaimSave) Save(ctx context.Context, claim *domain.ReimbursementClaim) error {
+	return errors.New("disk full")
+}
+
+func TestSubmitClaimRemovesReceiptWhenSaveFails(t *testing.T) {
+	ctx := context.Background()
+	repos := newTestRepos(t)
+	for _, category := range domain.DefaultReimbursementCategories {
+		if err := repos.Reimbursement.SaveCategory(ctx, &category); err != nil {
+			t.Fatalf("seed category %s: %v", category.Code, err)
+		}
+	}
+	emp := repos.saveEmployee(t, domain.Employee{Name: "Budi", BaseSalary: 4400000})
+	dir := t.TempDir()
+	service := NewReimbursementServiceImpl(failingClaimSave{repos.Reimbursement}, repos.Employee, storage.NewLocalFileStorage(dir))
+
+	_, err := service.SubmitClaim(ctx, domain.ReimbursementSubmission{
+		EmployeeID:   emp.ID,
+		CategoryCode: "MEDICAL",
+		ExpenseDate:  day(2025, 11, 10),
+		Amount:       150000,
+		Receipt:      strings.NewReader("receipt"),
+		ReceiptName:  "receipt.jpg",
+	})
+	if err == nil {
+		t.Fatal("SubmitClaim() error = nil, want the save error")
+	}
+
+	var files []string
+	if err := filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err == nil && !entry.IsDir() {
+			files = append(files, path)
+		}
+		return err
+	}); err != nil {
+		t.Fatalf("walk storage: %v", err)
+	}
+	if len(files) != 0 {
+		t.Errorf("stored files = %v, want the receipt removed", files)
+	}
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"fmt"
+	"hr-payroll/internal/domain"
+	"math"
+	"time"
+)
+
+// salaryAt mengembalikan gaji yang berlaku pada tanggal tertentu dari riwayat gaji (urut effective_from).
+// Tanggal sebelum riwayat pertama memakai riwayat pertama; tanpa riwayat sama sekali memakai data karyawan saat ini.
+func salaryAt(emp *domain.Employee, histories []domain.SalaryHistory, date time.Time) (float64, float64) {
+	if len(histories) == 0 {
+		return emp.BaseSalary, emp.Allowance
+	}
+
+	current := histories[0]
+	for _, h := range histories {
+		if truncateToDay(h.EffectiveFrom).After(date) {
+			break
+		}
+		current = h
+	}
+	return current.BaseSalary, current.Allowance
+}
+
+// baselineSalary membuat riwayat gaji awal dari data karyawan sebelum perubahan pertama dicatat
+func baselineSalary(emp *domain.Employee) *domain.SalaryHistory {
+	effectiveFrom := truncateToDay(emp.CreatedAt)
+	if emp.JoinDate != nil {
+		effectiveFrom = truncateToDay(*emp.JoinDate)
+	}
+	return &domain.SalaryHistory{
+		EmployeeID:    emp.ID,
+		BaseSalary:    emp.BaseSalary,
+		Allowance:     emp.Allowance,
+		EffectiveFrom: effectiveFrom,
+		Note:          "Gaji awal",
+		Baseline:      true,
+	}
+}
+
+// retroAdjustments menghitung baris rapel untuk slip periode sebelumnya yang terkena perubahan gaji berlaku mundur.
+// Sebuah slip dikoreksi jika ada riwayat gaji (bukan gaji awal) yang dicatat setelah slip dibuat
+// dan berlaku pada atau sebelum hari terakhir slip tersebut.
+func retroAdjustments(emp *domain.Employee, histories []domain.SalaryHistory, slips []domain.Payroll, period time.Time) []domain.PayrollItem {
+	// Total rapel yang sudah dibayarkan per periode (hanya dari slip sebelum periode ini)
+	alreadyPaid := map[time.Time]float64{}
+	for _, slip := range slips {
+		if !slip.Period.Before(period) {
+			continue
+		}
+		for _, item := range slip.Items {
+			if item.Code == domain.PayrollItemCodeRapel && item.RefPeriod != nil {
+				alreadyPaid[truncateToDay(*item.RefPeriod)] += item.Amount
+			}
+		}
+	}
+
+	var items []domain.PayrollItem
+	for _, slip := range slips {
+		if !slip.Period.Before(period) {
+			continue
+		}
+
+		factor, proratedBase, proratedAllowance, activeTo := slip.ProrationFactor, slip.ProratedBase, slip.ProratedAllowance, slip.ActiveTo
+		if slip.ProrationMethod == "" {
+			// Slip lama (sebelum pro-rata) selalu dibayar penuh
+			factor, proratedBase, proratedAllowance = 1, slip.BaseSalary, slip.Allowance
+			_, activeTo = monthBounds(slip.Period)
+		}
+
+		if !backdatedSince(histories, slip.GeneratedAt, activeTo) {
+			continue
+		}
+
+		base, allowance := salaryAt(emp, histories, activeTo)
+		expected := (base+allowance)*factor - base/workingDaysInMonth*slip.TotalAbsent
+		paid := proratedBase + proratedAllowance - slip.AbsenceDeduction + alreadyPaid[truncateToDay(slip.Period)]
+
+		diff := math.Round((expected-paid)*100) / 100
+		if diff == 0 {
+			continue
+		}
+
+		refPeriod := slip.Period
+		items = append(items, domain.PayrollItem{
+			Code:        domain.PayrollItemCodeRapel,
+			Type:        domain.PayrollItemEarning,
+			Description: fmt.Sprintf("Rapel gaji periode %s", slip.Period.Format("2006-01")),
+			Amount:      diff,
+			RefPeriod:   &refPeriod,
+		})
+	}
+	return items
+}
+
+// retroPaidFor: slip PAID setelah periode tersebut sudah membayar rapel untuk periode itu
+func retroPaidFor(slips []domain.Payroll, period time.Time) bool {
+	for _, slip := range slips {
+		if slip.Status != domain.PayrollStatusPaid || !slip.Period.After(period) {
+			continue
+		}
+		for _, item := range slip.Items {
+			if item.Code == domain.PayrollItemCodeRapel && item.RefPeriod != nil && truncateToDay(*item.RefPeriod).Equal(truncateToDay(period)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// backdatedSince: ada perubahan gaji yang dicatat setelah `generatedAt` tetapi berlaku paling lambat `activeTo`
+func backdatedSince(histories []domain.SalaryHistory, generatedAt, activeTo time.Time) bool {
+	for _, h := range histories {
+		if h.Baseline {
+			continue
+		}
+		if h.CreatedAt.After(generatedAt) && !truncateToDay(h.EffectiveFrom).After(activeTo) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"hr-payroll/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestRetroAdjustments(t *testing.T) {
+	emp := &domain.Employee{BaseSalary: 5500000, Allowance: 1000000}
+	october := day(2025, 10, 1)
+	raise := func(effectiveFrom, createdAt time.Time) []domain.SalaryHistory {
+		return []domain.SalaryHistory{
+			{BaseSalary: 5000000, Allowance: 1000000, EffectiveFrom: day(2025, 1, 1), CreatedAt: day(2025, 1, 1), Baseline: true},
+			{BaseSalary: 5500000, Allowance: 1000000, EffectiveFrom: effectiveFrom, CreatedAt: createdAt},
+		}
+	}
+	// Slip Oktober penuh dengan gaji lama, dibuat 25 Oktober
+	octoberSlip := func(modify func(*domain.Payroll)) domain.Payroll {
+		slip := domain.Payroll{
+			Period: october, GeneratedAt: day(2025, 10, 25), BaseSalary: 5000000, Allowance: 1000000,
+			ProrationMethod: domain.ProrationCalendarDays, ProrationFactor: 1, ProratedBase: 5000000, ProratedAllowance: 1000000,
+			ActiveFrom: october, ActiveTo: day(2025, 10, 31),
+		}
+		if modify != nil {
+			modify(&slip)
+		}
+		return slip
+	}
+	refOctober := october
+
+	tests := []struct {
+		name      string
+		histories []domain.SalaryHistory
+		slips     []domain.Payroll
+		period    time.Time
+		want      []float64 // Nominal rapel yang diharapkan (referensi periode Oktober)
+	}{
+		{
+			name:      "backdated raise after the slip was generated",
+			histories: raise(october, day(2025, 11, 10)),
+			slips:     []domain.Payroll{octoberSlip(nil)},
+			period:    day(2025, 11, 1),
+			want:      []float64{500000},
+		},
+		{
+			name:      "raise recorded before the slip was generated",
+			histories: raise(october, day(2025, 10, 20)),
+			slips:     []domain.Payroll{octoberSlip(nil)},
+			period:    day(2025, 11, 1),
+		},
+		{
+			name:      "raise effective after the slip period",
+			histories: raise(day(2025, 11, 1), day(2025, 11, 10)),
+			slips:     []domain.Payroll{octoberSlip(nil)},
+			period:    day(2025, 11, 1),
+		},
+		{
+			name:      "absence deduction uses the new daily rate",
+			histories: raise(october, day(2025, 11, 10)),
+			slips: []domain.Payroll{octoberSlip(func(p *domain.Payroll) {
+				p.TotalAbsent, p.AbsenceDeduction = 2, 5000000.0/workingDaysInMonth*2
+			})},
+			period: day(2025, 11, 1),
+			want:   []float64{454545.45},
+		},
+		{
+			name:      "prorated slip",
+			histories: raise(october, day(2025, 11, 10)),
+			slips: []domain.Payroll{octoberSlip(func(p *domain.Payroll) {
+				p.ProrationFactor, p.ProratedBase, p.ProratedAllowance = 0.5, 2500000, 500000
+			})},
+			period: day(2025, 11, 1),
+			want:   []float64{250000},
+		},
+		{
+			name:      "legacy slip without proration is treated as a full month",
+			histories: raise(october, day(2025, 11, 10)),
+			slips: []domain.Payroll{octoberSlip(func(p *domain.Payroll) {
+				p.ProrationMethod, p.ProrationFactor, p.ProratedBase, p.ProratedAllowance, p.ActiveTo = "", 0, 0, 0, time.Time{}
+			})},
+			period: day(2025, 11, 1),
+			want:   []float64{500000},
+		},
+		{
+			name:      "rapel already paid in a later slip",
+			histories: raise(october, day(2025, 11, 10)),
+			slips: []domain.Payroll{
+				octoberSlip(nil),
+				{Period: day(2025, 11, 1), GeneratedAt: day(2025, 11, 25), BaseSalary: 5500000, Allowance: 1000000,
+					ProrationMethod: domain.ProrationCalendarDays, ProrationFactor: 1, ProratedBase: 5500000, ProratedAllowance: 1000000, ActiveTo: day(2025, 11, 30),
+					Items: []domain.PayrollItem{{Code: domain.PayrollItemCodeRapel, Type: domain.PayrollItemEarning, Amount: 500000, RefPeriod: &refOctober}}},
+			},
+			period: day(2025, 12, 1),
+		},
+		{
+			name:      "slip of the period being generated is ignored",
+			histories: raise(october, day(2025, 11, 10)),
+			slips:     []domain.Payroll{octoberSlip(nil)},
+			period:    october,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := retroAdjustments(emp, tt.histories, tt.slips, tt.period)
+			if len(items) != len(tt.want) {
+				t.Fatalf("items = %+v, want %d rapel line(s)", items, len(tt.want))
+			}
+			for i, item := range items {
+				if item.Code != domain.PayrollItemCodeRapel || item.Amount != tt.want[i] {
+					t.Errorf("item %d = %s %v, want %s %v", i, item.Code, item.Amount, domain.PayrollItemCodeRapel, tt.want[i])
+				}
+				if item.RefPeriod == nil || !item.RefPeriod.Equal(october) {
+					t.Errorf("item %d RefPeriod = %v, want %v", i, item.RefPeriod, october)
+				}
+			}
+		})
+	}
+}
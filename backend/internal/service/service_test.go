@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"hr-payroll/internal/domain"
+	"hr-payroll/internal/repository/memory"
+	"testing"
+	"time"
+)
+
+// testRepos menampung repository memori yang dipakai bersama oleh test service
+type testRepos struct {
+	Employee      domain.EmployeeRepository
+	Attendance    domain.AttendanceRepository
+	Punch         domain.PunchRepository
+	Status        domain.AttendanceStatusRepository
+	Period        domain.AttendancePeriodRepository
+	Payroll       domain.PayrollRepository
+	Holiday       domain.HolidayRepository
+	SalaryHistory domain.SalaryHistoryRepository
+	Loan          domain.LoanRepository
+	Reimbursement domain.ReimbursementRepository
+	Adjustment    domain.PayrollAdjustmentRepository
+	// Tx me-rollback semua repository di atas jika transaksi gagal
+	Tx domain.TxManager
+}
+
+// newTestRepos membuat repository kosong dengan katalog status bawaan seperti hasil migrasi
+func newTestRepos(t *testing.T) *testRepos {
+	t.Helper()
+	repos := &testRepos{
+		Employee:      memory.NewEmployeeRepository(),
+		Attendance:    memory.NewAttendanceRepository(),
+		Punch:         memory.NewPunchRepository(),
+		Status:        memory.NewAttendanceStatusRepository(),
+		Period:        memory.NewAttendancePeriodRepository(),
+		Payroll:       memory.NewPayrollRepository(),
+		Holiday:       memory.NewHolidayRepository(),
+		SalaryHistory: memory.NewSalaryHistoryRepository(),
+		Loan:          memory.NewLoanRepository(),
+		Reimbursement: memory.NewReimbursementRepository(),
+		Adjustment:    memory.NewPayrollAdjustmentRepository(),
+	}
+	repos.Tx = memory.NewTxManager(repos.Employee, repos.Attendance, repos.Punch, repos.Status, repos.Period, repos.Payroll,
+		repos.Holiday, repos.SalaryHistory, repos.Loan, repos.Reimbursement, repos.Adjustment)
+	for _, status := range domain.DefaultAttendanceStatuses {
+		if err := repos.Status.Save(context.Background(), &status); err != nil {
+			t.Fatalf("seed attendance status %s: %v", status.Code, err)
+		}
+	}
+	return repos
+}
+
+func (r *testRepos) employeeService() domain.EmployeeService {
+	return NewEmployeeServiceImpl(r.Employee, r.SalaryHistory)
+}
+
+func (r *testRepos) attendanceService() domain.AttendanceService {
+	return NewAttendanceServiceImpl(r.Attendance, r.Punch, r.Status, r.Period, r.Payroll, r.Tx, AttendanceConfig{})
+}
+
+func (r *testRepos) payrollService() domain.PayrollService {
+	return NewPayrollServiceImpl(r.Employee, r.Attendance, r.Payroll, r.Holiday, r.SalaryHistory, r.Status, r.Loan, r.Reimbursement, r.Adjustment, r.Tx, PayrollConfig{})
+}
+
+// saveEmployee menyimpan karyawan langsung ke repository (tanpa riwayat gaji)
+func (r *testRepos) saveEmployee(t *testing.T, emp domain.Employee) *domain.Employee {
+	t.Helper()
+	if err := r.Employee.Save(context.Background(), &emp); err != nil {
+		t.Fatalf("save employee: %v", err)
+	}
+	return &emp
+}
+
+// day membuat tanggal UTC tanpa jam, sama seperti tanggal absensi dan periode slip
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
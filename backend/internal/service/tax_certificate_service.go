@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"hr-payroll/internal/domain"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TaxCertificateConfig menampung identitas pemotong pajak untuk bukti potong
+type TaxCertificateConfig struct {
+	Withholder domain.TaxWithholder
+}
+
+// TaxCertificateServiceImpl mengimplementasikan domain.TaxCertificateService
+type TaxCertificateServiceImpl struct {
+	PayRepo domain.PayrollRepository
+	EmpRepo domain.EmployeeRepository
+	Config  TaxCertificateConfig
+}
+
+func NewTaxCertificateServiceImpl(pr domain.PayrollRepository, er domain.EmployeeRepository, cfg TaxCertificateConfig) domain.TaxCertificateService {
+	return &TaxCertificateServiceImpl{PayRepo: pr, EmpRepo: er, Config: cfg}
+}
+
+// GetCertificates implements domain.TaxCertificateService
+func (s *TaxCertificateServiceImpl) GetCertificates(ctx context.Context, year int) ([]domain.TaxCertificate, error) {
+	if err := validTaxYear(year); err != nil {
+		return nil, err
+	}
+	slips, err := s.PayRepo.FindPaidByYear(ctx, year)
+	if err != nil {
+		return nil, err
+	}
+	byEmployee := map[uint][]domain.Payroll{}
+	for _, slip := range slips {
+		byEmployee[slip.EmployeeID] = append(byEmployee[slip.EmployeeID], slip)
+	}
+	employeeIDs := make([]uint, 0, len(byEmployee))
+	for id := range byEmployee {
+		employeeIDs = append(employeeIDs, id)
+	}
+	sort.Slice(employeeIDs, func(i, j int) bool { return employeeIDs[i] < employeeIDs[j] })
+
+	certificates := make([]domain.TaxCertificate, 0, len(employeeIDs))
+	for _, id := range employeeIDs {
+		employee, err := s.EmpRepo.FindByID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("employee %d not found", id)
+		}
+		certificate, err := buildTaxCertificate(employee, year, byEmployee[id], s.Config.Withholder)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, *certificate)
+	}
+	return certificates, nil
+}
+
+// GetCertificate implements domain.TaxCertificateService
+func (s *TaxCertificateServiceImpl) GetCertificate(ctx context.Context, year int, employeeID uint) (*domain.TaxCertificate, error) {
+	if err := validTaxYear(year); err != nil {
+		return nil, err
+	}
+	employee, err := s.EmpRepo.FindByID(ctx, employeeID)
+	if err != nil {
+		return nil, domain.ErrTaxCertificateNotFound
+	}
+	slips, err := s.PayRepo.FindPaidByYear(ctx, year)
+	if err != nil {
+		return nil, err
+	}
+	var own []domain.Payroll
+	for _, slip := range slips {
+		if slip.EmployeeID == employeeID {
+			own = append(own, slip)
+		}
+	}
+	if len(own) == 0 {
+		return nil, domain.ErrTaxCertificateNotFound
+	}
+	return buildTaxCertificate(employee, year, own, s.Config.Withholder)
+}
+
+func validTaxYear(year int) error {
+	if year < 2000 || year > time.Now().Year() {
+		return fmt.Errorf("%w: %d", domain.ErrInvalidTaxYear, year)
+	}
+	return nil
+}
+
+// buildTaxCertificate menjumlahkan slip PAID satu karyawan dalam setahun menjadi 1721-A1.
+// Slip REGULAR mengisi gaji dan tunjangan; baris kena pajak (THR, bonus, komisi, insentif) mengisi baris 7.
+// Reimbursement dan denda tidak termasuk penghasilan bruto.
+// PTKP memakai status awal tahun pajak yang tercatat pada slip, bukan status karyawan saat ini.
+func buildTaxCertificate(employee *domain.Employee, year int, slips []domain.Payroll, withholder domain.TaxWithholder) (*domain.TaxCertificate, error) {
+	taxStatus := yearTaxStatus(employee, slips, year)
+	ptkp, err := ptkpAnnual(taxStatus)
+	if err != nil {
+		return nil, fmt.Errorf("employee %d: %w", employee.ID, err)
+	}
+
+	certificate := &domain.TaxCertificate{
+		Year:          year,
+		Withholder:    withholder,
+		EmployeeID:    employee.ID,
+		EmployeeName:  employee.Name,
+		NPWP:          employee.NPWP,
+		NIK:           employee.NIK,
+		Position:      employee.Position,
+		TaxStatus:     taxStatus,
+		Dependents:    taxDependents(taxStatus),
+		TaxObjectCode: domain.TaxObjectCodePermanentEmployee,
+		PeriodFrom:    12,
+		PeriodTo:      1,
+		PayrollIDs:    []uint{},
+	}
+
+	// 1. Penghasilan bruto dan pajak yang sudah dipotong per slip
+	for _, slip := range slips {
+		month := int(slip.Period.Month())
+		certificate.PeriodFrom = min(certificate.PeriodFrom, month)
+		certificate.PeriodTo = max(certificate.PeriodTo, month)
+		certificate.PayrollIDs = append(certificate.PayrollIDs, slip.ID)
+
+		if slip.Type == domain.PayrollTypeRegular {
+			certificate.Salary += slip.ProratedBase - slip.AbsenceDeduction + slip.RetroAdjustment
+			certificate.OtherAllowances += slip.ProratedAllowance
+		}
+		for _, item := range slip.Items {
+			if item.Taxable && item.Type == domain.PayrollItemEarning {
+				certificate.BonusAndTHR += item.Amount
+			}
+		}
+		certificate.WithheldTax += slip.Tax
+	}
+	roundRupiah(&certificate.Salary, &certificate.OtherAllowances, &certificate.BonusAndTHR, &certificate.WithheldTax)
+	certificate.MonthsCount = certificate.PeriodTo - certificate.PeriodFrom + 1
+	certificate.Number = fmt.Sprintf("1.1-%02d.%02d-%07d", certificate.PeriodTo, year%100, employee.ID)
+	certificate.IssuedAt = time.Date(year, time.Month(certificate.PeriodTo)+1, 0, 0, 0, 0, 0, time.UTC)
+
+	// 2. Pengurang, neto dan PPh 21 setahun (tarif Pasal 17)
+	certificate.GrossIncome = certificate.Salary + certificate.TaxAllowance + certificate.OtherAllowances +
+		certificate.Honorarium + certificate.InsurancePremium + certificate.BenefitsInKind + certificate.BonusAndTHR
+	certificate.JobExpense = math.Round(jobExpense(certificate.GrossIncome, certificate.MonthsCount))
+	certificate.TotalDeductions = certificate.JobExpense + certificate.PensionContribution
+	certificate.NetIncome = certificate.GrossIncome - certificate.TotalDeductions
+	certificate.AnnualNetIncome = certificate.NetIncome + certificate.PreviousNetIncome
+	certificate.PTKP = ptkp
+	certificate.TaxableIncome = taxableIncome(certificate.AnnualNetIncome, ptkp)
+	certificate.AnnualTax = math.Round(progressiveTax(certificate.TaxableIncome))
+	certificate.TaxDue = certificate.AnnualTax - certificate.PreviousWithheldTax
+	certificate.TaxUnderpaid = certificate.TaxDue - certificate.WithheldTax
+	return certificate, nil
+}
+
+// taxDependents mengembalikan jumlah tanggungan dari status PTKP (K/2 -> 2)
+func taxDependents(taxStatus string) int {
+	_, count, found := strings.Cut(taxStatus, "/")
+	if !found {
+		return 0
+	}
+	dependents, _ := strconv.Atoi(count)
+	return dependents
+}
+
+// roundRupiah membulatkan nilai ke rupiah penuh sesuai pengisian formulir
+func roundRupiah(values ...*float64) {
+	for _, value := range values {
+		*value = math.Round(*value)
+	}
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"hr-payroll/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestTaxCertificateMatchesMonthlyWithholding(t *testing.T) {
+	tests := []struct {
+		name       string
+		baseSalary float64
+		taxStatus  string
+		bonus      float64    // bonus kena pajak di slip Desember
+		lastMonth  time.Month // bulan resign (masa pajak terakhir), 0 = Desember
+	}{
+		{name: "below PTKP", baseSalary: 4_000_000, taxStatus: "TK/0"},
+		{name: "first bracket", baseSalary: 10_000_000, taxStatus: "TK/0"},
+		{name: "second bracket with dependents", baseSalary: 25_000_000, taxStatus: "K/2"},
+		{name: "with December bonus", baseSalary: 15_000_000, taxStatus: "K/0", bonus: 30_000_000},
+		{name: "resigned in June", baseSalary: 12_000_000, taxStatus: "TK/1", lastMonth: time.June},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repos := newTestRepos(t)
+			joinDate := day(2024, 1, 1)
+			lastMonth := time.December
+			var resignDate *time.Time
+			if tt.lastMonth != 0 {
+				lastMonth = tt.lastMonth
+				resign := day(2025, lastMonth+1, 0)
+				resignDate = &resign
+			}
+			emp := repos.saveEmployee(t, domain.Employee{Name: "Budi", BaseSalary: tt.baseSalary, TaxStatus: tt.taxStatus, JoinDate: &joinDate, ResignDate: resignDate})
+			if tt.bonus > 0 {
+				if err := repos.Adjustment.Save(ctx, &domain.PayrollAdjustment{EmployeeID: emp.ID, Period: day(2025, 12, 1), Component: "BONUS", Type: domain.PayrollItemEarning, Taxable: true, Amount: tt.bonus}); err != nil {
+					t.Fatalf("save adjustment: %v", err)
+				}
+			}
+			payrollService := NewPayrollServiceImpl(repos.Employee, repos.Attendance, repos.Payroll, repos.Holiday, repos.SalaryHistory, repos.Status, repos.Loan, repos.Reimbursement, repos.Adjustment, repos.Tx, PayrollConfig{TaxWithholding: domain.TaxWithholdingTER})
+
+			for month := time.January; month <= lastMonth; month++ {
+				// Perubahan status PTKP di tengah tahun tidak mengubah PTKP tahun berjalan
+				if month == time.July {
+					emp.TaxStatus = "K/3"
+					if err := repos.Employee.Update(ctx, emp); err != nil {
+						t.Fatalf("update employee: %v", err)
+					}
+				}
+				slip, err := payrollService.GenerateMonthlyPayroll(ctx, emp.ID, day(2025, month, 1))
+				if err != nil {
+					t.Fatalf("GenerateMonthlyPayroll(%s) error = %v", month, err)
+				}
+				if slip.TaxStatus != tt.taxStatus {
+					t.Fatalf("%s slip TaxStatus = %q, want %q", month, slip.TaxStatus, tt.taxStatus)
+				}
+				wantMethod := domain.TaxMethodTER
+				if month == lastMonth {
+					wantMethod = domain.TaxMethodAnnual
+				}
+				if slip.Tax != 0 && slip.TaxMethod != wantMethod {
+					t.Errorf("%s slip TaxMethod = %q, want %q", month, slip.TaxMethod, wantMethod)
+				}
+				if _, err := payrollService.MarkPayrollPaid(ctx, slip.ID); err != nil {
+					t.Fatalf("MarkPayrollPaid(%s) error = %v", month, err)
+				}
+			}
+
+			certificate, err := NewTaxCertificateServiceImpl(repos.Payroll, repos.Employee, TaxCertificateConfig{}).GetCertificate(ctx, 2025, emp.ID)
+			if err != nil {
+				t.Fatalf("GetCertificate() error = %v", err)
+			}
+			if certificate.TaxStatus != tt.taxStatus {
+				t.Errorf("certificate TaxStatus = %q, want %q", certificate.TaxStatus, tt.taxStatus)
+			}
+			// TER Januari-November ditambah penyesuaian Pasal 17 di masa pajak terakhir harus tepat menutup pajak setahun
+			if certificate.TaxUnderpaid != 0 {
+				t.Errorf("TaxUnderpaid = %v (annual %v, withheld %v), want 0", certificate.TaxUnderpaid, certificate.AnnualTax, certificate.WithheldTax)
+			}
+		})
+	}
+}
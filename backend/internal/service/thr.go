@@ -0,0 +1,132 @@
+package service
+
+import (
+	"fmt"
+	"hr-payroll/internal/domain"
+	"math"
+	"time"
+)
+
+// validTHRWageBase memastikan dasar upah THR dikenali
+func validTHRWageBase(wageBase string) bool {
+	switch wageBase {
+	case domain.THRWageBaseOnly, domain.THRWageBasePlusAllowance:
+		return true
+	}
+	return false
+}
+
+// serviceStart adalah tanggal mulai masa kerja (join_date, atau tanggal data dibuat)
+func serviceStart(emp *domain.Employee) time.Time {
+	if emp.JoinDate != nil {
+		return truncateToDay(*emp.JoinDate)
+	}
+	return truncateToDay(emp.CreatedAt)
+}
+
+// monthsOfService menghitung masa kerja dalam bulan penuh dari start sampai date
+func monthsOfService(start, date time.Time) int {
+	months := (date.Year()-start.Year())*12 + int(date.Month()) - int(start.Month())
+	if date.Day() < start.Day() {
+		months--
+	}
+	if months < 0 {
+		return 0
+	}
+	return months
+}
+
+// calculateTHR menghitung slip THR per Permenaker 6/2016 tanpa menyimpannya:
+// masa kerja >= 12 bulan mendapat 1 bulan upah, 1-12 bulan mendapat masa kerja / 12 x 1 bulan upah.
+// holidayDate adalah batas perhitungan masa kerja, payoutDate menentukan periode slip,
+// taxStatus adalah status PTKP awal tahun pajak (lihat yearTaxStatus).
+func calculateTHR(emp *domain.Employee, histories []domain.SalaryHistory, taxStatus string, holidayDate, payoutDate time.Time, wageBase, withholding string) (*domain.Payroll, error) {
+	if !validTHRWageBase(wageBase) {
+		return nil, fmt.Errorf("unknown THR wage base: %s", wageBase)
+	}
+	holidayDate = truncateToDay(holidayDate)
+	start := serviceStart(emp)
+	months := monthsOfService(start, holidayDate)
+	if months < 1 {
+		return nil, fmt.Errorf("employee %d has less than one month of service on %s", emp.ID, holidayDate.Format("2006-01-02"))
+	}
+
+	// 1. Upah sebulan yang berlaku pada hari raya
+	base, allowance := salaryAt(emp, histories, holidayDate)
+	includedAllowance := allowance
+	if wageBase == domain.THRWageBaseOnly {
+		includedAllowance = 0
+	}
+
+	// 2. Pro-rata masa kerja (maks. 12/12)
+	factor := math.Min(float64(months), 12) / 12
+	proratedBase := math.Round(base*factor*100) / 100
+	proratedAllowance := math.Round(includedAllowance*factor*100) / 100
+	amount := proratedBase + proratedAllowance
+
+	// 3. PPh 21 THR di atas gaji bulanan penuh, sesuai PAYROLL_TAX_WITHHOLDING
+	tax, taxMethod, err := thrIncomeTax(withholding, base+allowance, amount, taxStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	period, _ := monthBounds(payoutDate)
+	items := []domain.PayrollItem{{
+		Code:        domain.PayrollItemCodeTHR,
+		Type:        domain.PayrollItemEarning,
+		Description: fmt.Sprintf("THR %d (%d/12 bulan upah)", holidayDate.Year(), int(math.Min(float64(months), 12))),
+		Amount:      amount,
+		Taxable:     true,
+	}}
+	if tax > 0 {
+		items = append(items, domain.PayrollItem{
+			Code:        domain.PayrollItemCodePPh21,
+			Type:        domain.PayrollItemDeduction,
+			Description: "PPh 21 atas THR",
+			Amount:      tax,
+		})
+	}
+
+	return &domain.Payroll{
+		EmployeeID:        emp.ID,
+		Period:            period,
+		Type:              domain.PayrollTypeTHR,
+		BaseSalary:        base,
+		Allowance:         includedAllowance,
+		ProrationFactor:   factor,
+		ActiveFrom:        start,
+		ActiveTo:          holidayDate,
+		ProratedBase:      proratedBase,
+		ProratedAllowance: proratedAllowance,
+		ServiceMonths:     months,
+		Tax:               tax,
+		TaxMethod:         taxMethod,
+		TaxStatus:         taxStatus,
+		TakeHomePay:       amount - tax,
+		GeneratedAt:       time.Now(),
+		Status:            domain.PayrollStatusGenerated,
+		Items:             items,
+	}, nil
+}
+
+// thrIncomeTax menghitung PPh 21 THR. NONE memakai metode selisih pajak setahun (penghasilan tidak teratur);
+// TER memakai selisih PPh 21 TER bulan itu dengan dan tanpa THR, karena gaji bulan itu dipotong di slip REGULAR.
+func thrIncomeTax(withholding string, regularMonthly, thr float64, taxStatus string) (float64, string, error) {
+	switch withholding {
+	case domain.TaxWithholdingNone, "":
+		tax, err := irregularIncomeTax(regularMonthly, thr, taxStatus)
+		return tax, domain.TaxMethodIrregularIncome, err
+	case domain.TaxWithholdingTER:
+		withTHR, _, err := terIncomeTax(regularMonthly+thr, taxStatus)
+		if err != nil {
+			return 0, "", err
+		}
+		withoutTHR, _, err := terIncomeTax(regularMonthly, taxStatus)
+		if err != nil {
+			return 0, "", err
+		}
+		return withTHR - withoutTHR, domain.TaxMethodTER, nil
+	default:
+		return 0, "", fmt.Errorf("unknown tax withholding: %s", withholding)
+	}
+}
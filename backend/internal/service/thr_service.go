@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hr-payroll/internal/domain"
+	"strings"
+)
+
+// THRConfig menampung pengaturan THR
+type THRConfig struct {
+	WageBase       string // domain.THRWageBaseOnly atau domain.THRWageBasePlusAllowance
+	TaxWithholding string // domain.TaxWithholdingNone atau domain.TaxWithholdingTER
+}
+
+// THRServiceImpl mengimplementasikan domain.THRService
+type THRServiceImpl struct {
+	Repo       domain.THRScheduleRepository
+	EmpRepo    domain.EmployeeRepository
+	PayRepo    domain.PayrollRepository
+	SalaryRepo domain.SalaryHistoryRepository
+	Config     THRConfig
+}
+
+func NewTHRServiceImpl(repo domain.THRScheduleRepository, er domain.EmployeeRepository, pr domain.PayrollRepository, sr domain.SalaryHistoryRepository, cfg THRConfig) domain.THRService {
+	if cfg.WageBase == "" {
+		cfg.WageBase = domain.THRWageBasePlusAllowance
+	}
+	if cfg.TaxWithholding == "" {
+		cfg.TaxWithholding = domain.TaxWithholdingNone
+	}
+	return &THRServiceImpl{Repo: repo, EmpRepo: er, PayRepo: pr, SalaryRepo: sr, Config: cfg}
+}
+
+// SaveSchedule implements domain.THRService
+func (s *THRServiceImpl) SaveSchedule(ctx context.Context, schedule *domain.THRSchedule) (*domain.THRSchedule, error) {
+	// 1. Validasi jadwal
+	schedule.Religion = strings.ToUpper(strings.TrimSpace(schedule.Religion))
+	if !validReligion(schedule.Religion) {
+		return nil, fmt.Errorf("invalid religion %q", schedule.Religion)
+	}
+	if schedule.HolidayDate.IsZero() || schedule.PayoutDate.IsZero() {
+		return nil, errors.New("holiday_date and payout_date are required")
+	}
+	schedule.HolidayDate = truncateToDay(schedule.HolidayDate)
+	schedule.PayoutDate = truncateToDay(schedule.PayoutDate)
+	if schedule.PayoutDate.After(schedule.HolidayDate.AddDate(0, 0, -domain.THRPayoutLeadDays)) {
+		return nil, fmt.Errorf("THR must be paid at least %d days before the holiday", domain.THRPayoutLeadDays)
+	}
+	schedule.Year = schedule.HolidayDate.Year()
+
+	// 2. Satu jadwal per tahun per agama: ganti jika sudah ada
+	existing, err := s.Repo.FindByYearAndReligion(ctx, schedule.Year, schedule.Religion)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		schedule.ID = 0
+		err = s.Repo.Save(ctx, schedule)
+	} else {
+		schedule.ID = existing.ID
+		schedule.CreatedAt = existing.CreatedAt
+		err = s.Repo.Update(ctx, schedule)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// GetSchedules implements domain.THRService
+func (s *THRServiceImpl) GetSchedules(ctx context.Context, year int) ([]domain.THRSchedule, error) {
+	return s.Repo.FindByYear(ctx, year)
+}
+
+// RunTHR implements domain.THRService.
+// Karyawan yang sudah punya slip THR untuk periode tersebut dilewati, sehingga run aman diulang.
+func (s *THRServiceImpl) RunTHR(ctx context.Context, req domain.THRRunRequest) (*domain.THRRunResult, error) {
+	if req.Year <= 0 {
+		return nil, errors.New("year is required")
+	}
+	religion := strings.ToUpper(strings.TrimSpace(req.Religion))
+
+	// 1. Jadwal per agama untuk tahun tersebut
+	schedules, err := s.Repo.FindByYear(ctx, req.Year)
+	if err != nil {
+		return nil, err
+	}
+	byReligion := make(map[string]domain.THRSchedule, len(schedules))
+	for _, schedule := range schedules {
+		byReligion[schedule.Religion] = schedule
+	}
+	if religion != "" {
+		if _, ok := byReligion[religion]; !ok {
+			return nil, domain.ErrTHRScheduleNotFound
+		}
+	}
+
+	employees, err := s.EmpRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.THRRunResult{Year: req.Year, DryRun: req.DryRun, Slips: []domain.Payroll{}, Skipped: []domain.THRSkip{}}
+	skip := func(emp *domain.Employee, reason string) {
+		result.Skipped = append(result.Skipped, domain.THRSkip{EmployeeID: emp.ID, Reason: reason})
+	}
+	for i := range employees {
+		emp := &employees[i]
+		if religion != "" && emp.Religion != religion {
+			continue
+		}
+
+		// 2. Syarat: jadwal agama ada, masih bekerja saat hari raya, masa kerja minimal 1 bulan
+		schedule, ok := byReligion[emp.Religion]
+		if !ok {
+			if emp.Religion == "" {
+				skip(emp, "religion is not set")
+			} else {
+				skip(emp, fmt.Sprintf("no THR schedule for %s in %d", emp.Religion, req.Year))
+			}
+			continue
+		}
+		if emp.ResignDate != nil && truncateToDay(*emp.ResignDate).Before(schedule.HolidayDate) {
+			skip(emp, "resigned before the holiday")
+			continue
+		}
+		if monthsOfService(serviceStart(emp), schedule.HolidayDate) < 1 {
+			skip(emp, "less than one month of service")
+			continue
+		}
+
+		period, _ := monthBounds(schedule.PayoutDate)
+		existing, err := s.PayRepo.FindByEmployeePeriodAndType(ctx, emp.ID, period, domain.PayrollTypeTHR)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			skip(emp, "THR slip already generated")
+			continue
+		}
+
+		// 3. Hitung dan simpan slip THR
+		histories, err := s.SalaryRepo.FindByEmployee(ctx, emp.ID)
+		if err != nil {
+			return nil, err
+		}
+		regularSlips, err := s.PayRepo.FindByEmployee(ctx, emp.ID)
+		if err != nil {
+			return nil, err
+		}
+		taxStatus := yearTaxStatus(emp, regularSlips, schedule.PayoutDate.Year())
+		slip, err := calculateTHR(emp, histories, taxStatus, schedule.HolidayDate, schedule.PayoutDate, s.Config.WageBase, s.Config.TaxWithholding)
+		if err != nil {
+			skip(emp, err.Error())
+			continue
+		}
+		if !req.DryRun {
+			if err := s.PayRepo.Save(ctx, slip); err != nil {
+				return nil, err
+			}
+		}
+		result.Slips = append(result.Slips, *slip)
+	}
+	return result, nil
+}
+
+// validReligion memastikan agama dikenali
+func validReligion(religion string) bool {
+	switch religion {
+	case domain.ReligionIslam, domain.ReligionKristen, domain.ReligionKatolik, domain.ReligionHindu, domain.ReligionBuddha, domain.ReligionKonghucu:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"hr-payroll/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestCalculateTHR(t *testing.T) {
+	holiday := day(2025, 3, 31)
+	payout := day(2025, 3, 20)
+	employee := func(joinDate time.Time) *domain.Employee {
+		return &domain.Employee{ID: 1, BaseSalary: 6000000, Allowance: 1200000, JoinDate: &joinDate}
+	}
+
+	tests := []struct {
+		name       string
+		emp        *domain.Employee
+		holiday    time.Time
+		wageBase   string
+		wantMonths int
+		wantAmount float64
+		wantErr    bool
+	}{
+		{name: "less than one month", emp: employee(day(2025, 3, 1)), holiday: holiday, wageBase: domain.THRWageBasePlusAllowance, wantErr: true},
+		{name: "one day short of one month", emp: employee(day(2025, 2, 28)), holiday: day(2025, 3, 27), wageBase: domain.THRWageBasePlusAllowance, wantErr: true},
+		{name: "exactly one month", emp: employee(day(2025, 2, 28)), holiday: holiday, wageBase: domain.THRWageBasePlusAllowance, wantMonths: 1, wantAmount: 600000},
+		{name: "eleven months", emp: employee(day(2024, 4, 30)), holiday: holiday, wageBase: domain.THRWageBasePlusAllowance, wantMonths: 11, wantAmount: 6600000},
+		{name: "one day short of twelve months", emp: employee(day(2024, 4, 1)), holiday: day(2025, 3, 31), wageBase: domain.THRWageBasePlusAllowance, wantMonths: 11, wantAmount: 6600000},
+		{name: "exactly twelve months", emp: employee(day(2024, 3, 31)), holiday: holiday, wageBase: domain.THRWageBasePlusAllowance, wantMonths: 12, wantAmount: 7200000},
+		{name: "more than twelve months is capped", emp: employee(day(2020, 1, 6)), holiday: holiday, wageBase: domain.THRWageBasePlusAllowance, wantMonths: 62, wantAmount: 7200000},
+		{name: "base salary only", emp: employee(day(2020, 1, 6)), holiday: holiday, wageBase: domain.THRWageBaseOnly, wantMonths: 62, wantAmount: 6000000},
+		{name: "unknown wage base", emp: employee(day(2020, 1, 6)), holiday: holiday, wageBase: "GROSS", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slip, err := calculateTHR(tt.emp, nil, "TK/0", tt.holiday, payout, tt.wageBase, domain.TaxWithholdingNone)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("calculateTHR() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if slip.ServiceMonths != tt.wantMonths {
+				t.Errorf("ServiceMonths = %d, want %d", slip.ServiceMonths, tt.wantMonths)
+			}
+			if amount := slip.ProratedBase + slip.ProratedAllowance; amount != tt.wantAmount {
+				t.Errorf("THR amount = %v, want %v", amount, tt.wantAmount)
+			}
+
+			// PPh 21 THR dihitung di atas upah sebulan penuh (gaji + tunjangan), apa pun dasar upah THR
+			wantTax, _ := irregularIncomeTax(7200000, tt.wantAmount, "TK/0")
+			if slip.Tax != wantTax || slip.TakeHomePay != tt.wantAmount-wantTax {
+				t.Errorf("Tax = %v, TakeHomePay = %v, want %v and %v", slip.Tax, slip.TakeHomePay, wantTax, tt.wantAmount-wantTax)
+			}
+			if slip.Type != domain.PayrollTypeTHR || !slip.Period.Equal(day(2025, 3, 1)) || slip.TaxStatus != "TK/0" {
+				t.Errorf("slip = %s %v %s, want THR slip for 2025-03 with TK/0", slip.Type, slip.Period, slip.TaxStatus)
+			}
+		})
+	}
+}
+
+func TestTHRIncomeTax(t *testing.T) {
+	tests := []struct {
+		name        string
+		withholding string
+		wantTax     float64
+		wantMethod  string
+		wantErr     bool
+	}{
+		{name: "none uses the irregular income method", withholding: domain.TaxWithholdingNone, wantTax: 1500000, wantMethod: domain.TaxMethodIrregularIncome},
+		{name: "TER difference with and without THR", withholding: domain.TaxWithholdingTER, wantTax: 1600000, wantMethod: domain.TaxMethodTER},
+		{name: "unknown setting", withholding: "ANNUALIZED", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tax, method, err := thrIncomeTax(tt.withholding, 10000000, 10000000, "TK/0")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("thrIncomeTax() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tax != tt.wantTax || method != tt.wantMethod {
+				t.Errorf("thrIncomeTax() = %v (%q), want %v (%q)", tax, method, tt.wantTax, tt.wantMethod)
+			}
+		})
+	}
+}
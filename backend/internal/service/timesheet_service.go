@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"hr-payroll/internal/domain"
+	"math"
+	"time"
+)
+
+// TimesheetConfig menampung jadwal kerja standar untuk menghitung terlambat dan lembur
+type TimesheetConfig struct {
+	WorkStart        string         // Jam masuk "HH:MM"
+	WorkHours        float64        // Jam kerja normal per hari
+	LateGraceMinutes int            // Toleransi terlambat
+	Location         *time.Location // Zona waktu jam kerja (default UTC, sama dengan tanggal absensi)
+}
+
+// TimesheetServiceImpl mengimplementasikan domain.TimesheetService
+type TimesheetServiceImpl struct {
+	EmpRepo    domain.EmployeeRepository
+	AttRepo    domain.AttendanceRepository
+	StatusRepo domain.AttendanceStatusRepository
+	Config     TimesheetConfig
+}
+
+func NewTimesheetServiceImpl(er domain.EmployeeRepository, ar domain.AttendanceRepository, sr domain.AttendanceStatusRepository, cfg TimesheetConfig) domain.TimesheetService {
+	if cfg.WorkStart == "" {
+		cfg.WorkStart = "09:00"
+	}
+	if cfg.WorkHours <= 0 {
+		cfg.WorkHours = 8
+	}
+	if cfg.Location == nil {
+		cfg.Location = time.UTC
+	}
+	return &TimesheetServiceImpl{EmpRepo: er, AttRepo: ar, StatusRepo: sr, Config: cfg}
+}
+
+// GetMonthlyTimesheet implements domain.TimesheetService
+func (s *TimesheetServiceImpl) GetMonthlyTimesheet(ctx context.Context, period time.Time, department string) (*domain.Timesheet, error) {
+	workStart, err := time.Parse("15:04", s.Config.WorkStart)
+	if err != nil {
+		return nil, fmt.Errorf("invalid work start time %q, use HH:MM", s.Config.WorkStart)
+	}
+	periodStart, periodEnd := monthBounds(period)
+	daysInMonth := periodEnd.Day()
+
+	// 1. Karyawan yang aktif di bulan tersebut
+	var employees []domain.Employee
+	if department != "" {
+		employees, err = s.EmpRepo.FindByDepartment(ctx, department)
+	} else {
+		employees, err = s.EmpRepo.FindAll(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Seluruh absensi bulan tersebut dalam satu query, dikelompokkan per karyawan
+	attendances, err := s.AttRepo.FindAllByPeriod(ctx, periodStart, periodEnd)
+	if err != nil {
+		return nil, err
+	}
+	byEmployee := make(map[uint][]domain.Attendance)
+	for _, att := range attendances {
+		byEmployee[att.EmployeeID] = append(byEmployee[att.EmployeeID], att)
+	}
+	catalogue, err := statusCatalogue(ctx, s.StatusRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	timesheet := &domain.Timesheet{
+		Period:      periodStart,
+		Department:  department,
+		DaysInMonth: daysInMonth,
+		WorkStart:   s.Config.WorkStart,
+		WorkHours:   s.Config.WorkHours,
+		Rows:        []domain.TimesheetRow{},
+	}
+	for _, emp := range employees {
+		records := byEmployee[emp.ID]
+		if len(records) == 0 && !activeDuring(&emp, periodStart, periodEnd) {
+			continue
+		}
+
+		row := domain.TimesheetRow{
+			EmployeeID:   emp.ID,
+			EmployeeName: emp.Name,
+			Department:   emp.Department,
+			Days:         make([]string, daysInMonth),
+		}
+		workedMinutes, overtimeMinutes := 0, 0
+		for _, att := range records {
+			row.Days[att.Date.Day()-1] = att.Status
+
+			// 3. Hitung hadir / absen / cuti berdasarkan katalog status
+			status := catalogue[att.Status]
+			switch {
+			case status.CountsAsPresent:
+				row.Present++
+			case att.Status == domain.AttendanceStatusAbsent:
+				row.Absent++
+			default:
+				row.Leave++
+			}
+
+			// 4. Terlambat: check-in setelah jam masuk + toleransi (hanya status yang mewajibkan check-in)
+			if status.CheckInRequired && att.CheckIn != nil {
+				checkIn := att.CheckIn.In(s.Config.Location)
+				start := time.Date(att.Date.Year(), att.Date.Month(), att.Date.Day(), workStart.Hour(), workStart.Minute(), 0, 0, s.Config.Location)
+				if late := int(checkIn.Sub(start).Minutes()); late > s.Config.LateGraceMinutes {
+					row.LateMinutes += late
+				}
+			}
+
+			// 5. Jam kerja dan lembur (kelebihan dari jam kerja normal)
+			worked := att.WorkedMinutes
+			if worked == 0 && att.CheckIn != nil && att.CheckOut != nil {
+				worked = int(att.CheckOut.Sub(*att.CheckIn).Minutes())
+			}
+			workedMinutes += worked
+			if extra := worked - int(s.Config.WorkHours*60); extra > 0 {
+				overtimeMinutes += extra
+			}
+		}
+		row.WorkedHours = minutesToHours(workedMinutes)
+		row.OvertimeHours = minutesToHours(overtimeMinutes)
+		timesheet.Rows = append(timesheet.Rows, row)
+	}
+	return timesheet, nil
+}
+
+// activeDuring: karyawan aktif minimal satu hari dalam rentang
+func activeDuring(emp *domain.Employee, from, to time.Time) bool {
+	if emp.JoinDate != nil && truncateToDay(*emp.JoinDate).After(to) {
+		return false
+	}
+	if emp.ResignDate != nil && truncateToDay(*emp.ResignDate).Before(from) {
+		return false
+	}
+	return true
+}
+
+// minutesToHours mengubah menit ke jam dengan 2 desimal
+func minutesToHours(minutes int) float64 {
+	return math.Round(float64(minutes)/60*100) / 100
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"hr-payroll/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestGetMonthlyTimesheetDefaultLocation(t *testing.T) {
+	// Zona waktu server tidak boleh memengaruhi hitungan terlambat jika Location tidak diisi
+	local := time.Local
+	time.Local = time.FixedZone("WIB", 7*60*60)
+	t.Cleanup(func() { time.Local = local })
+
+	ctx := context.Background()
+	repos := newTestRepos(t)
+	emp := repos.saveEmployee(t, domain.Employee{Name: "Budi", BaseSalary: 4400000})
+	checkIn := day(2025, 11, 10).Add(9*time.Hour + 20*time.Minute)
+	checkOut := day(2025, 11, 10).Add(17 * time.Hour)
+	if err := repos.Attendance.Save(ctx, &domain.Attendance{EmployeeID: emp.ID, Date: day(2025, 11, 10), Status: domain.AttendanceStatusPresent, CheckIn: &checkIn, CheckOut: &checkOut}); err != nil {
+		t.Fatalf("save attendance: %v", err)
+	}
+
+	service := NewTimesheetServiceImpl(repos.Employee, repos.Attendance, repos.Status, TimesheetConfig{})
+	timesheet, err := service.GetMonthlyTimesheet(ctx, day(2025, 11, 1), "")
+	if err != nil {
+		t.Fatalf("GetMonthlyTimesheet() error = %v", err)
+	}
+	if len(timesheet.Rows) != 1 {
+		t.Fatalf("rows = %+v, want one row", timesheet.Rows)
+	}
+	if row := timesheet.Rows[0]; row.LateMinutes != 20 || row.Present != 1 {
+		t.Errorf("row = %+v, want 20 late minutes against 09:00 UTC", row)
+	}
+}
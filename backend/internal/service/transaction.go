@@ -0,0 +1,21 @@
+package service
+
+import (
+	"context"
+	"hr-payroll/internal/domain"
+)
+
+// inTransaction menjalankan fn lewat TxManager dan mengembalikan hasil dari percobaan yang berhasil di-commit
+func inTransaction[T any](ctx context.Context, tx domain.TxManager, fn func(ctx context.Context) (T, error)) (T, error) {
+	var result T
+	err := tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = fn(ctx)
+		return err
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return result, nil
+}
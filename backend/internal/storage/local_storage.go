@@ -0,0 +1,70 @@
+package storage
+
+import (
+	"errors"
+	"hr-payroll/internal/domain"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalFileStorage implements domain.FileStorage di folder lokal
+type LocalFileStorage struct {
+	BaseDir string
+}
+
+func NewLocalFileStorage(baseDir string) domain.FileStorage {
+	return &LocalFileStorage{BaseDir: baseDir}
+}
+
+// Save implements domain.FileStorage. name adalah path relatif, mis. "selfies/1/xxx.jpg".
+func (s *LocalFileStorage) Save(name string, r io.Reader) (string, error) {
+	fullPath, err := s.resolve(name)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0o755); err != nil {
+		return "", err
+	}
+
+	file, err := os.Create(fullPath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	if _, err := io.Copy(file, r); err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(name), nil
+}
+
+// Open implements domain.FileStorage.
+func (s *LocalFileStorage) Open(path string) (io.ReadCloser, error) {
+	fullPath, err := s.resolve(path)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(fullPath)
+}
+
+// Delete implements domain.FileStorage. File yang sudah tidak ada tidak dianggap error.
+func (s *LocalFileStorage) Delete(path string) error {
+	fullPath, err := s.resolve(path)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(fullPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// resolve mencegah path keluar dari BaseDir
+func (s *LocalFileStorage) resolve(name string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(name))
+	if clean == "." || filepath.IsAbs(clean) || strings.HasPrefix(clean, "..") {
+		return "", errors.New("invalid storage path")
+	}
+	return filepath.Join(s.BaseDir, clean), nil
+}